@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,7 +15,9 @@ import (
 
 	"explorer-api/internal/app/services"
 	"explorer-api/internal/infrastructure/database"
+	"explorer-api/internal/infrastructure/metrics"
 	"explorer-api/internal/infrastructure/queue"
+	"explorer-api/internal/infrastructure/tracing"
 	"explorer-api/internal/infrastructure/websocket"
 	"explorer-api/internal/interfaces/http/handlers"
 	"explorer-api/internal/interfaces/http/middleware"
@@ -22,15 +26,35 @@ import (
 func main() {
 	log.Println("🚀 Iniciando BesuScan API...")
 
+	// Configurar tracing distribuído (OpenTelemetry)
+	shutdownTracing, err := tracing.Init(context.Background(), "besuscan-api")
+	if err != nil {
+		log.Fatalf("❌ Falha ao configurar tracing: %v", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Printf("⚠️ Erro ao finalizar tracing: %v", err)
+		}
+	}()
+
 	// Configurar Gin
 	r := gin.Default()
 
+	// Métricas Prometheus por rota (latência e status)
+	r.Use(middleware.Metrics())
+
+	// Span por requisição (continua o traceparent recebido)
+	r.Use(middleware.Tracing())
+
 	// Middleware CORS para desenvolvimento
 	r.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
 		c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Access-Control-Expose-Headers", "X-Address-Labels")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -47,10 +71,21 @@ func main() {
 	}
 	defer db.Close()
 
+	// Recusar iniciar com um schema mais antigo que o exigido pela API
+	schemaVersion, err := database.CheckSchemaVersion(context.Background(), db)
+	if err != nil {
+		log.Fatalf("❌ Schema do banco incompatível com a API: %v", err)
+	}
+	log.Printf("✅ Schema do banco na versão %d", schemaVersion)
+
 	// Inicializar WebSocket Hub
 	hub := websocket.NewHub()
 	go hub.Run()
 
+	// Registrar métricas do pool do banco e dos clientes WebSocket
+	metrics.RegisterDatabase(db)
+	metrics.RegisterWebSocketClients(hub.GetClientCount)
+
 	// Configurar URL do RabbitMQ
 	rabbitmqURL := os.Getenv("RABBITMQ_URL")
 	if rabbitmqURL == "" {
@@ -104,6 +139,10 @@ func main() {
 	tokenHoldingRepo := database.NewPostgresTokenHoldingRepository(db)
 	validatorRepo := database.NewPostgresValidatorRepository(db)
 	userRepo := database.NewPostgresUserRepository(db)
+	alertRepo := database.NewPostgresAlertRepository(db)
+	riskPolicyRepo := database.NewPostgresRiskPolicyRepository(db)
+	screeningRepo := database.NewPostgresScreeningRepository(db)
+	complianceCaseRepo := database.NewPostgresComplianceCaseRepository(db)
 
 	// Configurar URL do RPC Besu
 	rpcURL := os.Getenv("BESU_RPC_URL")
@@ -118,6 +157,17 @@ func main() {
 		log.Println("⚠️ Usando JWT_SECRET padrão. Configure JWT_SECRET no ambiente para produção!")
 	}
 
+	// Casas decimais da moeda nativa na simulação das regras de tags (devem ser as mesmas do worker)
+	nativeTokenDecimals := 18
+	if value := os.Getenv("NATIVE_TOKEN_DECIMALS"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			log.Printf("⚠️ NATIVE_TOKEN_DECIMALS inválido (%s), usando 18", value)
+		} else {
+			nativeTokenDecimals = parsed
+		}
+	}
+
 	// Inicializar serviços
 	blockService := services.NewBlockService(blockRepo)
 	transactionService := services.NewTransactionService(transactionRepo)
@@ -126,6 +176,18 @@ func main() {
 	validatorService := services.NewValidatorService(validatorRepo, blockRepo, rpcURL)
 	eventService := services.NewEventService()
 	authService := services.NewAuthService(userRepo, jwtSecret)
+	alertService := services.NewAlertService(alertRepo)
+	riskPolicyService := services.NewRiskPolicyService(riskPolicyRepo)
+	screeningService := services.NewScreeningService(screeningRepo)
+	complianceCaseService := services.NewComplianceCaseService(complianceCaseRepo)
+	tagRuleService := services.NewTagRuleService(database.NewPostgresTagRuleRepository(db, nativeTokenDecimals))
+	addressLabelService := services.NewAddressLabelService(database.NewPostgresAddressLabelRepository(db))
+	userOperationService := services.NewUserOperationService(database.NewPostgresUserOperationRepository(db))
+	contractCallService := services.NewContractCallService(db, rpcURL)
+	simulationService := services.NewSimulationService(db, contractCallService)
+	exportService := services.NewExportService(db)
+	searchService := services.NewSearchService(db)
+	flowService := services.NewFlowService(db)
 
 	// Inicializar serviço de fila (se AMQP Client estiver disponível)
 	var queueService *services.QueueService
@@ -139,8 +201,21 @@ func main() {
 	smartContractHandler := handlers.NewSmartContractHandler(smartContractService)
 	validatorHandler := handlers.NewValidatorHandler(validatorService)
 	eventHandler := handlers.NewEventHandler(eventService)
-	statsHandler := handlers.NewStatsHandler(blockService, transactionService, smartContractService, accountService, db)
+	networkStatsService := services.NewNetworkStatsService(db)
+	statsHandler := handlers.NewStatsHandler(blockService, transactionService, smartContractService, accountService, networkStatsService)
 	authHandler := handlers.NewAuthHandler(authService)
+	alertHandler := handlers.NewAlertHandler(alertService)
+	riskPolicyHandler := handlers.NewRiskPolicyHandler(riskPolicyService)
+	screeningHandler := handlers.NewScreeningHandler(screeningService)
+	complianceCaseHandler := handlers.NewComplianceCaseHandler(complianceCaseService)
+	tagRuleHandler := handlers.NewTagRuleHandler(tagRuleService)
+	addressLabelHandler := handlers.NewAddressLabelHandler(addressLabelService)
+	userOperationHandler := handlers.NewUserOperationHandler(userOperationService)
+	contractReadHandler := handlers.NewContractReadHandler(contractCallService)
+	simulationHandler := handlers.NewSimulationHandler(simulationService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	flowHandler := handlers.NewFlowHandler(flowService)
 
 	// AccountHandler com ou sem queue service
 	accountHandler := handlers.NewAccountHandler(accountService, queueService, smartContractService)
@@ -162,12 +237,15 @@ func main() {
 		})
 	})
 
+	// Métricas Prometheus
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	// Rota WebSocket
 	r.GET("/ws", wsHandler.HandleWebSocket)
 	r.GET("/ws/stats", wsHandler.GetStats)
 
-	// Rotas da API v1
-	api := r.Group("/api")
+	// Rotas da API v1 (respostas JSON com endereços incluem os labels aprovados em "labels")
+	api := r.Group("/api", middleware.AddressLabels(addressLabelService))
 	{
 		// Rotas de autenticação (públicas)
 		auth := api.Group("/auth")
@@ -183,6 +261,10 @@ func main() {
 		// Rotas de estatísticas gerais (públicas)
 		api.GET("/stats", statsHandler.GetGeneralStats)                   // GET /api/stats
 		api.GET("/stats/recent-activity", statsHandler.GetRecentActivity) // GET /api/stats/recent-activity
+		api.GET("/stats/charts", statsHandler.GetCharts)                  // GET /api/stats/charts?metric=tx_count&interval=hour
+
+		// Busca universal (type-ahead)
+		api.GET("/search", searchHandler.Search) // GET /api/search?q=0x...&limit=10
 		// Rotas de blocos
 		blocks := api.Group("/blocks")
 		{
@@ -235,7 +317,10 @@ func main() {
 			smartContracts.GET("/:address/source", smartContractHandler.GetSmartContractSourceCode)   // GET /api/smart-contracts/0x.../source
 			smartContracts.GET("/:address/functions", smartContractHandler.GetSmartContractFunctions) // GET /api/smart-contracts/0x.../functions
 			smartContracts.GET("/:address/events", smartContractHandler.GetSmartContractEvents)       // GET /api/smart-contracts/0x.../events
+			smartContracts.GET("/:address/events/export", exportHandler.ExportContractEvents)         // GET /api/smart-contracts/0x.../events/export?format=csv
 			smartContracts.GET("/:address/metrics", smartContractHandler.GetSmartContractMetrics)     // GET /api/smart-contracts/0x.../metrics
+			smartContracts.GET("/:address/read", contractReadHandler.ReadContractViews)               // GET /api/smart-contracts/0x.../read?block=latest
+			smartContracts.POST("/:address/read", contractReadHandler.ReadContract)                   // POST /api/smart-contracts/0x.../read - Chamar função view/pure
 		}
 
 		// Rotas de accounts
@@ -261,9 +346,21 @@ func main() {
 			accounts.GET("/:address/tokens", accountHandler.GetTokenHoldings)              // GET /api/accounts/0x.../tokens
 			accounts.GET("/:address/transactions", accountHandler.GetAccountTransactions)  // GET /api/accounts/0x.../transactions?limit=50
 			accounts.GET("/:address/events", accountHandler.GetAccountEvents)              // GET /api/accounts/0x.../events?limit=50
+			accounts.GET("/:address/transactions/export", exportHandler.ExportAccountTransactions)      // GET /api/accounts/0x.../transactions/export?format=csv
+			accounts.GET("/:address/events/export", exportHandler.ExportAccountEvents)                  // GET /api/accounts/0x.../events/export?format=ndjson
+			accounts.GET("/:address/token-transfers/export", exportHandler.ExportAccountTokenTransfers) // GET /api/accounts/0x.../token-transfers/export?from_date=2024-01-01
 			accounts.GET("/:address/method-stats", accountHandler.GetAccountMethodStats)   // GET /api/accounts/0x.../method-stats?limit=20
 			accounts.GET("/:address/is-contract", accountHandler.IsContract)               // GET /api/accounts/0x.../is-contract
 
+			// ===== GRAFO DE FLUXO DE FUNDOS (INVESTIGAÇÕES) =====
+			accounts.GET("/:address/flow", flowHandler.GetAccountFlow) // GET /api/accounts/0x.../flow?direction=out&hops=2&token=native&export=graphml
+
+			// ===== TRIAGEM DE SANÇÕES - REQUER AUTENTICAÇÃO =====
+			accounts.GET("/:address/exposure", authMiddleware.RequireAuth(), screeningHandler.GetAccountExposure) // GET /api/accounts/0x.../exposure
+
+			// ===== USEROPERATIONS ERC-4337 =====
+			accounts.GET("/:address/userops", userOperationHandler.GetAccountUserOperations) // GET /api/accounts/0x.../userops?role=sender|paymaster|bundler
+
 			// ===== NOVAS ROTAS DE ESCRITA (VIA QUEUE) - REQUEREM AUTENTICAÇÃO =====
 			if queueService != nil {
 				accounts.POST("", authMiddleware.RequireAuth(), accountHandler.CreateAccount)                              // POST /api/accounts - Criar account
@@ -297,6 +394,90 @@ func main() {
 			events.GET("/block/:number", eventHandler.GetEventsByBlock)           // GET /api/events/block/123
 			events.GET("/:id", eventHandler.GetEvent)                             // GET /api/events/:id
 		}
+
+		// Rotas de alertas (webhooks) - requerem autenticação
+		alerts := api.Group("/alerts", authMiddleware.RequireAuth())
+		{
+			alerts.GET("", alertHandler.GetAlertRules)                                        // GET /api/alerts
+			alerts.POST("", alertHandler.CreateAlertRule)                                     // POST /api/alerts
+			alerts.GET("/:id", alertHandler.GetAlertRule)                                     // GET /api/alerts/1
+			alerts.PUT("/:id", alertHandler.UpdateAlertRule)                                  // PUT /api/alerts/1
+			alerts.DELETE("/:id", alertHandler.DeleteAlertRule)                               // DELETE /api/alerts/1
+			alerts.GET("/:id/deliveries", alertHandler.GetAlertDeliveries)                    // GET /api/alerts/1/deliveries?status=failed
+			alerts.POST("/:id/deliveries/:deliveryId/retry", alertHandler.RetryAlertDelivery) // POST /api/alerts/1/deliveries/10/retry
+		}
+
+		// Rotas da política de risco - leitura autenticada, publicação e ativação apenas por admins
+		riskPolicies := api.Group("/risk-policies", authMiddleware.RequireAuth())
+		{
+			riskPolicies.GET("", riskPolicyHandler.GetRiskPolicies)                                                      // GET /api/risk-policies
+			riskPolicies.GET("/active", riskPolicyHandler.GetActiveRiskPolicy)                                           // GET /api/risk-policies/active
+			riskPolicies.GET("/:version", riskPolicyHandler.GetRiskPolicy)                                               // GET /api/risk-policies/2
+			riskPolicies.POST("", authMiddleware.RequireAdmin(), riskPolicyHandler.CreateRiskPolicy)                     // POST /api/risk-policies?format=yaml&activate=true
+			riskPolicies.POST("/:version/activate", authMiddleware.RequireAdmin(), riskPolicyHandler.ActivateRiskPolicy) // POST /api/risk-policies/2/activate
+		}
+
+		// Rotas das listas de triagem (sanções/denylists) - leitura autenticada, importação e ativação apenas por admins
+		screening := api.Group("/screening", authMiddleware.RequireAuth())
+		{
+			screening.GET("/lists", screeningHandler.GetScreeningLists)                                                      // GET /api/screening/lists?name=ofac
+			screening.GET("/lists/:id", screeningHandler.GetScreeningList)                                                   // GET /api/screening/lists/1
+			screening.GET("/lists/:id/entries", screeningHandler.GetScreeningListEntries)                                    // GET /api/screening/lists/1/entries
+			screening.POST("/lists", authMiddleware.RequireAdmin(), screeningHandler.ImportScreeningList)                    // POST /api/screening/lists?name=ofac&source=OFAC&version=2024-05-01&format=csv
+			screening.POST("/lists/:id/activate", authMiddleware.RequireAdmin(), screeningHandler.ActivateScreeningList)     // POST /api/screening/lists/1/activate
+			screening.POST("/lists/:id/deactivate", authMiddleware.RequireAdmin(), screeningHandler.DeactivateScreeningList) // POST /api/screening/lists/1/deactivate
+		}
+
+		// Rotas dos casos de compliance - toda alteração fica no histórico do caso com o usuário autenticado
+		complianceCases := api.Group("/compliance/cases", authMiddleware.RequireAuth())
+		{
+			complianceCases.GET("", complianceCaseHandler.GetComplianceCases)                             // GET /api/compliance/cases?state=open&assigned_to=3
+			complianceCases.POST("", complianceCaseHandler.CreateComplianceCase)                          // POST /api/compliance/cases
+			complianceCases.GET("/:id", complianceCaseHandler.GetComplianceCase)                          // GET /api/compliance/cases/1
+			complianceCases.GET("/:id/history", complianceCaseHandler.GetComplianceCaseHistory)           // GET /api/compliance/cases/1/history
+			complianceCases.POST("/:id/transition", complianceCaseHandler.TransitionComplianceCase)       // POST /api/compliance/cases/1/transition
+			complianceCases.PUT("/:id/assignee", complianceCaseHandler.AssignComplianceCase)              // PUT /api/compliance/cases/1/assignee
+			complianceCases.POST("/:id/addresses", complianceCaseHandler.AddComplianceCaseAddress)        // POST /api/compliance/cases/1/addresses
+			complianceCases.POST("/:id/transactions", complianceCaseHandler.AddComplianceCaseTransaction) // POST /api/compliance/cases/1/transactions
+			complianceCases.POST("/:id/notes", complianceCaseHandler.AddComplianceCaseNote)               // POST /api/compliance/cases/1/notes
+		}
+
+		// Rotas das regras de tags automáticas - leitura e simulação autenticadas, edição apenas por admins
+		tagRules := api.Group("/tag-rules", authMiddleware.RequireAuth())
+		{
+			tagRules.GET("", tagRuleHandler.GetTagRules)                                         // GET /api/tag-rules?tag=whale&active=true
+			tagRules.POST("/preview", tagRuleHandler.PreviewTagRule)                             // POST /api/tag-rules/preview
+			tagRules.GET("/:id", tagRuleHandler.GetTagRule)                                      // GET /api/tag-rules/1
+			tagRules.GET("/:id/preview", tagRuleHandler.PreviewStoredTagRule)                    // GET /api/tag-rules/1/preview
+			tagRules.POST("", authMiddleware.RequireAdmin(), tagRuleHandler.CreateTagRule)       // POST /api/tag-rules
+			tagRules.PUT("/:id", authMiddleware.RequireAdmin(), tagRuleHandler.UpdateTagRule)    // PUT /api/tag-rules/1
+			tagRules.DELETE("/:id", authMiddleware.RequireAdmin(), tagRuleHandler.DeleteTagRule) // DELETE /api/tag-rules/1
+		}
+
+		// Rotas dos labels de endereços - leitura pública, sugestões autenticadas, moderação e importação por admins
+		labels := api.Group("/labels")
+		{
+			labels.GET("", addressLabelHandler.GetAddressLabels)                                                 // GET /api/labels?category=bridge&q=ponte
+			labels.GET("/review", authMiddleware.RequireAdmin(), addressLabelHandler.GetAddressLabelReviewQueue) // GET /api/labels/review?status=pending
+			labels.GET("/:id", authMiddleware.OptionalAuth(), addressLabelHandler.GetAddressLabel)               // GET /api/labels/1
+			labels.POST("/suggestions", authMiddleware.RequireAuth(), addressLabelHandler.SuggestAddressLabel)   // POST /api/labels/suggestions
+			labels.POST("", authMiddleware.RequireAdmin(), addressLabelHandler.CreateAddressLabel)               // POST /api/labels
+			labels.POST("/import", authMiddleware.RequireAdmin(), addressLabelHandler.ImportAddressLabels)       // POST /api/labels/import (CSV)
+			labels.PUT("/:id", authMiddleware.RequireAdmin(), addressLabelHandler.UpdateAddressLabel)            // PUT /api/labels/1
+			labels.POST("/:id/approve", authMiddleware.RequireAdmin(), addressLabelHandler.ApproveAddressLabel)  // POST /api/labels/1/approve
+			labels.POST("/:id/reject", authMiddleware.RequireAdmin(), addressLabelHandler.RejectAddressLabel)    // POST /api/labels/1/reject
+			labels.DELETE("/:id", authMiddleware.RequireAdmin(), addressLabelHandler.DeleteAddressLabel)         // DELETE /api/labels/1
+		}
+
+		// Rotas das UserOperations ERC-4337 indexadas das EntryPoints configuradas no worker
+		userOps := api.Group("/userops")
+		{
+			userOps.GET("", userOperationHandler.GetUserOperations)      // GET /api/userops?sender=0x...&paymaster=0x...&success=false
+			userOps.GET("/:hash", userOperationHandler.GetUserOperation) // GET /api/userops/0x...
+		}
+
+		// Simulação de transações (eth_call, eth_estimateGas e debug_traceCall) sem envio à rede - autenticada, gas limitado
+		api.POST("/simulate", authMiddleware.RequireAuth(), simulationHandler.Simulate) // POST /api/simulate
 	}
 
 	// Obter porta do ambiente
@@ -308,6 +489,7 @@ func main() {
 	log.Printf("🌐 API rodando na porta %s", port)
 	log.Println("📋 Rotas disponíveis:")
 	log.Println("  GET /health - Status da API")
+	log.Println("  GET /metrics - Métricas Prometheus")
 	log.Println("  GET /ws - Conexão WebSocket")
 	log.Println("  GET /ws/stats - Estatísticas WebSocket")
 	log.Println("--------------------------------")
@@ -320,6 +502,9 @@ func main() {
 	log.Println("  POST /api/auth/refresh - Renovar token (requer auth)")
 	log.Println("--------------------------------")
 	log.Println("📊 ROTAS PÚBLICAS:")
+	log.Println("  GET /api/stats - Estatísticas gerais da rede")
+	log.Println("  GET /api/stats/charts?metric=&interval=hour|day&from=&to= - Séries dos rollups de estatísticas")
+	log.Println("  GET /api/search?q= - Busca universal (bloco, transação, endereço, contrato, token, evento)")
 	log.Println("  GET /api/blocks - Lista de blocos recentes")
 	log.Println("  GET /api/blocks/search - Busca com filtros avançados")
 	log.Println("  GET /api/blocks/latest - Último bloco")
@@ -348,6 +533,71 @@ func main() {
 	log.Println("  GET /api/events/transaction/:hash - Eventos por transação")
 	log.Println("  GET /api/events/block/:number - Eventos por bloco")
 	log.Println("  GET /api/events/:id - Evento específico")
+	log.Println("--------------------------------")
+	log.Println("📤 ROTAS DE EXPORTAÇÃO (CSV/NDJSON em streaming):")
+	log.Println("  GET /api/accounts/:address/transactions/export - Transações da account")
+	log.Println("  GET /api/accounts/:address/events/export - Eventos da account")
+	log.Println("  GET /api/accounts/:address/token-transfers/export - Transferências de tokens da account")
+	log.Println("  GET /api/smart-contracts/:address/events/export - Eventos do contrato")
+	log.Println("--------------------------------")
+	log.Println("🕸️ GRAFO DE FLUXO DE FUNDOS:")
+	log.Println("  GET /api/accounts/:address/flow?direction=&hops=&token=&from=&to= - Grafo por contraparte (export=json|graphml)")
+	log.Println("--------------------------------")
+	log.Println("🧾 USEROPERATIONS ERC-4337:")
+	log.Println("  GET /api/userops?sender=&paymaster=&bundler=&entry_point=&tx_hash=&success= - Listar UserOperations")
+	log.Println("  GET /api/userops/:hash - UserOperation específica")
+	log.Println("  GET /api/accounts/:address/userops?role=sender|paymaster|bundler - UserOperations da account")
+	log.Println("--------------------------------")
+	log.Println("📖 LEITURA DE CONTRATOS (eth_call com o ABI armazenado):")
+	log.Println("  GET /api/smart-contracts/:address/read?block= - Funções view/pure sem argumentos")
+	log.Println("  POST /api/smart-contracts/:address/read - Chamar função view/pure com argumentos")
+	log.Println("  POST /api/simulate - Simular transação (revert, logs, gas estimado e árvore de chamadas) (requer auth)")
+	log.Println("--------------------------------")
+	log.Println("🔔 ROTAS DE ALERTAS (requerem autenticação):")
+	log.Println("  GET/POST /api/alerts - Listar/criar regras de alerta")
+	log.Println("  GET/PUT/DELETE /api/alerts/:id - Gerenciar regra de alerta")
+	log.Println("  GET /api/alerts/:id/deliveries - Log de entregas do webhook")
+	log.Println("  POST /api/alerts/:id/deliveries/:deliveryId/retry - Reenviar entrega")
+	log.Println("--------------------------------")
+	log.Println("⚖️ ROTAS DA POLÍTICA DE RISCO (requerem autenticação):")
+	log.Println("  GET /api/risk-policies - Listar versões da política")
+	log.Println("  GET /api/risk-policies/active - Versão ativa")
+	log.Println("  GET /api/risk-policies/:version - Detalhes de uma versão")
+	log.Println("  POST /api/risk-policies - Publicar nova versão YAML/JSON (admin)")
+	log.Println("  POST /api/risk-policies/:version/activate - Ativar versão e reavaliar accounts (admin)")
+	log.Println("--------------------------------")
+	log.Println("🛡️ ROTAS DE TRIAGEM DE SANÇÕES (requerem autenticação):")
+	log.Println("  GET /api/screening/lists - Listar versões das listas")
+	log.Println("  GET /api/screening/lists/:id - Detalhes de uma versão")
+	log.Println("  GET /api/screening/lists/:id/entries - Endereços de uma versão")
+	log.Println("  POST /api/screening/lists - Importar lista CSV/JSON (admin)")
+	log.Println("  POST /api/screening/lists/:id/activate|deactivate - Ativar/desativar versão (admin)")
+	log.Println("  GET /api/accounts/:address/exposure - Relatório de exposição da account")
+	log.Println("--------------------------------")
+	log.Println("📂 ROTAS DOS CASOS DE COMPLIANCE (requerem autenticação):")
+	log.Println("  GET /api/compliance/cases - Listar casos (state, priority, origin, assigned_to, address)")
+	log.Println("  POST /api/compliance/cases - Abrir caso manualmente")
+	log.Println("  GET /api/compliance/cases/:id - Detalhes do caso com endereços, transações e notas")
+	log.Println("  GET /api/compliance/cases/:id/history - Histórico de alterações do caso")
+	log.Println("  POST /api/compliance/cases/:id/transition - Mudar estado (open, investigating, escalated, closed)")
+	log.Println("  PUT /api/compliance/cases/:id/assignee - Atribuir caso")
+	log.Println("  POST /api/compliance/cases/:id/addresses|transactions|notes - Vincular endereço, transação ou nota")
+	log.Println("  GET /api/tag-rules - Listar regras de tags automáticas")
+	log.Println("  GET /api/tag-rules/:id - Detalhes de uma regra")
+	log.Println("  POST /api/tag-rules/preview - Simular condições e listar accounts que casam")
+	log.Println("  GET /api/tag-rules/:id/preview - Simular regra gravada (accounts que ganhariam ou perderiam a tag)")
+	log.Println("  POST /api/tag-rules - Criar regra (admin)")
+	log.Println("  PUT /api/tag-rules/:id - Editar condições, descrição ou estado (admin)")
+	log.Println("  DELETE /api/tag-rules/:id - Remover regra (admin)")
+	log.Println("  GET /api/labels - Listar labels aprovados (category, q, verified, address)")
+	log.Println("  GET /api/labels/:id - Detalhes de um label")
+	log.Println("  POST /api/labels/suggestions - Sugerir label para moderação")
+	log.Println("  GET /api/labels/review - Fila de moderação (admin)")
+	log.Println("  POST /api/labels - Criar label aprovado (admin)")
+	log.Println("  POST /api/labels/import - Importar labels de CSV (admin)")
+	log.Println("  PUT /api/labels/:id - Editar label (admin)")
+	log.Println("  POST /api/labels/:id/approve|reject - Moderar sugestão (admin)")
+	log.Println("  DELETE /api/labels/:id - Remover label (admin)")
 
 	if queueService != nil {
 		log.Println("--------------------------------")
@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
 	"regexp"
 	"strings"
 	"time"
 
 	"explorer-api/internal/domain/entities"
 	"explorer-api/internal/domain/repositories"
+	"explorer-api/internal/infrastructure/database"
 )
 
 // AccountService gerencia a lógica de negócio para accounts
@@ -54,6 +56,13 @@ func (s *AccountService) GetAccountByAddress(ctx context.Context, address string
 		return nil, fmt.Errorf("erro ao buscar account: %w", err)
 	}
 
+	// Explicação do score pela política de risco; a account continua disponível se a consulta falhar
+	evaluation, err := s.accountRepo.GetLatestRiskEvaluation(ctx, normalizedAddress)
+	if err != nil {
+		log.Printf("⚠️ Erro ao buscar avaliação de risco da account %s: %v", normalizedAddress, err)
+	}
+	account.RiskEvaluation = evaluation
+
 	return account, nil
 }
 
@@ -537,8 +546,7 @@ func (s *AccountService) GetAccountTransactionsWithFilters(ctx context.Context,
 		WHERE at.account_address = $1
 	`
 
-	countQuery := `
-		SELECT COUNT(*)
+	countFromClause := `
 		FROM account_transactions at
 		LEFT JOIN smart_contracts sc ON LOWER(at.contract_address) = LOWER(sc.address)
 		WHERE at.account_address = $1
@@ -579,7 +587,17 @@ func (s *AccountService) GetAccountTransactionsWithFilters(ctx context.Context,
 	if len(conditions) > 0 {
 		finalWhereClause := strings.Join(conditions, " AND ")
 		baseQuery += " AND " + finalWhereClause
-		countQuery += " AND " + finalWhereClause
+		countFromClause += " AND " + finalWhereClause
+	}
+
+	// Posicionar pelo cursor (keyset) apenas na busca; a contagem reflete o filtro completo
+	queryArgs := args
+	offset := (filters.Page - 1) * filters.Limit
+	if filters.usesCursor() {
+		condition, cursorArgs := filters.cursorCondition(len(args))
+		baseQuery += " AND " + condition
+		queryArgs = append(append([]interface{}{}, args...), cursorArgs...)
+		offset = 0
 	}
 
 	// Adicionar ordenação
@@ -588,23 +606,21 @@ func (s *AccountService) GetAccountTransactionsWithFilters(ctx context.Context,
 	}
 	baseQuery += " " + orderClause
 
-	// Adicionar paginação
-	offset := (filters.Page - 1) * filters.Limit
-	baseQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", filters.Limit, offset)
+	// Adicionar paginação - uma linha a mais indica se existe próxima página
+	baseQuery += fmt.Sprintf(" LIMIT %d OFFSET %d", filters.Limit+1, offset)
 
 	// Log da query para debug
 	fmt.Printf("DEBUG - Query: %s\n", baseQuery)
-	fmt.Printf("DEBUG - Args: %+v\n", args)
+	fmt.Printf("DEBUG - Args: %+v\n", queryArgs)
 
-	// Executar query de contagem
-	var total int64
-	err = s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	// Executar contagem (estimada em contas com muitas transações)
+	total, approximate, err := database.EstimateCount(ctx, s.db, countFromClause, args)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao contar transações: %w", err)
 	}
 
 	// Executar query principal
-	rows, err := s.db.QueryContext(ctx, baseQuery, args...)
+	rows, err := s.db.QueryContext(ctx, baseQuery, queryArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar transações: %w", err)
 	}
@@ -697,16 +713,26 @@ func (s *AccountService) GetAccountTransactionsWithFilters(ctx context.Context,
 		transactions = append(transactions, transaction)
 	}
 
-	// Calcular total de páginas
-	totalPages := int((total + int64(filters.Limit) - 1) / int64(filters.Limit))
+	hasMore := len(transactions) > filters.Limit
+	if hasMore {
+		transactions = transactions[:filters.Limit]
+	}
 
-	return &PaginatedResult[map[string]interface{}]{
-		Data:       transactions,
-		Page:       filters.Page,
-		Limit:      filters.Limit,
-		Total:      int(total),
-		TotalPages: totalPages,
-	}, nil
+	result := &PaginatedResult[map[string]interface{}]{
+		Data:             transactions,
+		Page:             filters.Page,
+		Limit:            filters.Limit,
+		Total:            int(total),
+		TotalPages:       totalPagesFor(total, filters.Limit),
+		TotalApproximate: approximate,
+	}
+
+	if hasMore && filters.SupportsCursor() {
+		last := transactions[len(transactions)-1]
+		result.NextCursor = filters.nextTransactionCursor(uint64(last["block_number"].(int64)), uint64(last["transaction_index"].(int)))
+	}
+
+	return result, nil
 }
 
 // GetAccountEvents retorna todos os eventos relacionados a uma conta (sem filtros - para compatibilidade)
@@ -794,9 +820,10 @@ func (s *AccountService) GetAccountEventsWithFilters(ctx context.Context, addres
 		argIndex++
 	}
 
-	// Filtro por data inicial
+	// Filtro por data inicial (também limita os blocos para descartar partições)
 	if filters.FromDate != "" {
 		whereConditions = append(whereConditions, fmt.Sprintf("timestamp >= $%d", argIndex))
+		whereConditions = append(whereConditions, blockLowerBound("block_number", fmt.Sprintf("$%d", argIndex)))
 		args = append(args, filters.FromDate)
 		argIndex++
 	}
@@ -804,6 +831,7 @@ func (s *AccountService) GetAccountEventsWithFilters(ctx context.Context, addres
 	// Filtro por data final
 	if filters.ToDate != "" {
 		whereConditions = append(whereConditions, fmt.Sprintf("timestamp <= $%d", argIndex))
+		whereConditions = append(whereConditions, blockUpperBound("block_number", fmt.Sprintf("$%d", argIndex)))
 		args = append(args, filters.ToDate+" 23:59:59") // Incluir o dia inteiro
 		argIndex++
 	}
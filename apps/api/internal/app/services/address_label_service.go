@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+var (
+	// ErrAddressLabelNotFound indica que o label não existe
+	ErrAddressLabelNotFound = errors.New("label não encontrado")
+	// ErrInvalidAddressLabel indica um label ou arquivo de importação que não passou na validação
+	ErrInvalidAddressLabel = errors.New("label inválido")
+	// ErrAddressLabelConflict indica a moderação de um label que não está mais pendente
+	ErrAddressLabelConflict = errors.New("label não está pendente de moderação")
+)
+
+const (
+	// addressLabelCacheSize limita os endereços mantidos no cache de labels aprovados
+	addressLabelCacheSize = 100000
+	// addressLabelCacheTTL descarta o cache periodicamente, para refletir alterações feitas por outras instâncias da API
+	addressLabelCacheTTL = time.Minute
+)
+
+// AddressLabelService gerencia os labels públicos de endereços e sua moderação
+type AddressLabelService struct {
+	labelRepo repositories.AddressLabelRepository
+
+	// Cache dos labels aprovados por endereço (nil = endereço sem label), usado pelo middleware de respostas.
+	// Toda alteração de labels desta instância o limpa
+	mu          sync.Mutex
+	cache       map[string]*entities.AddressLabelSummary
+	cacheExpiry time.Time
+	generation  uint64 // Incrementada a cada limpeza; consultas iniciadas antes dela não gravam no cache
+}
+
+// NewAddressLabelService cria uma nova instância do serviço de labels
+func NewAddressLabelService(labelRepo repositories.AddressLabelRepository) *AddressLabelService {
+	return &AddressLabelService{
+		labelRepo: labelRepo,
+		cache:     make(map[string]*entities.AddressLabelSummary),
+	}
+}
+
+// invalidateLabels limpa o cache de labels aprovados
+func (s *AddressLabelService) invalidateLabels() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = make(map[string]*entities.AddressLabelSummary)
+	s.generation++
+}
+
+// labelFromRequest monta e valida um label a partir da requisição
+func labelFromRequest(request *entities.AddressLabelRequest) (*entities.AddressLabel, error) {
+	label := &entities.AddressLabel{
+		Address:     request.Address,
+		Name:        request.Name,
+		Category:    entities.AddressLabelCategory(request.Category),
+		Description: request.Description,
+		Source:      request.Source,
+		URL:         request.URL,
+		Verified:    request.Verified,
+	}
+	if err := entities.NormalizeAddressLabel(label); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddressLabel, err)
+	}
+	return label, nil
+}
+
+// CreateLabel grava um label aprovado, substituindo o label aprovado anterior do endereço
+func (s *AddressLabelService) CreateLabel(ctx context.Context, request *entities.AddressLabelRequest, actor string) (*entities.AddressLabel, error) {
+	label, err := labelFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	label.Status = entities.AddressLabelApproved
+	label.SubmittedBy = actor
+	label.ReviewedBy = &actor
+	label.ReviewedAt = &now
+	if err := s.labelRepo.Create(ctx, label); err != nil {
+		return nil, err
+	}
+	s.invalidateLabels()
+
+	return s.GetLabel(ctx, label.ID)
+}
+
+// SuggestLabel grava a sugestão de um usuário, pendente de moderação. Sugestões nunca chegam verificadas
+func (s *AddressLabelService) SuggestLabel(ctx context.Context, request *entities.AddressLabelRequest, actor string) (*entities.AddressLabel, error) {
+	label, err := labelFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	label.Verified = false
+	label.Status = entities.AddressLabelPending
+	label.SubmittedBy = actor
+	if err := s.labelRepo.Create(ctx, label); err != nil {
+		return nil, err
+	}
+
+	return s.GetLabel(ctx, label.ID)
+}
+
+// GetLabel busca um label
+func (s *AddressLabelService) GetLabel(ctx context.Context, id int64) (*entities.AddressLabel, error) {
+	label, err := s.labelRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if label == nil {
+		return nil, ErrAddressLabelNotFound
+	}
+	return label, nil
+}
+
+// ListLabels lista os labels
+func (s *AddressLabelService) ListLabels(ctx context.Context, filters *entities.AddressLabelFilters, page, limit int) (*PaginatedResult[*entities.AddressLabel], error) {
+	if filters.Category != "" && !entities.AddressLabelCategory(filters.Category).IsValid() {
+		return nil, fmt.Errorf("%w: categoria inválida", ErrInvalidAddressLabel)
+	}
+	if filters.Status != "" && !entities.AddressLabelStatus(filters.Status).IsValid() {
+		return nil, fmt.Errorf("%w: status inválido", ErrInvalidAddressLabel)
+	}
+
+	offset := (page - 1) * limit
+	labels, total, err := s.labelRepo.FindAll(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[*entities.AddressLabel]{
+		Data:       labels,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// UpdateLabel altera os campos de um label. O endereço não muda: para outro endereço, crie outro label
+func (s *AddressLabelService) UpdateLabel(ctx context.Context, id int64, request *entities.UpdateAddressLabelRequest) (*entities.AddressLabel, error) {
+	label, err := s.GetLabel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil {
+		label.Name = *request.Name
+	}
+	if request.Category != nil {
+		label.Category = entities.AddressLabelCategory(*request.Category)
+	}
+	if request.Description != nil {
+		label.Description = request.Description
+	}
+	if request.Source != nil {
+		label.Source = request.Source
+	}
+	if request.URL != nil {
+		label.URL = request.URL
+	}
+	if request.Verified != nil {
+		label.Verified = *request.Verified
+	}
+	if err := entities.NormalizeAddressLabel(label); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddressLabel, err)
+	}
+
+	updated, err := s.labelRepo.Update(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrAddressLabelNotFound
+	}
+	s.invalidateLabels()
+
+	return s.GetLabel(ctx, id)
+}
+
+// ReviewLabel aprova ou rejeita uma sugestão pendente
+func (s *AddressLabelService) ReviewLabel(ctx context.Context, id int64, approve bool, note *string, actor string) (*entities.AddressLabel, error) {
+	if _, err := s.GetLabel(ctx, id); err != nil {
+		return nil, err
+	}
+
+	status := entities.AddressLabelRejected
+	if approve {
+		status = entities.AddressLabelApproved
+	}
+	if note != nil && strings.TrimSpace(*note) == "" {
+		note = nil
+	}
+
+	reviewed, err := s.labelRepo.Review(ctx, id, status, actor, note)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewed {
+		return nil, ErrAddressLabelConflict
+	}
+	s.invalidateLabels()
+
+	return s.GetLabel(ctx, id)
+}
+
+// DeleteLabel remove um label
+func (s *AddressLabelService) DeleteLabel(ctx context.Context, id int64) error {
+	deleted, err := s.labelRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAddressLabelNotFound
+	}
+	s.invalidateLabels()
+	return nil
+}
+
+// ImportLabels valida o CSV inteiro e grava os labels como aprovados; uma linha inválida cancela a importação
+func (s *AddressLabelService) ImportLabels(ctx context.Context, content []byte, actor string) (*entities.AddressLabelImportResult, error) {
+	if strings.TrimSpace(string(content)) == "" {
+		return nil, fmt.Errorf("%w: conteúdo vazio", ErrInvalidAddressLabel)
+	}
+
+	labels, err := entities.ParseAddressLabelCSV(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddressLabel, err)
+	}
+
+	result, err := s.labelRepo.Import(ctx, labels, actor)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLabels()
+	return result, nil
+}
+
+// LabelsFor busca os labels aprovados dos endereços, indexados pelo endereço em minúsculas. Só os endereços
+// ausentes do cache vão ao banco
+func (s *AddressLabelService) LabelsFor(ctx context.Context, addresses []string) (map[string]*entities.AddressLabelSummary, error) {
+	labels := make(map[string]*entities.AddressLabelSummary)
+	var missing []string
+
+	s.mu.Lock()
+	if now := time.Now(); now.After(s.cacheExpiry) {
+		s.cache = make(map[string]*entities.AddressLabelSummary)
+		s.cacheExpiry = now.Add(addressLabelCacheTTL)
+		s.generation++
+	}
+	generation := s.generation
+	for _, address := range addresses {
+		address = strings.ToLower(address)
+		label, ok := s.cache[address]
+		switch {
+		case !ok:
+			missing = append(missing, address)
+		case label != nil:
+			labels[address] = label
+		}
+	}
+	s.mu.Unlock()
+
+	if len(missing) == 0 {
+		return labels, nil
+	}
+
+	found, err := s.labelRepo.FindApproved(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache)+len(missing) > addressLabelCacheSize {
+		s.cache = make(map[string]*entities.AddressLabelSummary)
+	}
+	for _, address := range missing {
+		label := found[address]
+		if generation == s.generation {
+			s.cache[address] = label
+		}
+		if label != nil {
+			labels[address] = label
+		}
+	}
+
+	return labels, nil
+}
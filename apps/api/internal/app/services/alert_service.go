@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+var (
+	// ErrAlertRuleNotFound indica que a regra não existe ou não pertence ao usuário
+	ErrAlertRuleNotFound = errors.New("regra de alerta não encontrada")
+	// ErrInvalidAlertRule indica dados inválidos na regra
+	ErrInvalidAlertRule = errors.New("regra de alerta inválida")
+)
+
+// AlertService gerencia regras de alerta e o log de entregas de webhook
+type AlertService struct {
+	alertRepo repositories.AlertRepository
+}
+
+// NewAlertService cria uma nova instância do serviço de alertas
+func NewAlertService(alertRepo repositories.AlertRepository) *AlertService {
+	return &AlertService{
+		alertRepo: alertRepo,
+	}
+}
+
+// AlertOwner identifica o usuário que opera as regras
+type AlertOwner struct {
+	UserID  string
+	IsAdmin bool
+}
+
+// canAccess verifica se o usuário pode acessar a regra (admins acessam todas)
+func (o AlertOwner) canAccess(rule *entities.AlertRule) bool {
+	return o.IsAdmin || (rule.CreatedBy != nil && *rule.CreatedBy == o.UserID)
+}
+
+// CreateRule valida e cria uma nova regra. O segredo é gerado se não for informado
+func (s *AlertService) CreateRule(ctx context.Context, owner AlertOwner, req *entities.CreateAlertRuleRequest) (*entities.AlertRule, error) {
+	rule := &entities.AlertRule{
+		Name:          strings.TrimSpace(req.Name),
+		Description:   req.Description,
+		SubjectType:   req.SubjectType,
+		Conditions:    req.Conditions,
+		WebhookURL:    strings.TrimSpace(req.WebhookURL),
+		WebhookSecret: req.WebhookSecret,
+		IsActive:      true,
+		CreatedBy:     &owner.UserID,
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if rule.WebhookSecret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		rule.WebhookSecret = secret
+	}
+
+	if err := validateAlertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.alertRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetRule busca uma regra acessível pelo usuário
+func (s *AlertService) GetRule(ctx context.Context, owner AlertOwner, id int64) (*entities.AlertRule, error) {
+	rule, err := s.alertRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil || !owner.canAccess(rule) {
+		return nil, ErrAlertRuleNotFound
+	}
+	return rule, nil
+}
+
+// ListRules lista as regras do usuário (admins veem todas)
+func (s *AlertService) ListRules(ctx context.Context, owner AlertOwner, page, limit int) (*PaginatedResult[*entities.AlertRule], error) {
+	var createdBy *string
+	if !owner.IsAdmin {
+		createdBy = &owner.UserID
+	}
+
+	offset := (page - 1) * limit
+	rules, total, err := s.alertRepo.FindAll(ctx, createdBy, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[*entities.AlertRule]{
+		Data:       rules,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// UpdateRule aplica alterações parciais em uma regra
+func (s *AlertService) UpdateRule(ctx context.Context, owner AlertOwner, id int64, req *entities.UpdateAlertRuleRequest) (*entities.AlertRule, error) {
+	rule, err := s.GetRule(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		rule.Description = req.Description
+	}
+	if req.SubjectType != nil {
+		rule.SubjectType = *req.SubjectType
+	}
+	if req.Conditions != nil {
+		rule.Conditions = *req.Conditions
+	}
+	if req.WebhookURL != nil {
+		rule.WebhookURL = strings.TrimSpace(*req.WebhookURL)
+	}
+	if req.WebhookSecret != nil && *req.WebhookSecret != "" {
+		rule.WebhookSecret = *req.WebhookSecret
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if err := validateAlertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.alertRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteRule remove uma regra e seu log de entregas
+func (s *AlertService) DeleteRule(ctx context.Context, owner AlertOwner, id int64) error {
+	if _, err := s.GetRule(ctx, owner, id); err != nil {
+		return err
+	}
+	return s.alertRepo.Delete(ctx, id)
+}
+
+// ListDeliveries lista o log de entregas de uma regra
+func (s *AlertService) ListDeliveries(ctx context.Context, owner AlertOwner, ruleID int64, status string, page, limit int) (*PaginatedResult[*entities.AlertDelivery], error) {
+	if _, err := s.GetRule(ctx, owner, ruleID); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	deliveries, total, err := s.alertRepo.FindDeliveries(ctx, ruleID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[*entities.AlertDelivery]{
+		Data:       deliveries,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// RetryDelivery reagenda uma entrega pendente ou falha para envio imediato pelo worker
+func (s *AlertService) RetryDelivery(ctx context.Context, owner AlertOwner, ruleID, deliveryID int64) error {
+	if _, err := s.GetRule(ctx, owner, ruleID); err != nil {
+		return err
+	}
+
+	updated, err := s.alertRepo.RetryDelivery(ctx, ruleID, deliveryID)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return fmt.Errorf("%w: entrega não encontrada ou já entregue", ErrInvalidAlertRule)
+	}
+	return nil
+}
+
+// validateAlertRule valida os campos da regra
+func validateAlertRule(ctx context.Context, rule *entities.AlertRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("%w: nome é obrigatório", ErrInvalidAlertRule)
+	}
+
+	switch rule.SubjectType {
+	case entities.AlertSubjectTransaction, entities.AlertSubjectEvent,
+		entities.AlertSubjectTokenTransfer, entities.AlertSubjectComplianceChange:
+	default:
+		return fmt.Errorf("%w: subject_type deve ser transaction, event, token_transfer ou compliance", ErrInvalidAlertRule)
+	}
+
+	parsed, err := url.Parse(rule.WebhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: webhook_url deve ser uma URL http(s) válida", ErrInvalidAlertRule)
+	}
+	if err := validateWebhookHost(ctx, parsed.Hostname()); err != nil {
+		return err
+	}
+
+	if len(rule.WebhookSecret) < 16 {
+		return fmt.Errorf("%w: webhook_secret deve ter pelo menos 16 caracteres", ErrInvalidAlertRule)
+	}
+
+	conditions := rule.Conditions
+	switch strings.ToLower(conditions.Direction) {
+	case "", "any", "in", "out":
+	default:
+		return fmt.Errorf("%w: direction deve ser in, out ou any", ErrInvalidAlertRule)
+	}
+
+	for _, address := range append(append([]string{}, conditions.Addresses...), conditions.ContractAddresses...) {
+		if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+			return fmt.Errorf("%w: endereço inválido %s", ErrInvalidAlertRule, address)
+		}
+	}
+
+	if conditions.MinValue != "" {
+		if value, ok := new(big.Int).SetString(conditions.MinValue, 10); !ok || value.Sign() < 0 {
+			return fmt.Errorf("%w: min_value deve ser um inteiro decimal em wei", ErrInvalidAlertRule)
+		}
+	}
+
+	for _, status := range conditions.ComplianceStatuses {
+		switch status {
+		case "compliant", "flagged", "under_review":
+		default:
+			return fmt.Errorf("%w: compliance_statuses aceita compliant, flagged ou under_review", ErrInvalidAlertRule)
+		}
+	}
+
+	return nil
+}
+
+// validateWebhookHost resolve o host do webhook e rejeita destinos internos (loopback, redes privadas,
+// link-local como o endpoint de metadados 169.254.169.254, etc.). O worker repete a verificação na
+// conexão, já que o DNS pode mudar depois do cadastro
+func validateWebhookHost(ctx context.Context, host string) error {
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(ips) == 0 {
+		return fmt.Errorf("%w: não foi possível resolver o host do webhook_url", ErrInvalidAlertRule)
+	}
+	for _, ip := range ips {
+		if isInternalIP(ip.IP) {
+			return fmt.Errorf("%w: webhook_url não pode apontar para um endereço interno (%s)", ErrInvalidAlertRule, ip.IP)
+		}
+	}
+	return nil
+}
+
+// isInternalIP indica se o IP pertence a uma faixa que não pode receber webhooks
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace é a faixa de CGNAT (RFC 6598), também usada em redes internas de provedores de nuvem
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// generateWebhookSecret gera um segredo aleatório para assinatura dos webhooks
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("erro ao gerar segredo do webhook: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
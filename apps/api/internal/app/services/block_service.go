@@ -122,24 +122,30 @@ func (s *BlockService) GetBlocksWithFilters(ctx context.Context, filters *BlockF
 	// Converter filtros para SQL
 	whereClause, args, orderClause := filters.ToSQL()
 
-	// Calcular offset para paginação
+	// Calcular offset para paginação (ignorado quando há cursor)
 	offset := (filters.Page - 1) * filters.Limit
+	if filters.usesCursor() {
+		offset = 0
+	}
+	pageWhere, pageArgs := filters.withCursor(whereClause, args)
 
-	// Buscar blocos
-	blocks, err := s.blockRepo.FindWithFilters(ctx, whereClause, args, orderClause, filters.Limit, offset)
+	// Buscar um bloco a mais para saber se existe próxima página
+	blocks, err := s.blockRepo.FindWithFilters(ctx, pageWhere, pageArgs, orderClause, filters.Limit+1, offset)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar blocos com filtros: %w", err)
 	}
 
-	// Contar total de blocos
-	total, err := s.blockRepo.CountWithFilters(ctx, whereClause, args)
+	hasMore := len(blocks) > filters.Limit
+	if hasMore {
+		blocks = blocks[:filters.Limit]
+	}
+
+	// Contar total de blocos (estimado em consultas muito grandes)
+	total, approximate, err := s.blockRepo.EstimateCountWithFilters(ctx, whereClause, args)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao contar blocos com filtros: %w", err)
 	}
 
-	// Calcular total de páginas
-	totalPages := int((total + int64(filters.Limit) - 1) / int64(filters.Limit))
-
 	// Converter para resumos se necessário
 	var data interface{}
 	if len(blocks) > 0 {
@@ -152,13 +158,24 @@ func (s *BlockService) GetBlocksWithFilters(ctx context.Context, filters *BlockF
 		data = []*entities.BlockSummary{}
 	}
 
-	return &PaginatedResponse{
-		Data:       data,
-		Page:       filters.Page,
-		Limit:      filters.Limit,
-		Total:      total,
-		TotalPages: totalPages,
-	}, nil
+	response := &PaginatedResponse{
+		Data:             data,
+		Page:             filters.Page,
+		Limit:            filters.Limit,
+		Total:            total,
+		TotalPages:       totalPagesFor(total, filters.Limit),
+		TotalApproximate: approximate,
+	}
+
+	if hasMore && filters.SupportsCursor() {
+		last := blocks[len(blocks)-1]
+		response.NextCursor = PageCursor{
+			BlockNumber: last.Number,
+			Desc:        filters.OrderDir == "desc",
+		}.Encode()
+	}
+
+	return response, nil
 }
 
 // GetBlocksStats retorna estatísticas dos blocos
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+var (
+	// ErrComplianceCaseNotFound indica que o caso não existe
+	ErrComplianceCaseNotFound = errors.New("caso de compliance não encontrado")
+	// ErrInvalidComplianceCase indica uma requisição que não passou na validação
+	ErrInvalidComplianceCase = errors.New("caso de compliance inválido")
+	// ErrComplianceCaseConflict indica uma transição não permitida ou concorrente, ou um caso fechado
+	ErrComplianceCaseConflict = errors.New("operação não permitida no estado atual do caso")
+)
+
+// ComplianceCaseService gerencia o fluxo de investigação dos casos de compliance. Os estados do caso conduzem o
+// status de compliance das accounts investigadas
+type ComplianceCaseService struct {
+	caseRepo repositories.ComplianceCaseRepository
+}
+
+// NewComplianceCaseService cria uma nova instância do serviço de casos
+func NewComplianceCaseService(caseRepo repositories.ComplianceCaseRepository) *ComplianceCaseService {
+	return &ComplianceCaseService{
+		caseRepo: caseRepo,
+	}
+}
+
+// CreateCase abre um caso manualmente
+func (s *ComplianceCaseService) CreateCase(ctx context.Context, req *entities.CreateComplianceCaseRequest, actor string) (*entities.ComplianceCase, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title é obrigatório", ErrInvalidComplianceCase)
+	}
+	priority := strings.ToLower(strings.TrimSpace(req.Priority))
+	if priority == "" {
+		priority = entities.ComplianceCasePriorityMedium
+	}
+	if !entities.IsValidCasePriority(priority) {
+		return nil, fmt.Errorf("%w: priority deve ser low, medium, high ou critical", ErrInvalidComplianceCase)
+	}
+	if len(req.Addresses) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um endereço", ErrInvalidComplianceCase)
+	}
+	if err := s.validateAssignee(ctx, req.AssignedTo); err != nil {
+		return nil, err
+	}
+
+	addresses := make([]entities.ComplianceCaseAddress, 0, len(req.Addresses))
+	seen := make(map[string]bool, len(req.Addresses))
+	for i := range req.Addresses {
+		address, err := newCaseAddress(&req.Addresses[i], actor)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[address.Address] {
+			seen[address.Address] = true
+			addresses = append(addresses, *address)
+		}
+	}
+
+	transactions := make([]entities.ComplianceCaseTransaction, 0, len(req.Transactions))
+	seen = make(map[string]bool, len(req.Transactions))
+	for i := range req.Transactions {
+		transaction, err := newCaseTransaction(&req.Transactions[i], actor)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[transaction.TxHash] {
+			seen[transaction.TxHash] = true
+			transactions = append(transactions, *transaction)
+		}
+	}
+
+	var note *string
+	if req.Note != nil && strings.TrimSpace(*req.Note) != "" {
+		body := strings.TrimSpace(*req.Note)
+		note = &body
+	}
+
+	cc := &entities.ComplianceCase{
+		Title:       title,
+		Description: req.Description,
+		Priority:    priority,
+		Origin:      entities.ComplianceCaseOriginManual,
+		AssignedTo:  req.AssignedTo,
+		OpenedBy:    actor,
+	}
+	if err := s.caseRepo.Create(ctx, cc, addresses, transactions, note); err != nil {
+		return nil, err
+	}
+	return s.GetCase(ctx, cc.ID)
+}
+
+// GetCase busca um caso com seus vínculos e notas
+func (s *ComplianceCaseService) GetCase(ctx context.Context, id int64) (*entities.ComplianceCase, error) {
+	cc, err := s.caseRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cc == nil {
+		return nil, ErrComplianceCaseNotFound
+	}
+	return cc, nil
+}
+
+// ListCases lista os casos que atendem aos filtros
+func (s *ComplianceCaseService) ListCases(ctx context.Context, filters *entities.ComplianceCaseFilters, page, limit int) (*PaginatedResult[*entities.ComplianceCase], error) {
+	filters.Address = strings.ToLower(strings.TrimSpace(filters.Address))
+	if filters.State != "" && !entities.ComplianceCaseState(filters.State).IsValid() {
+		return nil, fmt.Errorf("%w: state deve ser open, investigating, escalated ou closed", ErrInvalidComplianceCase)
+	}
+	if filters.Priority != "" && !entities.IsValidCasePriority(filters.Priority) {
+		return nil, fmt.Errorf("%w: priority deve ser low, medium, high ou critical", ErrInvalidComplianceCase)
+	}
+
+	offset := (page - 1) * limit
+	cases, total, err := s.caseRepo.FindCases(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[*entities.ComplianceCase]{
+		Data:       cases,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// ListEvents lista o histórico de um caso
+func (s *ComplianceCaseService) ListEvents(ctx context.Context, id int64, page, limit int) (*PaginatedResult[entities.ComplianceCaseEvent], error) {
+	if _, err := s.GetCase(ctx, id); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	events, total, err := s.caseRepo.FindEvents(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[entities.ComplianceCaseEvent]{
+		Data:       events,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// TransitionCase muda o estado do caso. Fechar exige a resolução, que define o status final das accounts
+func (s *ComplianceCaseService) TransitionCase(ctx context.Context, id int64, req *entities.TransitionComplianceCaseRequest, actor string) (*entities.ComplianceCase, error) {
+	to := entities.ComplianceCaseState(strings.ToLower(string(req.State)))
+	if !to.IsValid() {
+		return nil, fmt.Errorf("%w: state deve ser open, investigating, escalated ou closed", ErrInvalidComplianceCase)
+	}
+
+	var resolution *entities.ComplianceCaseResolution
+	if to == entities.ComplianceCaseClosed {
+		if req.Resolution == nil {
+			return nil, fmt.Errorf("%w: resolution é obrigatória ao fechar o caso", ErrInvalidComplianceCase)
+		}
+		switch *req.Resolution {
+		case entities.ComplianceCaseCleared, entities.ComplianceCaseConfirmed:
+			resolution = req.Resolution
+		default:
+			return nil, fmt.Errorf("%w: resolution deve ser cleared ou confirmed", ErrInvalidComplianceCase)
+		}
+	} else if req.Resolution != nil {
+		return nil, fmt.Errorf("%w: resolution só é aceita ao fechar o caso", ErrInvalidComplianceCase)
+	}
+
+	cc, err := s.GetCase(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !cc.State.CanTransition(to) {
+		return nil, fmt.Errorf("%w: transição %s → %s não permitida", ErrComplianceCaseConflict, cc.State, to)
+	}
+
+	updated, err := s.caseRepo.UpdateState(ctx, id, cc.State, to, resolution, actor, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, fmt.Errorf("%w: o caso foi alterado por outra requisição", ErrComplianceCaseConflict)
+	}
+	return s.GetCase(ctx, id)
+}
+
+// AssignCase atribui o caso a um usuário ativo (nil remove a atribuição)
+func (s *ComplianceCaseService) AssignCase(ctx context.Context, id int64, assignedTo *int, actor string) (*entities.ComplianceCase, error) {
+	if _, err := s.activeCase(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.validateAssignee(ctx, assignedTo); err != nil {
+		return nil, err
+	}
+
+	if err := s.caseRepo.Assign(ctx, id, assignedTo, actor); err != nil {
+		return nil, err
+	}
+	return s.GetCase(ctx, id)
+}
+
+// AddAddress vincula um endereço ao caso
+func (s *ComplianceCaseService) AddAddress(ctx context.Context, id int64, req *entities.CaseAddressRequest, actor string) (*entities.ComplianceCase, error) {
+	address, err := newCaseAddress(req, actor)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.activeCase(ctx, id); err != nil {
+		return nil, err
+	}
+
+	added, err := s.caseRepo.AddAddress(ctx, id, address)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, fmt.Errorf("%w: endereço %s já vinculado ao caso", ErrComplianceCaseConflict, address.Address)
+	}
+	return s.GetCase(ctx, id)
+}
+
+// AddTransaction vincula uma transação ao caso como evidência
+func (s *ComplianceCaseService) AddTransaction(ctx context.Context, id int64, req *entities.CaseTransactionRequest, actor string) (*entities.ComplianceCase, error) {
+	transaction, err := newCaseTransaction(req, actor)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.activeCase(ctx, id); err != nil {
+		return nil, err
+	}
+
+	added, err := s.caseRepo.AddTransaction(ctx, id, transaction)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, fmt.Errorf("%w: transação %s já vinculada ao caso", ErrComplianceCaseConflict, transaction.TxHash)
+	}
+	return s.GetCase(ctx, id)
+}
+
+// AddNote adiciona uma nota de investigação. Notas são aceitas também em casos fechados
+func (s *ComplianceCaseService) AddNote(ctx context.Context, id int64, body, actor string) (*entities.ComplianceCaseNote, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: body é obrigatório", ErrInvalidComplianceCase)
+	}
+	if _, err := s.GetCase(ctx, id); err != nil {
+		return nil, err
+	}
+
+	note := &entities.ComplianceCaseNote{Author: actor, Body: body}
+	if err := s.caseRepo.AddNote(ctx, id, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// activeCase busca um caso que ainda aceita alterações
+func (s *ComplianceCaseService) activeCase(ctx context.Context, id int64) (*entities.ComplianceCase, error) {
+	cc, err := s.GetCase(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cc.State == entities.ComplianceCaseClosed {
+		return nil, fmt.Errorf("%w: caso fechado, reabra-o antes de alterá-lo", ErrComplianceCaseConflict)
+	}
+	return cc, nil
+}
+
+// validateAssignee verifica se o responsável informado é um usuário ativo
+func (s *ComplianceCaseService) validateAssignee(ctx context.Context, assignedTo *int) error {
+	if assignedTo == nil {
+		return nil
+	}
+	exists, err := s.caseRepo.UserExists(ctx, *assignedTo)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: usuário %d não encontrado", ErrInvalidComplianceCase, *assignedTo)
+	}
+	return nil
+}
+
+// newCaseAddress valida e normaliza um endereço a vincular
+func newCaseAddress(req *entities.CaseAddressRequest, actor string) (*entities.ComplianceCaseAddress, error) {
+	address := strings.ToLower(strings.TrimSpace(req.Address))
+	if !entities.IsValidCaseAddress(address) {
+		return nil, fmt.Errorf("%w: endereço inválido: %s", ErrInvalidComplianceCase, req.Address)
+	}
+
+	role := strings.ToLower(strings.TrimSpace(req.Role))
+	switch role {
+	case "":
+		role = entities.ComplianceCaseRoleSubject
+	case entities.ComplianceCaseRoleSubject, entities.ComplianceCaseRoleCounterparty:
+	default:
+		return nil, fmt.Errorf("%w: role deve ser subject ou counterparty", ErrInvalidComplianceCase)
+	}
+
+	return &entities.ComplianceCaseAddress{Address: address, Role: role, AddedBy: actor}, nil
+}
+
+// newCaseTransaction valida e normaliza uma transação a vincular
+func newCaseTransaction(req *entities.CaseTransactionRequest, actor string) (*entities.ComplianceCaseTransaction, error) {
+	hash := strings.ToLower(strings.TrimSpace(req.TxHash))
+	if !entities.IsValidCaseTxHash(hash) {
+		return nil, fmt.Errorf("%w: hash de transação inválido: %s", ErrInvalidComplianceCase, req.TxHash)
+	}
+	return &entities.ComplianceCaseTransaction{TxHash: hash, Note: req.Note, AddedBy: actor}, nil
+}
@@ -0,0 +1,542 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrContractNotFound indica que o endereço não está em smart_contracts
+	ErrContractNotFound = errors.New("smart contract não encontrado")
+	// ErrContractABIUnavailable indica um contrato sem ABI gravado (nem no contrato nem na implementação)
+	ErrContractABIUnavailable = errors.New("ABI não disponível para este contrato")
+	// ErrInvalidContractCall indica função, argumentos ou bloco inválidos
+	ErrInvalidContractCall = errors.New("chamada de contrato inválida")
+)
+
+// Seletores de Error(string) e Panic(uint256), os reverts padrão do Solidity
+var (
+	revertErrorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	revertPanicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// panicReasons descreve os códigos de Panic(uint256) do Solidity
+var panicReasons = map[uint64]string{
+	0x00: "panic genérico do compilador",
+	0x01: "assert falhou",
+	0x11: "overflow ou underflow aritmético",
+	0x12: "divisão ou módulo por zero",
+	0x21: "conversão para enum fora do intervalo",
+	0x22: "storage byte array codificado incorretamente",
+	0x31: "pop() em array vazio",
+	0x32: "acesso a array fora dos limites",
+	0x41: "memória demais alocada ou array grande demais",
+	0x51: "chamada a função interna não inicializada",
+}
+
+// loadContractABI carrega o ABI gravado do contrato. Em proxies, o ABI da implementação
+// (proxy_implementation) é combinado com o do proxy; funções com a mesma assinatura ficam com a da implementação
+func loadContractABI(ctx context.Context, db *sql.DB, address string) (*abi.ABI, error) {
+	proxyABI, implementation, err := findStoredABI(ctx, db, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var implementationABI []byte
+	if implementation != nil && !strings.EqualFold(*implementation, address) {
+		implementationABI, _, err = findStoredABI(ctx, db, *implementation)
+		if err != nil && !errors.Is(err, ErrContractNotFound) {
+			return nil, err
+		}
+	}
+
+	var parsed []*abi.ABI
+	for _, definition := range [][]byte{implementationABI, proxyABI} {
+		if len(bytes.TrimSpace(definition)) == 0 || bytes.Equal(bytes.TrimSpace(definition), []byte("null")) {
+			continue
+		}
+		contractABI, err := abi.JSON(bytes.NewReader(definition))
+		if err != nil {
+			return nil, fmt.Errorf("ABI gravado inválido: %w", err)
+		}
+		parsed = append(parsed, &contractABI)
+	}
+	if len(parsed) == 0 {
+		return nil, ErrContractABIUnavailable
+	}
+
+	return mergeABIs(parsed...), nil
+}
+
+// findStoredABI busca o ABI e a implementação de um contrato
+func findStoredABI(ctx context.Context, db *sql.DB, address string) ([]byte, *string, error) {
+	var (
+		definition     []byte
+		implementation *string
+	)
+	err := db.QueryRowContext(ctx, `
+		SELECT abi, proxy_implementation FROM smart_contracts
+		WHERE address = ANY($1)
+		ORDER BY address = $2 DESC
+		LIMIT 1`, pq.Array([]string{strings.ToLower(address), address}), strings.ToLower(address),
+	).Scan(&definition, &implementation)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrContractNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao buscar ABI do contrato: %w", err)
+	}
+	return definition, implementation, nil
+}
+
+// mergeABIs combina ABIs na ordem de prioridade: entradas com assinatura já presente são ignoradas
+func mergeABIs(abis ...*abi.ABI) *abi.ABI {
+	merged := &abi.ABI{
+		Methods: make(map[string]abi.Method),
+		Events:  make(map[string]abi.Event),
+		Errors:  make(map[string]abi.Error),
+	}
+	methodSigs := make(map[string]bool)
+	eventIDs := make(map[common.Hash]bool)
+	errorIDs := make(map[common.Hash]bool)
+
+	for _, contractABI := range abis {
+		if !merged.HasFallback() && contractABI.HasFallback() {
+			merged.Fallback = contractABI.Fallback
+		}
+		if !merged.HasReceive() && contractABI.HasReceive() {
+			merged.Receive = contractABI.Receive
+		}
+		for _, method := range contractABI.Methods {
+			if methodSigs[method.Sig] {
+				continue
+			}
+			methodSigs[method.Sig] = true
+			name := abi.ResolveNameConflict(method.RawName, func(s string) bool { _, ok := merged.Methods[s]; return ok })
+			merged.Methods[name] = method
+		}
+		for _, event := range contractABI.Events {
+			if eventIDs[event.ID] {
+				continue
+			}
+			eventIDs[event.ID] = true
+			name := abi.ResolveNameConflict(event.RawName, func(s string) bool { _, ok := merged.Events[s]; return ok })
+			merged.Events[name] = event
+		}
+		for name, abiError := range contractABI.Errors {
+			if errorIDs[abiError.ID] {
+				continue
+			}
+			errorIDs[abiError.ID] = true
+			name = abi.ResolveNameConflict(name, func(s string) bool { _, ok := merged.Errors[s]; return ok })
+			merged.Errors[name] = abiError
+		}
+	}
+	return merged
+}
+
+// resolveContractMethod encontra a função pelo seletor (0x12345678), pela assinatura (transfer(address,uint256))
+// ou pelo nome; nomes sobrecarregados são desambiguados pelo número de argumentos
+func resolveContractMethod(contractABI *abi.ABI, function string, argCount int) (*abi.Method, error) {
+	function = strings.TrimSpace(function)
+	if function == "" {
+		return nil, fmt.Errorf("%w: função é obrigatória", ErrInvalidContractCall)
+	}
+
+	if strings.HasPrefix(function, "0x") && len(function) == 10 {
+		selector, err := hexutil.Decode(function)
+		if err != nil {
+			return nil, fmt.Errorf("%w: seletor inválido", ErrInvalidContractCall)
+		}
+		method, err := contractABI.MethodById(selector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: seletor %s não está no ABI", ErrInvalidContractCall, function)
+		}
+		return method, nil
+	}
+
+	if strings.Contains(function, "(") {
+		signature := strings.ReplaceAll(function, " ", "")
+		for _, method := range contractABI.Methods {
+			if method.Sig == signature {
+				method := method
+				return &method, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: função %s não está no ABI", ErrInvalidContractCall, signature)
+	}
+
+	var named, matching []abi.Method
+	for _, method := range contractABI.Methods {
+		if method.RawName == function {
+			named = append(named, method)
+			if len(method.Inputs) == argCount {
+				matching = append(matching, method)
+			}
+		}
+	}
+	switch {
+	case len(named) == 0:
+		return nil, fmt.Errorf("%w: função %s não está no ABI", ErrInvalidContractCall, function)
+	case len(matching) == 1:
+		return &matching[0], nil
+	case len(named) == 1:
+		return nil, fmt.Errorf("%w: %s espera %d argumentos, recebeu %d", ErrInvalidContractCall, named[0].Sig, len(named[0].Inputs), argCount)
+	}
+
+	signatures := make([]string, len(named))
+	for i, method := range named {
+		signatures[i] = method.Sig
+	}
+	sort.Strings(signatures)
+	return nil, fmt.Errorf("%w: %s é sobrecarregada, informe a assinatura (%s)", ErrInvalidContractCall, function, strings.Join(signatures, ", "))
+}
+
+// packContractCall codifica a calldata da função com os argumentos JSON
+func packContractCall(method *abi.Method, rawArgs []json.RawMessage) ([]byte, error) {
+	if len(rawArgs) != len(method.Inputs) {
+		return nil, fmt.Errorf("%w: %s espera %d argumentos, recebeu %d", ErrInvalidContractCall, method.Sig, len(method.Inputs), len(rawArgs))
+	}
+
+	args := make([]interface{}, len(rawArgs))
+	for i, input := range method.Inputs {
+		value, err := abiValueFromJSON(input.Type, rawArgs[i])
+		if err != nil {
+			name := input.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+			return nil, fmt.Errorf("%w: argumento %s (%s): %v", ErrInvalidContractCall, name, input.Type.String(), err)
+		}
+		args[i] = value
+	}
+
+	packed, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContractCall, err)
+	}
+	return append(append([]byte{}, method.ID...), packed...), nil
+}
+
+// abiValueFromJSON converte um valor JSON no tipo Go esperado pelo ABI. Inteiros aceitam número ou string
+// (decimal ou 0x), bytes são hex, arrays são listas e tuplas são objetos (pelos nomes) ou listas (pela ordem)
+func abiValueFromJSON(t abi.Type, raw json.RawMessage) (interface{}, error) {
+	value, err := abiReflectValue(t, raw)
+	if err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+// abiReflectValue monta o valor do tipo t.GetType() a partir do JSON
+func abiReflectValue(t abi.Type, raw json.RawMessage) (reflect.Value, error) {
+	goType := t.GetType()
+	raw = bytes.TrimSpace(raw)
+
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		text := string(raw)
+		if strings.HasPrefix(text, `"`) {
+			if err := json.Unmarshal(raw, &text); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		number, ok := parseBigInt(strings.TrimSpace(text))
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("inteiro inválido: %s", text)
+		}
+		if t.T == abi.UintTy && number.Sign() < 0 {
+			return reflect.Value{}, fmt.Errorf("valor negativo para %s", t.String())
+		}
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size))
+		if t.T == abi.IntTy {
+			limit.Rsh(limit, 1)
+			if number.Cmp(limit) >= 0 || number.Cmp(new(big.Int).Neg(limit)) < 0 {
+				return reflect.Value{}, fmt.Errorf("valor fora do intervalo de %s", t.String())
+			}
+		} else if number.Cmp(limit) >= 0 {
+			return reflect.Value{}, fmt.Errorf("valor fora do intervalo de %s", t.String())
+		}
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return reflect.ValueOf(number), nil
+		}
+		value := reflect.New(goType).Elem()
+		if t.T == abi.IntTy {
+			value.SetInt(number.Int64())
+		} else {
+			value.SetUint(number.Uint64())
+		}
+		return value, nil
+
+	case abi.BoolTy:
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return reflect.Value{}, fmt.Errorf("booleano inválido")
+		}
+		return reflect.ValueOf(value), nil
+
+	case abi.StringTy:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return reflect.Value{}, fmt.Errorf("string inválida")
+		}
+		return reflect.ValueOf(value), nil
+
+	case abi.AddressTy:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil || !common.IsHexAddress(value) {
+			return reflect.Value{}, fmt.Errorf("endereço inválido")
+		}
+		return reflect.ValueOf(common.HexToAddress(value)), nil
+
+	case abi.BytesTy, abi.FixedBytesTy, abi.FunctionTy:
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return reflect.Value{}, fmt.Errorf("bytes devem ser uma string hex")
+		}
+		data, err := hexutil.Decode(text)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("hex inválido: %v", err)
+		}
+		if t.T == abi.BytesTy {
+			return reflect.ValueOf(data), nil
+		}
+		if len(data) != goType.Len() {
+			return reflect.Value{}, fmt.Errorf("esperados %d bytes, recebidos %d", goType.Len(), len(data))
+		}
+		value := reflect.New(goType).Elem()
+		reflect.Copy(value, reflect.ValueOf(data))
+		return value, nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return reflect.Value{}, fmt.Errorf("esperada uma lista")
+		}
+		var value reflect.Value
+		if t.T == abi.SliceTy {
+			value = reflect.MakeSlice(goType, len(items), len(items))
+		} else {
+			if len(items) != t.Size {
+				return reflect.Value{}, fmt.Errorf("esperados %d itens, recebidos %d", t.Size, len(items))
+			}
+			value = reflect.New(goType).Elem()
+		}
+		for i, item := range items {
+			element, err := abiReflectValue(*t.Elem, item)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("item %d: %v", i, err)
+			}
+			value.Index(i).Set(element)
+		}
+		return value, nil
+
+	case abi.TupleTy:
+		items := make([]json.RawMessage, len(t.TupleElems))
+		if bytes.HasPrefix(raw, []byte("[")) {
+			var list []json.RawMessage
+			if err := json.Unmarshal(raw, &list); err != nil || len(list) != len(items) {
+				return reflect.Value{}, fmt.Errorf("esperada uma lista com %d componentes", len(items))
+			}
+			copy(items, list)
+		} else {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				return reflect.Value{}, fmt.Errorf("esperado um objeto com os componentes da tupla")
+			}
+			for i, name := range t.TupleRawNames {
+				field, ok := fields[name]
+				if !ok {
+					return reflect.Value{}, fmt.Errorf("componente %s ausente", name)
+				}
+				items[i] = field
+			}
+		}
+		value := reflect.New(goType).Elem()
+		for i, elem := range t.TupleElems {
+			field, err := abiReflectValue(*elem, items[i])
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%s: %v", t.TupleRawNames[i], err)
+			}
+			value.Field(i).Set(field)
+		}
+		return value, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("tipo %s não suportado", t.String())
+}
+
+// parseBigInt interpreta um inteiro decimal ou hexadecimal (0x), com sinal opcional
+func parseBigInt(text string) (*big.Int, bool) {
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+	base := 10
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		text, base = text[2:], 16
+	}
+	number, ok := new(big.Int).SetString(text, base)
+	if !ok {
+		return nil, false
+	}
+	if negative {
+		number.Neg(number)
+	}
+	return number, true
+}
+
+// decodeContractValues decodifica valores ABI (retornos, argumentos de erros e de eventos) com seus nomes
+func decodeContractValues(arguments abi.Arguments, data []byte) ([]entities.ContractValue, error) {
+	values, err := arguments.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	return namedContractValues(arguments, values), nil
+}
+
+// namedContractValues associa os valores decodificados aos nomes e tipos dos argumentos
+func namedContractValues(arguments abi.Arguments, values []interface{}) []entities.ContractValue {
+	decoded := make([]entities.ContractValue, 0, len(values))
+	for i, value := range values {
+		if i >= len(arguments) {
+			break
+		}
+		name := arguments[i].Name
+		if name == "" {
+			name = fmt.Sprintf("output%d", i)
+		}
+		decoded = append(decoded, entities.ContractValue{
+			Name:  name,
+			Type:  arguments[i].Type.String(),
+			Value: formatABIValue(arguments[i].Type, reflect.ValueOf(value)),
+		})
+	}
+	return decoded
+}
+
+// formatABIValue converte um valor decodificado em um valor JSON: inteiros como strings decimais,
+// endereços com checksum, bytes em hex, listas e tuplas como objetos
+func formatABIValue(t abi.Type, value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	for value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		if number, ok := value.Interface().(*big.Int); ok {
+			return number.String()
+		}
+		return fmt.Sprint(value.Interface())
+	case abi.AddressTy:
+		if address, ok := value.Interface().(common.Address); ok {
+			return address.Hex()
+		}
+	case abi.BytesTy:
+		if data, ok := value.Interface().([]byte); ok {
+			return hexutil.Encode(data)
+		}
+	case abi.FixedBytesTy, abi.FunctionTy:
+		data := make([]byte, value.Len())
+		reflect.Copy(reflect.ValueOf(data), value)
+		return hexutil.Encode(data)
+	case abi.SliceTy, abi.ArrayTy:
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			items[i] = formatABIValue(*t.Elem, value.Index(i))
+		}
+		return items
+	case abi.TupleTy:
+		fields := make(map[string]interface{}, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			name := t.TupleRawNames[i]
+			if name == "" {
+				name = fmt.Sprintf("field%d", i)
+			}
+			fields[name] = formatABIValue(*elem, value.Field(i))
+		}
+		return fields
+	}
+	return value.Interface()
+}
+
+// decodeRevertReason decodifica os dados de um revert: Error(string), Panic(uint256) ou um erro customizado
+// de contractABI (opcional)
+func decodeRevertReason(data []byte, contractABI *abi.ABI) *entities.RevertReason {
+	reason := &entities.RevertReason{Kind: "unknown", Data: hexutil.Encode(data)}
+	if len(data) == 0 {
+		reason.Message = "execução revertida sem motivo"
+		return reason
+	}
+	if len(data) < 4 {
+		reason.Message = "dados de revert inválidos"
+		return reason
+	}
+
+	selector := data[:4]
+	switch {
+	case bytes.Equal(selector, revertErrorSelector):
+		if message, err := abi.UnpackRevert(data); err == nil {
+			reason.Kind, reason.Message = "error", message
+			return reason
+		}
+	case bytes.Equal(selector, revertPanicSelector):
+		if len(data) == 36 {
+			code := new(big.Int).SetBytes(data[4:])
+			reason.Kind = "panic"
+			if code.IsUint64() {
+				value := code.Uint64()
+				reason.Code = &value
+				if description, ok := panicReasons[value]; ok {
+					reason.Message = fmt.Sprintf("Panic(0x%02x): %s", value, description)
+					return reason
+				}
+			}
+			reason.Message = fmt.Sprintf("Panic(0x%s)", code.Text(16))
+			return reason
+		}
+	}
+
+	if contractABI != nil {
+		for _, abiError := range contractABI.Errors {
+			if !bytes.Equal(abiError.ID[:4], selector) {
+				continue
+			}
+			args, err := decodeContractValues(abiError.Inputs, data[4:])
+			if err != nil {
+				continue
+			}
+			reason.Kind = "custom"
+			reason.Signature = abiError.Sig
+			reason.Args = args
+			reason.Message = abiError.Sig
+			if len(args) > 0 {
+				parts := make([]string, len(args))
+				for i, arg := range args {
+					parts[i] = fmt.Sprintf("%s=%v", arg.Name, arg.Value)
+				}
+				reason.Message = fmt.Sprintf("%s(%s)", abiError.Name, strings.Join(parts, ", "))
+			}
+			return reason
+		}
+	}
+
+	reason.Message = fmt.Sprintf("erro desconhecido (seletor %s)", hexutil.Encode(selector))
+	return reason
+}
@@ -0,0 +1,416 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"explorer-api/internal/domain/entities"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// contractViewCacheSize limita os resultados de leitura em lote mantidos em memória (contrato + bloco)
+	contractViewCacheSize = 512
+	// maxContractViews limita as funções executadas na leitura em lote de um contrato
+	maxContractViews = 200
+	// contractRPCTimeout limita cada requisição ao nó
+	contractRPCTimeout = 30 * time.Second
+)
+
+var (
+	// ErrContractCallReverted indica que a execução reverteu; o motivo decodificado vem em ContractRevertError
+	ErrContractCallReverted = errors.New("execução revertida")
+	// ErrRPCUnavailable indica que o nó não respondeu à chamada
+	ErrRPCUnavailable = errors.New("nó RPC indisponível")
+)
+
+// ContractRevertError carrega o motivo decodificado de uma chamada revertida
+type ContractRevertError struct {
+	Reason *entities.RevertReason
+}
+
+func (e *ContractRevertError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrContractCallReverted.Error(), e.Reason.Message)
+}
+
+func (e *ContractRevertError) Is(target error) bool {
+	return target == ErrContractCallReverted
+}
+
+// contractRPCRequest é uma requisição JSON-RPC ao nó
+type contractRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+// contractRPCResponse é uma resposta JSON-RPC do nó
+type contractRPCResponse struct {
+	ID     int               `json:"id"`
+	Result json.RawMessage   `json:"result"`
+	Error  *contractRPCError `json:"error"`
+}
+
+// contractRPCError é um erro JSON-RPC; em reverts, Data traz os dados do revert
+type contractRPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// revertData extrai os dados de revert do erro (nil se o erro não for um revert)
+func (e *contractRPCError) revertData() ([]byte, bool) {
+	var text string
+	if len(e.Data) > 0 && json.Unmarshal(e.Data, &text) == nil && strings.HasPrefix(text, "0x") {
+		if data, err := hexutil.Decode(text); err == nil {
+			return data, true
+		}
+	}
+	if e.Code == 3 || strings.Contains(strings.ToLower(e.Message), "revert") {
+		return nil, true
+	}
+	return nil, false
+}
+
+// contractCallBlock é o bloco resolvido de uma chamada: o parâmetro enviado ao nó e o número (nil em pending)
+type contractCallBlock struct {
+	param  string
+	number *uint64
+}
+
+// ContractCallService executa funções view/pure dos contratos via eth_call, codificando os argumentos e
+// decodificando os retornos com o ABI gravado em smart_contracts
+type ContractCallService struct {
+	db         *sql.DB
+	rpcURL     string
+	httpClient *http.Client
+
+	mu         sync.Mutex
+	viewCache  map[string]*entities.ContractViewsResult
+	cacheOrder []string
+}
+
+// NewContractCallService cria uma nova instância do serviço de chamadas a contratos
+func NewContractCallService(db *sql.DB, rpcURL string) *ContractCallService {
+	return &ContractCallService{
+		db:         db,
+		rpcURL:     rpcURL,
+		httpClient: &http.Client{Timeout: contractRPCTimeout},
+		viewCache:  make(map[string]*entities.ContractViewsResult),
+	}
+}
+
+// ReadContract executa uma função view/pure com os argumentos JSON e retorna os valores nomeados
+func (s *ContractCallService) ReadContract(ctx context.Context, address string, request *entities.ContractReadRequest) (*entities.ContractReadResult, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: endereço inválido", ErrInvalidContractCall)
+	}
+	if request.From != "" && !common.IsHexAddress(request.From) {
+		return nil, fmt.Errorf("%w: from inválido", ErrInvalidContractCall)
+	}
+
+	contractABI, err := loadContractABI(ctx, s.db, address)
+	if err != nil {
+		return nil, err
+	}
+	method, err := resolveContractMethod(contractABI, request.Function, len(request.Args))
+	if err != nil {
+		return nil, err
+	}
+	if !method.IsConstant() {
+		return nil, fmt.Errorf("%w: %s altera estado; apenas funções view/pure podem ser lidas", ErrInvalidContractCall, method.Sig)
+	}
+	data, err := packContractCall(method, request.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := s.resolveBlock(ctx, request.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	call := map[string]interface{}{"to": strings.ToLower(address), "data": hexutil.Encode(data)}
+	if request.From != "" {
+		call["from"] = strings.ToLower(request.From)
+	}
+	response, err := s.callRPC(ctx, "eth_call", call, block.param)
+	if err != nil {
+		return nil, err
+	}
+	output, err := callResult(response, contractABI)
+	if err != nil {
+		return nil, err
+	}
+	if len(output) == 0 && len(method.Outputs) > 0 {
+		return nil, fmt.Errorf("%w: a chamada não retornou dados; o contrato existe nesse bloco?", ErrInvalidContractCall)
+	}
+
+	outputs, err := decodeContractValues(method.Outputs, output)
+	if err != nil {
+		return nil, fmt.Errorf("%w: retorno incompatível com o ABI: %v", ErrInvalidContractCall, err)
+	}
+
+	return &entities.ContractReadResult{
+		Address:     common.HexToAddress(address).Hex(),
+		Function:    method.Sig,
+		Selector:    hexutil.Encode(method.ID),
+		BlockNumber: block.number,
+		Outputs:     outputs,
+		Raw:         hexutil.Encode(output),
+	}, nil
+}
+
+// ReadContractViews executa em lote todas as funções view/pure sem argumentos do contrato (ex.: name,
+// totalSupply, owner). Os resultados ficam em cache por contrato e bloco
+func (s *ContractCallService) ReadContractViews(ctx context.Context, address, blockTag string) (*entities.ContractViewsResult, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: endereço inválido", ErrInvalidContractCall)
+	}
+
+	contractABI, err := loadContractABI(ctx, s.db, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []abi.Method
+	for _, method := range contractABI.Methods {
+		if method.IsConstant() && len(method.Inputs) == 0 && len(method.Outputs) > 0 {
+			methods = append(methods, method)
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Sig < methods[j].Sig })
+	if len(methods) > maxContractViews {
+		methods = methods[:maxContractViews]
+	}
+
+	block, err := s.resolveBlock(ctx, blockTag)
+	if err != nil {
+		return nil, err
+	}
+	if block.number == nil {
+		return nil, fmt.Errorf("%w: a leitura em lote não aceita o bloco pending", ErrInvalidContractCall)
+	}
+
+	cacheKey := strings.ToLower(address) + ":" + strconv.FormatUint(*block.number, 10)
+	if cached := s.cachedViews(cacheKey); cached != nil {
+		return cached, nil
+	}
+
+	result := &entities.ContractViewsResult{
+		Address:     common.HexToAddress(address).Hex(),
+		BlockNumber: *block.number,
+		Functions:   make([]*entities.ContractViewResult, len(methods)),
+	}
+	if len(methods) == 0 {
+		s.storeViews(cacheKey, result)
+		return result, nil
+	}
+
+	requests := make([]contractRPCRequest, len(methods))
+	for i, method := range methods {
+		call := map[string]interface{}{"to": strings.ToLower(address), "data": hexutil.Encode(method.ID)}
+		requests[i] = contractRPCRequest{JSONRPC: "2.0", Method: "eth_call", Params: []interface{}{call, block.param}, ID: i}
+	}
+	responses, err := s.callRPCBatch(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheable := true
+	for i, method := range methods {
+		view := &entities.ContractViewResult{Function: method.Sig, Selector: hexutil.Encode(method.ID)}
+		result.Functions[i] = view
+
+		output, err := callResult(responses[i], contractABI)
+		var revert *ContractRevertError
+		switch {
+		case errors.As(err, &revert):
+			view.Revert = revert.Reason
+		case err != nil:
+			view.Error = err.Error()
+			cacheable = cacheable && !errors.Is(err, ErrRPCUnavailable)
+		case len(output) == 0:
+			view.Error = "a chamada não retornou dados"
+		default:
+			outputs, err := decodeContractValues(method.Outputs, output)
+			if err != nil {
+				view.Error = "retorno incompatível com o ABI: " + err.Error()
+			} else {
+				view.Outputs = outputs
+			}
+		}
+	}
+
+	// Falhas do nó são transitórias: só guarda o bloco quando cada view respondeu ou reverteu
+	if cacheable {
+		s.storeViews(cacheKey, result)
+	}
+	return result, nil
+}
+
+// cachedViews retorna uma cópia do resultado em cache, marcada como cached
+func (s *ContractCallService) cachedViews(key string) *entities.ContractViewsResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.viewCache[key]
+	if !ok {
+		return nil
+	}
+	copied := *cached
+	copied.Cached = true
+	return &copied
+}
+
+// storeViews guarda o resultado, descartando o mais antigo quando o cache está cheio
+func (s *ContractCallService) storeViews(key string, result *entities.ContractViewsResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.viewCache[key]; ok {
+		return
+	}
+	if len(s.cacheOrder) >= contractViewCacheSize {
+		delete(s.viewCache, s.cacheOrder[0])
+		s.cacheOrder = s.cacheOrder[1:]
+	}
+	s.viewCache[key] = result
+	s.cacheOrder = append(s.cacheOrder, key)
+}
+
+// resolveBlock converte o bloco da requisição no parâmetro do eth_call. Tags nomeadas (exceto pending) são
+// fixadas no número do bloco, para que todas as chamadas vejam o mesmo estado
+func (s *ContractCallService) resolveBlock(ctx context.Context, tag string) (*contractCallBlock, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	switch tag {
+	case "pending":
+		return &contractCallBlock{param: tag}, nil
+	case "", "latest", "safe", "finalized", "earliest":
+		if tag == "" {
+			tag = "latest"
+		}
+		response, err := s.callRPC(ctx, "eth_getBlockByNumber", tag, false)
+		if err != nil {
+			return nil, err
+		}
+		if response.Error != nil {
+			return nil, fmt.Errorf("%w: %s", ErrRPCUnavailable, response.Error.Message)
+		}
+		var header struct {
+			Number *hexutil.Uint64 `json:"number"`
+		}
+		if err := json.Unmarshal(response.Result, &header); err != nil || header.Number == nil {
+			return nil, fmt.Errorf("%w: bloco %s não encontrado", ErrInvalidContractCall, tag)
+		}
+		number := uint64(*header.Number)
+		return &contractCallBlock{param: hexutil.EncodeUint64(number), number: &number}, nil
+	}
+
+	number, ok := parseBigInt(tag)
+	if !ok || number.Sign() < 0 || !number.IsUint64() {
+		return nil, fmt.Errorf("%w: bloco deve ser latest, safe, finalized, earliest, pending ou um número", ErrInvalidContractCall)
+	}
+	value := number.Uint64()
+	return &contractCallBlock{param: hexutil.EncodeUint64(value), number: &value}, nil
+}
+
+// callResult extrai o retorno de um eth_call, convertendo reverts em ContractRevertError
+func callResult(response *contractRPCResponse, contractABI *abi.ABI) ([]byte, error) {
+	if response.Error != nil {
+		if data, reverted := response.Error.revertData(); reverted {
+			reason := decodeRevertReason(data, contractABI)
+			if len(data) == 0 && response.Error.Message != "" {
+				reason.Message = response.Error.Message
+			}
+			return nil, &ContractRevertError{Reason: reason}
+		}
+		return nil, fmt.Errorf("%w: %s", ErrRPCUnavailable, response.Error.Message)
+	}
+
+	var text string
+	if err := json.Unmarshal(response.Result, &text); err != nil {
+		return nil, fmt.Errorf("%w: resposta inválida do eth_call", ErrRPCUnavailable)
+	}
+	output, err := hexutil.Decode(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: resposta inválida do eth_call", ErrRPCUnavailable)
+	}
+	return output, nil
+}
+
+// callRPC envia uma requisição JSON-RPC ao nó; erros JSON-RPC ficam em response.Error
+func (s *ContractCallService) callRPC(ctx context.Context, method string, params ...interface{}) (*contractRPCResponse, error) {
+	request := contractRPCRequest{JSONRPC: "2.0", Method: method, Params: params, ID: 1}
+	var response contractRPCResponse
+	if err := s.postRPC(ctx, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// callRPCBatch envia as requisições em um único batch JSON-RPC e retorna as respostas na ordem das requisições
+func (s *ContractCallService) callRPCBatch(ctx context.Context, requests []contractRPCRequest) ([]*contractRPCResponse, error) {
+	var responses []*contractRPCResponse
+	if err := s.postRPC(ctx, requests, &responses); err != nil {
+		return nil, err
+	}
+
+	ordered := make([]*contractRPCResponse, len(requests))
+	for _, response := range responses {
+		if response != nil && response.ID >= 0 && response.ID < len(ordered) {
+			ordered[response.ID] = response
+		}
+	}
+	for i, response := range ordered {
+		if response == nil {
+			ordered[i] = &contractRPCResponse{ID: i, Error: &contractRPCError{Message: "sem resposta do nó"}}
+		}
+	}
+	return ordered, nil
+}
+
+// postRPC faz o POST JSON-RPC
+func (s *ContractCallService) postRPC(ctx context.Context, payload, response interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar requisição JSON-RPC: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("erro ao criar requisição JSON-RPC: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRPCUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRPCUnavailable, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: status HTTP %d", ErrRPCUnavailable, resp.StatusCode)
+	}
+	if err := json.Unmarshal(content, response); err != nil {
+		return fmt.Errorf("%w: resposta JSON-RPC inválida", ErrRPCUnavailable)
+	}
+	return nil
+}
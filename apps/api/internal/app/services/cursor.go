@@ -0,0 +1,85 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCursor indica um cursor de paginação malformado ou incompatível com a ordenação
+var ErrInvalidCursor = errors.New("cursor de paginação inválido")
+
+// PageCursor guarda a posição da última linha retornada na ordenação (block_number, tx_index, log_index).
+// É trafegado como token opaco para paginação por keyset, sem OFFSET
+type PageCursor struct {
+	BlockNumber uint64 `json:"b"`
+	TxIndex     uint64 `json:"t,omitempty"`
+	LogIndex    uint64 `json:"l,omitempty"`
+	Desc        bool   `json:"d,omitempty"`
+}
+
+// Encode serializa o cursor em um token base64 seguro para URLs
+func (c PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor converte um token recebido na query string em cursor
+func DecodeCursor(token string) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(token))
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// keysetCondition gera a comparação de tupla que posiciona a consulta após o cursor,
+// ex: block_number <= $3 AND (block_number, transaction_index) < ($3, $4). A condição redundante
+// na primeira coluna permite ao PostgreSQL descartar as partições por faixa de blocos, o que a
+// comparação de tupla sozinha não faz. Os placeholders começam em argIndex+1
+func (c PageCursor) keysetCondition(columns []string, argIndex int) string {
+	operator := ">"
+	if c.Desc {
+		operator = "<"
+	}
+
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", argIndex+i+1)
+	}
+
+	condition := fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), operator, strings.Join(placeholders, ", "))
+	if len(columns) > 1 {
+		condition = fmt.Sprintf("%s %s= %s AND %s", columns[0], operator, placeholders[0], condition)
+	}
+	return condition
+}
+
+// keysetOrder gera o ORDER BY estável usado pela paginação por keyset
+func keysetOrder(columns []string, desc bool) string {
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+
+	ordered := make([]string, len(columns))
+	for i, column := range columns {
+		ordered[i] = column + " " + direction
+	}
+	return "ORDER BY " + strings.Join(ordered, ", ")
+}
+
+// totalPagesFor calcula o número de páginas para um total e limite
+func totalPagesFor(total int64, limit int) int {
+	if limit <= 0 {
+		return 0
+	}
+	return int((total + int64(limit) - 1) / int64(limit))
+}
@@ -8,7 +8,7 @@ import (
 // EventService define as operações de negócio para eventos
 type EventService interface {
 	// GetEvents busca eventos com filtros e paginação
-	GetEvents(ctx context.Context, filters entities.EventFilters) ([]*entities.EventSummary, int64, error)
+	GetEvents(ctx context.Context, filters entities.EventFilters) (*PaginatedResult[*entities.EventSummary], error)
 
 	// GetEventByID busca um evento pelo ID
 	GetEventByID(ctx context.Context, id string) (*entities.Event, error)
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/infrastructure/database"
 
 	_ "github.com/lib/pq"
 )
@@ -47,22 +48,31 @@ func NewEventService() EventService {
 }
 
 // GetEvents busca eventos com filtros e paginação
-func (s *eventServiceImpl) GetEvents(ctx context.Context, filters entities.EventFilters) ([]*entities.EventSummary, int64, error) {
+func (s *eventServiceImpl) GetEvents(ctx context.Context, filters entities.EventFilters) (*PaginatedResult[*entities.EventSummary], error) {
 	// Construir query base
 	baseQuery := `
 		SELECT e.id, e.contract_address, e.event_name, e.transaction_hash, 
-		       e.block_number, e.timestamp, e.from_address, e.to_address,
+		       e.block_number, e.log_index, e.timestamp, e.from_address, e.to_address,
 		       e.data, e.decoded_data, sc.name as contract_name
 		FROM events e
 		LEFT JOIN smart_contracts sc ON e.contract_address = sc.address
 	`
 
-	countQuery := `
-		SELECT COUNT(*)
+	countFromClause := `
 		FROM events e
 		LEFT JOIN smart_contracts sc ON e.contract_address = sc.address
 	`
 
+	// Eventos são sempre ordenados de forma decrescente; o cursor precisa seguir a mesma direção
+	var cursor *PageCursor
+	if filters.Cursor != nil && *filters.Cursor != "" {
+		decoded, err := DecodeCursor(*filters.Cursor)
+		if err != nil || !decoded.Desc {
+			return nil, ErrInvalidCursor
+		}
+		cursor = decoded
+	}
+
 	// Construir condições WHERE
 	var conditions []string
 	var args []interface{}
@@ -111,25 +121,37 @@ func (s *eventServiceImpl) GetEvents(ctx context.Context, filters entities.Event
 		whereClause = " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	// Contar total de registros
-	var totalCount int64
-	err := s.db.QueryRowContext(ctx, countQuery+whereClause, args...).Scan(&totalCount)
+	// Contar total de registros (estimado em consultas muito grandes)
+	totalCount, approximate, err := database.EstimateCount(ctx, s.db, countFromClause+whereClause, args)
 	if err != nil {
-		return nil, 0, fmt.Errorf("erro ao contar eventos: %w", err)
+		return nil, fmt.Errorf("erro ao contar eventos: %w", err)
+	}
+
+	// Posicionar pelo cursor (keyset) apenas na busca; a contagem reflete o filtro completo
+	offset := (filters.Page - 1) * filters.Limit
+	if cursor != nil {
+		condition := cursor.keysetCondition(eventKeysetColumns, argIndex-1)
+		if whereClause == "" {
+			whereClause = " WHERE " + condition
+		} else {
+			whereClause += " AND " + condition
+		}
+		args = append(args, cursor.BlockNumber, cursor.LogIndex)
+		argIndex += 2
+		offset = 0
 	}
 
-	// Construir query final com ordenação e paginação
-	orderBy := " ORDER BY e.block_number DESC, e.log_index DESC"
+	// Construir query final com ordenação e paginação - uma linha a mais indica se existe próxima página
+	orderBy := " " + keysetOrder(eventKeysetColumns, true)
 	limitOffset := fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
 
 	finalQuery := baseQuery + whereClause + orderBy + limitOffset
-	offset := (filters.Page - 1) * filters.Limit
-	args = append(args, filters.Limit, offset)
+	args = append(args, filters.Limit+1, offset)
 
 	// Executar query
 	rows, err := s.db.QueryContext(ctx, finalQuery, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("erro ao buscar eventos: %w", err)
+		return nil, fmt.Errorf("erro ao buscar eventos: %w", err)
 	}
 	defer rows.Close()
 
@@ -146,6 +168,7 @@ func (s *eventServiceImpl) GetEvents(ctx context.Context, filters entities.Event
 			&event.EventName,
 			&event.TransactionHash,
 			&event.BlockNumber,
+			&event.LogIndex,
 			&event.Timestamp,
 			&fromAddress,
 			&toAddress,
@@ -180,9 +203,35 @@ func (s *eventServiceImpl) GetEvents(ctx context.Context, filters entities.Event
 		events = append(events, &event)
 	}
 
-	return events, totalCount, nil
+	hasMore := len(events) > filters.Limit
+	if hasMore {
+		events = events[:filters.Limit]
+	}
+
+	result := &PaginatedResult[*entities.EventSummary]{
+		Data:             events,
+		Page:             filters.Page,
+		Limit:            filters.Limit,
+		Total:            int(totalCount),
+		TotalPages:       totalPagesFor(totalCount, filters.Limit),
+		TotalApproximate: approximate,
+	}
+
+	if hasMore {
+		last := events[len(events)-1]
+		result.NextCursor = PageCursor{
+			BlockNumber: last.BlockNumber,
+			LogIndex:    last.LogIndex,
+			Desc:        true,
+		}.Encode()
+	}
+
+	return result, nil
 }
 
+// eventKeysetColumns são as colunas da ordenação por keyset de eventos
+var eventKeysetColumns = []string{"e.block_number", "e.log_index"}
+
 // GetEventByID busca um evento pelo ID
 func (s *eventServiceImpl) GetEventByID(ctx context.Context, id string) (*entities.Event, error) {
 	query := `
@@ -302,11 +351,13 @@ func (s *eventServiceImpl) GetEventStats(ctx context.Context) (*entities.EventSt
 		stats.PopularEvents = append(stats.PopularEvents, popular)
 	}
 
-	// Atividade recente (últimos eventos por data)
+	// Atividade recente (últimos eventos por data), limitada aos blocos da janela para
+	// ler apenas as partições recentes
 	rows, err = s.db.QueryContext(ctx, `
 		SELECT DATE(timestamp) as date, COUNT(*) as count
 		FROM events
 		WHERE timestamp >= NOW() - INTERVAL '7 days'
+			AND `+blockLowerBound("block_number", "NOW() - INTERVAL '7 days'")+`
 		GROUP BY DATE(timestamp)
 		ORDER BY date DESC
 		LIMIT 10
@@ -335,7 +386,12 @@ func (s *eventServiceImpl) SearchEvents(ctx context.Context, query string, limit
 		Limit:     limit,
 		Page:      (offset / limit) + 1,
 	}
-	return s.GetEvents(ctx, filters)
+
+	result, err := s.GetEvents(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result.Data, int64(result.Total), nil
 }
 
 // GetEventsByContract busca eventos por endereço do contrato
@@ -402,8 +458,12 @@ func (s *eventServiceImpl) CountEventsByContract(ctx context.Context, contractAd
 // mockEventService para fallback em caso de erro de conexão
 type mockEventService struct{}
 
-func (s *mockEventService) GetEvents(ctx context.Context, filters entities.EventFilters) ([]*entities.EventSummary, int64, error) {
-	return []*entities.EventSummary{}, 0, nil
+func (s *mockEventService) GetEvents(ctx context.Context, filters entities.EventFilters) (*PaginatedResult[*entities.EventSummary], error) {
+	return &PaginatedResult[*entities.EventSummary]{
+		Data:  []*entities.EventSummary{},
+		Page:  filters.Page,
+		Limit: filters.Limit,
+	}, nil
 }
 
 func (s *mockEventService) GetEventByID(ctx context.Context, id string) (*entities.Event, error) {
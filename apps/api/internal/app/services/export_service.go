@@ -0,0 +1,626 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrInvalidExport indica parâmetros inválidos para exportação
+var ErrInvalidExport = errors.New("parâmetros de exportação inválidos")
+
+const (
+	// exportFetchSize é o número de linhas buscadas do cursor por vez
+	exportFetchSize = 1000
+	// exportMaxDecodedKeys limita as colunas decodificadas descobertas automaticamente
+	exportMaxDecodedKeys = 100
+	// exportDecodedPrefix é o prefixo das colunas com campos decodificados da ABI
+	exportDecodedPrefix = "decoded."
+)
+
+// ExportFormat representa o formato de saída da exportação
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+// ExportDataset identifica o conjunto de dados exportado
+type ExportDataset string
+
+const (
+	ExportAccountTransactions   ExportDataset = "account_transactions"
+	ExportAccountEvents         ExportDataset = "account_events"
+	ExportAccountTokenTransfers ExportDataset = "account_token_transfers"
+	ExportContractEvents        ExportDataset = "contract_events"
+)
+
+// ExportFilters representa os filtros de uma exportação
+type ExportFilters struct {
+	Format    ExportFormat
+	Columns   []string // Vazio exporta todas as colunas
+	FromDate  *time.Time
+	ToDate    *time.Time
+	FromBlock *int64
+	ToBlock   *int64
+}
+
+// exportColumnKind define como o valor da coluna é serializado
+type exportColumnKind int
+
+const (
+	exportText exportColumnKind = iota
+	exportNumber
+	exportJSON
+	exportHexInt // Inteiro em hexadecimal convertido para decimal
+)
+
+// exportColumn representa uma coluna exportável
+type exportColumn struct {
+	Name string
+	Expr string
+	Kind exportColumnKind
+}
+
+// exportDatasetSpec descreve a query de um conjunto de dados exportável
+type exportDatasetSpec struct {
+	From        string // FROM com joins
+	Where       string // Condição base usando $1 (e $2 quando aplicável)
+	BlockExpr   string
+	TimeExpr    string
+	OrderBy     string
+	DecodedExpr string // Expressão jsonb com campos decodificados ("" se não houver)
+	Columns     []exportColumn
+}
+
+var exportDatasets = map[ExportDataset]exportDatasetSpec{
+	ExportAccountTransactions: {
+		From:        "account_transactions at",
+		Where:       "at.account_address = $1",
+		BlockExpr:   "at.block_number",
+		TimeExpr:    "at.timestamp",
+		OrderBy:     "at.block_number, at.transaction_index",
+		DecodedExpr: "at.decoded_input",
+		Columns: []exportColumn{
+			{"transaction_hash", "at.transaction_hash", exportText},
+			{"block_number", "at.block_number", exportNumber},
+			{"transaction_index", "at.transaction_index", exportNumber},
+			{"timestamp", "at.timestamp", exportText},
+			{"transaction_type", "at.transaction_type", exportText},
+			{"from_address", "at.from_address", exportText},
+			{"to_address", "at.to_address", exportText},
+			{"value", "at.value", exportText},
+			{"gas_limit", "at.gas_limit", exportNumber},
+			{"gas_used", "at.gas_used", exportNumber},
+			{"gas_price", "at.gas_price", exportText},
+			{"status", "at.status", exportText},
+			{"method_name", "at.method_name", exportText},
+			{"method_signature", "at.method_signature", exportText},
+			{"contract_address", "at.contract_address", exportText},
+			{"contract_name", "at.contract_name", exportText},
+			{"error_message", "at.error_message", exportText},
+		},
+	},
+	ExportAccountEvents: {
+		From:        "account_events ae",
+		Where:       "ae.account_address = $1",
+		BlockExpr:   "ae.block_number",
+		TimeExpr:    "ae.timestamp",
+		OrderBy:     "ae.block_number, ae.log_index",
+		DecodedExpr: "ae.decoded_data",
+		Columns: []exportColumn{
+			{"event_id", "ae.event_id", exportText},
+			{"transaction_hash", "ae.transaction_hash", exportText},
+			{"block_number", "ae.block_number", exportNumber},
+			{"log_index", "ae.log_index", exportNumber},
+			{"timestamp", "ae.timestamp", exportText},
+			{"contract_address", "ae.contract_address", exportText},
+			{"contract_name", "ae.contract_name", exportText},
+			{"event_name", "ae.event_name", exportText},
+			{"event_signature", "ae.event_signature", exportText},
+			{"involvement_type", "ae.involvement_type", exportText},
+			{"topics", "ae.topics", exportJSON},
+		},
+	},
+	ExportAccountTokenTransfers: {
+		From: "events e LEFT JOIN smart_contracts sc ON e.contract_address = sc.address",
+		Where: `e.event_name = 'Transfer' AND (e.decoded_data @> jsonb_build_object('from', $1::text)
+			OR e.decoded_data @> jsonb_build_object('to', $1::text))`,
+		BlockExpr: "e.block_number",
+		TimeExpr:  "e.timestamp",
+		OrderBy:   "e.block_number, e.log_index",
+		Columns: []exportColumn{
+			{"transaction_hash", "e.transaction_hash", exportText},
+			{"block_number", "e.block_number", exportNumber},
+			{"log_index", "e.log_index", exportNumber},
+			{"timestamp", "e.timestamp", exportText},
+			{"direction", "CASE WHEN e.decoded_data->>'from' = $1 THEN 'out' ELSE 'in' END", exportText},
+			{"token_address", "e.contract_address", exportText},
+			{"token_name", "sc.name", exportText},
+			{"token_symbol", "sc.symbol", exportText},
+			{"token_type", "sc.contract_type", exportText},
+			{"from_address", "e.decoded_data->>'from'", exportText},
+			{"to_address", "e.decoded_data->>'to'", exportText},
+			{"value", "e.decoded_data->>'value'", exportHexInt},
+			{"token_id", "e.decoded_data->>'tokenId'", exportHexInt},
+		},
+	},
+	ExportContractEvents: {
+		From:        "events e",
+		Where:       "e.contract_address IN ($1, $2)",
+		BlockExpr:   "e.block_number",
+		TimeExpr:    "e.timestamp",
+		OrderBy:     "e.block_number, e.log_index",
+		DecodedExpr: "e.decoded_data",
+		Columns: []exportColumn{
+			{"event_id", "e.id", exportText},
+			{"transaction_hash", "e.transaction_hash", exportText},
+			{"block_number", "e.block_number", exportNumber},
+			{"log_index", "e.log_index", exportNumber},
+			{"transaction_index", "e.transaction_index", exportNumber},
+			{"timestamp", "e.timestamp", exportText},
+			{"event_name", "e.event_name", exportText},
+			{"event_signature", "e.event_signature", exportText},
+			{"from_address", "e.from_address", exportText},
+			{"to_address", "e.to_address", exportText},
+			{"topics", "e.topics", exportJSON},
+			{"status", "e.status", exportText},
+		},
+	},
+}
+
+// ExportJob representa uma exportação validada e pronta para streaming
+type ExportJob struct {
+	Dataset     ExportDataset
+	Format      ExportFormat
+	Filename    string
+	query       string
+	args        []interface{}
+	columns     []exportColumn
+	decodedKeys []string // Colunas decodificadas selecionadas (nil = todas no NDJSON)
+	hasDecoded  bool
+}
+
+// ContentType retorna o content type da exportação
+func (j *ExportJob) ContentType() string {
+	if j.Format == ExportFormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ExportService exporta históricos em CSV/NDJSON diretamente de um cursor do PostgreSQL
+type ExportService struct {
+	db *sql.DB
+}
+
+// NewExportService cria uma nova instância do serviço de exportação
+func NewExportService(db *sql.DB) *ExportService {
+	return &ExportService{db: db}
+}
+
+// PrepareExport valida os filtros e monta a query da exportação
+func (s *ExportService) PrepareExport(ctx context.Context, dataset ExportDataset, address string, filters *ExportFilters) (*ExportJob, error) {
+	spec, ok := exportDatasets[dataset]
+	if !ok {
+		return nil, fmt.Errorf("%w: conjunto de dados desconhecido %s", ErrInvalidExport, dataset)
+	}
+
+	if !isHexAddress(address) {
+		return nil, fmt.Errorf("%w: endereço inválido", ErrInvalidExport)
+	}
+	address = strings.ToLower(address)
+
+	switch filters.Format {
+	case "":
+		filters.Format = ExportFormatCSV
+	case ExportFormatCSV, ExportFormatNDJSON:
+	default:
+		return nil, fmt.Errorf("%w: formato deve ser csv ou ndjson", ErrInvalidExport)
+	}
+
+	if filters.FromBlock != nil && filters.ToBlock != nil && *filters.FromBlock > *filters.ToBlock {
+		return nil, fmt.Errorf("%w: from_block maior que to_block", ErrInvalidExport)
+	}
+	if filters.FromDate != nil && filters.ToDate != nil && filters.FromDate.After(*filters.ToDate) {
+		return nil, fmt.Errorf("%w: from_date maior que to_date", ErrInvalidExport)
+	}
+
+	// Montar condições
+	args := []interface{}{address}
+	if dataset == ExportContractEvents {
+		// Eventos são gravados com o endereço em formato checksum pelo indexer
+		args = append(args, toChecksumAddress(address))
+	}
+	conditions := []string{spec.Where}
+	if filters.FromBlock != nil {
+		args = append(args, *filters.FromBlock)
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", spec.BlockExpr, len(args)))
+	}
+	if filters.ToBlock != nil {
+		args = append(args, *filters.ToBlock)
+		conditions = append(conditions, fmt.Sprintf("%s <= $%d", spec.BlockExpr, len(args)))
+	}
+	if filters.FromDate != nil {
+		args = append(args, *filters.FromDate)
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", spec.TimeExpr, len(args)))
+	}
+	if filters.ToDate != nil {
+		args = append(args, *filters.ToDate)
+		conditions = append(conditions, fmt.Sprintf("%s <= $%d", spec.TimeExpr, len(args)))
+	}
+	fromWhere := fmt.Sprintf("FROM %s WHERE %s", spec.From, strings.Join(conditions, " AND "))
+
+	job := &ExportJob{
+		Dataset: dataset,
+		Format:  filters.Format,
+		args:    args,
+		Filename: fmt.Sprintf("%s_%s_%s.%s", dataset, address, time.Now().UTC().Format("20060102T150405Z"),
+			filters.Format),
+	}
+
+	// Selecionar colunas
+	if len(filters.Columns) == 0 {
+		job.columns = spec.Columns
+		job.hasDecoded = spec.DecodedExpr != ""
+		if job.hasDecoded && job.Format == ExportFormatCSV {
+			// CSV precisa do cabeçalho completo antes da primeira linha
+			keys, err := s.discoverDecodedKeys(ctx, spec.DecodedExpr, fromWhere, args)
+			if err != nil {
+				return nil, err
+			}
+			job.decodedKeys = keys
+		}
+	} else {
+		byName := make(map[string]exportColumn, len(spec.Columns))
+		for _, column := range spec.Columns {
+			byName[column.Name] = column
+		}
+
+		job.decodedKeys = []string{}
+		for _, name := range filters.Columns {
+			name = strings.TrimSpace(name)
+			if column, ok := byName[name]; ok {
+				job.columns = append(job.columns, column)
+				continue
+			}
+			if strings.HasPrefix(name, exportDecodedPrefix) && spec.DecodedExpr != "" && len(name) > len(exportDecodedPrefix) {
+				job.decodedKeys = append(job.decodedKeys, strings.TrimPrefix(name, exportDecodedPrefix))
+				job.hasDecoded = true
+				continue
+			}
+			return nil, fmt.Errorf("%w: coluna desconhecida %s", ErrInvalidExport, name)
+		}
+	}
+
+	// Montar SELECT
+	selects := make([]string, 0, len(job.columns)+1)
+	for _, column := range job.columns {
+		selects = append(selects, column.Expr)
+	}
+	if job.hasDecoded {
+		selects = append(selects, spec.DecodedExpr+"::text")
+	}
+	if len(selects) == 0 {
+		return nil, fmt.Errorf("%w: nenhuma coluna selecionada", ErrInvalidExport)
+	}
+
+	job.query = fmt.Sprintf("SELECT %s %s ORDER BY %s", strings.Join(selects, ", "), fromWhere, spec.OrderBy)
+
+	return job, nil
+}
+
+// discoverDecodedKeys busca os campos decodificados presentes no intervalo exportado
+func (s *ExportService) discoverDecodedKeys(ctx context.Context, decodedExpr, fromWhere string, args []interface{}) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT DISTINCT key FROM (
+			SELECT jsonb_object_keys(%[1]s) AS key %[2]s AND jsonb_typeof(%[1]s) = 'object'
+		) k ORDER BY key LIMIT %[3]d`, decodedExpr, fromWhere, exportMaxDecodedKeys)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao descobrir campos decodificados: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// Stream executa a exportação escrevendo as linhas conforme são lidas do cursor.
+// Retorna o número de linhas exportadas.
+func (s *ExportService) Stream(ctx context.Context, job *ExportJob, w io.Writer) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("erro ao iniciar transação de exportação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DECLARE export_cursor NO SCROLL CURSOR FOR "+job.query, job.args...); err != nil {
+		return 0, fmt.Errorf("erro ao abrir cursor de exportação: %w", err)
+	}
+
+	writer := newExportRowWriter(job, w)
+	if err := writer.writeHeader(); err != nil {
+		return 0, err
+	}
+
+	width := len(job.columns)
+	if job.hasDecoded {
+		width++
+	}
+	values := make([]sql.NullString, width)
+	dest := make([]interface{}, width)
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	var total int64
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM export_cursor", exportFetchSize)
+	for {
+		rows, err := tx.QueryContext(ctx, fetchQuery)
+		if err != nil {
+			return total, fmt.Errorf("erro ao ler cursor de exportação: %w", err)
+		}
+
+		fetched := 0
+		for rows.Next() {
+			if err := rows.Scan(dest...); err != nil {
+				rows.Close()
+				return total, fmt.Errorf("erro ao ler linha exportada: %w", err)
+			}
+			if err := writer.writeRow(values); err != nil {
+				rows.Close()
+				return total, err
+			}
+			fetched++
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return total, fmt.Errorf("erro ao ler cursor de exportação: %w", err)
+		}
+		rows.Close()
+
+		total += int64(fetched)
+		if err := writer.flush(); err != nil {
+			return total, err
+		}
+
+		if fetched < exportFetchSize {
+			break
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("⚠️ Erro ao finalizar transação de exportação: %v", err)
+	}
+
+	return total, nil
+}
+
+// exportRowWriter serializa linhas em CSV ou NDJSON
+type exportRowWriter struct {
+	job     *ExportJob
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	json    *json.Encoder
+	flusher interface{ Flush() }
+}
+
+// newExportRowWriter cria o writer adequado ao formato da exportação
+func newExportRowWriter(job *ExportJob, w io.Writer) *exportRowWriter {
+	writer := &exportRowWriter{job: job, buf: bufio.NewWriterSize(w, 64*1024)}
+	if flusher, ok := w.(interface{ Flush() }); ok {
+		writer.flusher = flusher
+	}
+	if job.Format == ExportFormatNDJSON {
+		writer.json = json.NewEncoder(writer.buf)
+	} else {
+		writer.csv = csv.NewWriter(writer.buf)
+	}
+	return writer
+}
+
+// writeHeader escreve o cabeçalho do CSV
+func (w *exportRowWriter) writeHeader() error {
+	if w.csv == nil {
+		return nil
+	}
+
+	header := make([]string, 0, len(w.job.columns)+len(w.job.decodedKeys))
+	for _, column := range w.job.columns {
+		header = append(header, column.Name)
+	}
+	for _, key := range w.job.decodedKeys {
+		header = append(header, exportDecodedPrefix+key)
+	}
+	return w.csv.Write(header)
+}
+
+// writeRow escreve uma linha no formato da exportação
+func (w *exportRowWriter) writeRow(values []sql.NullString) error {
+	var decoded map[string]interface{}
+	if w.job.hasDecoded {
+		if raw := values[len(values)-1]; raw.Valid && raw.String != "" {
+			if err := json.Unmarshal([]byte(raw.String), &decoded); err != nil {
+				decoded = nil // Conteúdo que não é objeto não é achatado
+			}
+		}
+	}
+
+	if w.csv != nil {
+		record := make([]string, 0, len(w.job.columns)+len(w.job.decodedKeys))
+		for i, column := range w.job.columns {
+			record = append(record, formatExportText(column.Kind, values[i]))
+		}
+		for _, key := range w.job.decodedKeys {
+			record = append(record, formatDecodedText(decoded[key]))
+		}
+		return w.csv.Write(record)
+	}
+
+	row := make(map[string]interface{}, len(w.job.columns)+len(decoded))
+	for i, column := range w.job.columns {
+		row[column.Name] = formatExportJSON(column.Kind, values[i])
+	}
+	if w.job.decodedKeys == nil {
+		for key, value := range decoded {
+			row[exportDecodedPrefix+key] = value
+		}
+	} else {
+		for _, key := range w.job.decodedKeys {
+			row[exportDecodedPrefix+key] = decoded[key]
+		}
+	}
+	return w.json.Encode(row)
+}
+
+// flush envia ao cliente o que foi escrito até agora
+func (w *exportRowWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+	return nil
+}
+
+// formatExportText formata um valor para CSV
+func formatExportText(kind exportColumnKind, value sql.NullString) string {
+	if !value.Valid {
+		return ""
+	}
+	if kind == exportHexInt {
+		return hexToDecimalString(value.String)
+	}
+	return value.String
+}
+
+// formatExportJSON formata um valor para NDJSON preservando números e JSON
+func formatExportJSON(kind exportColumnKind, value sql.NullString) interface{} {
+	if !value.Valid {
+		return nil
+	}
+	switch kind {
+	case exportNumber:
+		return json.Number(value.String)
+	case exportJSON:
+		return json.RawMessage(value.String)
+	case exportHexInt:
+		return hexToDecimalString(value.String)
+	default:
+		return value.String
+	}
+}
+
+// formatDecodedText formata um campo decodificado para uma célula CSV
+func formatDecodedText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+}
+
+// hexToDecimalString converte "0x..." para decimal, mantendo o valor original se não for hex
+func hexToDecimalString(value string) string {
+	if !strings.HasPrefix(value, "0x") {
+		return value
+	}
+	n, ok := new(big.Int).SetString(value[2:], 16)
+	if !ok {
+		return value
+	}
+	return n.String()
+}
+
+// isHexAddress verifica se a string é um endereço hexadecimal de 20 bytes
+func isHexAddress(address string) bool {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(address[2:])
+	return err == nil
+}
+
+// toChecksumAddress converte um endereço para o formato EIP-55
+func toChecksumAddress(address string) string {
+	lower := strings.ToLower(strings.TrimPrefix(address, "0x"))
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(lower))
+	hash := hex.EncodeToString(hasher.Sum(nil))
+
+	result := []byte(lower)
+	for i, c := range result {
+		if c >= 'a' && c <= 'f' && hash[i] >= '8' {
+			result[i] = c - 32
+		}
+	}
+	return "0x" + string(result)
+}
+
+// ParseExportColumns converte a lista de colunas separada por vírgula
+func ParseExportColumns(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	var columns []string
+	for _, column := range strings.Split(raw, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// ExportColumns retorna as colunas disponíveis de um conjunto de dados
+func ExportColumns(dataset ExportDataset) []string {
+	spec, ok := exportDatasets[dataset]
+	if !ok {
+		return nil
+	}
+	columns := make([]string, 0, len(spec.Columns)+1)
+	for _, column := range spec.Columns {
+		columns = append(columns, column.Name)
+	}
+	if spec.DecodedExpr != "" {
+		columns = append(columns, exportDecodedPrefix+"<campo>")
+	}
+	return columns
+}
@@ -0,0 +1,705 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrInvalidFlow indica parâmetros inválidos para o grafo de fluxo de fundos
+var ErrInvalidFlow = errors.New("parâmetros do grafo de fluxo inválidos")
+
+const (
+	// FlowAssetNative identifica transferências da moeda nativa nas arestas e no filtro token
+	FlowAssetNative = "native"
+
+	// flowMaxHops limita a profundidade do grafo
+	flowMaxHops = 3
+	// flowDefaultCounterparties é o número padrão de contrapartes mantidas por endereço em cada hop
+	flowDefaultCounterparties = 25
+	// flowMaxCounterparties é o máximo de contrapartes mantidas por endereço em cada hop
+	flowMaxCounterparties = 100
+	// flowDefaultNodes é o número padrão de nós do grafo
+	flowDefaultNodes = 200
+	// flowMaxNodes é o máximo de nós do grafo
+	flowMaxNodes = 1000
+	// flowMaxTokenRows limita as transferências de tokens lidas por hop (somadas em Go por serem uint256)
+	flowMaxTokenRows = 50000
+	// flowNativeDecimals são as casas decimais da moeda nativa
+	flowNativeDecimals = 18
+	// flowTransferTopic é o topic0 do evento Transfer(address,address,uint256)
+	flowTransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+)
+
+// FlowDirection define quais transferências são seguidas a partir de cada endereço
+type FlowDirection string
+
+const (
+	FlowDirectionOut  FlowDirection = "out"  // Para onde os fundos foram
+	FlowDirectionIn   FlowDirection = "in"   // De onde os fundos vieram
+	FlowDirectionBoth FlowDirection = "both" // Os dois sentidos
+)
+
+// FlowQuery representa os parâmetros do grafo de fluxo de fundos
+type FlowQuery struct {
+	Address           string
+	Direction         FlowDirection
+	Hops              int
+	Token             string // Vazio = moeda nativa e tokens ERC-20; "native" ou endereço do token
+	FromDate          *time.Time
+	ToDate            *time.Time
+	MinValue          *big.Int // Valor mínimo da aresta, nas unidades mínimas do ativo (wei)
+	MinTxCount        int64    // Número mínimo de transferências da aresta
+	MaxCounterparties int      // Contrapartes mantidas por endereço em cada hop, por número de transferências
+	MaxNodes          int
+}
+
+// FlowNode é um endereço do grafo
+type FlowNode struct {
+	Address          string   `json:"address"`
+	Hop              int      `json:"hop"` // Distância até o endereço consultado
+	Label            *string  `json:"label,omitempty"`
+	ContractName     *string  `json:"contract_name,omitempty"`
+	IsContract       bool     `json:"is_contract"`
+	Tags             []string `json:"tags,omitempty"`
+	ComplianceStatus *string  `json:"compliance_status,omitempty"` // nil se o endereço não está em accounts
+	RiskScore        *int     `json:"risk_score,omitempty"`
+}
+
+// FlowEdge é o total transferido de um endereço para outro em um ativo
+type FlowEdge struct {
+	From          string    `json:"from"`
+	To            string    `json:"to"`
+	Asset         string    `json:"asset"` // "native" ou endereço do token
+	TokenSymbol   *string   `json:"token_symbol,omitempty"`
+	TokenDecimals *int      `json:"token_decimals,omitempty"`
+	Value         string    `json:"value"`            // Unidades mínimas do ativo
+	Amount        *string   `json:"amount,omitempty"` // Valor com as casas decimais aplicadas, quando conhecidas
+	TxCount       int64     `json:"tx_count"`
+	FirstSeenAt   time.Time `json:"first_seen_at"`
+	LastSeenAt    time.Time `json:"last_seen_at"`
+
+	value *big.Int
+}
+
+// FlowGraph é o grafo de fluxo de fundos de um endereço
+type FlowGraph struct {
+	Root      string        `json:"root"`
+	Direction FlowDirection `json:"direction"`
+	Hops      int           `json:"hops"`
+	Nodes     []*FlowNode   `json:"nodes"`
+	Edges     []*FlowEdge   `json:"edges"`
+	Truncated bool          `json:"truncated"` // Algum limite de nós ou de transferências foi atingido
+}
+
+// FlowService monta grafos de fluxo de fundos (moeda nativa e ERC-20) para investigações
+type FlowService struct {
+	db *sql.DB
+}
+
+// NewFlowService cria uma nova instância do serviço de fluxo de fundos
+func NewFlowService(db *sql.DB) *FlowService {
+	return &FlowService{db: db}
+}
+
+// flowEdgeKey identifica uma aresta agregada
+type flowEdgeKey struct {
+	from, to, asset string
+}
+
+// normalize valida os parâmetros e aplica os padrões
+func (q *FlowQuery) normalize() error {
+	if !isHexAddress(q.Address) {
+		return fmt.Errorf("%w: endereço inválido", ErrInvalidFlow)
+	}
+	q.Address = strings.ToLower(q.Address)
+
+	switch q.Direction {
+	case "":
+		q.Direction = FlowDirectionOut
+	case FlowDirectionOut, FlowDirectionIn, FlowDirectionBoth:
+	default:
+		return fmt.Errorf("%w: direction deve ser out, in ou both", ErrInvalidFlow)
+	}
+
+	if q.Hops == 0 {
+		q.Hops = 1
+	}
+	if q.Hops < 1 || q.Hops > flowMaxHops {
+		return fmt.Errorf("%w: hops deve estar entre 1 e %d", ErrInvalidFlow, flowMaxHops)
+	}
+
+	q.Token = strings.ToLower(strings.TrimSpace(q.Token))
+	if q.Token != "" && q.Token != FlowAssetNative && !isHexAddress(q.Token) {
+		return fmt.Errorf("%w: token deve ser native ou o endereço do token", ErrInvalidFlow)
+	}
+
+	if q.FromDate != nil && q.ToDate != nil && q.FromDate.After(*q.ToDate) {
+		return fmt.Errorf("%w: from maior que to", ErrInvalidFlow)
+	}
+	if q.MinValue != nil && q.MinValue.Sign() < 0 {
+		return fmt.Errorf("%w: min_value não pode ser negativo", ErrInvalidFlow)
+	}
+	if q.MinTxCount < 0 {
+		return fmt.Errorf("%w: min_tx_count não pode ser negativo", ErrInvalidFlow)
+	}
+
+	if q.MaxCounterparties <= 0 {
+		q.MaxCounterparties = flowDefaultCounterparties
+	}
+	if q.MaxCounterparties > flowMaxCounterparties {
+		q.MaxCounterparties = flowMaxCounterparties
+	}
+	if q.MaxNodes <= 0 {
+		q.MaxNodes = flowDefaultNodes
+	}
+	if q.MaxNodes > flowMaxNodes {
+		q.MaxNodes = flowMaxNodes
+	}
+	return nil
+}
+
+// BuildGraph expande o grafo hop a hop a partir do endereço, agregando as transferências por contraparte e ativo.
+// Arestas abaixo dos limites são podadas e cada endereço mantém apenas as contrapartes mais frequentes
+func (s *FlowService) BuildGraph(ctx context.Context, query FlowQuery) (*FlowGraph, error) {
+	if err := query.normalize(); err != nil {
+		return nil, err
+	}
+
+	graph := &FlowGraph{Root: query.Address, Direction: query.Direction, Hops: query.Hops}
+	nodes := map[string]*FlowNode{query.Address: {Address: query.Address}}
+	edges := make(map[flowEdgeKey]*FlowEdge)
+	frontier := []string{query.Address}
+
+	for hop := 1; hop <= query.Hops && len(frontier) > 0; hop++ {
+		hopEdges, truncated, err := s.loadEdges(ctx, frontier, &query)
+		if err != nil {
+			return nil, err
+		}
+		graph.Truncated = graph.Truncated || truncated
+
+		inFrontier := make(map[string]bool, len(frontier))
+		for _, address := range frontier {
+			inFrontier[address] = true
+		}
+
+		// Agrupar por endereço da fronteira e contraparte
+		type counterparty struct {
+			address string
+			txCount int64
+			edges   []*FlowEdge
+		}
+		byOrigin := make(map[string]map[string]*counterparty)
+		for _, edge := range hopEdges {
+			if edge.TxCount < query.MinTxCount || (query.MinValue != nil && edge.value.Cmp(query.MinValue) < 0) {
+				continue
+			}
+			for _, origin := range []string{edge.From, edge.To} {
+				other := edge.To
+				if origin == edge.To {
+					other = edge.From
+				}
+				if !inFrontier[origin] || !flowFollows(query.Direction, origin == edge.From) {
+					continue
+				}
+				if byOrigin[origin] == nil {
+					byOrigin[origin] = make(map[string]*counterparty)
+				}
+				cp := byOrigin[origin][other]
+				if cp == nil {
+					cp = &counterparty{address: other}
+					byOrigin[origin][other] = cp
+				}
+				cp.txCount += edge.TxCount
+				cp.edges = append(cp.edges, edge)
+			}
+		}
+
+		var next []string
+		for _, origin := range frontier {
+			counterparties := make([]*counterparty, 0, len(byOrigin[origin]))
+			for _, cp := range byOrigin[origin] {
+				counterparties = append(counterparties, cp)
+			}
+			sort.Slice(counterparties, func(i, j int) bool {
+				if counterparties[i].txCount != counterparties[j].txCount {
+					return counterparties[i].txCount > counterparties[j].txCount
+				}
+				return counterparties[i].address < counterparties[j].address
+			})
+			if len(counterparties) > query.MaxCounterparties {
+				counterparties = counterparties[:query.MaxCounterparties]
+				graph.Truncated = true
+			}
+
+			for _, cp := range counterparties {
+				if _, ok := nodes[cp.address]; !ok {
+					if len(nodes) >= query.MaxNodes {
+						graph.Truncated = true
+						continue
+					}
+					nodes[cp.address] = &FlowNode{Address: cp.address, Hop: hop}
+					next = append(next, cp.address)
+				}
+				for _, edge := range cp.edges {
+					edges[flowEdgeKey{edge.From, edge.To, edge.Asset}] = edge
+				}
+			}
+		}
+		frontier = next
+	}
+
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		if graph.Nodes[i].Hop != graph.Nodes[j].Hop {
+			return graph.Nodes[i].Hop < graph.Nodes[j].Hop
+		}
+		return graph.Nodes[i].Address < graph.Nodes[j].Address
+	})
+
+	graph.Edges = make([]*FlowEdge, 0, len(edges))
+	for _, edge := range edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Asset < b.Asset
+	})
+
+	if err := s.loadNodeDetails(ctx, nodes); err != nil {
+		return nil, err
+	}
+	if err := s.loadTokenDetails(ctx, graph.Edges); err != nil {
+		return nil, err
+	}
+
+	return graph, nil
+}
+
+// flowFollows indica se uma aresta em que o endereço é remetente (ou destinatário) é seguida na direção pedida
+func flowFollows(direction FlowDirection, isSender bool) bool {
+	switch direction {
+	case FlowDirectionOut:
+		return isSender
+	case FlowDirectionIn:
+		return !isSender
+	default:
+		return true
+	}
+}
+
+// loadEdges busca as transferências dos endereços da fronteira agregadas por remetente, destinatário e ativo
+func (s *FlowService) loadEdges(ctx context.Context, frontier []string, query *FlowQuery) ([]*FlowEdge, bool, error) {
+	var edges []*FlowEdge
+	truncated := false
+
+	if query.Token == "" || query.Token == FlowAssetNative {
+		native, err := s.loadNativeEdges(ctx, frontier, query)
+		if err != nil {
+			return nil, false, err
+		}
+		edges = append(edges, native...)
+	}
+
+	if query.Token != FlowAssetNative {
+		tokens, limited, err := s.loadTokenEdges(ctx, frontier, query)
+		if err != nil {
+			return nil, false, err
+		}
+		edges = append(edges, tokens...)
+		truncated = limited
+	}
+
+	return edges, truncated, nil
+}
+
+// loadNativeEdges soma as transferências da moeda nativa no banco a partir de account_transactions
+func (s *FlowService) loadNativeEdges(ctx context.Context, frontier []string, query *FlowQuery) ([]*FlowEdge, error) {
+	args := []interface{}{pq.Array(frontier)}
+	conditions := []string{
+		"at.account_address = ANY($1)",
+		"at.status = 'success'",
+		"at.to_address IS NOT NULL",
+		"LOWER(at.from_address) <> LOWER(at.to_address)",
+		"at.value ~ '^[0-9]+$'",
+		"at.value <> '0'",
+	}
+	// from_address e to_address são gravados com checksum; account_address, em minúsculas
+	switch query.Direction {
+	case FlowDirectionOut:
+		conditions = append(conditions, "LOWER(at.from_address) = at.account_address")
+	case FlowDirectionIn:
+		conditions = append(conditions, "LOWER(at.to_address) = at.account_address")
+	}
+	if query.FromDate != nil {
+		args = append(args, *query.FromDate)
+		conditions = append(conditions, fmt.Sprintf(`at."timestamp" >= $%d`, len(args)))
+	}
+	if query.ToDate != nil {
+		args = append(args, *query.ToDate)
+		conditions = append(conditions, fmt.Sprintf(`at."timestamp" <= $%d`, len(args)))
+	}
+
+	// Uma transação entre dois endereços da fronteira aparece para os dois; DISTINCT ON evita contá-la duas vezes
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT from_address, to_address, SUM(value::numeric)::text, COUNT(*), MIN(ts), MAX(ts)
+		FROM (
+			SELECT DISTINCT ON (at.transaction_hash)
+				LOWER(at.from_address) AS from_address, LOWER(at.to_address) AS to_address, at.value, at."timestamp" AS ts
+			FROM account_transactions at
+			WHERE %s
+		) t
+		GROUP BY from_address, to_address`, strings.Join(conditions, " AND ")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar transferências nativas: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []*FlowEdge
+	for rows.Next() {
+		edge := &FlowEdge{Asset: FlowAssetNative}
+		if err := rows.Scan(&edge.From, &edge.To, &edge.Value, &edge.TxCount, &edge.FirstSeenAt, &edge.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("erro ao ler transferência nativa: %w", err)
+		}
+		value, ok := new(big.Int).SetString(edge.Value, 10)
+		if !ok {
+			return nil, fmt.Errorf("erro ao ler valor da transferência nativa: %s", edge.Value)
+		}
+		edge.value = value
+		decimals := flowNativeDecimals
+		edge.TokenDecimals = &decimals
+		edges = append(edges, edge)
+	}
+
+	return edges, rows.Err()
+}
+
+// loadTokenEdges lê as transferências ERC-20 (Transfer com 3 topics) e soma os valores uint256 em Go
+func (s *FlowService) loadTokenEdges(ctx context.Context, frontier []string, query *FlowQuery) ([]*FlowEdge, bool, error) {
+	args := []interface{}{pq.Array(frontier), flowTransferTopic}
+	conditions := []string{
+		"e.event_signature = $2",
+		"NOT e.removed",
+		"jsonb_array_length(e.topics) = 3",
+	}
+	switch query.Direction {
+	case FlowDirectionOut:
+		conditions = append(conditions, "'0x' || right(e.topics->>1, 40) = f.address")
+	case FlowDirectionIn:
+		conditions = append(conditions, "'0x' || right(e.topics->>2, 40) = f.address")
+	}
+	if query.Token != "" {
+		// Eventos são gravados com o endereço do contrato em formato checksum pelo indexer
+		args = append(args, pq.Array([]string{query.Token, toChecksumAddress(query.Token)}))
+		conditions = append(conditions, fmt.Sprintf("e.contract_address = ANY($%d)", len(args)))
+	}
+	if query.FromDate != nil {
+		args = append(args, *query.FromDate)
+		conditions = append(conditions, fmt.Sprintf(`e."timestamp" >= $%d`, len(args)))
+	}
+	if query.ToDate != nil {
+		args = append(args, *query.ToDate)
+		conditions = append(conditions, fmt.Sprintf(`e."timestamp" <= $%d`, len(args)))
+	}
+	args = append(args, flowMaxTokenRows+1)
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT DISTINCT ON (e.id) e.id, LOWER(e.contract_address), '0x' || right(e.topics->>1, 40),
+			'0x' || right(e.topics->>2, 40), e.data, e."timestamp"
+		FROM unnest($1::text[]) AS f(address)
+		JOIN events e ON e.topics @> jsonb_build_array('0x000000000000000000000000' || substr(f.address, 3))
+		WHERE %s
+		ORDER BY e.id
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args)), args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("erro ao buscar transferências de tokens: %w", err)
+	}
+	defer rows.Close()
+
+	edges := make(map[flowEdgeKey]*FlowEdge)
+	count := 0
+	truncated := false
+	for rows.Next() {
+		count++
+		if count > flowMaxTokenRows {
+			truncated = true
+			break
+		}
+
+		var id, token, from, to string
+		var data []byte
+		var ts time.Time
+		if err := rows.Scan(&id, &token, &from, &to, &data, &ts); err != nil {
+			return nil, false, fmt.Errorf("erro ao ler transferência de token: %w", err)
+		}
+		if from == to || len(data) == 0 {
+			continue
+		}
+
+		key := flowEdgeKey{from, to, token}
+		edge := edges[key]
+		if edge == nil {
+			edge = &FlowEdge{From: from, To: to, Asset: token, value: new(big.Int), FirstSeenAt: ts, LastSeenAt: ts}
+			edges[key] = edge
+		}
+		edge.value.Add(edge.value, new(big.Int).SetBytes(data))
+		edge.TxCount++
+		if ts.Before(edge.FirstSeenAt) {
+			edge.FirstSeenAt = ts
+		}
+		if ts.After(edge.LastSeenAt) {
+			edge.LastSeenAt = ts
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("erro ao ler transferências de tokens: %w", err)
+	}
+
+	result := make([]*FlowEdge, 0, len(edges))
+	for _, edge := range edges {
+		edge.Value = edge.value.String()
+		result = append(result, edge)
+	}
+	return result, truncated, nil
+}
+
+// loadNodeDetails preenche label, tags, contrato e compliance dos nós
+func (s *FlowService) loadNodeDetails(ctx context.Context, nodes map[string]*FlowNode) error {
+	addresses := make([]string, 0, len(nodes)*2)
+	for address := range nodes {
+		addresses = append(addresses, address)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT a.address, a.label, a.is_contract, a.compliance_status, a.risk_score,
+			ARRAY(SELECT t.tag FROM account_tags t WHERE t.address = a.address ORDER BY t.tag)
+		FROM accounts a
+		WHERE a.address = ANY($1)`, pq.Array(addresses))
+	if err != nil {
+		return fmt.Errorf("erro ao buscar accounts do grafo: %w", err)
+	}
+	for rows.Next() {
+		var address, status string
+		var label sql.NullString
+		var isContract bool
+		var riskScore sql.NullInt64
+		var tags []string
+		if err := rows.Scan(&address, &label, &isContract, &status, &riskScore, pq.Array(&tags)); err != nil {
+			rows.Close()
+			return fmt.Errorf("erro ao ler account do grafo: %w", err)
+		}
+		node := nodes[strings.ToLower(address)]
+		if node == nil {
+			continue
+		}
+		if label.Valid && label.String != "" {
+			node.Label = &label.String
+		}
+		if riskScore.Valid {
+			score := int(riskScore.Int64)
+			node.RiskScore = &score
+		}
+		node.IsContract = isContract
+		node.ComplianceStatus = &status
+		node.Tags = tags
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao ler accounts do grafo: %w", err)
+	}
+
+	// Contratos podem estar gravados em formato checksum
+	for address := range nodes {
+		addresses = append(addresses, toChecksumAddress(address))
+	}
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT LOWER(address), name FROM smart_contracts WHERE address = ANY($1) AND name IS NOT NULL AND name <> ''`,
+		pq.Array(addresses))
+	if err != nil {
+		return fmt.Errorf("erro ao buscar contratos do grafo: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var address, name string
+		if err := rows.Scan(&address, &name); err != nil {
+			return fmt.Errorf("erro ao ler contrato do grafo: %w", err)
+		}
+		if node := nodes[address]; node != nil {
+			node.IsContract = true
+			node.ContractName = &name
+		}
+	}
+
+	return rows.Err()
+}
+
+// loadTokenDetails preenche símbolo, casas decimais e valor formatado das arestas de tokens
+func (s *FlowService) loadTokenDetails(ctx context.Context, edges []*FlowEdge) error {
+	seen := make(map[string]bool)
+	var tokens []string
+	for _, edge := range edges {
+		if edge.Asset != FlowAssetNative && !seen[edge.Asset] {
+			seen[edge.Asset] = true
+			tokens = append(tokens, edge.Asset, toChecksumAddress(edge.Asset))
+		}
+	}
+
+	symbols := make(map[string]string)
+	decimals := make(map[string]int)
+	if len(tokens) > 0 {
+		rows, err := s.db.QueryContext(ctx, `
+			SELECT LOWER(sc.address), sc.symbol,
+				(SELECT th.token_decimals FROM token_holdings th WHERE th.token_address = sc.address LIMIT 1)
+			FROM smart_contracts sc
+			WHERE sc.address = ANY($1)`, pq.Array(tokens))
+		if err != nil {
+			return fmt.Errorf("erro ao buscar tokens do grafo: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var address string
+			var symbol sql.NullString
+			var tokenDecimals sql.NullInt64
+			if err := rows.Scan(&address, &symbol, &tokenDecimals); err != nil {
+				return fmt.Errorf("erro ao ler token do grafo: %w", err)
+			}
+			if symbol.Valid && symbol.String != "" {
+				symbols[address] = symbol.String
+			}
+			if tokenDecimals.Valid {
+				decimals[address] = int(tokenDecimals.Int64)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("erro ao ler tokens do grafo: %w", err)
+		}
+	}
+
+	for _, edge := range edges {
+		if edge.Asset != FlowAssetNative {
+			if symbol, ok := symbols[edge.Asset]; ok {
+				edge.TokenSymbol = &symbol
+			}
+			if d, ok := decimals[edge.Asset]; ok {
+				edge.TokenDecimals = &d
+			}
+		}
+		if edge.TokenDecimals != nil {
+			amount := formatFlowAmount(edge.value, *edge.TokenDecimals)
+			edge.Amount = &amount
+		}
+	}
+	return nil
+}
+
+// formatFlowAmount aplica as casas decimais a um valor em unidades mínimas
+func formatFlowAmount(value *big.Int, decimals int) string {
+	if decimals <= 0 {
+		return value.String()
+	}
+	digits := value.String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fraction == "" {
+		return integer
+	}
+	return integer + "." + fraction
+}
+
+// WriteGraphML escreve o grafo em GraphML para ferramentas externas (Gephi, yEd, Cytoscape)
+func (g *FlowGraph) WriteGraphML(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+
+	keys := [][4]string{
+		{"label", "node", "label", "string"},
+		{"contract_name", "node", "contract_name", "string"},
+		{"hop", "node", "hop", "int"},
+		{"is_contract", "node", "is_contract", "boolean"},
+		{"tags", "node", "tags", "string"},
+		{"compliance_status", "node", "compliance_status", "string"},
+		{"risk_score", "node", "risk_score", "int"},
+		{"asset", "edge", "asset", "string"},
+		{"token_symbol", "edge", "token_symbol", "string"},
+		{"value", "edge", "value", "string"},
+		{"amount", "edge", "amount", "double"},
+		{"tx_count", "edge", "tx_count", "long"},
+		{"first_seen_at", "edge", "first_seen_at", "string"},
+		{"last_seen_at", "edge", "last_seen_at", "string"},
+	}
+	for _, key := range keys {
+		fmt.Fprintf(&b, `  <key id="%s" for="%s" attr.name="%s" attr.type="%s"/>`+"\n", key[0], key[1], key[2], key[3])
+	}
+	fmt.Fprintf(&b, `  <graph id="%s" edgedefault="directed">`+"\n", graphMLEscape(g.Root))
+
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, `    <node id="%s">`+"\n", graphMLEscape(node.Address))
+		writeGraphMLData(&b, "label", node.Label)
+		writeGraphMLData(&b, "contract_name", node.ContractName)
+		fmt.Fprintf(&b, `      <data key="hop">%d</data>`+"\n", node.Hop)
+		fmt.Fprintf(&b, `      <data key="is_contract">%t</data>`+"\n", node.IsContract)
+		if len(node.Tags) > 0 {
+			tags := strings.Join(node.Tags, ",")
+			writeGraphMLData(&b, "tags", &tags)
+		}
+		writeGraphMLData(&b, "compliance_status", node.ComplianceStatus)
+		if node.RiskScore != nil {
+			fmt.Fprintf(&b, `      <data key="risk_score">%d</data>`+"\n", *node.RiskScore)
+		}
+		b.WriteString("    </node>\n")
+	}
+
+	for i, edge := range g.Edges {
+		fmt.Fprintf(&b, `    <edge id="e%d" source="%s" target="%s">`+"\n", i, graphMLEscape(edge.From), graphMLEscape(edge.To))
+		writeGraphMLData(&b, "asset", &edge.Asset)
+		writeGraphMLData(&b, "token_symbol", edge.TokenSymbol)
+		writeGraphMLData(&b, "value", &edge.Value)
+		writeGraphMLData(&b, "amount", edge.Amount)
+		fmt.Fprintf(&b, `      <data key="tx_count">%s</data>`+"\n", strconv.FormatInt(edge.TxCount, 10))
+		fmt.Fprintf(&b, `      <data key="first_seen_at">%s</data>`+"\n", edge.FirstSeenAt.UTC().Format(time.RFC3339))
+		fmt.Fprintf(&b, `      <data key="last_seen_at">%s</data>`+"\n", edge.LastSeenAt.UTC().Format(time.RFC3339))
+		b.WriteString("    </edge>\n")
+	}
+
+	b.WriteString("  </graph>\n</graphml>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeGraphMLData escreve um atributo opcional do GraphML
+func writeGraphMLData(b *strings.Builder, key string, value *string) {
+	if value == nil {
+		return
+	}
+	fmt.Fprintf(b, `      <data key="%s">%s</data>`+"\n", key, graphMLEscape(*value))
+}
+
+// graphMLEscape escapa texto para XML
+func graphMLEscape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrInvalidChart indica parâmetros inválidos para séries de estatísticas
+var ErrInvalidChart = errors.New("parâmetros de gráfico inválidos")
+
+// chartMaxPoints limita a quantidade de períodos retornados em uma série
+const chartMaxPoints = 1000
+
+// ChartInterval representa a granularidade de uma série (períodos dos rollups do worker)
+type ChartInterval string
+
+const (
+	ChartIntervalHour ChartInterval = "hour"
+	ChartIntervalDay  ChartInterval = "day"
+)
+
+// chartMetric descreve como uma métrica é calculada a partir das colunas de network_stats
+type chartMetric struct {
+	Expr    string // Expressão sobre network_stats; NULL indica período sem dados suficientes
+	Counter bool   // Contadores valem zero em períodos sem linha; métricas derivadas ficam nulas
+}
+
+// chartMetrics contém as métricas disponíveis em /api/stats/charts
+var chartMetrics = map[string]chartMetric{
+	"block_count":        {Expr: "s.block_count", Counter: true},
+	"tx_count":           {Expr: "s.tx_count", Counter: true},
+	"failed_tx_count":    {Expr: "s.failed_tx_count", Counter: true},
+	"active_addresses":   {Expr: "s.active_addresses", Counter: true},
+	"new_addresses":      {Expr: "s.new_addresses", Counter: true},
+	"contracts_deployed": {Expr: "s.contracts_deployed", Counter: true},
+	"token_transfers":    {Expr: "s.token_transfers", Counter: true},
+	"gas_used":           {Expr: "s.gas_used", Counter: true},
+	"gas_limit":          {Expr: "s.gas_limit", Counter: true},
+	"gas_utilization":    {Expr: "s.gas_used * 100.0 / NULLIF(s.gas_limit, 0)"},
+	"avg_gas_per_block":  {Expr: "s.gas_used / NULLIF(s.block_count, 0)"},
+	"avg_block_time":     {Expr: "s.block_time_sum / NULLIF(s.block_time_count, 0)"},
+	"failed_tx_ratio":    {Expr: "s.failed_tx_count::DOUBLE PRECISION / NULLIF(s.tx_count, 0)"},
+}
+
+// ChartMetrics retorna os nomes das métricas disponíveis, em ordem alfabética
+func ChartMetrics() []string {
+	names := make([]string, 0, len(chartMetrics))
+	for name := range chartMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ChartPoint representa o valor de uma métrica em um período
+type ChartPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     *float64  `json:"value"`
+}
+
+// ChartSeries representa uma série temporal de uma métrica
+type ChartSeries struct {
+	Metric   string        `json:"metric"`
+	Interval ChartInterval `json:"interval"`
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Points   []ChartPoint  `json:"points"`
+}
+
+// NetworkSummary resume os rollups de uma janela de tempo
+type NetworkSummary struct {
+	BlockCount        int64
+	TxCount           int64
+	ContractsDeployed int64
+	AvgBlockTime      float64 // Segundos; zero sem dados
+	GasUtilization    float64 // Percentual de gas_used sobre gas_limit
+	AvgGasUsed        int64   // Gas médio por bloco
+	PeakHourTxCount   int64   // Maior número de transações em uma hora da janela
+}
+
+// NetworkStatsService lê os rollups de estatísticas da rede mantidos pelo worker
+type NetworkStatsService struct {
+	db *sql.DB
+}
+
+// NewNetworkStatsService cria uma nova instância do serviço de estatísticas da rede
+func NewNetworkStatsService(db *sql.DB) *NetworkStatsService {
+	return &NetworkStatsService{db: db}
+}
+
+// GetChart retorna a série de uma métrica entre from e to, com um ponto por período.
+// Sem datas, usa as últimas 24 horas (hour) ou os últimos 30 dias (day)
+func (s *NetworkStatsService) GetChart(ctx context.Context, metric string, interval ChartInterval, from, to *time.Time) (*ChartSeries, error) {
+	spec, ok := chartMetrics[metric]
+	if !ok {
+		return nil, fmt.Errorf("%w: métrica %q desconhecida", ErrInvalidChart, metric)
+	}
+
+	var step time.Duration
+	switch interval {
+	case ChartIntervalHour:
+		step = time.Hour
+	case ChartIntervalDay:
+		step = 24 * time.Hour
+	default:
+		return nil, fmt.Errorf("%w: interval deve ser hour ou day", ErrInvalidChart)
+	}
+
+	end := time.Now().UTC()
+	if to != nil {
+		end = to.UTC()
+	}
+	start := end.Add(-24 * time.Hour)
+	if interval == ChartIntervalDay {
+		start = end.AddDate(0, 0, -30)
+	}
+	if from != nil {
+		start = from.UTC()
+	}
+	start, end = start.Truncate(step), end.Truncate(step)
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: from deve ser anterior a to", ErrInvalidChart)
+	}
+	if points := int64(end.Sub(start)/step) + 1; points > chartMaxPoints {
+		return nil, fmt.Errorf("%w: intervalo de %d períodos excede o máximo de %d", ErrInvalidChart, points, chartMaxPoints)
+	}
+
+	value := spec.Expr
+	if spec.Counter {
+		value = fmt.Sprintf("COALESCE(%s, 0)", spec.Expr)
+	}
+
+	// generate_series preenche os períodos sem blocos para que a série não tenha lacunas; o passo é
+	// em segundos para não depender do fuso da sessão
+	query := fmt.Sprintf(`
+		SELECT g.bucket, (%s)::DOUBLE PRECISION
+		FROM generate_series($2::timestamptz, $3::timestamptz, $4::interval) AS g(bucket)
+		LEFT JOIN network_stats s ON s.period = $1 AND s.bucket = g.bucket
+		ORDER BY g.bucket`, value)
+
+	rows, err := s.db.QueryContext(ctx, query, string(interval), start, end, fmt.Sprintf("%d seconds", int64(step.Seconds())))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar série %s: %w", metric, err)
+	}
+	defer rows.Close()
+
+	series := &ChartSeries{
+		Metric:   metric,
+		Interval: interval,
+		From:     start,
+		To:       end,
+		Points:   []ChartPoint{},
+	}
+	for rows.Next() {
+		var point ChartPoint
+		if err := rows.Scan(&point.Timestamp, &point.Value); err != nil {
+			return nil, fmt.Errorf("erro ao ler ponto da série %s: %w", metric, err)
+		}
+		point.Timestamp = point.Timestamp.UTC()
+		series.Points = append(series.Points, point)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler série %s: %w", metric, err)
+	}
+
+	return series, nil
+}
+
+// GetSummary agrega os rollups por hora a partir de since (inclusive) até until (exclusive)
+func (s *NetworkStatsService) GetSummary(ctx context.Context, since, until time.Time) (*NetworkSummary, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(block_count), 0),
+			COALESCE(SUM(tx_count), 0),
+			COALESCE(SUM(contracts_deployed), 0),
+			COALESCE(SUM(block_time_sum) / NULLIF(SUM(block_time_count), 0), 0),
+			COALESCE(SUM(gas_used) * 100.0 / NULLIF(SUM(gas_limit), 0), 0)::DOUBLE PRECISION,
+			COALESCE(ROUND(SUM(gas_used) / NULLIF(SUM(block_count), 0)), 0)::BIGINT,
+			COALESCE(MAX(tx_count), 0)
+		FROM network_stats
+		WHERE period = 'hour' AND bucket >= date_trunc('hour', $1::timestamptz, 'UTC') AND bucket < $2`
+
+	summary := &NetworkSummary{}
+	if err := s.db.QueryRowContext(ctx, query, since.UTC(), until.UTC()).Scan(
+		&summary.BlockCount,
+		&summary.TxCount,
+		&summary.ContractsDeployed,
+		&summary.AvgBlockTime,
+		&summary.GasUtilization,
+		&summary.AvgGasUsed,
+		&summary.PeakHourTxCount,
+	); err != nil {
+		return nil, fmt.Errorf("erro ao resumir estatísticas da rede: %w", err)
+	}
+
+	return summary, nil
+}
+
+// GetDailyActiveAddresses retorna os endereços ativos do dia (UTC) que contém at.
+// Endereços distintos não podem ser somados entre horas, por isso a janela é o dia do rollup
+func (s *NetworkStatsService) GetDailyActiveAddresses(ctx context.Context, at time.Time) (int64, error) {
+	var active int64
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(active_addresses), 0)
+		FROM network_stats
+		WHERE period = 'day' AND bucket = date_trunc('day', $1::timestamptz, 'UTC')
+	`, at.UTC()).Scan(&active)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar endereços ativos do dia: %w", err)
+	}
+	return active, nil
+}
@@ -0,0 +1,18 @@
+package services
+
+import "fmt"
+
+// transactions, events, account_transactions e account_events são particionadas por faixa de
+// block_number. Filtros apenas por data não permitem descartar partições; os limites abaixo
+// convertem o intervalo de tempo em intervalo de blocos pela tabela blocks, e o PostgreSQL
+// descarta as partições fora da faixa em tempo de execução
+
+// blockLowerBound limita column ao primeiro bloco com timestamp >= timeExpr
+func blockLowerBound(column, timeExpr string) string {
+	return fmt.Sprintf("%s >= (SELECT COALESCE(MIN(b.number), 0) FROM blocks b WHERE b.timestamp >= %s)", column, timeExpr)
+}
+
+// blockUpperBound limita column ao último bloco com timestamp <= timeExpr
+func blockUpperBound(column, timeExpr string) string {
+	return fmt.Sprintf("%s <= (SELECT COALESCE(MAX(b.number), 0) FROM blocks b WHERE b.timestamp <= %s)", column, timeExpr)
+}
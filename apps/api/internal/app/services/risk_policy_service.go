@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+var (
+	// ErrRiskPolicyNotFound indica que a versão da política não existe
+	ErrRiskPolicyNotFound = errors.New("política de risco não encontrada")
+	// ErrInvalidRiskPolicy indica uma política que não passou na validação
+	ErrInvalidRiskPolicy = errors.New("política de risco inválida")
+)
+
+// RiskPolicyService gerencia as versões da política de risco avaliada pelo worker
+type RiskPolicyService struct {
+	riskPolicyRepo repositories.RiskPolicyRepository
+}
+
+// NewRiskPolicyService cria uma nova instância do serviço de políticas de risco
+func NewRiskPolicyService(riskPolicyRepo repositories.RiskPolicyRepository) *RiskPolicyService {
+	return &RiskPolicyService{
+		riskPolicyRepo: riskPolicyRepo,
+	}
+}
+
+// CreatePolicy valida e grava uma nova versão da política, ativando-a se solicitado
+func (s *RiskPolicyService) CreatePolicy(ctx context.Context, content []byte, format entities.RiskPolicyFormat, createdBy string, activate bool) (*entities.RiskPolicyVersion, error) {
+	if strings.TrimSpace(string(content)) == "" {
+		return nil, fmt.Errorf("%w: conteúdo vazio", ErrInvalidRiskPolicy)
+	}
+
+	policy, err := entities.ParseRiskPolicy(content, format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRiskPolicy, err)
+	}
+
+	version := &entities.RiskPolicyVersion{
+		Name:      policy.Name,
+		Format:    format,
+		Content:   string(content),
+		CreatedBy: &createdBy,
+	}
+	if err := s.riskPolicyRepo.Create(ctx, version); err != nil {
+		return nil, err
+	}
+
+	if activate {
+		return s.ActivatePolicy(ctx, version.Version)
+	}
+	return version, nil
+}
+
+// GetPolicy busca uma versão da política
+func (s *RiskPolicyService) GetPolicy(ctx context.Context, version int64) (*entities.RiskPolicyVersion, error) {
+	policy, err := s.riskPolicyRepo.FindByVersion(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, ErrRiskPolicyNotFound
+	}
+	return policy, nil
+}
+
+// GetActivePolicy busca a versão em uso pelo worker
+func (s *RiskPolicyService) GetActivePolicy(ctx context.Context) (*entities.RiskPolicyVersion, error) {
+	policy, err := s.riskPolicyRepo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, ErrRiskPolicyNotFound
+	}
+	return policy, nil
+}
+
+// ListPolicies lista as versões da política
+func (s *RiskPolicyService) ListPolicies(ctx context.Context, page, limit int) (*PaginatedResult[*entities.RiskPolicyVersion], error) {
+	offset := (page - 1) * limit
+	policies, total, err := s.riskPolicyRepo.FindAll(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[*entities.RiskPolicyVersion]{
+		Data:       policies,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// ActivatePolicy torna uma versão ativa. A versão é validada de novo para não ativar conteúdo que o worker
+// rejeitaria; as accounts avaliadas pela versão anterior são reavaliadas pelo worker
+func (s *RiskPolicyService) ActivatePolicy(ctx context.Context, version int64) (*entities.RiskPolicyVersion, error) {
+	policy, err := s.GetPolicy(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := entities.ParseRiskPolicy([]byte(policy.Content), policy.Format); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRiskPolicy, err)
+	}
+
+	activated, err := s.riskPolicyRepo.Activate(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if !activated {
+		return nil, ErrRiskPolicyNotFound
+	}
+
+	return s.GetPolicy(ctx, version)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+var (
+	// ErrScreeningListNotFound indica que a versão da lista não existe
+	ErrScreeningListNotFound = errors.New("lista de triagem não encontrada")
+	// ErrInvalidScreeningList indica um arquivo de lista que não passou na validação
+	ErrInvalidScreeningList = errors.New("lista de triagem inválida")
+	// ErrExposureAccountNotFound indica que a account do relatório de exposição não existe
+	ErrExposureAccountNotFound = errors.New("account não encontrada")
+)
+
+// ScreeningListMetadata são os metadados informados na importação; sobrepõem os do arquivo
+type ScreeningListMetadata struct {
+	Name    string
+	Source  string
+	Version string
+}
+
+// ScreeningService gerencia as listas de sanções/denylists aplicadas pelo worker
+type ScreeningService struct {
+	screeningRepo repositories.ScreeningRepository
+}
+
+// NewScreeningService cria uma nova instância do serviço de triagem
+func NewScreeningService(screeningRepo repositories.ScreeningRepository) *ScreeningService {
+	return &ScreeningService{
+		screeningRepo: screeningRepo,
+	}
+}
+
+// ImportList valida o arquivo e grava uma nova versão ativa da lista. O worker retriagem as accounts
+// com a nova versão e libera as exposições da versão substituída
+func (s *ScreeningService) ImportList(ctx context.Context, content []byte, format entities.ScreeningListFormat, meta ScreeningListMetadata, importedBy string) (*entities.ScreeningList, error) {
+	if strings.TrimSpace(string(content)) == "" {
+		return nil, fmt.Errorf("%w: conteúdo vazio", ErrInvalidScreeningList)
+	}
+
+	file, err := entities.ParseScreeningList(content, format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScreeningList, err)
+	}
+
+	list := &entities.ScreeningList{
+		Name:       firstNonEmpty(meta.Name, file.Name),
+		Source:     firstNonEmpty(meta.Source, file.Source),
+		Version:    firstNonEmpty(meta.Version, file.Version),
+		Format:     format,
+		ImportedBy: &importedBy,
+	}
+	switch {
+	case list.Name == "":
+		return nil, fmt.Errorf("%w: name é obrigatório", ErrInvalidScreeningList)
+	case list.Source == "":
+		return nil, fmt.Errorf("%w: source é obrigatório", ErrInvalidScreeningList)
+	case list.Version == "":
+		return nil, fmt.Errorf("%w: version é obrigatório", ErrInvalidScreeningList)
+	}
+
+	sum := sha256.Sum256(content)
+	list.Checksum = hex.EncodeToString(sum[:])
+
+	if err := s.screeningRepo.CreateList(ctx, list, file.Entries); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetList busca uma versão de lista
+func (s *ScreeningService) GetList(ctx context.Context, id int64) (*entities.ScreeningList, error) {
+	list, err := s.screeningRepo.FindListByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, ErrScreeningListNotFound
+	}
+	return list, nil
+}
+
+// ListLists lista as versões importadas, opcionalmente de uma única lista
+func (s *ScreeningService) ListLists(ctx context.Context, name string, page, limit int) (*PaginatedResult[*entities.ScreeningList], error) {
+	offset := (page - 1) * limit
+	lists, total, err := s.screeningRepo.FindLists(ctx, strings.TrimSpace(name), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[*entities.ScreeningList]{
+		Data:       lists,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// ListEntries lista os endereços de uma versão
+func (s *ScreeningService) ListEntries(ctx context.Context, id int64, page, limit int) (*PaginatedResult[entities.ScreeningEntry], error) {
+	if _, err := s.GetList(ctx, id); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	entries, total, err := s.screeningRepo.FindEntries(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[entities.ScreeningEntry]{
+		Data:       entries,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// SetListActive ativa ou desativa uma versão; a retriagem é feita pelo worker
+func (s *ScreeningService) SetListActive(ctx context.Context, id int64, active bool) (*entities.ScreeningList, error) {
+	found, err := s.screeningRepo.SetListActive(ctx, id, active)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrScreeningListNotFound
+	}
+	return s.GetList(ctx, id)
+}
+
+// GetExposureReport busca a exposição de uma account às listas ativas
+func (s *ScreeningService) GetExposureReport(ctx context.Context, address string) (*entities.AccountExposureReport, error) {
+	report, err := s.screeningRepo.GetExposureReport(ctx, strings.ToLower(address))
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrExposureAccountNotFound
+	}
+	return report, nil
+}
+
+// firstNonEmpty retorna o primeiro valor não vazio
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
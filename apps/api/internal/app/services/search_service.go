@@ -0,0 +1,608 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrInvalidSearch indica um termo de busca vazio ou inválido
+var ErrInvalidSearch = errors.New("termo de busca inválido")
+
+const (
+	// searchQueryTimeout limita o tempo de cada consulta executada em paralelo
+	searchQueryTimeout = 2 * time.Second
+	// searchMinTextLength é o tamanho mínimo de um termo textual para busca por nome
+	searchMinTextLength = 2
+	// searchMaxLimit é o número máximo de sugestões retornadas
+	searchMaxLimit = 25
+)
+
+// SearchQueryKind representa a classificação do termo digitado
+type SearchQueryKind string
+
+const (
+	SearchKindBlockNumber   SearchQueryKind = "block_number"
+	SearchKindHash          SearchQueryKind = "hash"
+	SearchKindAddress       SearchQueryKind = "address"
+	SearchKindAddressPrefix SearchQueryKind = "address_prefix"
+	SearchKindText          SearchQueryKind = "text"
+)
+
+// SearchResultType representa o tipo de uma sugestão
+type SearchResultType string
+
+const (
+	SearchResultBlock       SearchResultType = "block"
+	SearchResultTransaction SearchResultType = "transaction"
+	SearchResultAccount     SearchResultType = "account"
+	SearchResultContract    SearchResultType = "contract"
+	SearchResultToken       SearchResultType = "token"
+	SearchResultEvent       SearchResultType = "event"
+	SearchResultValidator   SearchResultType = "validator"
+)
+
+// searchTypePriority desempata sugestões com a mesma pontuação
+var searchTypePriority = map[SearchResultType]int{
+	SearchResultBlock:       0,
+	SearchResultTransaction: 1,
+	SearchResultToken:       2,
+	SearchResultContract:    3,
+	SearchResultValidator:   4,
+	SearchResultAccount:     5,
+	SearchResultEvent:       6,
+}
+
+// SearchResult representa uma sugestão tipada da busca universal
+type SearchResult struct {
+	Type        SearchResultType       `json:"type"`
+	ID          string                 `json:"id"` // Identificador usado na rota de detalhe (número, hash, endereço ou nome)
+	Label       string                 `json:"label"`
+	Description string                 `json:"description,omitempty"`
+	Score       float64                `json:"score"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// SearchResponse representa o resultado da busca universal
+type SearchResponse struct {
+	Query   string          `json:"query"`
+	Kind    SearchQueryKind `json:"kind"`
+	Results []SearchResult  `json:"results"`
+	Partial bool            `json:"partial,omitempty"` // Alguma fonte falhou ou excedeu o tempo limite
+}
+
+// searchSource é uma consulta executada em paralelo pela busca universal
+type searchSource struct {
+	name string
+	run  func(ctx context.Context) ([]SearchResult, error)
+}
+
+// SearchService implementa a busca universal do explorer
+type SearchService struct {
+	db *sql.DB
+}
+
+// NewSearchService cria uma nova instância do serviço de busca
+func NewSearchService(db *sql.DB) *SearchService {
+	return &SearchService{db: db}
+}
+
+// ClassifySearchQuery identifica o tipo do termo digitado
+func ClassifySearchQuery(query string) SearchQueryKind {
+	if _, err := strconv.ParseUint(strings.TrimPrefix(query, "#"), 10, 64); err == nil {
+		return SearchKindBlockNumber
+	}
+
+	if strings.HasPrefix(strings.ToLower(query), "0x") && isHexString(query[2:]) {
+		switch len(query) {
+		case 66:
+			return SearchKindHash
+		case 42:
+			return SearchKindAddress
+		default:
+			if len(query) > 2 && len(query) < 42 {
+				return SearchKindAddressPrefix
+			}
+		}
+	}
+
+	return SearchKindText
+}
+
+// Search classifica o termo e consulta as fontes relevantes em paralelo, retornando sugestões ordenadas
+func (s *SearchService) Search(ctx context.Context, query string, limit int) (*SearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: parâmetro 'q' é obrigatório", ErrInvalidSearch)
+	}
+	if limit <= 0 || limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+
+	response := &SearchResponse{
+		Query:   query,
+		Kind:    ClassifySearchQuery(query),
+		Results: []SearchResult{},
+	}
+
+	sources := s.sourcesFor(response.Kind, query, limit)
+	if len(sources) == 0 {
+		return response, nil
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []SearchResult
+	)
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source searchSource) {
+			defer wg.Done()
+
+			sourceCtx, cancel := context.WithTimeout(ctx, searchQueryTimeout)
+			defer cancel()
+
+			found, err := source.run(sourceCtx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("⚠️ Busca em %s falhou para '%s': %v", source.name, query, err)
+				response.Partial = true
+				return
+			}
+			results = append(results, found...)
+		}(source)
+	}
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return searchTypePriority[results[i].Type] < searchTypePriority[results[j].Type]
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	if results != nil {
+		response.Results = results
+	}
+
+	return response, nil
+}
+
+// sourcesFor define quais consultas executar para cada classificação
+func (s *SearchService) sourcesFor(kind SearchQueryKind, query string, limit int) []searchSource {
+	switch kind {
+	case SearchKindBlockNumber:
+		number, _ := strconv.ParseUint(strings.TrimPrefix(query, "#"), 10, 64)
+		return []searchSource{
+			{"blocks", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchBlockByNumber(ctx, number)
+			}},
+		}
+
+	case SearchKindHash:
+		hash := strings.ToLower(query)
+		return []searchSource{
+			{"transactions", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchTransactionByHash(ctx, hash)
+			}},
+			{"blocks", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchBlockByHash(ctx, hash)
+			}},
+		}
+
+	case SearchKindAddress:
+		address := strings.ToLower(query)
+		addresses := pq.Array([]string{address, toChecksumAddress(address)})
+		return []searchSource{
+			{"smart_contracts", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchContractByAddress(ctx, addresses)
+			}},
+			{"accounts", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchAccountByAddress(ctx, addresses)
+			}},
+			{"validators", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchValidatorByAddress(ctx, addresses)
+			}},
+		}
+
+	case SearchKindAddressPrefix:
+		prefix := strings.ToLower(query) + "%"
+		return []searchSource{
+			{"smart_contracts", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchContractsByAddressPrefix(ctx, prefix, limit)
+			}},
+			{"accounts", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchAccountsByAddressPrefix(ctx, prefix, limit)
+			}},
+		}
+
+	default:
+		if len([]rune(query)) < searchMinTextLength {
+			return nil
+		}
+		term := strings.ToLower(query)
+		prefix := escapeLikePattern(term) + "%"
+		return []searchSource{
+			{"smart_contracts", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchContractsByName(ctx, term, prefix, limit)
+			}},
+			{"events", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchEventNames(ctx, term, prefix, limit)
+			}},
+			{"accounts", func(ctx context.Context) ([]SearchResult, error) {
+				return s.searchAccountsByLabel(ctx, term, prefix, limit)
+			}},
+		}
+	}
+}
+
+// searchBlockByNumber busca um bloco pelo número
+func (s *SearchService) searchBlockByNumber(ctx context.Context, number uint64) ([]SearchResult, error) {
+	return s.searchBlock(ctx, `number = $1`, number)
+}
+
+// searchBlockByHash busca um bloco pelo hash
+func (s *SearchService) searchBlockByHash(ctx context.Context, hash string) ([]SearchResult, error) {
+	return s.searchBlock(ctx, `hash = $1`, hash)
+}
+
+// searchBlock busca um único bloco pela condição informada
+func (s *SearchService) searchBlock(ctx context.Context, condition string, arg interface{}) ([]SearchResult, error) {
+	var (
+		number    uint64
+		hash      string
+		timestamp time.Time
+		txCount   int
+		miner     sql.NullString
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT number, hash, timestamp, tx_count, miner FROM blocks WHERE `+condition, arg,
+	).Scan(&number, &hash, &timestamp, &txCount, &miner)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar bloco: %w", err)
+	}
+
+	return []SearchResult{{
+		Type:        SearchResultBlock,
+		ID:          strconv.FormatUint(number, 10),
+		Label:       fmt.Sprintf("Bloco #%d", number),
+		Description: fmt.Sprintf("%d transações · %s", txCount, timestamp.UTC().Format(time.RFC3339)),
+		Score:       1,
+		Metadata: map[string]interface{}{
+			"hash":     hash,
+			"tx_count": txCount,
+			"miner":    miner.String,
+		},
+	}}, nil
+}
+
+// searchTransactionByHash busca uma transação pelo hash
+func (s *SearchService) searchTransactionByHash(ctx context.Context, hash string) ([]SearchResult, error) {
+	var (
+		blockNumber sql.NullInt64
+		from        string
+		to          sql.NullString
+		status      string
+		value       string
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT block_number, from_address, to_address, status, value FROM transactions WHERE hash = $1`, hash,
+	).Scan(&blockNumber, &from, &to, &status, &value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar transação: %w", err)
+	}
+
+	description := "De " + from
+	if to.Valid {
+		description += " para " + to.String
+	} else {
+		description += " (criação de contrato)"
+	}
+
+	metadata := map[string]interface{}{
+		"from":   from,
+		"status": status,
+		"value":  value,
+	}
+	if to.Valid {
+		metadata["to"] = to.String
+	}
+	if blockNumber.Valid {
+		metadata["block_number"] = blockNumber.Int64
+	}
+
+	return []SearchResult{{
+		Type:        SearchResultTransaction,
+		ID:          hash,
+		Label:       "Transação " + shortenHex(hash),
+		Description: description,
+		Score:       1,
+		Metadata:    metadata,
+	}}, nil
+}
+
+// searchContractByAddress busca um smart contract pelo endereço exato
+func (s *SearchService) searchContractByAddress(ctx context.Context, addresses interface{}) ([]SearchResult, error) {
+	return s.queryContracts(ctx, `
+		SELECT address, name, symbol, contract_type, is_verified, 1.0
+		FROM smart_contracts
+		WHERE address = ANY($1)
+		LIMIT 1`, addresses)
+}
+
+// searchContractsByAddressPrefix busca smart contracts cujo endereço começa com o prefixo
+func (s *SearchService) searchContractsByAddressPrefix(ctx context.Context, prefix string, limit int) ([]SearchResult, error) {
+	return s.queryContracts(ctx, `
+		SELECT address, name, symbol, contract_type, is_verified, 0.6
+		FROM smart_contracts
+		WHERE LOWER(address) LIKE $1
+		ORDER BY total_transactions DESC NULLS LAST
+		LIMIT $2`, prefix, limit)
+}
+
+// searchContractsByName busca contratos e tokens por prefixo ou similaridade (trigram) de nome e símbolo
+func (s *SearchService) searchContractsByName(ctx context.Context, term, prefix string, limit int) ([]SearchResult, error) {
+	return s.queryContracts(ctx, `
+		SELECT address, name, symbol, contract_type, is_verified,
+		       CASE
+		           WHEN LOWER(symbol) = $1 OR LOWER(name) = $1 THEN 1.0
+		           WHEN LOWER(symbol) LIKE $2 OR LOWER(name) LIKE $2 THEN
+		               0.8 + 0.15 * GREATEST(similarity(LOWER(COALESCE(name, '')), $1), similarity(LOWER(COALESCE(symbol, '')), $1))
+		           ELSE
+		               0.7 * GREATEST(similarity(LOWER(COALESCE(name, '')), $1), similarity(LOWER(COALESCE(symbol, '')), $1))
+		       END AS score
+		FROM smart_contracts
+		WHERE LOWER(name) LIKE $2 OR LOWER(symbol) LIKE $2
+		   OR LOWER(name) % $1 OR LOWER(symbol) % $1
+		ORDER BY score DESC, is_verified DESC, total_transactions DESC NULLS LAST
+		LIMIT $3`, term, prefix, limit)
+}
+
+// queryContracts executa uma consulta de smart contracts e converte em sugestões
+func (s *SearchService) queryContracts(ctx context.Context, query string, args ...interface{}) ([]SearchResult, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar smart contracts: %w", err)
+	}
+	defer rows.Close()
+
+	var results []SearchResult
+	for rows.Next() {
+		var (
+			address      string
+			name         sql.NullString
+			symbol       sql.NullString
+			contractType sql.NullString
+			isVerified   sql.NullBool
+			score        float64
+		)
+		if err := rows.Scan(&address, &name, &symbol, &contractType, &isVerified, &score); err != nil {
+			return nil, fmt.Errorf("erro ao ler smart contract: %w", err)
+		}
+
+		result := SearchResult{
+			Type:  SearchResultContract,
+			ID:    address,
+			Label: address,
+			Score: score,
+			Metadata: map[string]interface{}{
+				"contract_type": contractType.String,
+				"is_verified":   isVerified.Bool,
+			},
+		}
+		if name.Valid && name.String != "" {
+			result.Label = name.String
+			result.Description = address
+		}
+		if symbol.Valid && symbol.String != "" {
+			// Contratos com símbolo são tokens (ERC-20, ERC-721, ...)
+			result.Type = SearchResultToken
+			result.Label = fmt.Sprintf("%s (%s)", result.Label, symbol.String)
+			result.Metadata["symbol"] = symbol.String
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// searchAccountByAddress busca uma account pelo endereço exato
+func (s *SearchService) searchAccountByAddress(ctx context.Context, addresses interface{}) ([]SearchResult, error) {
+	return s.queryAccounts(ctx, `
+		SELECT address, label, account_type, transaction_count, 1.0
+		FROM accounts
+		WHERE address = ANY($1)
+		LIMIT 1`, addresses)
+}
+
+// searchAccountsByAddressPrefix busca accounts cujo endereço começa com o prefixo
+func (s *SearchService) searchAccountsByAddressPrefix(ctx context.Context, prefix string, limit int) ([]SearchResult, error) {
+	return s.queryAccounts(ctx, `
+		SELECT address, label, account_type, transaction_count, 0.5
+		FROM accounts
+		WHERE LOWER(address) LIKE $1
+		ORDER BY transaction_count DESC
+		LIMIT $2`, prefix, limit)
+}
+
+// searchAccountsByLabel busca accounts por prefixo ou similaridade (trigram) do label
+func (s *SearchService) searchAccountsByLabel(ctx context.Context, term, prefix string, limit int) ([]SearchResult, error) {
+	return s.queryAccounts(ctx, `
+		SELECT address, label, account_type, transaction_count,
+		       CASE
+		           WHEN LOWER(label) = $1 THEN 0.95
+		           WHEN LOWER(label) LIKE $2 THEN 0.75 + 0.15 * similarity(LOWER(label), $1)
+		           ELSE 0.65 * similarity(LOWER(label), $1)
+		       END AS score
+		FROM accounts
+		WHERE label IS NOT NULL AND (LOWER(label) LIKE $2 OR LOWER(label) % $1)
+		ORDER BY score DESC, transaction_count DESC
+		LIMIT $3`, term, prefix, limit)
+}
+
+// queryAccounts executa uma consulta de accounts e converte em sugestões
+func (s *SearchService) queryAccounts(ctx context.Context, query string, args ...interface{}) ([]SearchResult, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var results []SearchResult
+	for rows.Next() {
+		var (
+			address          string
+			label            sql.NullString
+			accountType      string
+			transactionCount int64
+			score            float64
+		)
+		if err := rows.Scan(&address, &label, &accountType, &transactionCount, &score); err != nil {
+			return nil, fmt.Errorf("erro ao ler account: %w", err)
+		}
+
+		result := SearchResult{
+			Type:        SearchResultAccount,
+			ID:          address,
+			Label:       address,
+			Description: fmt.Sprintf("%s · %d transações", accountType, transactionCount),
+			Score:       score,
+			Metadata: map[string]interface{}{
+				"account_type":      accountType,
+				"transaction_count": transactionCount,
+			},
+		}
+		if label.Valid && label.String != "" {
+			result.Label = label.String
+			result.Description = address + " · " + result.Description
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// searchValidatorByAddress busca um validador QBFT pelo endereço
+func (s *SearchService) searchValidatorByAddress(ctx context.Context, addresses interface{}) ([]SearchResult, error) {
+	var (
+		address  string
+		status   string
+		isActive bool
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT address, status, is_active FROM validators WHERE address = ANY($1) LIMIT 1`, addresses,
+	).Scan(&address, &status, &isActive)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar validador: %w", err)
+	}
+
+	return []SearchResult{{
+		Type:        SearchResultValidator,
+		ID:          address,
+		Label:       "Validador " + shortenHex(address),
+		Description: "Status: " + status,
+		Score:       1,
+		Metadata: map[string]interface{}{
+			"status":    status,
+			"is_active": isActive,
+		},
+	}}, nil
+}
+
+// searchEventNames busca nomes de eventos conhecidos pelas ABIs registradas
+func (s *SearchService) searchEventNames(ctx context.Context, term, prefix string, limit int) ([]SearchResult, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT event_name, COUNT(DISTINCT contract_address) AS contracts,
+		       COALESCE(SUM(emission_count), 0) AS emissions,
+		       MAX(CASE
+		           WHEN LOWER(event_name) = $1 THEN 0.9
+		           WHEN LOWER(event_name) LIKE $2 THEN 0.7 + 0.15 * similarity(LOWER(event_name), $1)
+		           ELSE 0.6 * similarity(LOWER(event_name), $1)
+		       END) AS score
+		FROM smart_contract_events
+		WHERE LOWER(event_name) LIKE $2 OR LOWER(event_name) % $1
+		GROUP BY event_name
+		ORDER BY score DESC, emissions DESC
+		LIMIT $3`, term, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar eventos: %w", err)
+	}
+	defer rows.Close()
+
+	var results []SearchResult
+	for rows.Next() {
+		var (
+			name      string
+			contracts int64
+			emissions int64
+			score     float64
+		)
+		if err := rows.Scan(&name, &contracts, &emissions, &score); err != nil {
+			return nil, fmt.Errorf("erro ao ler evento: %w", err)
+		}
+
+		results = append(results, SearchResult{
+			Type:        SearchResultEvent,
+			ID:          name,
+			Label:       name,
+			Description: fmt.Sprintf("Emitido por %d contratos", contracts),
+			Score:       score,
+			Metadata: map[string]interface{}{
+				"contracts": contracts,
+				"emissions": emissions,
+			},
+		})
+	}
+
+	return results, rows.Err()
+}
+
+// isHexString verifica se a string contém apenas dígitos hexadecimais
+func isHexString(value string) bool {
+	if value == "" {
+		return false
+	}
+	if len(value)%2 == 1 {
+		value = "0" + value
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
+
+// shortenHex abrevia hashes e endereços para exibição (0x1234…abcd)
+func shortenHex(value string) string {
+	if len(value) <= 14 {
+		return value
+	}
+	return value[:6] + "…" + value[len(value)-4:]
+}
+
+// escapeLikePattern escapa os curingas do LIKE presentes no termo digitado
+func escapeLikePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
@@ -0,0 +1,415 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxSimulationGas limita o gas de cada simulação; sem ele o nó usaria o gas limit do bloco no debug_traceCall
+const maxSimulationGas = 10_000_000
+
+// callTracerFrame é um frame do callTracer do debug_traceCall (mesmo formato no Besu e no geth)
+type callTracerFrame struct {
+	Type    string            `json:"type"`
+	From    string            `json:"from"`
+	To      string            `json:"to"`
+	Value   *hexutil.Big      `json:"value"`
+	Gas     hexutil.Uint64    `json:"gas"`
+	GasUsed hexutil.Uint64    `json:"gasUsed"`
+	Input   hexutil.Bytes     `json:"input"`
+	Output  hexutil.Bytes     `json:"output"`
+	Error   string            `json:"error"`
+	Calls   []callTracerFrame `json:"calls"`
+	Logs    []callTracerLog   `json:"logs"`
+}
+
+// callTracerLog é um log emitido em um frame; Position é o número de subchamadas feitas antes do log
+type callTracerLog struct {
+	Address  common.Address `json:"address"`
+	Topics   []common.Hash  `json:"topics"`
+	Data     hexutil.Bytes  `json:"data"`
+	Position hexutil.Uint64 `json:"position"`
+}
+
+// simulationABIs carrega sob demanda os ABIs gravados dos contratos envolvidos em uma simulação
+type simulationABIs struct {
+	ctx       context.Context
+	db        *sql.DB
+	byAddress map[string]*abi.ABI
+	loaded    []*abi.ABI
+}
+
+// get retorna o ABI do endereço, ou nil se o contrato não tem ABI gravado
+func (r *simulationABIs) get(address string) *abi.ABI {
+	key := strings.ToLower(address)
+	if contractABI, ok := r.byAddress[key]; ok {
+		return contractABI
+	}
+
+	contractABI, err := loadContractABI(r.ctx, r.db, key)
+	if err != nil {
+		if !errors.Is(err, ErrContractNotFound) && !errors.Is(err, ErrContractABIUnavailable) {
+			log.Printf("⚠️ Erro ao carregar ABI de %s para simulação: %v", key, err)
+		}
+		contractABI = nil
+	}
+	r.byAddress[key] = contractABI
+	if contractABI != nil {
+		r.loaded = append(r.loaded, contractABI)
+	}
+	return contractABI
+}
+
+// SimulationService simula transações (eth_call, eth_estimateGas e debug_traceCall) sem enviá-las à rede,
+// decodificando revert, retorno, logs e chamadas internas com os ABIs gravados em smart_contracts
+type SimulationService struct {
+	db    *sql.DB
+	calls *ContractCallService
+}
+
+// NewSimulationService cria uma nova instância do serviço de simulação
+func NewSimulationService(db *sql.DB, contractCallService *ContractCallService) *SimulationService {
+	return &SimulationService{
+		db:    db,
+		calls: contractCallService,
+	}
+}
+
+// Simulate executa a transação no estado do bloco pedido. Reverts não são erros: o resultado vem com
+// success=false e o motivo decodificado. O trace é opcional; sem debug_traceCall no nó, logs e árvore de
+// chamadas ficam vazios e um aviso é incluído
+func (s *SimulationService) Simulate(ctx context.Context, request *entities.SimulationRequest) (*entities.SimulationResult, error) {
+	if !common.IsHexAddress(request.To) {
+		return nil, fmt.Errorf("%w: to inválido", ErrInvalidContractCall)
+	}
+	if request.From != "" && !common.IsHexAddress(request.From) {
+		return nil, fmt.Errorf("%w: from inválido", ErrInvalidContractCall)
+	}
+	if request.Data != "" && request.Function != "" {
+		return nil, fmt.Errorf("%w: informe data ou function + args, não ambos", ErrInvalidContractCall)
+	}
+
+	value := new(big.Int)
+	if request.Value != "" {
+		parsed, ok := parseBigInt(request.Value)
+		if !ok || parsed.Sign() < 0 {
+			return nil, fmt.Errorf("%w: value deve ser um inteiro não negativo em wei", ErrInvalidContractCall)
+		}
+		value = parsed
+	}
+
+	abis := &simulationABIs{ctx: ctx, db: s.db, byAddress: make(map[string]*abi.ABI)}
+	to := strings.ToLower(request.To)
+
+	var (
+		data   []byte
+		method *abi.Method
+		err    error
+	)
+	switch {
+	case request.Function != "":
+		var contractABI *abi.ABI
+		if contractABI, err = loadContractABI(ctx, s.db, to); err != nil {
+			return nil, err
+		}
+		abis.byAddress[to] = contractABI
+		abis.loaded = append(abis.loaded, contractABI)
+		if method, err = resolveContractMethod(contractABI, request.Function, len(request.Args)); err != nil {
+			return nil, err
+		}
+		if data, err = packContractCall(method, request.Args); err != nil {
+			return nil, err
+		}
+	case request.Data != "":
+		if data, err = hexutil.Decode(request.Data); err != nil {
+			return nil, fmt.Errorf("%w: data deve ser hex com prefixo 0x", ErrInvalidContractCall)
+		}
+		if contractABI := abis.get(to); contractABI != nil && len(data) >= 4 {
+			method, _ = contractABI.MethodById(data[:4])
+		}
+	}
+
+	block, err := s.calls.resolveBlock(ctx, request.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	call := map[string]interface{}{"to": to, "data": hexutil.Encode(data), "value": hexutil.EncodeBig(value)}
+	if request.From != "" {
+		call["from"] = strings.ToLower(request.From)
+	}
+	gas := uint64(maxSimulationGas)
+	if request.Gas != "" {
+		requested, ok := parseBigInt(request.Gas)
+		if !ok || requested.Sign() <= 0 || !requested.IsUint64() {
+			return nil, fmt.Errorf("%w: gas deve ser um inteiro positivo", ErrInvalidContractCall)
+		}
+		if requested.Uint64() > maxSimulationGas {
+			return nil, fmt.Errorf("%w: gas deve ser no máximo %d", ErrInvalidContractCall, maxSimulationGas)
+		}
+		gas = requested.Uint64()
+	}
+	call["gas"] = hexutil.EncodeUint64(gas)
+
+	tracerOptions := map[string]interface{}{"tracer": "callTracer", "tracerConfig": map[string]interface{}{"withLog": true}}
+	responses, err := s.calls.callRPCBatch(ctx, []contractRPCRequest{
+		{JSONRPC: "2.0", Method: "eth_call", Params: []interface{}{call, block.param}, ID: 0},
+		{JSONRPC: "2.0", Method: "eth_estimateGas", Params: []interface{}{call, block.param}, ID: 1},
+		{JSONRPC: "2.0", Method: "debug_traceCall", Params: []interface{}{call, block.param, tracerOptions}, ID: 2},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entities.SimulationResult{
+		To:          common.HexToAddress(to).Hex(),
+		Data:        hexutil.Encode(data),
+		Value:       value.String(),
+		BlockNumber: block.number,
+		Logs:        []*entities.SimulatedLog{},
+	}
+	if request.From != "" {
+		result.From = common.HexToAddress(request.From).Hex()
+	}
+	if method != nil {
+		result.Function = method.Sig
+		result.Selector = hexutil.Encode(method.ID)
+	}
+
+	// O trace vem antes do revert: ele carrega os ABIs dos contratos chamados, usados para decodificar erros
+	// customizados que sobem de chamadas internas
+	if traceResponse := responses[2]; traceResponse.Error != nil {
+		result.Warnings = append(result.Warnings, "debug_traceCall indisponível no nó (logs e árvore de chamadas omitidos): "+traceResponse.Error.Message)
+	} else {
+		var root callTracerFrame
+		if err := json.Unmarshal(traceResponse.Result, &root); err != nil {
+			result.Warnings = append(result.Warnings, "resposta do debug_traceCall em formato inesperado: "+err.Error())
+		} else {
+			result.TraceAvailable = true
+			result.CallTrace = buildSimulatedCall(&root, abis)
+			gasUsed := uint64(root.GasUsed)
+			result.GasUsed = &gasUsed
+			if root.Error == "" {
+				collectSimulatedLogs(&root, abis, &result.Logs)
+			}
+		}
+	}
+
+	output, err := callResult(responses[0], abis.get(to))
+	var revert *ContractRevertError
+	switch {
+	case errors.As(err, &revert):
+		result.Revert = revert.Reason
+		if revert.Reason.Kind == "unknown" {
+			if revertData, decodeErr := hexutil.Decode(revert.Reason.Data); decodeErr == nil && len(revertData) >= 4 {
+				result.Revert = decodeRevertWithABIs(revertData, nil, abis.loaded)
+			}
+		}
+	case err != nil:
+		return nil, err
+	default:
+		result.Success = true
+		result.ReturnData = hexutil.Encode(output)
+		if method != nil && len(method.Outputs) > 0 {
+			outputs, err := decodeContractValues(method.Outputs, output)
+			if err != nil {
+				result.Warnings = append(result.Warnings, "retorno incompatível com o ABI: "+err.Error())
+			} else {
+				result.Outputs = outputs
+			}
+		}
+	}
+
+	if estimateResponse := responses[1]; estimateResponse.Error != nil {
+		if _, reverted := estimateResponse.Error.revertData(); !reverted || result.Success {
+			result.Warnings = append(result.Warnings, "eth_estimateGas falhou: "+estimateResponse.Error.Message)
+		}
+	} else {
+		var estimate hexutil.Uint64
+		if err := json.Unmarshal(estimateResponse.Result, &estimate); err != nil {
+			result.Warnings = append(result.Warnings, "resposta do eth_estimateGas inválida")
+		} else {
+			gas := uint64(estimate)
+			result.GasEstimate = &gas
+		}
+	}
+
+	return result, nil
+}
+
+// decodeRevertWithABIs decodifica o revert com o ABI principal e, se o erro customizado não estiver nele,
+// com os demais ABIs da simulação
+func decodeRevertWithABIs(data []byte, primary *abi.ABI, others []*abi.ABI) *entities.RevertReason {
+	reason := decodeRevertReason(data, primary)
+	if reason.Kind != "unknown" {
+		return reason
+	}
+	for _, contractABI := range others {
+		if candidate := decodeRevertReason(data, contractABI); candidate.Kind != "unknown" {
+			return candidate
+		}
+	}
+	return reason
+}
+
+// buildSimulatedCall converte o frame do callTracer, identificando a função e o revert com o ABI do destino
+func buildSimulatedCall(frame *callTracerFrame, abis *simulationABIs) *entities.SimulatedCall {
+	call := &entities.SimulatedCall{
+		Type:    frame.Type,
+		From:    frame.From,
+		To:      frame.To,
+		Gas:     uint64(frame.Gas),
+		GasUsed: uint64(frame.GasUsed),
+		Input:   hexutil.Encode(frame.Input),
+		Error:   frame.Error,
+	}
+	if frame.Value != nil && frame.Value.ToInt().Sign() > 0 {
+		call.Value = frame.Value.ToInt().String()
+	}
+	if len(frame.Output) > 0 {
+		call.Output = hexutil.Encode(frame.Output)
+	}
+
+	var contractABI *abi.ABI
+	if common.IsHexAddress(frame.To) {
+		contractABI = abis.get(frame.To)
+	}
+	isCreate := strings.HasPrefix(strings.ToUpper(frame.Type), "CREATE")
+	if contractABI != nil && !isCreate && len(frame.Input) >= 4 {
+		if method, err := contractABI.MethodById(frame.Input[:4]); err == nil {
+			call.Function = method.Sig
+			if values, err := method.Inputs.UnpackValues(frame.Input[4:]); err == nil {
+				call.Args = namedContractValues(method.Inputs, values)
+			}
+		}
+	}
+	if frame.Error != "" && len(frame.Output) > 0 {
+		call.Revert = decodeRevertWithABIs(frame.Output, contractABI, abis.loaded)
+	}
+
+	for i := range frame.Calls {
+		call.Calls = append(call.Calls, buildSimulatedCall(&frame.Calls[i], abis))
+	}
+	return call
+}
+
+// collectSimulatedLogs coleta os logs na ordem de emissão, intercalando os do frame com os das subchamadas
+// pela posição. Frames que falharam são ignorados, pois seus logs são descartados com o revert
+func collectSimulatedLogs(frame *callTracerFrame, abis *simulationABIs, logs *[]*entities.SimulatedLog) {
+	next := 0
+	emitUntil := func(position int) {
+		for next < len(frame.Logs) && int(frame.Logs[next].Position) <= position {
+			*logs = append(*logs, decodeSimulatedLog(&frame.Logs[next], abis))
+			next++
+		}
+	}
+	for i := range frame.Calls {
+		emitUntil(i)
+		if frame.Calls[i].Error == "" {
+			collectSimulatedLogs(&frame.Calls[i], abis, logs)
+		}
+	}
+	for ; next < len(frame.Logs); next++ {
+		*logs = append(*logs, decodeSimulatedLog(&frame.Logs[next], abis))
+	}
+}
+
+// decodeSimulatedLog decodifica o log com o ABI do emissor ou, se o evento não estiver nele, com os demais
+// ABIs da simulação (ex.: eventos de bibliotecas)
+func decodeSimulatedLog(raw *callTracerLog, abis *simulationABIs) *entities.SimulatedLog {
+	simulated := &entities.SimulatedLog{
+		Address: raw.Address.Hex(),
+		Topics:  make([]string, len(raw.Topics)),
+		Data:    hexutil.Encode(raw.Data),
+	}
+	for i, topic := range raw.Topics {
+		simulated.Topics[i] = topic.Hex()
+	}
+	if len(raw.Topics) == 0 {
+		return simulated
+	}
+
+	candidates := abis.loaded
+	if emitterABI := abis.get(raw.Address.Hex()); emitterABI != nil {
+		candidates = append([]*abi.ABI{emitterABI}, abis.loaded...)
+	}
+	for _, contractABI := range candidates {
+		for _, event := range contractABI.Events {
+			if event.Anonymous || event.ID != raw.Topics[0] {
+				continue
+			}
+			args, ok := decodeEventArgs(&event, raw.Topics[1:], raw.Data)
+			if !ok {
+				continue
+			}
+			simulated.Event = event.Name
+			simulated.Signature = event.Sig
+			simulated.Args = args
+			return simulated
+		}
+	}
+	return simulated
+}
+
+// decodeEventArgs decodifica os argumentos do evento na ordem da declaração. Argumentos indexados de tipo
+// dinâmico só existem como hash no tópico
+func decodeEventArgs(event *abi.Event, topics []common.Hash, data []byte) ([]entities.ContractValue, bool) {
+	indexed := 0
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+	if indexed != len(topics) {
+		return nil, false
+	}
+
+	nonIndexed := event.Inputs.NonIndexed()
+	values, err := nonIndexed.UnpackValues(data)
+	if err != nil {
+		return nil, false
+	}
+
+	args := make([]entities.ContractValue, 0, len(event.Inputs))
+	topicIndex, valueIndex := 0, 0
+	for i, input := range event.Inputs {
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		arg := entities.ContractValue{Name: name, Type: input.Type.String()}
+
+		if input.Indexed {
+			topic := topics[topicIndex]
+			topicIndex++
+			arg.Value = topic.Hex()
+			switch input.Type.T {
+			case abi.IntTy, abi.UintTy, abi.BoolTy, abi.AddressTy, abi.FixedBytesTy:
+				if decoded, err := (abi.Arguments{{Type: input.Type}}).UnpackValues(topic.Bytes()); err == nil && len(decoded) == 1 {
+					arg.Value = formatABIValue(input.Type, reflect.ValueOf(decoded[0]))
+				}
+			}
+		} else {
+			if valueIndex >= len(values) {
+				return nil, false
+			}
+			arg.Value = formatABIValue(input.Type, reflect.ValueOf(values[valueIndex]))
+			valueIndex++
+		}
+		args = append(args, arg)
+	}
+	return args, true
+}
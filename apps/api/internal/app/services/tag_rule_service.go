@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+// tagRulePreviewSample é o número de accounts retornadas na amostra da simulação
+const tagRulePreviewSample = 50
+
+var (
+	// ErrTagRuleNotFound indica que a regra de tag não existe
+	ErrTagRuleNotFound = errors.New("regra de tag não encontrada")
+	// ErrInvalidTagRule indica uma regra que não passou na validação
+	ErrInvalidTagRule = errors.New("regra de tag inválida")
+)
+
+// TagRuleService gerencia as regras de tags automáticas avaliadas pelo worker
+type TagRuleService struct {
+	tagRuleRepo repositories.TagRuleRepository
+}
+
+// NewTagRuleService cria uma nova instância do serviço de regras de tags
+func NewTagRuleService(tagRuleRepo repositories.TagRuleRepository) *TagRuleService {
+	return &TagRuleService{
+		tagRuleRepo: tagRuleRepo,
+	}
+}
+
+// CreateRule valida e grava uma nova regra. O worker aplica a regra a todas as accounts na próxima reavaliação
+func (s *TagRuleService) CreateRule(ctx context.Context, request *entities.CreateTagRuleRequest, actor string) (*entities.TagRule, error) {
+	tag := strings.ToLower(strings.TrimSpace(request.Tag))
+	if !entities.IsValidTagRuleTag(tag) {
+		return nil, fmt.Errorf("%w: tag deve conter apenas letras minúsculas, números, '.', '_', ':' e '-'", ErrInvalidTagRule)
+	}
+	if err := entities.ValidateTagConditions(request.Conditions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTagRule, err)
+	}
+
+	rule := &entities.TagRule{
+		Tag:         tag,
+		Description: request.Description,
+		Conditions:  request.Conditions,
+		IsActive:    request.IsActive == nil || *request.IsActive,
+		CreatedBy:   &actor,
+	}
+	if err := s.tagRuleRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return s.GetRule(ctx, rule.ID)
+}
+
+// GetRule busca uma regra de tag
+func (s *TagRuleService) GetRule(ctx context.Context, id int64) (*entities.TagRule, error) {
+	rule, err := s.tagRuleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, ErrTagRuleNotFound
+	}
+	return rule, nil
+}
+
+// ListRules lista as regras de tags
+func (s *TagRuleService) ListRules(ctx context.Context, filters *entities.TagRuleFilters, page, limit int) (*PaginatedResult[*entities.TagRule], error) {
+	offset := (page - 1) * limit
+	rules, total, err := s.tagRuleRepo.FindAll(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[*entities.TagRule]{
+		Data:       rules,
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// UpdateRule altera descrição, condições ou estado da regra. A tag não muda: para renomear, crie outra regra
+func (s *TagRuleService) UpdateRule(ctx context.Context, id int64, request *entities.UpdateTagRuleRequest, actor string) (*entities.TagRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Description != nil {
+		rule.Description = request.Description
+	}
+	if request.Conditions != nil {
+		if err := entities.ValidateTagConditions(request.Conditions); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTagRule, err)
+		}
+		rule.Conditions = request.Conditions
+	}
+	if request.IsActive != nil {
+		rule.IsActive = *request.IsActive
+	}
+	rule.UpdatedBy = &actor
+
+	updated, err := s.tagRuleRepo.Update(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrTagRuleNotFound
+	}
+
+	return s.GetRule(ctx, id)
+}
+
+// DeleteRule remove uma regra de tag
+func (s *TagRuleService) DeleteRule(ctx context.Context, id int64) error {
+	deleted, err := s.tagRuleRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrTagRuleNotFound
+	}
+	return nil
+}
+
+// PreviewRule simula condições ainda não gravadas, mostrando as accounts que casariam
+func (s *TagRuleService) PreviewRule(ctx context.Context, request *entities.PreviewTagRuleRequest) (*entities.TagRulePreview, error) {
+	tag := strings.ToLower(strings.TrimSpace(request.Tag))
+	if tag != "" && !entities.IsValidTagRuleTag(tag) {
+		return nil, fmt.Errorf("%w: tag inválida", ErrInvalidTagRule)
+	}
+	if err := entities.ValidateTagConditions(request.Conditions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTagRule, err)
+	}
+
+	return s.tagRuleRepo.Preview(ctx, tag, request.Conditions, 0, tagRulePreviewSample)
+}
+
+// PreviewStoredRule simula uma regra gravada, mostrando as accounts que ganhariam ou perderiam a tag
+func (s *TagRuleService) PreviewStoredRule(ctx context.Context, id int64) (*entities.TagRulePreview, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateTagConditions(rule.Conditions); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTagRule, err)
+	}
+
+	return s.tagRuleRepo.Preview(ctx, rule.Tag, rule.Conditions, rule.ID, tagRulePreviewSample)
+}
@@ -134,17 +134,26 @@ func (s *TransactionService) GetTransactionsWithFilters(ctx context.Context, fil
 	// Converter filtros para SQL
 	whereClause, args, orderClause := filters.ToSQL()
 
-	// Calcular offset
+	// Calcular offset (ignorado quando há cursor)
 	offset := (filters.Page - 1) * filters.Limit
+	if filters.usesCursor() {
+		offset = 0
+	}
+	pageWhere, pageArgs := filters.withCursor(whereClause, args)
 
-	// Buscar transações
-	transactions, err := s.transactionRepo.FindWithFilters(ctx, whereClause, args, orderClause, filters.Limit, offset)
+	// Buscar uma transação a mais para saber se existe próxima página
+	transactions, err := s.transactionRepo.FindWithFilters(ctx, pageWhere, pageArgs, orderClause, filters.Limit+1, offset)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar transações com filtros: %w", err)
 	}
 
-	// Contar total
-	total, err := s.transactionRepo.CountWithFilters(ctx, whereClause, args)
+	hasMore := len(transactions) > filters.Limit
+	if hasMore {
+		transactions = transactions[:filters.Limit]
+	}
+
+	// Contar total (estimado em consultas muito grandes)
+	total, approximate, err := s.transactionRepo.EstimateCountWithFilters(ctx, whereClause, args)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao contar transações com filtros: %w", err)
 	}
@@ -155,19 +164,24 @@ func (s *TransactionService) GetTransactionsWithFilters(ctx context.Context, fil
 		summaries[i] = transaction.ToSummary()
 	}
 
-	// Calcular total de páginas
-	totalPages := int(total) / filters.Limit
-	if int(total)%filters.Limit > 0 {
-		totalPages++
+	response := &PaginatedResponse{
+		Data:             summaries,
+		Page:             filters.Page,
+		Limit:            filters.Limit,
+		Total:            total,
+		TotalPages:       totalPagesFor(total, filters.Limit),
+		TotalApproximate: approximate,
 	}
 
-	return &PaginatedResponse{
-		Data:       summaries,
-		Page:       filters.Page,
-		Limit:      filters.Limit,
-		Total:      total,
-		TotalPages: totalPages,
-	}, nil
+	// Transações pendentes não têm posição no keyset
+	if hasMore && filters.SupportsCursor() {
+		last := transactions[len(transactions)-1]
+		if last.BlockNumber != nil && last.TransactionIndex != nil {
+			response.NextCursor = filters.nextTransactionCursor(*last.BlockNumber, *last.TransactionIndex)
+		}
+	}
+
+	return response, nil
 }
 
 // GetTransactionStats retorna estatísticas das transações
@@ -229,8 +243,11 @@ type TransactionFilters struct {
 	OrderDir string `json:"order_dir,omitempty"` // Direção (asc, desc)
 
 	// Paginação
-	Page  int `json:"page,omitempty"`  // Página (padrão: 1)
-	Limit int `json:"limit,omitempty"` // Limite por página (padrão: 10, máx: 100)
+	Page   int    `json:"page,omitempty"`   // Página (padrão: 1)
+	Limit  int    `json:"limit,omitempty"`  // Limite por página (padrão: 10, máx: 100)
+	Cursor string `json:"cursor,omitempty"` // Cursor opaco da paginação por keyset (substitui page)
+
+	cursor *PageCursor
 }
 
 // Validate valida e normaliza os filtros
@@ -262,6 +279,20 @@ func (f *TransactionFilters) Validate() error {
 		f.OrderDir = "desc"
 	}
 
+	// Cursor implica ordenação por (block_number, transaction_index) na direção em que foi gerado
+	if f.Cursor != "" {
+		cursor, err := DecodeCursor(f.Cursor)
+		if err != nil {
+			return err
+		}
+		f.cursor = cursor
+		f.OrderBy = "block_number"
+		f.OrderDir = "asc"
+		if cursor.Desc {
+			f.OrderDir = "desc"
+		}
+	}
+
 	// Validar endereços
 	if f.From != "" && (len(f.From) != 42 || f.From[:2] != "0x") {
 		return fmt.Errorf("endereço 'from' inválido: %s", f.From)
@@ -366,16 +397,24 @@ func (f *TransactionFilters) ToSQL() (whereClause string, args []interface{}, or
 		args = append(args, *f.TxType)
 	}
 
-	// Filtros de tempo - usar os timestamps processados se disponíveis
+	// Filtros de tempo - usar os timestamps processados se disponíveis. Fora das pendentes
+	// (sem bloco), o mesmo parâmetro também limita os blocos para descartar partições
+	prunable := f.Status != "pending"
 	if f.FromTimestamp != nil {
 		argIndex++
 		conditions = append(conditions, fmt.Sprintf("timestamp >= $%d", argIndex))
 		args = append(args, *f.FromTimestamp)
+		if prunable {
+			conditions = append(conditions, blockLowerBound("block_number", fmt.Sprintf("$%d", argIndex)))
+		}
 	}
 	if f.ToTimestamp != nil {
 		argIndex++
 		conditions = append(conditions, fmt.Sprintf("timestamp <= $%d", argIndex))
 		args = append(args, *f.ToTimestamp)
+		if prunable {
+			conditions = append(conditions, blockUpperBound("block_number", fmt.Sprintf("$%d", argIndex)))
+		}
 	}
 
 	// Filtros de bloco
@@ -412,12 +451,57 @@ func (f *TransactionFilters) ToSQL() (whereClause string, args []interface{}, or
 		whereClause = strings.Join(conditions, " AND ")
 	}
 
-	// Construir ORDER clause
-	orderClause = fmt.Sprintf("ORDER BY %s %s", f.OrderBy, strings.ToUpper(f.OrderDir))
+	// Construir ORDER clause - por bloco, desempatar pelo índice para manter a ordem estável
+	if f.SupportsCursor() {
+		orderClause = keysetOrder(transactionKeysetColumns, f.OrderDir == "desc")
+	} else {
+		orderClause = fmt.Sprintf("ORDER BY %s %s", f.OrderBy, strings.ToUpper(f.OrderDir))
+	}
 
 	return whereClause, args, orderClause
 }
 
+// transactionKeysetColumns são as colunas da ordenação por keyset de transações
+var transactionKeysetColumns = []string{"block_number", "transaction_index"}
+
+// SupportsCursor indica se a ordenação atual permite paginação por keyset
+func (f *TransactionFilters) SupportsCursor() bool {
+	return f.OrderBy == "block_number"
+}
+
+// usesCursor indica se a consulta está posicionada por um cursor
+func (f *TransactionFilters) usesCursor() bool {
+	return f.cursor != nil
+}
+
+// cursorCondition retorna a condição de keyset com placeholders a partir de argIndex+1
+func (f *TransactionFilters) cursorCondition(argIndex int) (string, []interface{}) {
+	return f.cursor.keysetCondition(transactionKeysetColumns, argIndex), []interface{}{f.cursor.BlockNumber, f.cursor.TxIndex}
+}
+
+// withCursor acrescenta a posição do cursor às cláusulas geradas por ToSQL.
+// A contagem continua usando as cláusulas originais para refletir o total do filtro
+func (f *TransactionFilters) withCursor(whereClause string, args []interface{}) (string, []interface{}) {
+	if f.cursor == nil {
+		return whereClause, args
+	}
+
+	condition, cursorArgs := f.cursorCondition(len(args))
+	if whereClause != "" {
+		whereClause += " AND "
+	}
+	return whereClause + condition, append(append([]interface{}{}, args...), cursorArgs...)
+}
+
+// nextTransactionCursor monta o cursor a partir da posição da última linha retornada
+func (f *TransactionFilters) nextTransactionCursor(blockNumber, txIndex uint64) string {
+	return PageCursor{
+		BlockNumber: blockNumber,
+		TxIndex:     txIndex,
+		Desc:        f.OrderDir == "desc",
+	}.Encode()
+}
+
 // processDateFilters processa filtros de data
 func (f *TransactionFilters) processDateFilters() error {
 	// Processar FromDate
@@ -30,20 +30,24 @@ type PaginationParams struct {
 
 // PaginatedResponse representa uma resposta paginada
 type PaginatedResponse struct {
-	Data       interface{} `json:"data"`
-	Page       int         `json:"page"`
-	Limit      int         `json:"limit"`
-	Total      int64       `json:"total"`
-	TotalPages int         `json:"total_pages"`
+	Data             interface{} `json:"data"`
+	Page             int         `json:"page"`
+	Limit            int         `json:"limit"`
+	Total            int64       `json:"total"`
+	TotalPages       int         `json:"total_pages"`
+	TotalApproximate bool        `json:"total_approximate,omitempty"` // Total estimado pelo planner
+	NextCursor       string      `json:"next_cursor,omitempty"`       // Cursor da próxima página (keyset)
 }
 
 // PaginatedResult representa um resultado paginado genérico
 type PaginatedResult[T any] struct {
-	Data       []T `json:"data"`
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
-	Total      int `json:"total"`
-	TotalPages int `json:"total_pages"`
+	Data             []T    `json:"data"`
+	Page             int    `json:"page"`
+	Limit            int    `json:"limit"`
+	Total            int    `json:"total"`
+	TotalPages       int    `json:"total_pages"`
+	TotalApproximate bool   `json:"total_approximate,omitempty"` // Total estimado pelo planner
+	NextCursor       string `json:"next_cursor,omitempty"`       // Cursor da próxima página (keyset)
 }
 
 // BlockFilters representa filtros para busca de blocos
@@ -79,8 +83,11 @@ type BlockFilters struct {
 	OrderDir string `json:"order_dir,omitempty"` // Direção (asc, desc)
 
 	// Paginação
-	Page  int `json:"page,omitempty"`  // Página (padrão: 1)
-	Limit int `json:"limit,omitempty"` // Limite por página (padrão: 10, máx: 100)
+	Page   int    `json:"page,omitempty"`   // Página (padrão: 1)
+	Limit  int    `json:"limit,omitempty"`  // Limite por página (padrão: 10, máx: 100)
+	Cursor string `json:"cursor,omitempty"` // Cursor opaco da paginação por keyset (substitui page)
+
+	cursor *PageCursor
 }
 
 // Validate valida e normaliza os filtros
@@ -112,6 +119,20 @@ func (f *BlockFilters) Validate() error {
 		f.OrderDir = "desc"
 	}
 
+	// Cursor implica ordenação por número do bloco na direção em que foi gerado
+	if f.Cursor != "" {
+		cursor, err := DecodeCursor(f.Cursor)
+		if err != nil {
+			return err
+		}
+		f.cursor = cursor
+		f.OrderBy = "number"
+		f.OrderDir = "asc"
+		if cursor.Desc {
+			f.OrderDir = "desc"
+		}
+	}
+
 	// Validar intervalos
 	if f.MinSize > 0 && f.MaxSize > 0 && f.MinSize > f.MaxSize {
 		f.MinSize, f.MaxSize = f.MaxSize, f.MinSize
@@ -218,3 +239,29 @@ func (f *BlockFilters) ToSQL() (whereClause string, args []interface{}, orderCla
 
 	return whereClause, args, orderClause
 }
+
+// SupportsCursor indica se a ordenação atual permite paginação por keyset
+func (f *BlockFilters) SupportsCursor() bool {
+	return f.OrderBy == "number"
+}
+
+// usesCursor indica se a consulta está posicionada por um cursor
+func (f *BlockFilters) usesCursor() bool {
+	return f.cursor != nil
+}
+
+// withCursor acrescenta a posição do cursor às cláusulas geradas por ToSQL.
+// A contagem continua usando as cláusulas originais para refletir o total do filtro
+func (f *BlockFilters) withCursor(whereClause string, args []interface{}) (string, []interface{}) {
+	if f.cursor == nil {
+		return whereClause, args
+	}
+
+	condition := f.cursor.keysetCondition([]string{"number"}, len(args))
+	if whereClause == "" {
+		whereClause = "WHERE " + condition
+	} else {
+		whereClause += " AND " + condition
+	}
+	return whereClause, append(append([]interface{}{}, args...), f.cursor.BlockNumber)
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+var (
+	// ErrUserOperationNotFound indica que a UserOperation não foi indexada
+	ErrUserOperationNotFound = errors.New("UserOperation não encontrada")
+	// ErrInvalidUserOperationQuery indica um hash ou filtro inválido
+	ErrInvalidUserOperationQuery = errors.New("consulta de UserOperations inválida")
+)
+
+// UserOperationService consulta as UserOperations ERC-4337 indexadas pelo worker
+type UserOperationService struct {
+	userOpRepo repositories.UserOperationRepository
+}
+
+// NewUserOperationService cria uma nova instância do serviço de UserOperations
+func NewUserOperationService(userOpRepo repositories.UserOperationRepository) *UserOperationService {
+	return &UserOperationService{
+		userOpRepo: userOpRepo,
+	}
+}
+
+// GetUserOperation busca uma UserOperation pelo hash
+func (s *UserOperationService) GetUserOperation(ctx context.Context, hash string) (*entities.UserOperation, error) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if !entities.UserOperationHashPattern.MatchString(hash) {
+		return nil, fmt.Errorf("%w: hash deve ter 64 caracteres hexadecimais", ErrInvalidUserOperationQuery)
+	}
+
+	op, err := s.userOpRepo.FindByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if op == nil {
+		return nil, ErrUserOperationNotFound
+	}
+	return op, nil
+}
+
+// ListUserOperations lista as UserOperations pelos filtros
+func (s *UserOperationService) ListUserOperations(ctx context.Context, filters *entities.UserOperationFilters, page, limit int) (*PaginatedResult[*entities.UserOperation], error) {
+	if err := filters.Normalize(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUserOperationQuery, err)
+	}
+
+	offset := (page - 1) * limit
+	ops, total, approximate, err := s.userOpRepo.FindAll(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedResult[*entities.UserOperation]{
+		Data:             ops,
+		Page:             page,
+		Limit:            limit,
+		Total:            int(total),
+		TotalPages:       int((total + int64(limit) - 1) / int64(limit)),
+		TotalApproximate: approximate,
+	}, nil
+}
@@ -31,6 +31,9 @@ type Account struct {
 	ComplianceStatus string  `json:"compliance_status" db:"compliance_status"`
 	ComplianceNotes  *string `json:"compliance_notes,omitempty" db:"compliance_notes"`
 
+	// Última avaliação da política de risco (apenas no detalhe da account)
+	RiskEvaluation *AccountRiskEvaluation `json:"risk_evaluation,omitempty" db:"-"`
+
 	// Metadata
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
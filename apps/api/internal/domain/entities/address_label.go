@@ -0,0 +1,243 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AddressLabelCategory representa a categoria de um label de endereço
+type AddressLabelCategory string
+
+const (
+	AddressLabelBridge         AddressLabelCategory = "bridge"
+	AddressLabelTreasury       AddressLabelCategory = "treasury"
+	AddressLabelMultisig       AddressLabelCategory = "multisig"
+	AddressLabelValidator      AddressLabelCategory = "validator"
+	AddressLabelDeployer       AddressLabelCategory = "deployer"
+	AddressLabelExchange       AddressLabelCategory = "exchange"
+	AddressLabelToken          AddressLabelCategory = "token"
+	AddressLabelContract       AddressLabelCategory = "contract"
+	AddressLabelInfrastructure AddressLabelCategory = "infrastructure"
+	AddressLabelOther          AddressLabelCategory = "other"
+)
+
+// IsValid verifica se a categoria é conhecida
+func (c AddressLabelCategory) IsValid() bool {
+	switch c {
+	case AddressLabelBridge, AddressLabelTreasury, AddressLabelMultisig, AddressLabelValidator, AddressLabelDeployer,
+		AddressLabelExchange, AddressLabelToken, AddressLabelContract, AddressLabelInfrastructure, AddressLabelOther:
+		return true
+	}
+	return false
+}
+
+// AddressLabelStatus representa o estado de moderação de um label
+type AddressLabelStatus string
+
+const (
+	AddressLabelPending    AddressLabelStatus = "pending"    // Sugestão aguardando moderação
+	AddressLabelApproved   AddressLabelStatus = "approved"   // Label exibido nas respostas da API
+	AddressLabelRejected   AddressLabelStatus = "rejected"   // Sugestão recusada
+	AddressLabelSuperseded AddressLabelStatus = "superseded" // Substituído por outro label aprovado
+)
+
+// IsValid verifica se o estado é conhecido
+func (s AddressLabelStatus) IsValid() bool {
+	switch s {
+	case AddressLabelPending, AddressLabelApproved, AddressLabelRejected, AddressLabelSuperseded:
+		return true
+	}
+	return false
+}
+
+// AddressLabel é o nome público de um endereço conhecido. Cada endereço tem no máximo um label aprovado
+type AddressLabel struct {
+	ID          int64                `json:"id" db:"id"`
+	Address     string               `json:"address" db:"address"`
+	Name        string               `json:"name" db:"name"`
+	Category    AddressLabelCategory `json:"category" db:"category"`
+	Description *string              `json:"description,omitempty" db:"description"`
+	Source      *string              `json:"source,omitempty" db:"source"`
+	URL         *string              `json:"url,omitempty" db:"url"`
+	Verified    bool                 `json:"verified" db:"verified"`
+	Status      AddressLabelStatus   `json:"status" db:"status"`
+	SubmittedBy string               `json:"submitted_by" db:"submitted_by"`
+	ReviewedBy  *string              `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt  *time.Time           `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewNote  *string              `json:"review_note,omitempty" db:"review_note"`
+	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// AddressLabelSummary é o label embutido nas respostas que retornam endereços
+type AddressLabelSummary struct {
+	Name     string               `json:"name"`
+	Category AddressLabelCategory `json:"category"`
+	Verified bool                 `json:"verified"`
+	URL      *string              `json:"url,omitempty"`
+}
+
+// AddressLabelFilters representa os filtros da listagem de labels
+type AddressLabelFilters struct {
+	Address  string
+	Category string
+	Status   string
+	Query    string // Busca no nome
+	Verified *bool
+}
+
+// AddressLabelRequest representa a criação de um label por um admin ou a sugestão de um usuário
+type AddressLabelRequest struct {
+	Address     string  `json:"address" binding:"required"`
+	Name        string  `json:"name" binding:"required"`
+	Category    string  `json:"category" binding:"required"`
+	Description *string `json:"description,omitempty"`
+	Source      *string `json:"source,omitempty"`
+	URL         *string `json:"url,omitempty"`
+	Verified    bool    `json:"verified,omitempty"` // Ignorado em sugestões
+}
+
+// UpdateAddressLabelRequest representa a edição de um label (campos ausentes não são alterados)
+type UpdateAddressLabelRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Category    *string `json:"category,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Source      *string `json:"source,omitempty"`
+	URL         *string `json:"url,omitempty"`
+	Verified    *bool   `json:"verified,omitempty"`
+}
+
+// ReviewAddressLabelRequest representa a decisão de moderação de uma sugestão
+type ReviewAddressLabelRequest struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// AddressLabelImportResult resume uma importação de labels
+type AddressLabelImportResult struct {
+	Imported int `json:"imported"` // Labels gravados como aprovados
+	Replaced int `json:"replaced"` // Labels aprovados anteriores substituídos
+}
+
+// addressLabelAddressPattern valida endereços normalizados dos labels
+var addressLabelAddressPattern = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+
+// maxAddressLabelName é o tamanho máximo do nome exibido
+const maxAddressLabelName = 100
+
+// NormalizeAddressLabel normaliza endereço, nome e campos opcionais e valida o label
+func NormalizeAddressLabel(label *AddressLabel) error {
+	label.Address = strings.ToLower(strings.TrimSpace(label.Address))
+	if !addressLabelAddressPattern.MatchString(label.Address) {
+		return fmt.Errorf("endereço inválido %q", label.Address)
+	}
+
+	label.Name = strings.TrimSpace(label.Name)
+	if label.Name == "" {
+		return fmt.Errorf("name é obrigatório")
+	}
+	if len([]rune(label.Name)) > maxAddressLabelName {
+		return fmt.Errorf("name com mais de %d caracteres", maxAddressLabelName)
+	}
+
+	label.Category = AddressLabelCategory(strings.ToLower(strings.TrimSpace(string(label.Category))))
+	if !label.Category.IsValid() {
+		return fmt.Errorf("categoria inválida %q (use bridge, treasury, multisig, validator, deployer, exchange, token, contract, infrastructure ou other)", label.Category)
+	}
+
+	label.Description = trimOptional(label.Description)
+	label.Source = trimOptional(label.Source)
+	label.URL = trimOptional(label.URL)
+	if label.URL != nil {
+		parsed, err := url.Parse(*label.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("url inválida %q", *label.URL)
+		}
+	}
+
+	return nil
+}
+
+// trimOptional remove espaços e converte texto vazio em nil
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// ParseAddressLabelCSV lê as linhas address,name,category[,description,source,url,verified]; a primeira linha
+// é ignorada se não for um endereço. Endereços repetidos ficam com a última linha
+func ParseAddressLabelCSV(content []byte) ([]AddressLabel, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	seen := make(map[string]int)
+	var labels []AddressLabel
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV inválido: %w", err)
+		}
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if line == 1 && !strings.HasPrefix(strings.ToLower(strings.TrimSpace(record[0])), "0x") {
+			continue
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("linha %d: esperado address,name,category", line)
+		}
+
+		field := func(i int) *string {
+			if i < len(record) {
+				return &record[i]
+			}
+			return nil
+		}
+		label := AddressLabel{
+			Address:     record[0],
+			Name:        record[1],
+			Category:    AddressLabelCategory(record[2]),
+			Description: field(3),
+			Source:      field(4),
+			URL:         field(5),
+		}
+		if verified := field(6); verified != nil && strings.TrimSpace(*verified) != "" {
+			label.Verified, err = strconv.ParseBool(strings.TrimSpace(*verified))
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: verified inválido %q", line, *verified)
+			}
+		}
+		if err := NormalizeAddressLabel(&label); err != nil {
+			return nil, fmt.Errorf("linha %d: %w", line, err)
+		}
+
+		if idx, ok := seen[label.Address]; ok {
+			labels[idx] = label
+			continue
+		}
+		seen[label.Address] = len(labels)
+		labels = append(labels, label)
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("arquivo sem labels")
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Tipos de ocorrência suportados pelas regras de alerta
+const (
+	AlertSubjectTransaction      = "transaction"
+	AlertSubjectEvent            = "event"
+	AlertSubjectTokenTransfer    = "token_transfer"
+	AlertSubjectComplianceChange = "compliance"
+)
+
+// AlertRule representa uma regra de alerta com entrega via webhook
+type AlertRule struct {
+	ID              int64           `json:"id" db:"id"`
+	Name            string          `json:"name" db:"name"`
+	Description     *string         `json:"description,omitempty" db:"description"`
+	SubjectType     string          `json:"subject_type" db:"subject_type"`
+	Conditions      AlertConditions `json:"conditions" db:"conditions"`
+	WebhookURL      string          `json:"webhook_url" db:"webhook_url"`
+	WebhookSecret   string          `json:"-" db:"webhook_secret"` // Exposto apenas na criação
+	IsActive        bool            `json:"is_active" db:"is_active"`
+	CreatedBy       *string         `json:"created_by,omitempty" db:"created_by"`
+	LastTriggeredAt *time.Time      `json:"last_triggered_at,omitempty" db:"last_triggered_at"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// AlertConditions define os filtros avaliados pelo worker para disparar a regra
+type AlertConditions struct {
+	Addresses          []string `json:"addresses,omitempty"`
+	Direction          string   `json:"direction,omitempty"` // in, out ou any
+	ContractAddresses  []string `json:"contract_addresses,omitempty"`
+	EventNames         []string `json:"event_names,omitempty"`
+	EventSignatures    []string `json:"event_signatures,omitempty"`
+	MinValue           string   `json:"min_value,omitempty"` // Valor mínimo em wei
+	Status             string   `json:"status,omitempty"`
+	ComplianceStatuses []string `json:"compliance_statuses,omitempty"`
+}
+
+// Value implementa driver.Valuer para serializar para o banco
+func (c AlertConditions) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan implementa sql.Scanner para deserializar do banco
+func (c *AlertConditions) Scan(value interface{}) error {
+	if value == nil {
+		*c = AlertConditions{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("tipo inválido para AlertConditions: %T", value)
+	}
+}
+
+// AlertDelivery representa uma entrega de webhook registrada no log
+type AlertDelivery struct {
+	ID             int64           `json:"id" db:"id"`
+	RuleID         int64           `json:"rule_id" db:"rule_id"`
+	SubjectType    string          `json:"subject_type" db:"subject_type"`
+	SubjectKey     string          `json:"subject_key" db:"subject_key"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"` // pending, delivered, failed
+	Attempts       int             `json:"attempts" db:"attempts"`
+	ResponseStatus *int            `json:"response_status,omitempty" db:"response_status"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CreateAlertRuleRequest representa a requisição de criação de uma regra de alerta
+type CreateAlertRuleRequest struct {
+	Name          string          `json:"name" binding:"required"`
+	Description   *string         `json:"description,omitempty"`
+	SubjectType   string          `json:"subject_type" binding:"required"`
+	Conditions    AlertConditions `json:"conditions"`
+	WebhookURL    string          `json:"webhook_url" binding:"required"`
+	WebhookSecret string          `json:"webhook_secret,omitempty"` // Gerado automaticamente se vazio
+	IsActive      *bool           `json:"is_active,omitempty"`
+}
+
+// UpdateAlertRuleRequest representa a requisição de atualização de uma regra de alerta
+type UpdateAlertRuleRequest struct {
+	Name          *string          `json:"name,omitempty"`
+	Description   *string          `json:"description,omitempty"`
+	SubjectType   *string          `json:"subject_type,omitempty"`
+	Conditions    *AlertConditions `json:"conditions,omitempty"`
+	WebhookURL    *string          `json:"webhook_url,omitempty"`
+	WebhookSecret *string          `json:"webhook_secret,omitempty"`
+	IsActive      *bool            `json:"is_active,omitempty"`
+}
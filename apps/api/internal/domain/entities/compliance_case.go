@@ -0,0 +1,239 @@
+package entities
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+)
+
+// ComplianceCaseState representa o estado de um caso de compliance
+type ComplianceCaseState string
+
+const (
+	ComplianceCaseOpen          ComplianceCaseState = "open"
+	ComplianceCaseInvestigating ComplianceCaseState = "investigating"
+	ComplianceCaseEscalated     ComplianceCaseState = "escalated"
+	ComplianceCaseClosed        ComplianceCaseState = "closed"
+)
+
+// complianceCaseTransitions define as transições permitidas: open → investigating → escalated → closed,
+// com fechamento antecipado, retorno de escalated para investigação e reabertura de casos fechados
+var complianceCaseTransitions = map[ComplianceCaseState][]ComplianceCaseState{
+	ComplianceCaseOpen:          {ComplianceCaseInvestigating, ComplianceCaseClosed},
+	ComplianceCaseInvestigating: {ComplianceCaseEscalated, ComplianceCaseClosed},
+	ComplianceCaseEscalated:     {ComplianceCaseInvestigating, ComplianceCaseClosed},
+	ComplianceCaseClosed:        {ComplianceCaseOpen},
+}
+
+// CanTransition verifica se o caso pode passar do estado atual para o estado informado
+func (s ComplianceCaseState) CanTransition(to ComplianceCaseState) bool {
+	for _, allowed := range complianceCaseTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid verifica se o estado existe
+func (s ComplianceCaseState) IsValid() bool {
+	_, ok := complianceCaseTransitions[s]
+	return ok
+}
+
+// AccountStatus retorna o status de compliance e a origem aplicados às accounts investigadas (role subject)
+// quando o caso entra no estado. ok é false quando o estado não altera as accounts (abertura e reabertura)
+func (s ComplianceCaseState) AccountStatus(resolution ComplianceCaseResolution) (status, source string, ok bool) {
+	switch s {
+	case ComplianceCaseInvestigating:
+		return "under_review", "case", true
+	case ComplianceCaseEscalated:
+		return "flagged", "case", true
+	case ComplianceCaseClosed:
+		// Suspeita descartada devolve a account à política de risco; confirmada fica como decisão manual
+		if resolution == ComplianceCaseConfirmed {
+			return "flagged", "manual", true
+		}
+		return "compliant", "policy", true
+	default:
+		return "", "", false
+	}
+}
+
+// ComplianceCaseResolution representa a conclusão de um caso fechado
+type ComplianceCaseResolution string
+
+const (
+	ComplianceCaseCleared   ComplianceCaseResolution = "cleared"   // Suspeita descartada: accounts voltam a compliant
+	ComplianceCaseConfirmed ComplianceCaseResolution = "confirmed" // Suspeita confirmada: accounts ficam flagged
+)
+
+// Prioridades dos casos
+const (
+	ComplianceCasePriorityLow      = "low"
+	ComplianceCasePriorityMedium   = "medium"
+	ComplianceCasePriorityHigh     = "high"
+	ComplianceCasePriorityCritical = "critical"
+)
+
+// Papéis dos endereços vinculados a um caso
+const (
+	ComplianceCaseRoleSubject      = "subject"      // Status de compliance conduzido pelo caso
+	ComplianceCaseRoleCounterparty = "counterparty" // Apenas contexto da investigação
+)
+
+// Ações registradas no histórico dos casos
+const (
+	ComplianceCaseActionOpened         = "opened"
+	ComplianceCaseActionStateChanged   = "state_changed"
+	ComplianceCaseActionAssigned       = "assigned"
+	ComplianceCaseActionAddressAdded   = "address_added"
+	ComplianceCaseActionTxAdded        = "transaction_added"
+	ComplianceCaseActionNoteAdded      = "note_added"
+	ComplianceCaseActionAccountStatus  = "account_status"
+	ComplianceCaseActionSignal         = "signal"          // Registrado pelo worker
+	ComplianceCaseActionStatusOverride = "status_override" // Registrado pelo worker
+)
+
+// Origens dos casos
+const (
+	ComplianceCaseOriginManual     = "manual"
+	ComplianceCaseOriginRiskPolicy = "risk_policy"
+	ComplianceCaseOriginScreening  = "screening"
+)
+
+// ComplianceCase representa um caso de investigação de compliance
+type ComplianceCase struct {
+	ID           int64                     `json:"id" db:"id"`
+	Title        string                    `json:"title" db:"title"`
+	Description  *string                   `json:"description,omitempty" db:"description"`
+	State        ComplianceCaseState       `json:"state" db:"state"`
+	Priority     string                    `json:"priority" db:"priority"`
+	Origin       string                    `json:"origin" db:"origin"`
+	OriginRef    *string                   `json:"origin_ref,omitempty" db:"origin_ref"`
+	Resolution   *ComplianceCaseResolution `json:"resolution,omitempty" db:"resolution"`
+	AssignedTo   *int                      `json:"assigned_to,omitempty" db:"assigned_to"`
+	AssigneeName *string                   `json:"assignee_name,omitempty" db:"assignee_name"`
+	OpenedBy     string                    `json:"opened_by" db:"opened_by"`
+	OpenedAt     time.Time                 `json:"opened_at" db:"opened_at"`
+	UpdatedAt    time.Time                 `json:"updated_at" db:"updated_at"`
+	ClosedAt     *time.Time                `json:"closed_at,omitempty" db:"closed_at"`
+	AddressCount int                       `json:"address_count" db:"address_count"`
+
+	// Preenchidos apenas no detalhe do caso
+	Addresses    []ComplianceCaseAddress     `json:"addresses,omitempty"`
+	Transactions []ComplianceCaseTransaction `json:"transactions,omitempty"`
+	Notes        []ComplianceCaseNote        `json:"notes,omitempty"`
+}
+
+// ComplianceCaseAddress é um endereço vinculado a um caso, com o status atual da account
+type ComplianceCaseAddress struct {
+	Address          string    `json:"address" db:"address"`
+	Role             string    `json:"role" db:"role"`
+	AddedBy          string    `json:"added_by" db:"added_by"`
+	AddedAt          time.Time `json:"added_at" db:"added_at"`
+	ComplianceStatus *string   `json:"compliance_status,omitempty" db:"compliance_status"`
+	ComplianceSource *string   `json:"compliance_source,omitempty" db:"compliance_source"`
+}
+
+// ComplianceCaseTransaction é uma transação vinculada como evidência
+type ComplianceCaseTransaction struct {
+	TxHash  string    `json:"tx_hash" db:"tx_hash"`
+	Note    *string   `json:"note,omitempty" db:"note"`
+	AddedBy string    `json:"added_by" db:"added_by"`
+	AddedAt time.Time `json:"added_at" db:"added_at"`
+}
+
+// ComplianceCaseNote é uma nota de investigação
+type ComplianceCaseNote struct {
+	ID        int64     `json:"id" db:"id"`
+	Author    string    `json:"author" db:"author"`
+	Body      string    `json:"body" db:"body"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ComplianceCaseEvent é uma entrada do histórico de um caso
+type ComplianceCaseEvent struct {
+	ID        int64           `json:"id" db:"id"`
+	Actor     string          `json:"actor" db:"actor"` // ID do usuário ou "worker"
+	Action    string          `json:"action" db:"action"`
+	FromValue *string         `json:"from_value,omitempty" db:"from_value"`
+	ToValue   *string         `json:"to_value,omitempty" db:"to_value"`
+	Details   json.RawMessage `json:"details" db:"details"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// ComplianceCaseFilters representa os filtros da listagem de casos
+type ComplianceCaseFilters struct {
+	State      string
+	Priority   string
+	Origin     string
+	AssignedTo *int
+	Address    string
+}
+
+// complianceCaseAddressPattern valida endereços normalizados vinculados aos casos
+var complianceCaseAddressPattern = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+
+// complianceCaseTxHashPattern valida hashes normalizados de transações vinculadas aos casos
+var complianceCaseTxHashPattern = regexp.MustCompile(`^0x[0-9a-f]{64}$`)
+
+// IsValidCaseAddress verifica se o endereço normalizado pode ser vinculado a um caso
+func IsValidCaseAddress(address string) bool {
+	return complianceCaseAddressPattern.MatchString(address)
+}
+
+// IsValidCaseTxHash verifica se o hash normalizado pode ser vinculado a um caso
+func IsValidCaseTxHash(hash string) bool {
+	return complianceCaseTxHashPattern.MatchString(hash)
+}
+
+// IsValidCasePriority verifica se a prioridade existe
+func IsValidCasePriority(priority string) bool {
+	switch priority {
+	case ComplianceCasePriorityLow, ComplianceCasePriorityMedium, ComplianceCasePriorityHigh, ComplianceCasePriorityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateComplianceCaseRequest representa a requisição de abertura manual de um caso
+type CreateComplianceCaseRequest struct {
+	Title        string                   `json:"title" binding:"required"`
+	Description  *string                  `json:"description,omitempty"`
+	Priority     string                   `json:"priority,omitempty"` // Padrão: medium
+	AssignedTo   *int                     `json:"assigned_to,omitempty"`
+	Addresses    []CaseAddressRequest     `json:"addresses" binding:"required"`
+	Transactions []CaseTransactionRequest `json:"transactions,omitempty"`
+	Note         *string                  `json:"note,omitempty"`
+}
+
+// CaseAddressRequest representa um endereço vinculado a um caso
+type CaseAddressRequest struct {
+	Address string `json:"address" binding:"required"`
+	Role    string `json:"role,omitempty"` // Padrão: subject
+}
+
+// CaseTransactionRequest representa uma transação vinculada a um caso
+type CaseTransactionRequest struct {
+	TxHash string  `json:"tx_hash" binding:"required"`
+	Note   *string `json:"note,omitempty"`
+}
+
+// TransitionComplianceCaseRequest representa a mudança de estado de um caso
+type TransitionComplianceCaseRequest struct {
+	State      ComplianceCaseState       `json:"state" binding:"required"`
+	Resolution *ComplianceCaseResolution `json:"resolution,omitempty"` // Obrigatória ao fechar
+	Reason     *string                   `json:"reason,omitempty"`
+}
+
+// AssignComplianceCaseRequest representa a atribuição de um caso (assigned_to nulo remove a atribuição)
+type AssignComplianceCaseRequest struct {
+	AssignedTo *int `json:"assigned_to"`
+}
+
+// AddComplianceCaseNoteRequest representa uma nota de investigação
+type AddComplianceCaseNoteRequest struct {
+	Body string `json:"body" binding:"required"`
+}
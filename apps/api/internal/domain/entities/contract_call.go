@@ -0,0 +1,58 @@
+package entities
+
+import "encoding/json"
+
+// ContractReadRequest é a chamada de uma função view/pure de um contrato. Function aceita o nome
+// (balanceOf), a assinatura (balanceOf(address)) ou o seletor (0x70a08231); Args segue a ordem dos inputs
+type ContractReadRequest struct {
+	Function string            `json:"function" binding:"required"`
+	Args     []json.RawMessage `json:"args"`
+	Block    string            `json:"block,omitempty"` // latest (padrão), safe, finalized, earliest, pending ou número
+	From     string            `json:"from,omitempty"`  // msg.sender da chamada
+}
+
+// ContractValue é um valor decodificado segundo o ABI. Inteiros são strings decimais, bytes são hex e
+// tuplas são objetos com os nomes dos componentes
+type ContractValue struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// RevertReason é o motivo decodificado de uma execução revertida
+type RevertReason struct {
+	Kind      string          `json:"kind"`                // error (Error(string)), panic (Panic(uint256)), custom (erro do ABI) ou unknown; nas transações gravadas também out_of_gas e unavailable
+	Message   string          `json:"message"`             // Mensagem legível
+	Signature string          `json:"signature,omitempty"` // Assinatura do erro customizado
+	Code      *uint64         `json:"code,omitempty"`      // Código do Panic
+	Args      []ContractValue `json:"args,omitempty"`      // Argumentos do erro customizado
+	Data      string          `json:"data"`                // Dados do revert em hex
+	Source    string          `json:"source,omitempty"`    // Nas transações gravadas: receipt ou replay (reexecução no bloco pai)
+}
+
+// ContractReadResult é o resultado da chamada de uma função view/pure
+type ContractReadResult struct {
+	Address     string          `json:"address"`
+	Function    string          `json:"function"` // Assinatura da função chamada
+	Selector    string          `json:"selector"`
+	BlockNumber *uint64         `json:"block_number,omitempty"` // Nulo quando executada no bloco pending
+	Outputs     []ContractValue `json:"outputs"`
+	Raw         string          `json:"raw"` // Retorno do eth_call em hex
+}
+
+// ContractViewResult é o resultado de uma função view/pure sem argumentos na leitura em lote
+type ContractViewResult struct {
+	Function string          `json:"function"`
+	Selector string          `json:"selector"`
+	Outputs  []ContractValue `json:"outputs,omitempty"`
+	Revert   *RevertReason   `json:"revert,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// ContractViewsResult reúne os valores das funções view/pure sem argumentos de um contrato em um bloco
+type ContractViewsResult struct {
+	Address     string                `json:"address"`
+	BlockNumber uint64                `json:"block_number"`
+	Functions   []*ContractViewResult `json:"functions"`
+	Cached      bool                  `json:"cached"`
+}
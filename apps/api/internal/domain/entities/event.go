@@ -39,6 +39,7 @@ type EventSummary struct {
 	Method          string                 `json:"method"`
 	TransactionHash string                 `json:"transaction_hash"`
 	BlockNumber     uint64                 `json:"block_number"`
+	LogIndex        uint64                 `json:"log_index"`
 	Timestamp       time.Time              `json:"timestamp"`
 	FromAddress     string                 `json:"from_address"`
 	ToAddress       *string                `json:"to_address,omitempty"`
@@ -85,4 +86,5 @@ type EventFilters struct {
 	OrderDir        string  `json:"order_dir"`
 	Page            int     `json:"page"`
 	Limit           int     `json:"limit"`
+	Cursor          *string `json:"cursor,omitempty"`
 }
@@ -0,0 +1,267 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RiskPolicyFormat representa o formato em que a política de risco foi enviada
+type RiskPolicyFormat string
+
+const (
+	RiskPolicyFormatYAML RiskPolicyFormat = "yaml"
+	RiskPolicyFormatJSON RiskPolicyFormat = "json"
+)
+
+// RiskMetricScope agrupa as métricas pela origem dos dados
+type RiskMetricScope string
+
+const (
+	RiskScopeAccount        RiskMetricScope = "account"        // Colunas de accounts
+	RiskScopeTags           RiskMetricScope = "tags"           // Tags da account
+	RiskScopeActivity       RiskMetricScope = "activity"       // Agregados de account_transactions na janela
+	RiskScopeCounterparties RiskMetricScope = "counterparties" // Contrapartes distintas na janela
+)
+
+// riskMetricSpec descreve uma métrica disponível para as condições das regras
+type riskMetricSpec struct {
+	Scope             RiskMetricScope
+	RequiresWindow    bool
+	RequiresTag       bool
+	RequiresThreshold bool
+}
+
+// RiskMetrics contém as métricas aceitas nas condições das políticas de risco.
+// Valores monetários são em ETH e janelas aceitam sufixos s, m, h e d (ex.: 30m, 24h, 7d)
+var RiskMetrics = map[string]riskMetricSpec{
+	"account.age_hours":                  {Scope: RiskScopeAccount},
+	"account.transaction_count":          {Scope: RiskScopeAccount},
+	"account.contract_interactions":      {Scope: RiskScopeAccount},
+	"account.contract_interaction_ratio": {Scope: RiskScopeAccount},
+	"account.contract_deployments":       {Scope: RiskScopeAccount},
+	"account.balance":                    {Scope: RiskScopeAccount},
+	"account.is_contract":                {Scope: RiskScopeAccount},
+
+	"tags.has": {Scope: RiskScopeTags, RequiresTag: true},
+
+	"velocity.tx_count":       {Scope: RiskScopeActivity, RequiresWindow: true},
+	"velocity.sent_count":     {Scope: RiskScopeActivity, RequiresWindow: true},
+	"velocity.received_count": {Scope: RiskScopeActivity, RequiresWindow: true},
+	"velocity.failed_count":   {Scope: RiskScopeActivity, RequiresWindow: true},
+	"velocity.value_sent":     {Scope: RiskScopeActivity, RequiresWindow: true},
+	"velocity.value_received": {Scope: RiskScopeActivity, RequiresWindow: true},
+
+	"value.max_tx":   {Scope: RiskScopeActivity},
+	"value.tx_above": {Scope: RiskScopeActivity, RequiresThreshold: true},
+
+	"counterparties.distinct": {Scope: RiskScopeCounterparties},
+	"counterparties.tagged":   {Scope: RiskScopeCounterparties, RequiresTag: true},
+	"counterparties.flagged":  {Scope: RiskScopeCounterparties},
+}
+
+// riskOperators contém os operadores de comparação aceitos nas condições (avaliados pelo worker)
+var riskOperators = map[string]bool{
+	"gt": true, "gte": true, "lt": true, "lte": true, "eq": true, "ne": true,
+}
+
+// RiskPolicy é a política de risco versionada: regras ponderadas somadas em um score de 0 a MaxScore,
+// convertido em status de compliance pelos limites
+type RiskPolicy struct {
+	Name        string         `json:"name" yaml:"name"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	MaxScore    int            `json:"max_score,omitempty" yaml:"max_score,omitempty"` // Padrão e máximo: 10 (accounts.risk_score)
+	Thresholds  RiskThresholds `json:"thresholds" yaml:"thresholds"`
+	Rules       []RiskRule     `json:"rules" yaml:"rules"`
+}
+
+// RiskThresholds define a partir de qual score a account fica em revisão ou sinalizada
+type RiskThresholds struct {
+	UnderReview float64 `json:"under_review" yaml:"under_review"`
+	Flagged     float64 `json:"flagged" yaml:"flagged"`
+}
+
+// RiskRule soma Weight ao score quando todas as condições casam
+type RiskRule struct {
+	ID          string          `json:"id" yaml:"id"`
+	Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Weight      float64         `json:"weight" yaml:"weight"`
+	Conditions  []RiskCondition `json:"when" yaml:"when"`
+}
+
+// RiskCondition compara uma métrica da account com um valor
+type RiskCondition struct {
+	Metric    string  `json:"metric" yaml:"metric"`
+	Op        string  `json:"op" yaml:"op"`
+	Value     float64 `json:"value" yaml:"value"`
+	Window    string  `json:"window,omitempty" yaml:"window,omitempty"`       // Janela das métricas de atividade e contrapartes (vazio: todo o histórico)
+	Tag       string  `json:"tag,omitempty" yaml:"tag,omitempty"`             // Tag de tags.has e counterparties.tagged
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold,omitempty"` // Valor mínimo em ETH de value.tx_above
+}
+
+// ParseRiskPolicy lê e valida uma política em YAML ou JSON. Campos desconhecidos são rejeitados
+func ParseRiskPolicy(content []byte, format RiskPolicyFormat) (*RiskPolicy, error) {
+	policy := &RiskPolicy{}
+
+	switch format {
+	case RiskPolicyFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(policy); err != nil {
+			return nil, fmt.Errorf("política JSON inválida: %w", err)
+		}
+	case RiskPolicyFormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(policy); err != nil {
+			return nil, fmt.Errorf("política YAML inválida: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("formato de política desconhecido: %s", format)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate verifica limites, regras e condições da política e aplica o MaxScore padrão
+func (p *RiskPolicy) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("política sem name")
+	}
+	if p.MaxScore == 0 {
+		p.MaxScore = 10
+	}
+	if p.MaxScore < 1 || p.MaxScore > 10 {
+		return fmt.Errorf("max_score deve estar entre 1 e 10")
+	}
+	if p.Thresholds.UnderReview <= 0 || p.Thresholds.Flagged < p.Thresholds.UnderReview || p.Thresholds.Flagged > float64(p.MaxScore) {
+		return fmt.Errorf("thresholds devem satisfazer 0 < under_review <= flagged <= max_score")
+	}
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("política sem regras")
+	}
+
+	ids := make(map[string]bool)
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.ID == "" {
+			return fmt.Errorf("regra %d sem id", i+1)
+		}
+		if ids[rule.ID] {
+			return fmt.Errorf("id de regra duplicado: %s", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		if math.IsNaN(rule.Weight) || math.IsInf(rule.Weight, 0) || rule.Weight == 0 {
+			return fmt.Errorf("regra %s: weight deve ser um número diferente de zero", rule.ID)
+		}
+		if len(rule.Conditions) == 0 {
+			return fmt.Errorf("regra %s: when precisa de ao menos uma condição", rule.ID)
+		}
+		for j := range rule.Conditions {
+			if err := rule.Conditions[j].validate(); err != nil {
+				return fmt.Errorf("regra %s, condição %d: %w", rule.ID, j+1, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate verifica a métrica, o operador e os parâmetros exigidos pela métrica
+func (c *RiskCondition) validate() error {
+	spec, ok := RiskMetrics[c.Metric]
+	if !ok {
+		return fmt.Errorf("métrica desconhecida: %s", c.Metric)
+	}
+	if !riskOperators[c.Op] {
+		return fmt.Errorf("operador desconhecido: %s (use gt, gte, lt, lte, eq ou ne)", c.Op)
+	}
+
+	if c.Window != "" {
+		if spec.Scope != RiskScopeActivity && spec.Scope != RiskScopeCounterparties {
+			return fmt.Errorf("%s não aceita window", c.Metric)
+		}
+		if _, err := ParseRiskWindow(c.Window); err != nil {
+			return err
+		}
+	} else if spec.RequiresWindow {
+		return fmt.Errorf("%s exige window", c.Metric)
+	}
+
+	if spec.RequiresTag && strings.TrimSpace(c.Tag) == "" {
+		return fmt.Errorf("%s exige tag", c.Metric)
+	}
+	if !spec.RequiresTag && c.Tag != "" {
+		return fmt.Errorf("%s não aceita tag", c.Metric)
+	}
+	if spec.RequiresThreshold && c.Threshold <= 0 {
+		return fmt.Errorf("%s exige threshold maior que zero", c.Metric)
+	}
+	if !spec.RequiresThreshold && c.Threshold != 0 {
+		return fmt.Errorf("%s não aceita threshold", c.Metric)
+	}
+
+	return nil
+}
+
+// ParseRiskWindow converte janelas como 30m, 24h ou 7d em duração
+func ParseRiskWindow(window string) (time.Duration, error) {
+	if window == "" {
+		return 0, nil
+	}
+
+	var duration time.Duration
+	if strings.HasSuffix(window, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("window inválida: %s", window)
+		}
+		duration = time.Duration(days) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(window)
+		if err != nil {
+			return 0, fmt.Errorf("window inválida: %s", window)
+		}
+		duration = parsed
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("window deve ser positiva: %s", window)
+	}
+	return duration, nil
+}
+
+// RiskPolicyVersion é uma versão da política gravada em risk_policies
+type RiskPolicyVersion struct {
+	Version     int64            `json:"version" db:"version"`
+	Name        string           `json:"name" db:"name"`
+	Format      RiskPolicyFormat `json:"format" db:"format"`
+	Content     string           `json:"content,omitempty" db:"content"`
+	Checksum    string           `json:"checksum" db:"checksum"`
+	IsActive    bool             `json:"is_active" db:"is_active"`
+	CreatedBy   *string          `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+	ActivatedAt *time.Time       `json:"activated_at,omitempty" db:"activated_at"`
+}
+
+// AccountRiskEvaluation é a última avaliação de risco de uma account, com a contribuição de cada regra
+type AccountRiskEvaluation struct {
+	PolicyVersion int64           `json:"policy_version" db:"policy_version"`
+	PolicyName    string          `json:"policy_name" db:"policy_name"`
+	Score         int             `json:"score" db:"score"`
+	Status        string          `json:"status" db:"status"`
+	Breakdown     json.RawMessage `json:"breakdown" db:"breakdown"`
+	Trigger       string          `json:"trigger" db:"trigger"`
+	StatusSource  string          `json:"status_source" db:"compliance_source"` // policy ou manual (status definido manualmente não é alterado pela política)
+	EvaluatedAt   time.Time       `json:"evaluated_at" db:"evaluated_at"`
+}
@@ -0,0 +1,218 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ScreeningListFormat representa o formato do arquivo da lista importada
+type ScreeningListFormat string
+
+const (
+	ScreeningListFormatCSV  ScreeningListFormat = "csv"
+	ScreeningListFormatJSON ScreeningListFormat = "json"
+)
+
+// ScreeningList é uma versão importada de uma lista de sanções ou denylist
+type ScreeningList struct {
+	ID            int64               `json:"id" db:"id"`
+	Name          string              `json:"name" db:"name"`
+	Source        string              `json:"source" db:"source"`
+	Version       string              `json:"version" db:"version"`
+	Format        ScreeningListFormat `json:"format" db:"format"`
+	Checksum      string              `json:"checksum" db:"checksum"`
+	EntryCount    int                 `json:"entry_count" db:"entry_count"`
+	IsActive      bool                `json:"is_active" db:"is_active"`
+	NeedsRescreen bool                `json:"needs_rescreen" db:"needs_rescreen"` // Worker ainda não aplicou a última mudança
+	ImportedBy    *string             `json:"imported_by,omitempty" db:"imported_by"`
+	ImportedAt    time.Time           `json:"imported_at" db:"imported_at"`
+	ScreenedAt    *time.Time          `json:"screened_at,omitempty" db:"screened_at"`
+}
+
+// ScreeningEntry é um endereço de uma lista
+type ScreeningEntry struct {
+	Address string  `json:"address" db:"address"`
+	Label   *string `json:"label,omitempty" db:"label"`
+}
+
+// ScreeningListing indica que o próprio endereço consta em uma lista ativa
+type ScreeningListing struct {
+	ListID      int64   `json:"list_id" db:"list_id"`
+	ListName    string  `json:"list_name" db:"list_name"`
+	ListVersion string  `json:"list_version" db:"list_version"`
+	Source      string  `json:"source" db:"source"`
+	Label       *string `json:"label,omitempty" db:"label"`
+}
+
+// AccountExposure é a exposição de uma account a um endereço listado
+type AccountExposure struct {
+	ListID        int64     `json:"list_id" db:"list_id"`
+	ListName      string    `json:"list_name" db:"list_name"`
+	ListVersion   string    `json:"list_version" db:"list_version"`
+	ListedAddress string    `json:"listed_address" db:"listed_address"`
+	ListedLabel   *string   `json:"listed_label,omitempty" db:"listed_label"`
+	Hops          int       `json:"hops" db:"hops"` // 1 = contato direto
+	Weight        float64   `json:"weight" db:"weight"`
+	ViaAddress    string    `json:"via_address" db:"via_address"`
+	TxHash        string    `json:"tx_hash" db:"tx_hash"`
+	BlockNumber   int64     `json:"block_number" db:"block_number"`
+	Kind          string    `json:"kind" db:"kind"` // transaction, internal, token_transfer
+	FirstSeenAt   time.Time `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt    time.Time `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// AccountExposureReport reúne as listas em que a account consta e sua exposição nas listas ativas
+type AccountExposureReport struct {
+	Address          string             `json:"address"`
+	ComplianceStatus string             `json:"compliance_status"`
+	ComplianceSource string             `json:"compliance_source"` // policy, manual ou screening
+	ComplianceNotes  *string            `json:"compliance_notes,omitempty"`
+	MaxWeight        float64            `json:"max_weight"`
+	Listings         []ScreeningListing `json:"listings"`
+	Exposures        []AccountExposure  `json:"exposures"`
+}
+
+// screeningAddressPattern valida endereços normalizados das listas
+var screeningAddressPattern = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+
+// ScreeningListFile é o conteúdo interpretado de um arquivo de lista. Metadados ausentes no arquivo
+// vêm dos parâmetros da importação
+type ScreeningListFile struct {
+	Name    string
+	Source  string
+	Version string
+	Entries []ScreeningEntry
+}
+
+// ParseScreeningList interpreta uma lista em CSV (address[,label], cabeçalho opcional) ou JSON
+// (array de endereços, array de {address, label} ou objeto {name, source, version, entries}).
+// Endereços são normalizados em minúsculas e duplicados são descartados
+func ParseScreeningList(content []byte, format ScreeningListFormat) (*ScreeningListFile, error) {
+	var file *ScreeningListFile
+	var err error
+
+	switch format {
+	case ScreeningListFormatCSV:
+		file, err = parseScreeningCSV(content)
+	case ScreeningListFormatJSON:
+		file, err = parseScreeningJSON(content)
+	default:
+		return nil, fmt.Errorf("formato não suportado: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]int, len(file.Entries))
+	entries := make([]ScreeningEntry, 0, len(file.Entries))
+	for i, entry := range file.Entries {
+		address := strings.ToLower(strings.TrimSpace(entry.Address))
+		if !screeningAddressPattern.MatchString(address) {
+			return nil, fmt.Errorf("entrada %d: endereço inválido %q", i+1, entry.Address)
+		}
+		if entry.Label != nil {
+			label := strings.TrimSpace(*entry.Label)
+			entry.Label = nil
+			if label != "" {
+				entry.Label = &label
+			}
+		}
+		entry.Address = address
+
+		if idx, ok := seen[address]; ok {
+			if entries[idx].Label == nil {
+				entries[idx].Label = entry.Label
+			}
+			continue
+		}
+		seen[address] = len(entries)
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("lista sem endereços")
+	}
+
+	file.Entries = entries
+	return file, nil
+}
+
+// parseScreeningCSV lê as linhas address[,label]; a primeira linha é ignorada se não for um endereço
+func parseScreeningCSV(content []byte) (*ScreeningListFile, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	file := &ScreeningListFile{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV inválido: %w", err)
+		}
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if line == 1 && !strings.HasPrefix(strings.ToLower(strings.TrimSpace(record[0])), "0x") {
+			continue
+		}
+
+		entry := ScreeningEntry{Address: record[0]}
+		if len(record) > 1 {
+			label := record[1]
+			entry.Label = &label
+		}
+		file.Entries = append(file.Entries, entry)
+	}
+
+	return file, nil
+}
+
+// parseScreeningJSON aceita as três formas de lista em JSON
+func parseScreeningJSON(content []byte) (*ScreeningListFile, error) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("JSON vazio")
+	}
+
+	var raw []json.RawMessage
+	file := &ScreeningListFile{}
+	if trimmed[0] == '{' {
+		var doc struct {
+			Name    string            `json:"name"`
+			Source  string            `json:"source"`
+			Version string            `json:"version"`
+			Entries []json.RawMessage `json:"entries"`
+		}
+		if err := json.Unmarshal(trimmed, &doc); err != nil {
+			return nil, fmt.Errorf("JSON inválido: %w", err)
+		}
+		file.Name, file.Source, file.Version = doc.Name, doc.Source, doc.Version
+		raw = doc.Entries
+	} else if err := json.Unmarshal(trimmed, &raw); err != nil {
+		return nil, fmt.Errorf("JSON inválido: %w", err)
+	}
+
+	for i, item := range raw {
+		var address string
+		if err := json.Unmarshal(item, &address); err == nil {
+			file.Entries = append(file.Entries, ScreeningEntry{Address: address})
+			continue
+		}
+		var entry ScreeningEntry
+		if err := json.Unmarshal(item, &entry); err != nil {
+			return nil, fmt.Errorf("entrada %d: deve ser um endereço ou {address, label}", i+1)
+		}
+		file.Entries = append(file.Entries, entry)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,67 @@
+package entities
+
+import "encoding/json"
+
+// SimulationRequest é uma transação a ser simulada sem envio à rede. O calldata vem em Data ou é codificado
+// a partir de Function + Args com o ABI gravado do contrato de destino; sem nenhum dos dois a simulação é uma
+// transferência de valor
+type SimulationRequest struct {
+	From     string            `json:"from,omitempty"` // msg.sender (endereço zero se ausente)
+	To       string            `json:"to" binding:"required"`
+	Data     string            `json:"data,omitempty"`     // Calldata em hex
+	Function string            `json:"function,omitempty"` // Nome, assinatura ou seletor da função
+	Args     []json.RawMessage `json:"args,omitempty"`
+	Value    string            `json:"value,omitempty"` // Valor em wei (decimal ou hex)
+	Gas      string            `json:"gas,omitempty"`   // Limite de gas da simulação (opcional, até 10.000.000)
+	Block    string            `json:"block,omitempty"` // latest (padrão), safe, finalized, earliest, pending ou número
+}
+
+// SimulatedLog é um log que a transação emitiria, decodificado quando o ABI do emissor (ou de outro contrato
+// da chamada) define o evento
+type SimulatedLog struct {
+	Address   string          `json:"address"`
+	Topics    []string        `json:"topics"`
+	Data      string          `json:"data"`
+	Event     string          `json:"event,omitempty"`     // Nome do evento
+	Signature string          `json:"signature,omitempty"` // Assinatura do evento
+	Args      []ContractValue `json:"args,omitempty"`
+}
+
+// SimulatedCall é um frame da árvore de chamadas do debug_traceCall (callTracer)
+type SimulatedCall struct {
+	Type     string           `json:"type"` // CALL, STATICCALL, DELEGATECALL, CREATE...
+	From     string           `json:"from"`
+	To       string           `json:"to,omitempty"`
+	Value    string           `json:"value,omitempty"` // Valor em wei (decimal)
+	Gas      uint64           `json:"gas"`
+	GasUsed  uint64           `json:"gas_used"`
+	Input    string           `json:"input"`
+	Output   string           `json:"output,omitempty"`
+	Function string           `json:"function,omitempty"` // Assinatura da função chamada, quando o ABI do destino a define
+	Args     []ContractValue  `json:"args,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	Revert   *RevertReason    `json:"revert,omitempty"`
+	Calls    []*SimulatedCall `json:"calls,omitempty"`
+}
+
+// SimulationResult é o resultado da simulação: sucesso ou motivo do revert, retorno, gas estimado, logs e
+// árvore de chamadas (estes dois só quando o nó expõe debug_traceCall)
+type SimulationResult struct {
+	Success        bool            `json:"success"`
+	From           string          `json:"from,omitempty"`
+	To             string          `json:"to"`
+	Function       string          `json:"function,omitempty"` // Assinatura da função, quando identificada no ABI
+	Selector       string          `json:"selector,omitempty"`
+	Data           string          `json:"data"`
+	Value          string          `json:"value"`
+	BlockNumber    *uint64         `json:"block_number,omitempty"` // Nulo quando simulada no bloco pending
+	ReturnData     string          `json:"return_data,omitempty"`
+	Outputs        []ContractValue `json:"outputs,omitempty"`
+	Revert         *RevertReason   `json:"revert,omitempty"`
+	GasEstimate    *uint64         `json:"gas_estimate,omitempty"` // eth_estimateGas; ausente quando a transação reverte
+	GasUsed        *uint64         `json:"gas_used,omitempty"`     // Gas usado no trace
+	Logs           []*SimulatedLog `json:"logs"`
+	CallTrace      *SimulatedCall  `json:"call_trace,omitempty"`
+	TraceAvailable bool            `json:"trace_available"`
+	Warnings       []string        `json:"warnings,omitempty"`
+}
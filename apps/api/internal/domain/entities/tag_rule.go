@@ -0,0 +1,207 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TagMetricKind indica se a métrica é comparada como número ou como texto
+type TagMetricKind string
+
+const (
+	TagMetricNumeric TagMetricKind = "numeric" // Operadores gt, gte, lt, lte, eq, ne com value
+	TagMetricText    TagMetricKind = "text"    // Operadores in, not_in com values
+)
+
+// tagMetricSpec descreve uma métrica disponível para as condições das regras de tags
+type tagMetricSpec struct {
+	Kind           TagMetricKind
+	RequiresMethod bool
+	RequiresToken  bool
+}
+
+// TagMetrics contém as métricas aceitas nas condições das regras de tags (mesma lista do worker). Valores em
+// moeda nativa usam NATIVE_TOKEN_DECIMALS casas decimais e saldos de tokens usam as casas decimais do token
+var TagMetrics = map[string]tagMetricSpec{
+	"account.balance":               {Kind: TagMetricNumeric},
+	"account.transaction_count":     {Kind: TagMetricNumeric},
+	"account.contract_interactions": {Kind: TagMetricNumeric},
+	"account.contract_deployments":  {Kind: TagMetricNumeric},
+	"account.is_contract":           {Kind: TagMetricNumeric},
+	"account.age_days":              {Kind: TagMetricNumeric},
+	"account.inactive_days":         {Kind: TagMetricNumeric},
+	"account.type":                  {Kind: TagMetricText},
+	"account.contract_type":         {Kind: TagMetricText},
+
+	"methods.unique_contracts": {Kind: TagMetricNumeric},
+	"methods.calls":            {Kind: TagMetricNumeric, RequiresMethod: true},
+	"methods.most_used":        {Kind: TagMetricText},
+
+	"tokens.count":   {Kind: TagMetricNumeric},
+	"tokens.balance": {Kind: TagMetricNumeric, RequiresToken: true},
+
+	"activity.success_rate": {Kind: TagMetricNumeric},
+	"activity.failed_count": {Kind: TagMetricNumeric},
+	"activity.max_tx_value": {Kind: TagMetricNumeric},
+}
+
+// TagOperators contém os operadores aceitos por tipo de métrica, com o operador SQL correspondente
+var TagOperators = map[TagMetricKind]map[string]string{
+	TagMetricNumeric: {"gt": ">", "gte": ">=", "lt": "<", "lte": "<=", "eq": "=", "ne": "<>"},
+	TagMetricText:    {"in": "IN", "not_in": "NOT IN"},
+}
+
+// TagRule atribui Tag às accounts em que todas as condições casam. O worker grava as tags com
+// created_by = 'system' e as remove quando a account deixa de casar
+type TagRule struct {
+	ID          int64          `json:"id"`
+	Tag         string         `json:"tag"`
+	Description *string        `json:"description,omitempty"`
+	Conditions  []TagCondition `json:"conditions"`
+	IsActive    bool           `json:"is_active"`
+	CreatedBy   *string        `json:"created_by,omitempty"`
+	UpdatedBy   *string        `json:"updated_by,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	EvaluatedAt *time.Time     `json:"evaluated_at,omitempty"` // Última reavaliação completa pelo worker
+	TaggedCount int64          `json:"tagged_count"`           // Accounts com a tag atribuída pela regra
+}
+
+// TagCondition compara uma métrica da account com um valor numérico ou com uma lista de textos
+type TagCondition struct {
+	Metric string   `json:"metric"`
+	Op     string   `json:"op"`
+	Value  *float64 `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+	Method string   `json:"method,omitempty"` // Método de methods.calls
+	Token  string   `json:"token,omitempty"`  // Endereço do token de tokens.balance
+}
+
+// TagRuleFilters representa os filtros da listagem de regras de tags
+type TagRuleFilters struct {
+	Tag      string
+	IsActive *bool
+}
+
+// CreateTagRuleRequest representa a criação de uma regra de tag
+type CreateTagRuleRequest struct {
+	Tag         string         `json:"tag" binding:"required"`
+	Description *string        `json:"description,omitempty"`
+	Conditions  []TagCondition `json:"conditions" binding:"required"`
+	IsActive    *bool          `json:"is_active,omitempty"` // Padrão: true
+}
+
+// UpdateTagRuleRequest representa a edição de uma regra de tag (campos ausentes não são alterados)
+type UpdateTagRuleRequest struct {
+	Description *string        `json:"description,omitempty"`
+	Conditions  []TagCondition `json:"conditions,omitempty"`
+	IsActive    *bool          `json:"is_active,omitempty"`
+}
+
+// PreviewTagRuleRequest representa a simulação de uma regra sem gravá-la
+type PreviewTagRuleRequest struct {
+	Tag        string         `json:"tag,omitempty"` // Usada para calcular as accounts que ganhariam ou perderiam a tag
+	Conditions []TagCondition `json:"conditions" binding:"required"`
+}
+
+// TagRulePreview é o resultado da simulação de uma regra sobre as accounts atuais
+type TagRulePreview struct {
+	Tag         string   `json:"tag,omitempty"`
+	MatchCount  int64    `json:"match_count"`
+	WouldAdd    *int64   `json:"would_add,omitempty"`    // Accounts que casam e ainda não têm a tag de sistema
+	WouldRemove *int64   `json:"would_remove,omitempty"` // Accounts com a tag de sistema que deixariam de casar
+	Accounts    []string `json:"accounts"`               // Amostra das accounts que casam
+}
+
+// tagRuleTagPattern restringe as tags de regras a slugs minúsculos
+var tagRuleTagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._:-]{0,99}$`)
+
+// IsValidTagRuleTag verifica se a tag é um slug minúsculo de até 100 caracteres
+func IsValidTagRuleTag(tag string) bool {
+	return tagRuleTagPattern.MatchString(tag)
+}
+
+// ParseTagConditions lê e valida as condições gravadas em tag_rules.conditions
+func ParseTagConditions(content []byte) ([]TagCondition, error) {
+	var conditions []TagCondition
+	if err := json.Unmarshal(content, &conditions); err != nil {
+		return nil, fmt.Errorf("condições inválidas: %w", err)
+	}
+	return conditions, ValidateTagConditions(conditions)
+}
+
+// ValidateTagConditions verifica se a regra tem ao menos uma condição e se todas são válidas
+func ValidateTagConditions(conditions []TagCondition) error {
+	if len(conditions) == 0 {
+		return fmt.Errorf("regra sem condições")
+	}
+	for i := range conditions {
+		if err := conditions[i].Validate(); err != nil {
+			return fmt.Errorf("condição %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Validate verifica a métrica, o operador e os parâmetros exigidos pela métrica
+func (c *TagCondition) Validate() error {
+	spec, ok := TagMetrics[c.Metric]
+	if !ok {
+		return fmt.Errorf("métrica desconhecida: %s", c.Metric)
+	}
+	if _, ok := TagOperators[spec.Kind][c.Op]; !ok {
+		if spec.Kind == TagMetricText {
+			return fmt.Errorf("operador %s inválido para %s (use in ou not_in)", c.Op, c.Metric)
+		}
+		return fmt.Errorf("operador %s inválido para %s (use gt, gte, lt, lte, eq ou ne)", c.Op, c.Metric)
+	}
+
+	switch spec.Kind {
+	case TagMetricNumeric:
+		if c.Value == nil || math.IsNaN(*c.Value) || math.IsInf(*c.Value, 0) {
+			return fmt.Errorf("%s exige value numérico", c.Metric)
+		}
+		if len(c.Values) > 0 {
+			return fmt.Errorf("%s não aceita values", c.Metric)
+		}
+	case TagMetricText:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%s exige values", c.Metric)
+		}
+		if c.Value != nil {
+			return fmt.Errorf("%s não aceita value", c.Metric)
+		}
+	}
+
+	if spec.RequiresMethod && strings.TrimSpace(c.Method) == "" {
+		return fmt.Errorf("%s exige method", c.Metric)
+	}
+	if !spec.RequiresMethod && c.Method != "" {
+		return fmt.Errorf("%s não aceita method", c.Metric)
+	}
+	if spec.RequiresToken && !isHexAddress(c.Token) {
+		return fmt.Errorf("%s exige token (endereço do contrato)", c.Metric)
+	}
+	if !spec.RequiresToken && c.Token != "" {
+		return fmt.Errorf("%s não aceita token", c.Metric)
+	}
+
+	return nil
+}
+
+// isHexAddress verifica se o valor é um endereço 0x com 40 dígitos hexadecimais
+func isHexAddress(value string) bool {
+	if len(value) != 42 || !strings.HasPrefix(value, "0x") {
+		return false
+	}
+	for _, r := range value[2:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -7,28 +7,29 @@ import (
 
 // Transaction representa uma transação na blockchain para a API
 type Transaction struct {
-	Hash                 string     `json:"hash"`
-	BlockNumber          *uint64    `json:"block_number"`
-	BlockHash            *string    `json:"block_hash"`
-	TransactionIndex     *uint64    `json:"transaction_index"`
-	From                 string     `json:"from"`
-	To                   *string    `json:"to"`
-	Value                *big.Int   `json:"value"`
-	Gas                  uint64     `json:"gas"`
-	GasPrice             *big.Int   `json:"gas_price"`
-	GasUsed              *uint64    `json:"gas_used"`
-	MaxFeePerGas         *big.Int   `json:"max_fee_per_gas"`
-	MaxPriorityFeePerGas *big.Int   `json:"max_priority_fee_per_gas"`
-	Nonce                uint64     `json:"nonce"`
-	Data                 []byte     `json:"data"`
-	Status               string     `json:"status"`
-	ContractAddress      *string    `json:"contract_address"`
-	Type                 uint8      `json:"type"`
-	Method               *string    `json:"method,omitempty"`
-	MethodType           *string    `json:"method_type,omitempty"`
-	CreatedAt            time.Time  `json:"created_at"`
-	UpdatedAt            time.Time  `json:"updated_at"`
-	MinedAt              *time.Time `json:"mined_at"`
+	Hash                 string        `json:"hash"`
+	BlockNumber          *uint64       `json:"block_number"`
+	BlockHash            *string       `json:"block_hash"`
+	TransactionIndex     *uint64       `json:"transaction_index"`
+	From                 string        `json:"from"`
+	To                   *string       `json:"to"`
+	Value                *big.Int      `json:"value"`
+	Gas                  uint64        `json:"gas"`
+	GasPrice             *big.Int      `json:"gas_price"`
+	GasUsed              *uint64       `json:"gas_used"`
+	MaxFeePerGas         *big.Int      `json:"max_fee_per_gas"`
+	MaxPriorityFeePerGas *big.Int      `json:"max_priority_fee_per_gas"`
+	Nonce                uint64        `json:"nonce"`
+	Data                 []byte        `json:"data"`
+	Status               string        `json:"status"`
+	ContractAddress      *string       `json:"contract_address"`
+	Type                 uint8         `json:"type"`
+	Method               *string       `json:"method,omitempty"`
+	MethodType           *string       `json:"method_type,omitempty"`
+	RevertReason         *RevertReason `json:"revert_reason,omitempty"` // Motivo decodificado da falha (apenas no detalhe)
+	CreatedAt            time.Time     `json:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+	MinedAt              *time.Time    `json:"mined_at"`
 }
 
 // TransactionSummary representa um resumo de transação para listagens
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// UserOperation é uma UserOperation ERC-4337 executada por uma EntryPoint e indexada pelo worker.
+// Os valores de gas e taxas são inteiros em wei/unidades de gas serializados como string
+type UserOperation struct {
+	Hash                 string     `json:"user_op_hash" db:"user_op_hash"`
+	EntryPoint           string     `json:"entry_point" db:"entry_point"`
+	EntryPointVersion    *string    `json:"entry_point_version,omitempty" db:"entry_point_version"`
+	TxHash               string     `json:"tx_hash" db:"tx_hash"`
+	BlockNumber          uint64     `json:"block_number" db:"block_number"`
+	LogIndex             int        `json:"log_index" db:"log_index"`
+	MinedAt              *time.Time `json:"mined_at,omitempty" db:"mined_at"`
+	Sender               string     `json:"sender" db:"sender"`
+	Paymaster            *string    `json:"paymaster,omitempty" db:"paymaster"`
+	Bundler              string     `json:"bundler" db:"bundler"`
+	Beneficiary          *string    `json:"beneficiary,omitempty" db:"beneficiary"`
+	Factory              *string    `json:"factory,omitempty" db:"factory"`
+	Nonce                string     `json:"nonce" db:"nonce"`
+	Success              bool       `json:"success" db:"success"`
+	ActualGasCost        string     `json:"actual_gas_cost" db:"actual_gas_cost"`
+	ActualGasUsed        string     `json:"actual_gas_used" db:"actual_gas_used"`
+	CallGasLimit         *string    `json:"call_gas_limit,omitempty" db:"call_gas_limit"`
+	VerificationGasLimit *string    `json:"verification_gas_limit,omitempty" db:"verification_gas_limit"`
+	PreVerificationGas   *string    `json:"pre_verification_gas,omitempty" db:"pre_verification_gas"`
+	MaxFeePerGas         *string    `json:"max_fee_per_gas,omitempty" db:"max_fee_per_gas"`
+	MaxPriorityFeePerGas *string    `json:"max_priority_fee_per_gas,omitempty" db:"max_priority_fee_per_gas"`
+	CallSelector         *string    `json:"call_selector,omitempty" db:"call_selector"`
+	RevertReason         *string    `json:"revert_reason,omitempty" db:"revert_reason"`
+	CreatedAt            time.Time  `json:"created_at" db:"created_at"`
+}
+
+// UserOperationFilters são os filtros da listagem de UserOperations
+type UserOperationFilters struct {
+	Sender     string
+	Paymaster  string
+	Bundler    string
+	EntryPoint string
+	TxHash     string
+	Success    *bool
+	FromBlock  *uint64
+	ToBlock    *uint64
+}
+
+// userOperationAddressPattern valida os endereços dos filtros de UserOperations
+var userOperationAddressPattern = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+
+// UserOperationHashPattern valida hashes de UserOperations e de transações
+var UserOperationHashPattern = regexp.MustCompile(`^0x[0-9a-f]{64}$`)
+
+// Normalize converte endereços e hashes para minúsculas e valida os filtros
+func (f *UserOperationFilters) Normalize() error {
+	for _, filter := range []struct {
+		name  string
+		value *string
+	}{{"sender", &f.Sender}, {"paymaster", &f.Paymaster}, {"bundler", &f.Bundler}, {"entry_point", &f.EntryPoint}} {
+		*filter.value = strings.ToLower(strings.TrimSpace(*filter.value))
+		if *filter.value != "" && !userOperationAddressPattern.MatchString(*filter.value) {
+			return fmt.Errorf("%s deve ser um endereço 0x com 40 caracteres hexadecimais", filter.name)
+		}
+	}
+	f.TxHash = strings.ToLower(strings.TrimSpace(f.TxHash))
+	if f.TxHash != "" && !UserOperationHashPattern.MatchString(f.TxHash) {
+		return fmt.Errorf("tx_hash deve ser um hash 0x com 64 caracteres hexadecimais")
+	}
+	if f.FromBlock != nil && f.ToBlock != nil && *f.FromBlock > *f.ToBlock {
+		return fmt.Errorf("from_block maior que to_block")
+	}
+	return nil
+}
@@ -63,6 +63,9 @@ type AccountRepository interface {
 	GetStatsByType(ctx context.Context) (map[string]*AccountTypeStats, error)
 	GetComplianceStats(ctx context.Context) (*ComplianceStats, error)
 
+	// Risco
+	GetLatestRiskEvaluation(ctx context.Context, address string) (*entities.AccountRiskEvaluation, error)
+
 	// Bulk operations
 	CreateBatch(ctx context.Context, accounts []*entities.Account) error
 	UpdateBalances(ctx context.Context, updates map[string]string) error
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"explorer-api/internal/domain/entities"
+)
+
+// AddressLabelRepository define as operações de persistência dos labels públicos de endereços
+type AddressLabelRepository interface {
+	// Gravar label. Um label aprovado substitui o label aprovado anterior do endereço
+	Create(ctx context.Context, label *entities.AddressLabel) error
+
+	// Buscar label por ID (nil se não existir)
+	FindByID(ctx context.Context, id int64) (*entities.AddressLabel, error)
+
+	// Listar labels, dos mais recentes para os mais antigos
+	FindAll(ctx context.Context, filters *entities.AddressLabelFilters, limit, offset int) ([]*entities.AddressLabel, int64, error)
+
+	// Buscar os labels aprovados dos endereços informados, indexados pelo endereço
+	FindApproved(ctx context.Context, addresses []string) (map[string]*entities.AddressLabelSummary, error)
+
+	// Gravar nome, categoria, descrição, fonte, URL e verificação. Retorna false se o label não existir
+	Update(ctx context.Context, label *entities.AddressLabel) (bool, error)
+
+	// Aprovar ou rejeitar uma sugestão pendente. Retorna false se o label não estiver mais pendente
+	Review(ctx context.Context, id int64, status entities.AddressLabelStatus, reviewedBy string, note *string) (bool, error)
+
+	// Remover label
+	Delete(ctx context.Context, id int64) (bool, error)
+
+	// Gravar labels aprovados em uma transação, substituindo os aprovados anteriores dos endereços
+	Import(ctx context.Context, labels []entities.AddressLabel, importedBy string) (*entities.AddressLabelImportResult, error)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"explorer-api/internal/domain/entities"
+)
+
+// AlertRepository define as operações de persistência para regras de alerta e entregas
+type AlertRepository interface {
+	// Criar nova regra
+	Create(ctx context.Context, rule *entities.AlertRule) error
+
+	// Buscar regra por ID
+	FindByID(ctx context.Context, id int64) (*entities.AlertRule, error)
+
+	// Listar regras (createdBy nil lista todas)
+	FindAll(ctx context.Context, createdBy *string, limit, offset int) ([]*entities.AlertRule, int64, error)
+
+	// Atualizar regra
+	Update(ctx context.Context, rule *entities.AlertRule) error
+
+	// Remover regra (e suas entregas)
+	Delete(ctx context.Context, id int64) error
+
+	// Listar entregas de uma regra, opcionalmente filtrando por status
+	FindDeliveries(ctx context.Context, ruleID int64, status string, limit, offset int) ([]*entities.AlertDelivery, int64, error)
+
+	// Reagendar uma entrega para nova tentativa imediata
+	RetryDelivery(ctx context.Context, ruleID, deliveryID int64) (bool, error)
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"explorer-api/internal/domain/entities"
+)
+
+// ComplianceCaseRepository define as operações de persistência dos casos de compliance. Toda alteração grava
+// sua entrada no histórico do caso na mesma transação
+type ComplianceCaseRepository interface {
+	// Abrir caso com os endereços, transações e nota inicial
+	Create(ctx context.Context, cc *entities.ComplianceCase, addresses []entities.ComplianceCaseAddress, transactions []entities.ComplianceCaseTransaction, note *string) error
+
+	// Buscar caso por ID com endereços, transações e notas (nil se não existir)
+	FindByID(ctx context.Context, id int64) (*entities.ComplianceCase, error)
+
+	// Listar casos, dos atualizados mais recentemente para os mais antigos
+	FindCases(ctx context.Context, filters *entities.ComplianceCaseFilters, limit, offset int) ([]*entities.ComplianceCase, int64, error)
+
+	// Listar o histórico de um caso em ordem cronológica
+	FindEvents(ctx context.Context, caseID int64, limit, offset int) ([]entities.ComplianceCaseEvent, int64, error)
+
+	// Mudar o estado do caso se ele ainda estiver em from, aplicando o status de compliance às accounts
+	// investigadas. Retorna false se o caso não estiver mais em from
+	UpdateState(ctx context.Context, caseID int64, from, to entities.ComplianceCaseState, resolution *entities.ComplianceCaseResolution, actor string, reason *string) (bool, error)
+
+	// Atribuir o caso a um usuário (nil remove a atribuição)
+	Assign(ctx context.Context, caseID int64, assignedTo *int, actor string) error
+
+	// Vincular endereço; accounts investigadas recebem o status de compliance do estado atual do caso.
+	// Retorna false se o endereço já estiver vinculado
+	AddAddress(ctx context.Context, caseID int64, address *entities.ComplianceCaseAddress) (bool, error)
+
+	// Vincular transação. Retorna false se a transação já estiver vinculada
+	AddTransaction(ctx context.Context, caseID int64, transaction *entities.ComplianceCaseTransaction) (bool, error)
+
+	// Adicionar nota de investigação
+	AddNote(ctx context.Context, caseID int64, note *entities.ComplianceCaseNote) error
+
+	// Verificar se o usuário existe e está ativo
+	UserExists(ctx context.Context, userID int) (bool, error)
+}
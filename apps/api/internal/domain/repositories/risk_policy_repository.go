@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"explorer-api/internal/domain/entities"
+)
+
+// RiskPolicyRepository define as operações de persistência para as versões da política de risco
+type RiskPolicyRepository interface {
+	// Gravar nova versão (o checksum é calculado pelo banco)
+	Create(ctx context.Context, policy *entities.RiskPolicyVersion) error
+
+	// Buscar versão pelo número
+	FindByVersion(ctx context.Context, version int64) (*entities.RiskPolicyVersion, error)
+
+	// Buscar a versão ativa (nil se não houver)
+	FindActive(ctx context.Context) (*entities.RiskPolicyVersion, error)
+
+	// Listar versões, da mais recente para a mais antiga, sem o conteúdo
+	FindAll(ctx context.Context, limit, offset int) ([]*entities.RiskPolicyVersion, int64, error)
+
+	// Ativar uma versão, desativando a anterior
+	Activate(ctx context.Context, version int64) (bool, error)
+}
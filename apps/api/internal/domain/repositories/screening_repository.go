@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"explorer-api/internal/domain/entities"
+)
+
+// ScreeningRepository define as operações de persistência das listas de triagem e da exposição das accounts
+type ScreeningRepository interface {
+	// Importar nova versão ativa de uma lista, desativando a versão ativa anterior com o mesmo nome
+	CreateList(ctx context.Context, list *entities.ScreeningList, entries []entities.ScreeningEntry) error
+
+	// Buscar versão de lista por ID
+	FindListByID(ctx context.Context, id int64) (*entities.ScreeningList, error)
+
+	// Listar versões (name vazio lista todas)
+	FindLists(ctx context.Context, name string, limit, offset int) ([]*entities.ScreeningList, int64, error)
+
+	// Listar endereços de uma versão
+	FindEntries(ctx context.Context, listID int64, limit, offset int) ([]entities.ScreeningEntry, int64, error)
+
+	// Ativar ou desativar uma versão; ativar desativa a versão ativa com o mesmo nome
+	SetListActive(ctx context.Context, id int64, active bool) (bool, error)
+
+	// Relatório de exposição de uma account (nil se a account não existir)
+	GetExposureReport(ctx context.Context, address string) (*entities.AccountExposureReport, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"explorer-api/internal/domain/entities"
+)
+
+// TagRuleRepository define as operações de persistência das regras de tags avaliadas pelo worker
+type TagRuleRepository interface {
+	// Gravar nova regra. Uma tag pode ter várias regras
+	Create(ctx context.Context, rule *entities.TagRule) error
+
+	// Buscar regra por ID (nil se não existir)
+	FindByID(ctx context.Context, id int64) (*entities.TagRule, error)
+
+	// Listar regras em ordem alfabética de tag
+	FindAll(ctx context.Context, filters *entities.TagRuleFilters, limit, offset int) ([]*entities.TagRule, int64, error)
+
+	// Gravar descrição, condições e estado da regra. Retorna false se a regra não existir
+	Update(ctx context.Context, rule *entities.TagRule) (bool, error)
+
+	// Remover regra. As tags de sistema da regra são apagadas pelo worker na próxima reavaliação
+	Delete(ctx context.Context, id int64) (bool, error)
+
+	// Simular as condições sobre as accounts atuais sem gravar nada. Com tag, calcula também as accounts
+	// que ganhariam ou perderiam a tag de sistema, considerando as demais regras ativas da tag (exceto a
+	// regra excludeID, que as condições substituem)
+	Preview(ctx context.Context, tag string, conditions []entities.TagCondition, excludeID int64, sampleSize int) (*entities.TagRulePreview, error)
+}
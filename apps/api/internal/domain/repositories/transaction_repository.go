@@ -29,6 +29,9 @@ type TransactionRepository interface {
 	// CountWithFilters conta transações com filtros
 	CountWithFilters(ctx context.Context, whereClause string, args []interface{}) (int64, error)
 
+	// EstimateCountWithFilters conta transações com filtros, usando estimativa do planner em resultados grandes
+	EstimateCountWithFilters(ctx context.Context, whereClause string, args []interface{}) (count int64, approximate bool, err error)
+
 	// Count retorna o número total de transações
 	Count(ctx context.Context) (int64, error)
 
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"explorer-api/internal/domain/entities"
+)
+
+// UserOperationRepository define as operações de leitura das UserOperations ERC-4337 indexadas pelo worker
+type UserOperationRepository interface {
+	// Buscar UserOperation pelo hash (nil se não existir)
+	FindByHash(ctx context.Context, hash string) (*entities.UserOperation, error)
+
+	// Listar UserOperations, das mais recentes para as mais antigas. O total é estimado pelo planner
+	// em filtros muito amplos (approximate = true)
+	FindAll(ctx context.Context, filters *entities.UserOperationFilters, limit, offset int) (ops []*entities.UserOperation, total int64, approximate bool, err error)
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// ExactCountThreshold define até quantas linhas estimadas ainda vale executar o COUNT(*) exato
+const ExactCountThreshold = 100000
+
+// EstimateCount conta as linhas de uma consulta usando a estimativa do planner do PostgreSQL.
+// fromClause deve conter o FROM e o WHERE da consulta (ex: "FROM transactions WHERE status = $1").
+// Quando a estimativa fica abaixo de ExactCountThreshold o COUNT(*) exato é executado;
+// caso contrário a estimativa é retornada e approximate é true
+func EstimateCount(ctx context.Context, db *sql.DB, fromClause string, args []interface{}) (count int64, approximate bool, err error) {
+	var plan []byte
+	if err := db.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) SELECT 1 "+fromClause, args...).Scan(&plan); err != nil {
+		return 0, false, fmt.Errorf("erro ao estimar contagem: %w", err)
+	}
+
+	var explain []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explain); err != nil || len(explain) == 0 {
+		return 0, false, fmt.Errorf("erro ao interpretar plano de execução: %v", err)
+	}
+
+	estimate := int64(explain[0].Plan.PlanRows)
+	if estimate > ExactCountThreshold {
+		return estimate, true, nil
+	}
+
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) "+fromClause, args...).Scan(&count); err != nil {
+		return 0, false, fmt.Errorf("erro ao contar registros: %w", err)
+	}
+	return count, false, nil
+}
@@ -446,6 +446,42 @@ func (r *PostgresAccountRepository) GetComplianceStats(ctx context.Context) (*re
 
 // Métodos auxiliares
 
+// GetLatestRiskEvaluation busca a avaliação de risco mais recente da account; retorna nil se ela ainda não foi avaliada
+func (r *PostgresAccountRepository) GetLatestRiskEvaluation(ctx context.Context, address string) (*entities.AccountRiskEvaluation, error) {
+	query := `
+		SELECT e.policy_version, p.name, e.score, e.status, e.breakdown, e.trigger,
+		       a.compliance_source, e.evaluated_at
+		FROM account_risk_evaluations e
+		JOIN risk_policies p ON p.version = e.policy_version
+		JOIN accounts a ON a.address = e.address
+		WHERE e.address = $1
+		ORDER BY e.evaluated_at DESC, e.id DESC
+		LIMIT 1
+	`
+
+	var evaluation entities.AccountRiskEvaluation
+	var breakdown []byte
+	err := r.db.QueryRowContext(ctx, query, address).Scan(
+		&evaluation.PolicyVersion,
+		&evaluation.PolicyName,
+		&evaluation.Score,
+		&evaluation.Status,
+		&breakdown,
+		&evaluation.Trigger,
+		&evaluation.StatusSource,
+		&evaluation.EvaluatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar avaliação de risco: %w", err)
+	}
+	evaluation.Breakdown = breakdown
+
+	return &evaluation, nil
+}
+
 func (r *PostgresAccountRepository) buildWhereClause(filters *repositories.AccountFilters) (string, []interface{}) {
 	if filters == nil {
 		return "", nil
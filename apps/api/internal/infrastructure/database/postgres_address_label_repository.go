@@ -0,0 +1,345 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// addressLabelImportBatch é o número de labels gravados por INSERT na importação
+const addressLabelImportBatch = 1000
+
+// PostgresAddressLabelRepository implementa AddressLabelRepository usando PostgreSQL
+type PostgresAddressLabelRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAddressLabelRepository cria uma nova instância do repositório
+func NewPostgresAddressLabelRepository(db *sql.DB) repositories.AddressLabelRepository {
+	return &PostgresAddressLabelRepository{db: db}
+}
+
+const addressLabelColumns = `id, address, name, category, description, source, url, verified, status,
+	submitted_by, reviewed_by, reviewed_at, review_note, created_at, updated_at`
+
+// scanAddressLabel lê um label a partir de uma linha
+func scanAddressLabel(scanner interface{ Scan(...interface{}) error }) (*entities.AddressLabel, error) {
+	label := &entities.AddressLabel{}
+	err := scanner.Scan(
+		&label.ID, &label.Address, &label.Name, &label.Category, &label.Description, &label.Source, &label.URL,
+		&label.Verified, &label.Status, &label.SubmittedBy, &label.ReviewedBy, &label.ReviewedAt, &label.ReviewNote,
+		&label.CreatedAt, &label.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// lockAddressLabels serializa as aprovações, que trocam o label aprovado de um endereço
+func lockAddressLabels(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE address_labels IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("erro ao bloquear labels: %w", err)
+	}
+	return nil
+}
+
+// supersedeAddressLabels marca como substituídos os labels aprovados dos endereços
+func supersedeAddressLabels(ctx context.Context, tx *sql.Tx, addresses []string, exceptID int64) (int64, error) {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE address_labels SET status = 'superseded', updated_at = NOW()
+		WHERE status = 'approved' AND address = ANY($1) AND id <> $2`, pq.Array(addresses), exceptID)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao substituir labels aprovados: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// Create grava um label; se aprovado, substitui o label aprovado anterior do endereço
+func (r *PostgresAddressLabelRepository) Create(ctx context.Context, label *entities.AddressLabel) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if label.Status == entities.AddressLabelApproved {
+		if err := lockAddressLabels(ctx, tx); err != nil {
+			return err
+		}
+		if _, err := supersedeAddressLabels(ctx, tx, []string{label.Address}, 0); err != nil {
+			return err
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO address_labels (address, name, category, description, source, url, verified, status,
+			submitted_by, reviewed_by, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at`,
+		label.Address, label.Name, label.Category, label.Description, label.Source, label.URL, label.Verified,
+		label.Status, label.SubmittedBy, label.ReviewedBy, label.ReviewedAt,
+	).Scan(&label.ID, &label.CreatedAt, &label.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar label: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar label: %w", err)
+	}
+	return nil
+}
+
+// FindByID busca um label
+func (r *PostgresAddressLabelRepository) FindByID(ctx context.Context, id int64) (*entities.AddressLabel, error) {
+	query := `SELECT ` + addressLabelColumns + ` FROM address_labels WHERE id = $1`
+
+	label, err := scanAddressLabel(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar label: %w", err)
+	}
+
+	return label, nil
+}
+
+// FindAll lista os labels com filtros e paginação
+func (r *PostgresAddressLabelRepository) FindAll(ctx context.Context, filters *entities.AddressLabelFilters, limit, offset int) ([]*entities.AddressLabel, int64, error) {
+	where := []string{"TRUE"}
+	var args []interface{}
+	if filters != nil {
+		if filters.Address != "" {
+			args = append(args, strings.ToLower(filters.Address))
+			where = append(where, fmt.Sprintf("address = $%d", len(args)))
+		}
+		if filters.Category != "" {
+			args = append(args, filters.Category)
+			where = append(where, fmt.Sprintf("category = $%d", len(args)))
+		}
+		if filters.Status != "" {
+			args = append(args, filters.Status)
+			where = append(where, fmt.Sprintf("status = $%d", len(args)))
+		}
+		if filters.Query != "" {
+			args = append(args, "%"+filters.Query+"%")
+			where = append(where, fmt.Sprintf("name ILIKE $%d", len(args)))
+		}
+		if filters.Verified != nil {
+			args = append(args, *filters.Verified)
+			where = append(where, fmt.Sprintf("verified = $%d", len(args)))
+		}
+	}
+	whereClause := strings.Join(where, " AND ")
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM address_labels WHERE `+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar labels: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM address_labels WHERE %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`,
+		addressLabelColumns, whereClause, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []*entities.AddressLabel
+	for rows.Next() {
+		label, err := scanAddressLabel(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler label: %w", err)
+		}
+		labels = append(labels, label)
+	}
+
+	return labels, total, rows.Err()
+}
+
+// FindApproved busca os labels aprovados dos endereços pelo índice único de labels aprovados
+func (r *PostgresAddressLabelRepository) FindApproved(ctx context.Context, addresses []string) (map[string]*entities.AddressLabelSummary, error) {
+	labels := make(map[string]*entities.AddressLabelSummary)
+	if len(addresses) == 0 {
+		return labels, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT address, name, category, verified, url
+		FROM address_labels
+		WHERE status = 'approved' AND address = ANY($1)`, pq.Array(addresses))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar labels aprovados: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var address string
+		summary := &entities.AddressLabelSummary{}
+		if err := rows.Scan(&address, &summary.Name, &summary.Category, &summary.Verified, &summary.URL); err != nil {
+			return nil, fmt.Errorf("erro ao ler label aprovado: %w", err)
+		}
+		labels[address] = summary
+	}
+
+	return labels, rows.Err()
+}
+
+// Update grava os campos editáveis do label
+func (r *PostgresAddressLabelRepository) Update(ctx context.Context, label *entities.AddressLabel) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE address_labels
+		SET name = $2, category = $3, description = $4, source = $5, url = $6, verified = $7, updated_at = NOW()
+		WHERE id = $1`,
+		label.ID, label.Name, label.Category, label.Description, label.Source, label.URL, label.Verified)
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar label: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar label: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// Review aprova ou rejeita uma sugestão pendente. A aprovação substitui o label aprovado anterior do endereço
+func (r *PostgresAddressLabelRepository) Review(ctx context.Context, id int64, status entities.AddressLabelStatus, reviewedBy string, note *string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if status == entities.AddressLabelApproved {
+		if err := lockAddressLabels(ctx, tx); err != nil {
+			return false, err
+		}
+	}
+
+	var address string
+	err = tx.QueryRowContext(ctx, `
+		SELECT address FROM address_labels WHERE id = $1 AND status = 'pending' FOR UPDATE`, id).Scan(&address)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("erro ao buscar label: %w", err)
+	}
+
+	// O label aprovado anterior sai antes, por causa do índice único de labels aprovados
+	if status == entities.AddressLabelApproved {
+		if _, err := supersedeAddressLabels(ctx, tx, []string{address}, id); err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE address_labels
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW(), review_note = $4, updated_at = NOW()
+		WHERE id = $1`, id, status, reviewedBy, note); err != nil {
+		return false, fmt.Errorf("erro ao moderar label: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("erro ao confirmar moderação do label: %w", err)
+	}
+	return true, nil
+}
+
+// Delete remove um label
+func (r *PostgresAddressLabelRepository) Delete(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM address_labels WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("erro ao remover label: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao remover label: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// Import grava os labels como aprovados em uma transação, substituindo os aprovados anteriores
+func (r *PostgresAddressLabelRepository) Import(ctx context.Context, labels []entities.AddressLabel, importedBy string) (*entities.AddressLabelImportResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockAddressLabels(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	result := &entities.AddressLabelImportResult{}
+	for start := 0; start < len(labels); start += addressLabelImportBatch {
+		end := start + addressLabelImportBatch
+		if end > len(labels) {
+			end = len(labels)
+		}
+		batch := labels[start:end]
+
+		addresses := make([]string, len(batch))
+		names := make([]string, len(batch))
+		categories := make([]string, len(batch))
+		descriptions := make([]sql.NullString, len(batch))
+		sources := make([]sql.NullString, len(batch))
+		urls := make([]sql.NullString, len(batch))
+		verified := make([]bool, len(batch))
+		for i, label := range batch {
+			addresses[i] = label.Address
+			names[i] = label.Name
+			categories[i] = string(label.Category)
+			descriptions[i] = nullString(label.Description)
+			sources[i] = nullString(label.Source)
+			urls[i] = nullString(label.URL)
+			verified[i] = label.Verified
+		}
+
+		replaced, err := supersedeAddressLabels(ctx, tx, addresses, 0)
+		if err != nil {
+			return nil, err
+		}
+		result.Replaced += int(replaced)
+
+		inserted, err := tx.ExecContext(ctx, `
+			INSERT INTO address_labels (address, name, category, description, source, url, verified, status,
+				submitted_by, reviewed_by, reviewed_at)
+			SELECT l.address, l.name, l.category, l.description, l.source, l.url, l.verified, 'approved', $8, $8, NOW()
+			FROM UNNEST($1::text[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[], $7::boolean[])
+				AS l(address, name, category, description, source, url, verified)`,
+			pq.Array(addresses), pq.Array(names), pq.Array(categories), pq.Array(descriptions),
+			pq.Array(sources), pq.Array(urls), pq.Array(verified), importedBy)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao importar labels: %w", err)
+		}
+		count, err := inserted.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao importar labels: %w", err)
+		}
+		result.Imported += int(count)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar importação de labels: %w", err)
+	}
+	return result, nil
+}
+
+// nullString converte um texto opcional para gravação em arrays
+func nullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
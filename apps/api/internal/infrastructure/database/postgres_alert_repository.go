@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+// PostgresAlertRepository implementa AlertRepository usando PostgreSQL
+type PostgresAlertRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAlertRepository cria uma nova instância do repositório
+func NewPostgresAlertRepository(db *sql.DB) repositories.AlertRepository {
+	return &PostgresAlertRepository{db: db}
+}
+
+const alertRuleColumns = `id, name, description, subject_type, conditions, webhook_url, webhook_secret,
+		is_active, created_by, last_triggered_at, created_at, updated_at`
+
+// scanAlertRule lê uma regra de alerta a partir de uma linha
+func scanAlertRule(scanner interface{ Scan(...interface{}) error }) (*entities.AlertRule, error) {
+	rule := &entities.AlertRule{}
+	err := scanner.Scan(
+		&rule.ID, &rule.Name, &rule.Description, &rule.SubjectType, &rule.Conditions,
+		&rule.WebhookURL, &rule.WebhookSecret, &rule.IsActive, &rule.CreatedBy,
+		&rule.LastTriggeredAt, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Create cria uma nova regra de alerta
+func (r *PostgresAlertRepository) Create(ctx context.Context, rule *entities.AlertRule) error {
+	query := `
+		INSERT INTO alert_rules (name, description, subject_type, conditions, webhook_url, webhook_secret, is_active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		rule.Name, rule.Description, rule.SubjectType, rule.Conditions,
+		rule.WebhookURL, rule.WebhookSecret, rule.IsActive, rule.CreatedBy,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao criar regra de alerta: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID busca uma regra de alerta por ID
+func (r *PostgresAlertRepository) FindByID(ctx context.Context, id int64) (*entities.AlertRule, error) {
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules WHERE id = $1`
+
+	rule, err := scanAlertRule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar regra de alerta: %w", err)
+	}
+
+	return rule, nil
+}
+
+// FindAll lista regras de alerta com paginação
+func (r *PostgresAlertRepository) FindAll(ctx context.Context, createdBy *string, limit, offset int) ([]*entities.AlertRule, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM alert_rules WHERE ($1::text IS NULL OR created_by = $1)`, createdBy,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar regras de alerta: %w", err)
+	}
+
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules
+		WHERE ($1::text IS NULL OR created_by = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, createdBy, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar regras de alerta: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.AlertRule
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler regra de alerta: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, total, rows.Err()
+}
+
+// Update atualiza uma regra de alerta
+func (r *PostgresAlertRepository) Update(ctx context.Context, rule *entities.AlertRule) error {
+	query := `
+		UPDATE alert_rules
+		SET name = $2, description = $3, subject_type = $4, conditions = $5,
+		    webhook_url = $6, webhook_secret = $7, is_active = $8
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		rule.ID, rule.Name, rule.Description, rule.SubjectType, rule.Conditions,
+		rule.WebhookURL, rule.WebhookSecret, rule.IsActive,
+	).Scan(&rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar regra de alerta: %w", err)
+	}
+
+	return nil
+}
+
+// Delete remove uma regra de alerta
+func (r *PostgresAlertRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("erro ao remover regra de alerta: %w", err)
+	}
+	return nil
+}
+
+// FindDeliveries lista entregas de uma regra com paginação
+func (r *PostgresAlertRepository) FindDeliveries(ctx context.Context, ruleID int64, status string, limit, offset int) ([]*entities.AlertDelivery, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM alert_deliveries WHERE rule_id = $1 AND ($2 = '' OR status = $2)`, ruleID, status,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar entregas: %w", err)
+	}
+
+	query := `
+		SELECT id, rule_id, subject_type, subject_key, payload, status, attempts, response_status,
+		       last_error, next_attempt_at, delivered_at, created_at, updated_at
+		FROM alert_deliveries
+		WHERE rule_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, query, ruleID, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar entregas: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.AlertDelivery
+	for rows.Next() {
+		delivery := &entities.AlertDelivery{}
+		var payload []byte
+		if err := rows.Scan(
+			&delivery.ID, &delivery.RuleID, &delivery.SubjectType, &delivery.SubjectKey, &payload,
+			&delivery.Status, &delivery.Attempts, &delivery.ResponseStatus, &delivery.LastError,
+			&delivery.NextAttemptAt, &delivery.DeliveredAt, &delivery.CreatedAt, &delivery.UpdatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler entrega: %w", err)
+		}
+		delivery.Payload = payload
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, total, rows.Err()
+}
+
+// RetryDelivery reagenda uma entrega que não foi entregue para nova tentativa imediata
+func (r *PostgresAlertRepository) RetryDelivery(ctx context.Context, ruleID, deliveryID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE alert_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+		WHERE id = $1 AND rule_id = $2 AND status <> 'delivered'`, deliveryID, ruleID)
+	if err != nil {
+		return false, fmt.Errorf("erro ao reagendar entrega: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
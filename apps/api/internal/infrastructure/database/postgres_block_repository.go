@@ -250,6 +250,16 @@ func (r *PostgresBlockRepository) CountWithFilters(ctx context.Context, whereCla
 	return count, err
 }
 
+// EstimateCountWithFilters conta blocos com filtros, usando estimativa do planner em resultados grandes
+func (r *PostgresBlockRepository) EstimateCountWithFilters(ctx context.Context, whereClause string, args []interface{}) (int64, bool, error) {
+	fromClause := "FROM blocks"
+	if whereClause != "" {
+		fromClause += " " + whereClause
+	}
+
+	return EstimateCount(ctx, r.db, fromClause, args)
+}
+
 // GetUniqueMiners retorna lista de mineradores únicos
 func (r *PostgresBlockRepository) GetUniqueMiners(ctx context.Context) ([]string, error) {
 	query := `
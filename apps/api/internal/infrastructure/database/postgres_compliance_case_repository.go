@@ -0,0 +1,590 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// PostgresComplianceCaseRepository implementa ComplianceCaseRepository usando PostgreSQL
+type PostgresComplianceCaseRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresComplianceCaseRepository cria uma nova instância do repositório
+func NewPostgresComplianceCaseRepository(db *sql.DB) repositories.ComplianceCaseRepository {
+	return &PostgresComplianceCaseRepository{db: db}
+}
+
+const complianceCaseColumns = `c.id, c.title, c.description, c.state, c.priority, c.origin, c.origin_ref, c.resolution,
+		c.assigned_to, u.username, c.opened_by, c.opened_at, c.updated_at, c.closed_at,
+		(SELECT COUNT(*) FROM compliance_case_addresses ca WHERE ca.case_id = c.id)`
+
+// scanComplianceCase lê um caso a partir de uma linha
+func scanComplianceCase(scanner interface{ Scan(...interface{}) error }) (*entities.ComplianceCase, error) {
+	cc := &entities.ComplianceCase{}
+	err := scanner.Scan(
+		&cc.ID, &cc.Title, &cc.Description, &cc.State, &cc.Priority, &cc.Origin, &cc.OriginRef, &cc.Resolution,
+		&cc.AssignedTo, &cc.AssigneeName, &cc.OpenedBy, &cc.OpenedAt, &cc.UpdatedAt, &cc.ClosedAt, &cc.AddressCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// insertComplianceCaseEvent grava uma entrada no histórico do caso e atualiza updated_at
+func insertComplianceCaseEvent(ctx context.Context, tx *sql.Tx, caseID int64, actor, action string, from, to *string, details map[string]interface{}) error {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	payload, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar histórico do caso %d: %w", caseID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO compliance_case_events (case_id, actor, action, from_value, to_value, details)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		caseID, actor, action, from, to, payload); err != nil {
+		return fmt.Errorf("erro ao gravar histórico do caso %d: %w", caseID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE compliance_cases SET updated_at = NOW() WHERE id = $1`, caseID); err != nil {
+		return fmt.Errorf("erro ao atualizar caso %d: %w", caseID, err)
+	}
+	return nil
+}
+
+// caseBlockingStates retorna os estados de outros casos ativos que impedem o caso de alterar o status de uma
+// account investigada por ambos: um caso escalado mantém a account flagged e nenhum caso fechado libera
+// uma account ainda investigada em outro
+func caseBlockingStates(state entities.ComplianceCaseState) []string {
+	switch state {
+	case entities.ComplianceCaseInvestigating:
+		return []string{string(entities.ComplianceCaseEscalated)}
+	case entities.ComplianceCaseClosed:
+		return []string{
+			string(entities.ComplianceCaseOpen),
+			string(entities.ComplianceCaseInvestigating),
+			string(entities.ComplianceCaseEscalated),
+		}
+	default:
+		return []string{}
+	}
+}
+
+// applyCaseAccountStatus aplica às accounts investigadas pelo caso (ou apenas a address, se informado) o status
+// de compliance do estado e registra cada alteração no histórico
+func applyCaseAccountStatus(ctx context.Context, tx *sql.Tx, caseID int64, state entities.ComplianceCaseState, resolution entities.ComplianceCaseResolution, actor, address string) error {
+	status, source, ok := state.AccountStatus(resolution)
+	if !ok {
+		return nil
+	}
+	notes := fmt.Sprintf("Caso de compliance #%d (%s)", caseID, state)
+	if state == entities.ComplianceCaseClosed {
+		notes = fmt.Sprintf("Caso de compliance #%d fechado (%s)", caseID, resolution)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		WITH target AS (
+			SELECT ca.address FROM compliance_case_addresses ca
+			WHERE ca.case_id = $1 AND ca.role = 'subject' AND ($5 = '' OR ca.address = $5)
+				AND NOT EXISTS (
+					SELECT 1 FROM compliance_case_addresses o
+					JOIN compliance_cases oc ON oc.id = o.case_id
+					WHERE o.address = ca.address AND o.role = 'subject' AND oc.id <> $1 AND oc.state = ANY($6)
+				)
+		)
+		UPDATE accounts a SET
+			compliance_status = $2,
+			compliance_source = $3,
+			compliance_notes = $4,
+			updated_at = NOW()
+		FROM target t, accounts prev
+		WHERE a.address = t.address AND prev.address = a.address
+			AND (prev.compliance_status <> $2 OR prev.compliance_source <> $3)
+		RETURNING a.address, prev.compliance_status, prev.compliance_source`,
+		caseID, status, source, notes, address, pq.Array(caseBlockingStates(state)))
+	if err != nil {
+		return fmt.Errorf("erro ao aplicar status de compliance do caso %d: %w", caseID, err)
+	}
+
+	type change struct {
+		address, previous, previousSource string
+	}
+	var changes []change
+	for rows.Next() {
+		var c change
+		if err := rows.Scan(&c.address, &c.previous, &c.previousSource); err != nil {
+			rows.Close()
+			return fmt.Errorf("erro ao ler status de compliance alterado: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao ler status de compliance alterados: %w", err)
+	}
+
+	for _, c := range changes {
+		previous := c.previous
+		if err := insertComplianceCaseEvent(ctx, tx, caseID, actor, entities.ComplianceCaseActionAccountStatus, &previous, &status, map[string]interface{}{
+			"address":         c.address,
+			"previous_source": c.previousSource,
+			"source":          source,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create abre o caso com seus vínculos em uma transação
+func (r *PostgresComplianceCaseRepository) Create(ctx context.Context, cc *entities.ComplianceCase, addresses []entities.ComplianceCaseAddress, transactions []entities.ComplianceCaseTransaction, note *string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO compliance_cases (title, description, priority, origin, origin_ref, assigned_to, opened_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, state, opened_at, updated_at`,
+		cc.Title, cc.Description, cc.Priority, cc.Origin, cc.OriginRef, cc.AssignedTo, cc.OpenedBy,
+	).Scan(&cc.ID, &cc.State, &cc.OpenedAt, &cc.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir caso de compliance: %w", err)
+	}
+
+	opened := string(entities.ComplianceCaseOpen)
+	if err := insertComplianceCaseEvent(ctx, tx, cc.ID, cc.OpenedBy, entities.ComplianceCaseActionOpened, nil, &opened, map[string]interface{}{
+		"origin":   cc.Origin,
+		"priority": cc.Priority,
+	}); err != nil {
+		return err
+	}
+	if cc.AssignedTo != nil {
+		assignee := fmt.Sprint(*cc.AssignedTo)
+		if err := insertComplianceCaseEvent(ctx, tx, cc.ID, cc.OpenedBy, entities.ComplianceCaseActionAssigned, nil, &assignee, nil); err != nil {
+			return err
+		}
+	}
+
+	for i := range addresses {
+		if _, err := r.insertAddress(ctx, tx, cc.ID, &addresses[i]); err != nil {
+			return err
+		}
+	}
+	for i := range transactions {
+		if _, err := r.insertTransaction(ctx, tx, cc.ID, &transactions[i]); err != nil {
+			return err
+		}
+	}
+	if note != nil {
+		if err := r.insertNote(ctx, tx, cc.ID, &entities.ComplianceCaseNote{Author: cc.OpenedBy, Body: *note}); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar abertura do caso: %w", err)
+	}
+	cc.AddressCount = len(addresses)
+	return nil
+}
+
+// FindByID busca o caso com endereços, transações e notas
+func (r *PostgresComplianceCaseRepository) FindByID(ctx context.Context, id int64) (*entities.ComplianceCase, error) {
+	cc, err := scanComplianceCase(r.db.QueryRowContext(ctx, `
+		SELECT `+complianceCaseColumns+`
+		FROM compliance_cases c
+		LEFT JOIN users u ON u.id = c.assigned_to
+		WHERE c.id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar caso de compliance: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ca.address, ca.role, ca.added_by, ca.added_at, a.compliance_status, a.compliance_source
+		FROM compliance_case_addresses ca
+		LEFT JOIN accounts a ON a.address = ca.address
+		WHERE ca.case_id = $1
+		ORDER BY ca.role DESC, ca.added_at`, id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar endereços do caso: %w", err)
+	}
+	cc.Addresses = []entities.ComplianceCaseAddress{}
+	for rows.Next() {
+		var address entities.ComplianceCaseAddress
+		if err := rows.Scan(&address.Address, &address.Role, &address.AddedBy, &address.AddedAt, &address.ComplianceStatus, &address.ComplianceSource); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("erro ao ler endereço do caso: %w", err)
+		}
+		cc.Addresses = append(cc.Addresses, address)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler endereços do caso: %w", err)
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+		SELECT tx_hash, note, added_by, added_at FROM compliance_case_transactions
+		WHERE case_id = $1
+		ORDER BY added_at`, id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar transações do caso: %w", err)
+	}
+	cc.Transactions = []entities.ComplianceCaseTransaction{}
+	for rows.Next() {
+		var transaction entities.ComplianceCaseTransaction
+		if err := rows.Scan(&transaction.TxHash, &transaction.Note, &transaction.AddedBy, &transaction.AddedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("erro ao ler transação do caso: %w", err)
+		}
+		cc.Transactions = append(cc.Transactions, transaction)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler transações do caso: %w", err)
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+		SELECT id, author, body, created_at FROM compliance_case_notes
+		WHERE case_id = $1
+		ORDER BY created_at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar notas do caso: %w", err)
+	}
+	defer rows.Close()
+
+	cc.Notes = []entities.ComplianceCaseNote{}
+	for rows.Next() {
+		var note entities.ComplianceCaseNote
+		if err := rows.Scan(&note.ID, &note.Author, &note.Body, &note.CreatedAt); err != nil {
+			return nil, fmt.Errorf("erro ao ler nota do caso: %w", err)
+		}
+		cc.Notes = append(cc.Notes, note)
+	}
+
+	return cc, rows.Err()
+}
+
+// FindCases lista os casos que atendem aos filtros
+func (r *PostgresComplianceCaseRepository) FindCases(ctx context.Context, filters *entities.ComplianceCaseFilters, limit, offset int) ([]*entities.ComplianceCase, int64, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filters.State != "" {
+		addCondition("c.state = $%d", filters.State)
+	}
+	if filters.Priority != "" {
+		addCondition("c.priority = $%d", filters.Priority)
+	}
+	if filters.Origin != "" {
+		addCondition("c.origin = $%d", filters.Origin)
+	}
+	if filters.AssignedTo != nil {
+		addCondition("c.assigned_to = $%d", *filters.AssignedTo)
+	}
+	if filters.Address != "" {
+		addCondition("EXISTS (SELECT 1 FROM compliance_case_addresses f WHERE f.case_id = c.id AND f.address = $%d)", filters.Address)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM compliance_cases c `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar casos de compliance: %w", err)
+	}
+
+	args = append(args, limit, offset)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT `+complianceCaseColumns+`
+		FROM compliance_cases c
+		LEFT JOIN users u ON u.id = c.assigned_to
+		%s
+		ORDER BY c.updated_at DESC, c.id DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar casos de compliance: %w", err)
+	}
+	defer rows.Close()
+
+	cases := []*entities.ComplianceCase{}
+	for rows.Next() {
+		cc, err := scanComplianceCase(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler caso de compliance: %w", err)
+		}
+		cases = append(cases, cc)
+	}
+
+	return cases, total, rows.Err()
+}
+
+// FindEvents lista o histórico do caso
+func (r *PostgresComplianceCaseRepository) FindEvents(ctx context.Context, caseID int64, limit, offset int) ([]entities.ComplianceCaseEvent, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM compliance_case_events WHERE case_id = $1`, caseID,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar histórico do caso: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, actor, action, from_value, to_value, details, created_at
+		FROM compliance_case_events
+		WHERE case_id = $1
+		ORDER BY id
+		LIMIT $2 OFFSET $3`, caseID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar histórico do caso: %w", err)
+	}
+	defer rows.Close()
+
+	events := []entities.ComplianceCaseEvent{}
+	for rows.Next() {
+		var event entities.ComplianceCaseEvent
+		var details []byte
+		if err := rows.Scan(&event.ID, &event.Actor, &event.Action, &event.FromValue, &event.ToValue, &details, &event.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler histórico do caso: %w", err)
+		}
+		event.Details = json.RawMessage(details)
+		events = append(events, event)
+	}
+
+	return events, total, rows.Err()
+}
+
+// UpdateState muda o estado do caso e aplica o status de compliance às accounts investigadas na mesma transação
+func (r *PostgresComplianceCaseRepository) UpdateState(ctx context.Context, caseID int64, from, to entities.ComplianceCaseState, resolution *entities.ComplianceCaseResolution, actor string, reason *string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE compliance_cases SET
+			state = $3,
+			resolution = $4,
+			closed_at = CASE WHEN $3 = 'closed' THEN NOW() ELSE NULL END
+		WHERE id = $1 AND state = $2`,
+		caseID, string(from), string(to), resolution)
+	if err != nil {
+		return false, fmt.Errorf("erro ao mudar estado do caso %d: %w", caseID, err)
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	fromValue, toValue := string(from), string(to)
+	if err := insertComplianceCaseEvent(ctx, tx, caseID, actor, entities.ComplianceCaseActionStateChanged, &fromValue, &toValue, map[string]interface{}{
+		"resolution": resolution,
+		"reason":     reason,
+	}); err != nil {
+		return false, err
+	}
+
+	var res entities.ComplianceCaseResolution
+	if resolution != nil {
+		res = *resolution
+	}
+	if err := applyCaseAccountStatus(ctx, tx, caseID, to, res, actor, ""); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("erro ao confirmar mudança de estado do caso %d: %w", caseID, err)
+	}
+	return true, nil
+}
+
+// Assign atribui o caso e registra a atribuição anterior no histórico
+func (r *PostgresComplianceCaseRepository) Assign(ctx context.Context, caseID int64, assignedTo *int, actor string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous sql.NullInt64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT assigned_to FROM compliance_cases WHERE id = $1 FOR UPDATE`, caseID).Scan(&previous); err != nil {
+		return fmt.Errorf("erro ao buscar atribuição do caso %d: %w", caseID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE compliance_cases SET assigned_to = $2 WHERE id = $1`, caseID, assignedTo); err != nil {
+		return fmt.Errorf("erro ao atribuir caso %d: %w", caseID, err)
+	}
+
+	var fromValue, toValue *string
+	if previous.Valid {
+		value := fmt.Sprint(previous.Int64)
+		fromValue = &value
+	}
+	if assignedTo != nil {
+		value := fmt.Sprint(*assignedTo)
+		toValue = &value
+	}
+	if err := insertComplianceCaseEvent(ctx, tx, caseID, actor, entities.ComplianceCaseActionAssigned, fromValue, toValue, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar atribuição do caso %d: %w", caseID, err)
+	}
+	return nil
+}
+
+// AddAddress vincula um endereço e aplica a ele o status de compliance do estado atual do caso
+func (r *PostgresComplianceCaseRepository) AddAddress(ctx context.Context, caseID int64, address *entities.ComplianceCaseAddress) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	var state entities.ComplianceCaseState
+	if err := tx.QueryRowContext(ctx, `SELECT state FROM compliance_cases WHERE id = $1 FOR UPDATE`, caseID).Scan(&state); err != nil {
+		return false, fmt.Errorf("erro ao buscar caso %d: %w", caseID, err)
+	}
+
+	added, err := r.insertAddress(ctx, tx, caseID, address)
+	if err != nil || !added {
+		return false, err
+	}
+	if address.Role == entities.ComplianceCaseRoleSubject && state != entities.ComplianceCaseClosed {
+		if err := applyCaseAccountStatus(ctx, tx, caseID, state, "", address.AddedBy, address.Address); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("erro ao confirmar vínculo do endereço: %w", err)
+	}
+	return true, nil
+}
+
+// insertAddress grava o vínculo de um endereço e sua entrada no histórico
+func (r *PostgresComplianceCaseRepository) insertAddress(ctx context.Context, tx *sql.Tx, caseID int64, address *entities.ComplianceCaseAddress) (bool, error) {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO compliance_case_addresses (case_id, address, role, added_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (case_id, address) DO NOTHING
+		RETURNING added_at`,
+		caseID, address.Address, address.Role, address.AddedBy,
+	).Scan(&address.AddedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao vincular endereço ao caso %d: %w", caseID, err)
+	}
+
+	return true, insertComplianceCaseEvent(ctx, tx, caseID, address.AddedBy, entities.ComplianceCaseActionAddressAdded, nil, &address.Address, map[string]interface{}{
+		"role": address.Role,
+	})
+}
+
+// AddTransaction vincula uma transação como evidência
+func (r *PostgresComplianceCaseRepository) AddTransaction(ctx context.Context, caseID int64, transaction *entities.ComplianceCaseTransaction) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	added, err := r.insertTransaction(ctx, tx, caseID, transaction)
+	if err != nil || !added {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("erro ao confirmar vínculo da transação: %w", err)
+	}
+	return true, nil
+}
+
+// insertTransaction grava o vínculo de uma transação e sua entrada no histórico
+func (r *PostgresComplianceCaseRepository) insertTransaction(ctx context.Context, tx *sql.Tx, caseID int64, transaction *entities.ComplianceCaseTransaction) (bool, error) {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO compliance_case_transactions (case_id, tx_hash, note, added_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (case_id, tx_hash) DO NOTHING
+		RETURNING added_at`,
+		caseID, transaction.TxHash, transaction.Note, transaction.AddedBy,
+	).Scan(&transaction.AddedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao vincular transação ao caso %d: %w", caseID, err)
+	}
+
+	return true, insertComplianceCaseEvent(ctx, tx, caseID, transaction.AddedBy, entities.ComplianceCaseActionTxAdded, nil, &transaction.TxHash, map[string]interface{}{
+		"note": transaction.Note,
+	})
+}
+
+// AddNote adiciona uma nota de investigação
+func (r *PostgresComplianceCaseRepository) AddNote(ctx context.Context, caseID int64, note *entities.ComplianceCaseNote) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insertNote(ctx, tx, caseID, note); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar nota do caso %d: %w", caseID, err)
+	}
+	return nil
+}
+
+// insertNote grava uma nota e sua entrada no histórico
+func (r *PostgresComplianceCaseRepository) insertNote(ctx context.Context, tx *sql.Tx, caseID int64, note *entities.ComplianceCaseNote) error {
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO compliance_case_notes (case_id, author, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`,
+		caseID, note.Author, note.Body,
+	).Scan(&note.ID, &note.CreatedAt); err != nil {
+		return fmt.Errorf("erro ao gravar nota do caso %d: %w", caseID, err)
+	}
+
+	return insertComplianceCaseEvent(ctx, tx, caseID, note.Author, entities.ComplianceCaseActionNoteAdded, nil, nil, map[string]interface{}{
+		"note_id": note.ID,
+	})
+}
+
+// UserExists verifica se o usuário existe e está ativo
+func (r *PostgresComplianceCaseRepository) UserExists(ctx context.Context, userID int) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND is_active IS NOT FALSE)`, userID,
+	).Scan(&exists); err != nil {
+		return false, fmt.Errorf("erro ao buscar usuário %d: %w", userID, err)
+	}
+	return exists, nil
+}
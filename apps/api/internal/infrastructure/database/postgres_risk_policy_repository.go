@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+// PostgresRiskPolicyRepository implementa RiskPolicyRepository usando PostgreSQL
+type PostgresRiskPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRiskPolicyRepository cria uma nova instância do repositório
+func NewPostgresRiskPolicyRepository(db *sql.DB) repositories.RiskPolicyRepository {
+	return &PostgresRiskPolicyRepository{db: db}
+}
+
+const riskPolicyColumns = `version, name, format, content, checksum, is_active, created_by, created_at, activated_at`
+
+// scanRiskPolicy lê uma versão da política a partir de uma linha
+func scanRiskPolicy(scanner interface{ Scan(...interface{}) error }) (*entities.RiskPolicyVersion, error) {
+	policy := &entities.RiskPolicyVersion{}
+	err := scanner.Scan(
+		&policy.Version, &policy.Name, &policy.Format, &policy.Content, &policy.Checksum,
+		&policy.IsActive, &policy.CreatedBy, &policy.CreatedAt, &policy.ActivatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Create grava uma nova versão inativa da política
+func (r *PostgresRiskPolicyRepository) Create(ctx context.Context, policy *entities.RiskPolicyVersion) error {
+	query := `
+		INSERT INTO risk_policies (name, format, content, checksum, created_by)
+		VALUES ($1, $2, $3, encode(sha256(convert_to($3, 'UTF8')), 'hex'), $4)
+		RETURNING version, checksum, is_active, created_at`
+
+	err := r.db.QueryRowContext(ctx, query, policy.Name, policy.Format, policy.Content, policy.CreatedBy).
+		Scan(&policy.Version, &policy.Checksum, &policy.IsActive, &policy.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar política de risco: %w", err)
+	}
+
+	return nil
+}
+
+// FindByVersion busca uma versão da política
+func (r *PostgresRiskPolicyRepository) FindByVersion(ctx context.Context, version int64) (*entities.RiskPolicyVersion, error) {
+	query := `SELECT ` + riskPolicyColumns + ` FROM risk_policies WHERE version = $1`
+
+	policy, err := scanRiskPolicy(r.db.QueryRowContext(ctx, query, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar política de risco: %w", err)
+	}
+
+	return policy, nil
+}
+
+// FindActive busca a versão ativa da política
+func (r *PostgresRiskPolicyRepository) FindActive(ctx context.Context) (*entities.RiskPolicyVersion, error) {
+	query := `SELECT ` + riskPolicyColumns + ` FROM risk_policies WHERE is_active`
+
+	policy, err := scanRiskPolicy(r.db.QueryRowContext(ctx, query))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar política de risco ativa: %w", err)
+	}
+
+	return policy, nil
+}
+
+// FindAll lista as versões da política com paginação. O conteúdo não é carregado
+func (r *PostgresRiskPolicyRepository) FindAll(ctx context.Context, limit, offset int) ([]*entities.RiskPolicyVersion, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM risk_policies`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar políticas de risco: %w", err)
+	}
+
+	query := `
+		SELECT version, name, format, '' AS content, checksum, is_active, created_by, created_at, activated_at
+		FROM risk_policies
+		ORDER BY version DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar políticas de risco: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*entities.RiskPolicyVersion
+	for rows.Next() {
+		policy, err := scanRiskPolicy(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler política de risco: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, total, rows.Err()
+}
+
+// Activate troca a versão ativa em uma transação. O worker detecta a troca e reavalia as accounts
+func (r *PostgresRiskPolicyRepository) Activate(ctx context.Context, version int64) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serializa ativações concorrentes
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE risk_policies IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return false, fmt.Errorf("erro ao bloquear políticas de risco: %w", err)
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM risk_policies WHERE version = $1)`, version).Scan(&exists); err != nil {
+		return false, fmt.Errorf("erro ao buscar política de risco: %w", err)
+	}
+	if !exists {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE risk_policies SET is_active = FALSE WHERE is_active AND version <> $1`, version); err != nil {
+		return false, fmt.Errorf("erro ao desativar política de risco: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE risk_policies SET is_active = TRUE, activated_at = NOW()
+		WHERE version = $1 AND NOT is_active`, version); err != nil {
+		return false, fmt.Errorf("erro ao ativar política de risco: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("erro ao confirmar ativação da política de risco: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,285 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// screeningEntriesBatch limita os endereços gravados por comando na importação
+const screeningEntriesBatch = 5000
+
+// PostgresScreeningRepository implementa ScreeningRepository usando PostgreSQL
+type PostgresScreeningRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresScreeningRepository cria uma nova instância do repositório
+func NewPostgresScreeningRepository(db *sql.DB) repositories.ScreeningRepository {
+	return &PostgresScreeningRepository{db: db}
+}
+
+const screeningListColumns = `id, name, source, version, format, checksum, entry_count, is_active, needs_rescreen,
+		imported_by, imported_at, screened_at`
+
+// scanScreeningList lê uma versão de lista a partir de uma linha
+func scanScreeningList(scanner interface{ Scan(...interface{}) error }) (*entities.ScreeningList, error) {
+	list := &entities.ScreeningList{}
+	err := scanner.Scan(
+		&list.ID, &list.Name, &list.Source, &list.Version, &list.Format, &list.Checksum, &list.EntryCount,
+		&list.IsActive, &list.NeedsRescreen, &list.ImportedBy, &list.ImportedAt, &list.ScreenedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CreateList grava a nova versão e seus endereços em uma transação. O worker aplica a troca de versão
+func (r *PostgresScreeningRepository) CreateList(ctx context.Context, list *entities.ScreeningList, entries []entities.ScreeningEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE screening_lists SET is_active = FALSE, needs_rescreen = TRUE
+		WHERE name = $1 AND is_active`, list.Name); err != nil {
+		return fmt.Errorf("erro ao desativar versão anterior da lista: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO screening_lists (name, source, version, format, checksum, entry_count, imported_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+screeningListColumns,
+		list.Name, list.Source, list.Version, list.Format, list.Checksum, len(entries), list.ImportedBy,
+	).Scan(
+		&list.ID, &list.Name, &list.Source, &list.Version, &list.Format, &list.Checksum, &list.EntryCount,
+		&list.IsActive, &list.NeedsRescreen, &list.ImportedBy, &list.ImportedAt, &list.ScreenedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar lista de triagem: %w", err)
+	}
+
+	for start := 0; start < len(entries); start += screeningEntriesBatch {
+		end := start + screeningEntriesBatch
+		if end > len(entries) {
+			end = len(entries)
+		}
+		addresses := make([]string, 0, end-start)
+		labels := make([]sql.NullString, 0, end-start)
+		for _, entry := range entries[start:end] {
+			addresses = append(addresses, entry.Address)
+			if entry.Label != nil {
+				labels = append(labels, sql.NullString{String: *entry.Label, Valid: true})
+			} else {
+				labels = append(labels, sql.NullString{})
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO screening_entries (list_id, address, label)
+			SELECT $1, address, label FROM UNNEST($2::text[], $3::text[]) AS e(address, label)
+		`, list.ID, pq.Array(addresses), pq.Array(labels)); err != nil {
+			return fmt.Errorf("erro ao gravar endereços da lista: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar importação da lista: %w", err)
+	}
+	return nil
+}
+
+// FindListByID busca uma versão de lista
+func (r *PostgresScreeningRepository) FindListByID(ctx context.Context, id int64) (*entities.ScreeningList, error) {
+	query := `SELECT ` + screeningListColumns + ` FROM screening_lists WHERE id = $1`
+
+	list, err := scanScreeningList(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar lista de triagem: %w", err)
+	}
+
+	return list, nil
+}
+
+// FindLists lista as versões das listas, das mais recentes para as mais antigas
+func (r *PostgresScreeningRepository) FindLists(ctx context.Context, name string, limit, offset int) ([]*entities.ScreeningList, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM screening_lists WHERE ($1 = '' OR name = $1)`, name,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar listas de triagem: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+screeningListColumns+` FROM screening_lists
+		WHERE ($1 = '' OR name = $1)
+		ORDER BY is_active DESC, imported_at DESC, id DESC
+		LIMIT $2 OFFSET $3`, name, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar listas de triagem: %w", err)
+	}
+	defer rows.Close()
+
+	var lists []*entities.ScreeningList
+	for rows.Next() {
+		list, err := scanScreeningList(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler lista de triagem: %w", err)
+		}
+		lists = append(lists, list)
+	}
+
+	return lists, total, rows.Err()
+}
+
+// FindEntries lista os endereços de uma versão
+func (r *PostgresScreeningRepository) FindEntries(ctx context.Context, listID int64, limit, offset int) ([]entities.ScreeningEntry, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM screening_entries WHERE list_id = $1`, listID,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar endereços da lista: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT address, label FROM screening_entries
+		WHERE list_id = $1
+		ORDER BY address
+		LIMIT $2 OFFSET $3`, listID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar endereços da lista: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []entities.ScreeningEntry{}
+	for rows.Next() {
+		var entry entities.ScreeningEntry
+		if err := rows.Scan(&entry.Address, &entry.Label); err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler endereço da lista: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, total, rows.Err()
+}
+
+// SetListActive ativa ou desativa uma versão e agenda a retriagem das versões alteradas
+func (r *PostgresScreeningRepository) SetListActive(ctx context.Context, id int64, active bool) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	var name string
+	var isActive bool
+	err = tx.QueryRowContext(ctx, `SELECT name, is_active FROM screening_lists WHERE id = $1 FOR UPDATE`, id).Scan(&name, &isActive)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao buscar lista de triagem: %w", err)
+	}
+	if isActive == active {
+		return true, tx.Commit()
+	}
+
+	if active {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE screening_lists SET is_active = FALSE, needs_rescreen = TRUE
+			WHERE name = $1 AND is_active`, name); err != nil {
+			return false, fmt.Errorf("erro ao desativar versão ativa da lista: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE screening_lists SET is_active = $2, needs_rescreen = TRUE WHERE id = $1`, id, active); err != nil {
+		return false, fmt.Errorf("erro ao atualizar lista de triagem: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("erro ao confirmar alteração da lista: %w", err)
+	}
+	return true, nil
+}
+
+// GetExposureReport monta o relatório de exposição da account nas listas ativas
+func (r *PostgresScreeningRepository) GetExposureReport(ctx context.Context, address string) (*entities.AccountExposureReport, error) {
+	report := &entities.AccountExposureReport{
+		Address:   address,
+		Listings:  []entities.ScreeningListing{},
+		Exposures: []entities.AccountExposure{},
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT compliance_status, compliance_source, compliance_notes FROM accounts WHERE address = $1
+	`, address).Scan(&report.ComplianceStatus, &report.ComplianceSource, &report.ComplianceNotes)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar account: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.id, l.name, l.version, l.source, se.label
+		FROM screening_entries se
+		JOIN screening_lists l ON l.id = se.list_id AND l.is_active
+		WHERE se.address = $1
+		ORDER BY l.name`, address)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar listas da account: %w", err)
+	}
+	for rows.Next() {
+		var listing entities.ScreeningListing
+		if err := rows.Scan(&listing.ListID, &listing.ListName, &listing.ListVersion, &listing.Source, &listing.Label); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("erro ao ler lista da account: %w", err)
+		}
+		report.Listings = append(report.Listings, listing)
+		report.MaxWeight = 1
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler listas da account: %w", err)
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+		SELECT x.list_id, l.name, l.version, x.listed_address, se.label, x.hops, x.weight, x.via_address,
+			x.tx_hash, x.block_number, x.kind, x.first_seen_at, x.last_seen_at
+		FROM account_exposures x
+		JOIN screening_lists l ON l.id = x.list_id AND l.is_active
+		LEFT JOIN screening_entries se ON se.list_id = x.list_id AND se.address = x.listed_address
+		WHERE x.address = $1
+		ORDER BY x.weight DESC, x.hops, x.last_seen_at DESC`, address)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar exposição da account: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var exposure entities.AccountExposure
+		if err := rows.Scan(
+			&exposure.ListID, &exposure.ListName, &exposure.ListVersion, &exposure.ListedAddress, &exposure.ListedLabel,
+			&exposure.Hops, &exposure.Weight, &exposure.ViaAddress, &exposure.TxHash, &exposure.BlockNumber,
+			&exposure.Kind, &exposure.FirstSeenAt, &exposure.LastSeenAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao ler exposição da account: %w", err)
+		}
+		if exposure.Weight > report.MaxWeight {
+			report.MaxWeight = exposure.Weight
+		}
+		report.Exposures = append(report.Exposures, exposure)
+	}
+
+	return report, rows.Err()
+}
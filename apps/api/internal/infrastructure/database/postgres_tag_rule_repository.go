@@ -0,0 +1,355 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+// tagRulePreviewTimeout limita o tempo da simulação, que percorre todas as accounts
+const tagRulePreviewTimeout = "15s"
+
+// PostgresTagRuleRepository implementa TagRuleRepository usando PostgreSQL
+type PostgresTagRuleRepository struct {
+	db             *sql.DB
+	nativeDecimals int
+}
+
+// NewPostgresTagRuleRepository cria uma nova instância do repositório. nativeDecimals são as casas decimais
+// da moeda nativa usadas na simulação das regras (as mesmas do worker)
+func NewPostgresTagRuleRepository(db *sql.DB, nativeDecimals int) repositories.TagRuleRepository {
+	if nativeDecimals < 0 {
+		nativeDecimals = 18
+	}
+	return &PostgresTagRuleRepository{db: db, nativeDecimals: nativeDecimals}
+}
+
+const tagRuleColumns = `r.id, r.tag, r.description, r.conditions, r.is_active, r.created_by, r.updated_by,
+	r.created_at, r.updated_at, r.evaluated_at,
+	(SELECT COUNT(*) FROM account_tags t WHERE t.tag = r.tag AND t.created_by = 'system') AS tagged_count`
+
+// scanTagRule lê uma regra de tag a partir de uma linha
+func scanTagRule(scanner interface{ Scan(...interface{}) error }) (*entities.TagRule, error) {
+	rule := &entities.TagRule{}
+	var conditions []byte
+	err := scanner.Scan(
+		&rule.ID, &rule.Tag, &rule.Description, &conditions, &rule.IsActive, &rule.CreatedBy, &rule.UpdatedBy,
+		&rule.CreatedAt, &rule.UpdatedAt, &rule.EvaluatedAt, &rule.TaggedCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(conditions, &rule.Conditions); err != nil {
+		return nil, fmt.Errorf("condições inválidas na regra %d: %w", rule.ID, err)
+	}
+	return rule, nil
+}
+
+// Create grava uma nova regra
+func (r *PostgresTagRuleRepository) Create(ctx context.Context, rule *entities.TagRule) error {
+	conditions, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar condições: %w", err)
+	}
+
+	query := `
+		INSERT INTO tag_rules (tag, description, conditions, is_active, created_by, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		RETURNING id, created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query, rule.Tag, rule.Description, conditions, rule.IsActive, rule.CreatedBy).
+		Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar regra de tag: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID busca uma regra de tag
+func (r *PostgresTagRuleRepository) FindByID(ctx context.Context, id int64) (*entities.TagRule, error) {
+	query := `SELECT ` + tagRuleColumns + ` FROM tag_rules r WHERE r.id = $1`
+
+	rule, err := scanTagRule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar regra de tag: %w", err)
+	}
+
+	return rule, nil
+}
+
+// FindAll lista as regras de tags com filtros e paginação
+func (r *PostgresTagRuleRepository) FindAll(ctx context.Context, filters *entities.TagRuleFilters, limit, offset int) ([]*entities.TagRule, int64, error) {
+	where := []string{"TRUE"}
+	var args []interface{}
+	if filters != nil {
+		if filters.Tag != "" {
+			args = append(args, "%"+strings.ToLower(filters.Tag)+"%")
+			where = append(where, fmt.Sprintf("r.tag LIKE $%d", len(args)))
+		}
+		if filters.IsActive != nil {
+			args = append(args, *filters.IsActive)
+			where = append(where, fmt.Sprintf("r.is_active = $%d", len(args)))
+		}
+	}
+	whereClause := strings.Join(where, " AND ")
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM tag_rules r WHERE `+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("erro ao contar regras de tags: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM tag_rules r WHERE %s ORDER BY r.tag LIMIT $%d OFFSET $%d`,
+		tagRuleColumns, whereClause, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar regras de tags: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.TagRule
+	for rows.Next() {
+		rule, err := scanTagRule(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("erro ao ler regra de tag: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, total, rows.Err()
+}
+
+// Update grava a regra e avança updated_at, o que faz o worker reavaliá-la em todas as accounts
+func (r *PostgresTagRuleRepository) Update(ctx context.Context, rule *entities.TagRule) (bool, error) {
+	conditions, err := json.Marshal(rule.Conditions)
+	if err != nil {
+		return false, fmt.Errorf("erro ao serializar condições: %w", err)
+	}
+
+	query := `
+		UPDATE tag_rules
+		SET description = $2, conditions = $3, is_active = $4, updated_by = $5, updated_at = NOW()
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, rule.ID, rule.Description, conditions, rule.IsActive, rule.UpdatedBy)
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar regra de tag: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar regra de tag: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// Delete remove uma regra de tag
+func (r *PostgresTagRuleRepository) Delete(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tag_rules WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("erro ao remover regra de tag: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("erro ao remover regra de tag: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// Preview avalia as condições sobre todas as accounts em uma transação somente leitura com timeout
+func (r *PostgresTagRuleRepository) Preview(ctx context.Context, tag string, conditions []entities.TagCondition, excludeID int64, sampleSize int) (*entities.TagRulePreview, error) {
+	// $1 = tamanho da amostra, $2 = tag; as condições usam os parâmetros seguintes
+	expr, args, err := compileTagConditions(conditions, r.nativeDecimals, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SET LOCAL statement_timeout = '`+tagRulePreviewTimeout+`'`); err != nil {
+		return nil, fmt.Errorf("erro ao configurar timeout da simulação: %w", err)
+	}
+
+	// A tag continua nas accounts que casam com outra regra ativa da mesma tag
+	keptExpr := "FALSE"
+	if tag != "" {
+		others, err := r.otherRulesConditions(ctx, tx, tag, excludeID)
+		if err != nil {
+			return nil, err
+		}
+		parts := make([]string, 0, len(others))
+		for _, other := range others {
+			otherExpr, otherArgs, err := compileTagConditions(other, r.nativeDecimals, 3+len(args))
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, "("+otherExpr+")")
+			args = append(args, otherArgs...)
+		}
+		if len(parts) > 0 {
+			keptExpr = strings.Join(parts, " OR ")
+		}
+	}
+
+	query := fmt.Sprintf(`
+		WITH matched AS MATERIALIZED (
+			SELECT a.address FROM accounts a WHERE %s
+		),
+		kept AS MATERIALIZED (
+			SELECT a.address FROM accounts a
+			WHERE a.address IN (SELECT t.address FROM account_tags t WHERE t.tag = $2 AND t.created_by = 'system')
+				AND (%s)
+		)
+		SELECT
+			(SELECT COUNT(*) FROM matched),
+			COALESCE((SELECT ARRAY_AGG(s.address) FROM (SELECT address FROM matched ORDER BY address LIMIT $1) s), '{}'),
+			(SELECT COUNT(*) FROM matched m
+				WHERE NOT EXISTS (SELECT 1 FROM account_tags t WHERE t.address = m.address AND t.tag = $2)),
+			(SELECT COUNT(*) FROM account_tags t
+				WHERE t.tag = $2 AND t.created_by = 'system'
+					AND NOT EXISTS (SELECT 1 FROM matched m WHERE m.address = t.address)
+					AND NOT EXISTS (SELECT 1 FROM kept k WHERE k.address = t.address))
+	`, expr, keptExpr)
+
+	preview := &entities.TagRulePreview{Tag: tag}
+	var wouldAdd, wouldRemove int64
+	err = tx.QueryRowContext(ctx, query, append([]interface{}{sampleSize, tag}, args...)...).
+		Scan(&preview.MatchCount, pq.Array(&preview.Accounts), &wouldAdd, &wouldRemove)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao simular regra de tag: %w", err)
+	}
+
+	if tag != "" {
+		preview.WouldAdd = &wouldAdd
+		preview.WouldRemove = &wouldRemove
+	}
+	return preview, nil
+}
+
+// otherRulesConditions lê as condições das regras ativas da tag, exceto a regra excludeID. Regras com
+// condições inválidas são ignoradas, como no worker
+func (r *PostgresTagRuleRepository) otherRulesConditions(ctx context.Context, tx *sql.Tx, tag string, excludeID int64) ([][]entities.TagCondition, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT conditions FROM tag_rules WHERE tag = $1 AND is_active AND id <> $2`, tag, excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar regras da tag: %w", err)
+	}
+	defer rows.Close()
+
+	var result [][]entities.TagCondition
+	for rows.Next() {
+		var content []byte
+		if err := rows.Scan(&content); err != nil {
+			return nil, fmt.Errorf("erro ao ler regra da tag: %w", err)
+		}
+		if conditions, err := entities.ParseTagConditions(content); err == nil {
+			result = append(result, conditions)
+		}
+	}
+	return result, rows.Err()
+}
+
+// compileTagConditions converte as condições em uma expressão booleana sobre a account a, com os parâmetros
+// a partir de $firstArg. Mesma tradução usada pelo AccountTaggingService do worker
+func compileTagConditions(conditions []entities.TagCondition, nativeDecimals, firstArg int) (string, []interface{}, error) {
+	var args []interface{}
+	next := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", firstArg+len(args)-1)
+	}
+
+	parts := make([]string, 0, len(conditions))
+	for i := range conditions {
+		part, err := compileTagCondition(&conditions[i], nativeDecimals, next)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "", nil, fmt.Errorf("regra sem condições")
+	}
+
+	return strings.Join(parts, " AND "), args, nil
+}
+
+// compileTagCondition converte uma condição em uma expressão booleana sobre a account a
+func compileTagCondition(c *entities.TagCondition, nativeDecimals int, next func(interface{}) string) (string, error) {
+	native := func(column string) string {
+		return fmt.Sprintf(`(CASE WHEN %[1]s ~ '^[0-9]+$' THEN %[1]s::NUMERIC ELSE 0 END / 10::NUMERIC ^ %[2]d)`, column, nativeDecimals)
+	}
+
+	var metric string
+	switch c.Metric {
+	case "account.balance":
+		metric = native("a.balance")
+	case "account.transaction_count":
+		metric = `a.transaction_count`
+	case "account.contract_interactions":
+		metric = `a.contract_interactions`
+	case "account.contract_deployments":
+		metric = `a.smart_contract_deployments`
+	case "account.is_contract":
+		metric = `CASE WHEN a.is_contract THEN 1 ELSE 0 END`
+	case "account.age_days":
+		metric = `EXTRACT(EPOCH FROM NOW() - a.first_seen) / 86400`
+	case "account.inactive_days":
+		metric = `EXTRACT(EPOCH FROM NOW() - COALESCE(a.last_activity, a.first_seen)) / 86400`
+	case "account.type":
+		metric = `a.account_type`
+	case "account.contract_type":
+		metric = `a.contract_type`
+	case "methods.unique_contracts":
+		metric = `(SELECT COUNT(DISTINCT ci.contract_address) FROM contract_interactions ci WHERE ci.account_address = a.address)`
+	case "methods.calls":
+		metric = fmt.Sprintf(`(SELECT SUM(ci.interactions_count) FROM contract_interactions ci
+			WHERE ci.account_address = a.address AND ci.method = %s)`, next(c.Method))
+	case "methods.most_used":
+		metric = `(SELECT ci.method FROM contract_interactions ci WHERE ci.account_address = a.address
+			ORDER BY ci.interactions_count DESC, ci.method LIMIT 1)`
+	case "tokens.count":
+		metric = `(SELECT COUNT(*) FROM token_holdings th
+			WHERE th.account_address = a.address AND th.balance ~ '^[0-9]+$' AND th.balance::NUMERIC > 0)`
+	case "tokens.balance":
+		metric = fmt.Sprintf(`(SELECT SUM(th.balance::NUMERIC / 10::NUMERIC ^ th.token_decimals) FROM token_holdings th
+			WHERE th.account_address = a.address AND th.token_address = %s AND th.balance ~ '^[0-9]+$')`, next(strings.ToLower(c.Token)))
+	case "activity.success_rate":
+		metric = `(SELECT AVG(aa.success_rate) FROM account_analytics aa WHERE aa.address = a.address)`
+	case "activity.failed_count":
+		metric = `(SELECT COUNT(*) FROM account_transactions atx WHERE atx.account_address = a.address AND atx.status = 'failed')`
+	case "activity.max_tx_value":
+		metric = fmt.Sprintf(`(SELECT MAX(%s) FROM account_transactions atx WHERE atx.account_address = a.address)`, native("atx.value"))
+	default:
+		return "", fmt.Errorf("métrica desconhecida: %s", c.Metric)
+	}
+
+	spec := entities.TagMetrics[c.Metric]
+	operator, ok := entities.TagOperators[spec.Kind][c.Op]
+	if !ok {
+		return "", fmt.Errorf("operador %s inválido para %s", c.Op, c.Metric)
+	}
+
+	if spec.Kind == entities.TagMetricText {
+		match := fmt.Sprintf(`COALESCE(%s, '') = ANY(%s::TEXT[])`, metric, next(pq.Array(c.Values)))
+		if operator == "NOT IN" {
+			return "NOT (" + match + ")", nil
+		}
+		return "(" + match + ")", nil
+	}
+	return fmt.Sprintf(`(COALESCE(%s, 0)::NUMERIC %s %s::NUMERIC)`, metric, operator, next(*c.Value)), nil
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math/big"
 
@@ -23,19 +24,36 @@ func NewPostgresTransactionRepository(db *sql.DB) repositories.TransactionReposi
 	return &PostgresTransactionRepository{db: db}
 }
 
-// FindByHash busca uma transação pelo hash
+// FindByHash busca uma transação pelo hash. O bloco vem antes de transaction_hashes para que a consulta
+// em transactions leia apenas a partição do bloco (ou a default, para transações pendentes)
 func (r *PostgresTransactionRepository) FindByHash(ctx context.Context, hash string) (*entities.Transaction, error) {
+	var blockNumber sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `SELECT block_number FROM transaction_hashes WHERE hash = $1`, hash).Scan(&blockNumber)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blockFilter := "t.block_number IS NULL"
+	args := []interface{}{hash}
+	if blockNumber.Valid {
+		blockFilter = "t.block_number = $2"
+		args = append(args, blockNumber.Int64)
+	}
+
 	query := `
 		SELECT t.hash, t.block_number, t.block_hash, t.transaction_index, t.from_address, t.to_address,
 			   t.value, t.gas_limit, t.gas_used, t.gas_price, t.max_fee_per_gas, t.max_priority_fee_per_gas,
 			   t.nonce, t.data, t.transaction_type, t.status, t.contract_address,
 			   t.created_at, t.updated_at, t.mined_at,
-			   tm.method_name, tm.method_type
+			   tm.method_name, tm.method_type, t.revert_reason
 		FROM transactions t
 		LEFT JOIN transaction_methods tm ON t.hash = tm.transaction_hash
-		WHERE t.hash = $1`
+		WHERE t.hash = $1 AND ` + blockFilter
 
-	return r.scanTransaction(r.db.QueryRowContext(ctx, query, hash))
+	return r.scanTransaction(r.db.QueryRowContext(ctx, query, args...))
 }
 
 // FindRecent busca as N transações mais recentes
@@ -213,6 +231,16 @@ func (r *PostgresTransactionRepository) CountWithFilters(ctx context.Context, wh
 	return count, err
 }
 
+// EstimateCountWithFilters conta transações com filtros, usando estimativa do planner em resultados grandes
+func (r *PostgresTransactionRepository) EstimateCountWithFilters(ctx context.Context, whereClause string, args []interface{}) (int64, bool, error) {
+	fromClause := "FROM transactions t"
+	if whereClause != "" {
+		fromClause += " WHERE " + whereClause
+	}
+
+	return EstimateCount(ctx, r.db, fromClause, args)
+}
+
 // Count retorna o número total de transações
 func (r *PostgresTransactionRepository) Count(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM transactions`
@@ -231,9 +259,10 @@ func (r *PostgresTransactionRepository) CountByStatus(ctx context.Context, statu
 	return count, err
 }
 
-// Exists verifica se uma transação existe
+// Exists verifica se uma transação existe. transaction_hashes tem um hash por transação (mantido por
+// triggers em transactions), então a consulta não percorre as partições
 func (r *PostgresTransactionRepository) Exists(ctx context.Context, hash string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM transactions WHERE hash = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM transaction_hashes WHERE hash = $1)`
 
 	var exists bool
 	err := r.db.QueryRowContext(ctx, query, hash).Scan(&exists)
@@ -274,6 +303,7 @@ func (r *PostgresTransactionRepository) scanTransaction(row *sql.Row) (*entities
 	var transaction entities.Transaction
 	var value, gasPrice, maxFeePerGas, maxPriorityFeePerGas *string
 	var methodName, methodType *string
+	var revertReason []byte
 
 	err := row.Scan(
 		&transaction.Hash, &transaction.BlockNumber, &transaction.BlockHash,
@@ -283,7 +313,7 @@ func (r *PostgresTransactionRepository) scanTransaction(row *sql.Row) (*entities
 		&transaction.Data, &transaction.Type, &transaction.Status,
 		&transaction.ContractAddress, &transaction.CreatedAt,
 		&transaction.UpdatedAt, &transaction.MinedAt,
-		&methodName, &methodType,
+		&methodName, &methodType, &revertReason,
 	)
 
 	if err != nil {
@@ -293,6 +323,14 @@ func (r *PostgresTransactionRepository) scanTransaction(row *sql.Row) (*entities
 		return nil, err
 	}
 
+	// Motivo da falha gravado pelo worker
+	if len(revertReason) > 0 {
+		transaction.RevertReason = new(entities.RevertReason)
+		if err := json.Unmarshal(revertReason, transaction.RevertReason); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar revert_reason: %w", err)
+		}
+	}
+
 	// Converter strings para big.Int
 	if value != nil {
 		transaction.Value = new(big.Int)
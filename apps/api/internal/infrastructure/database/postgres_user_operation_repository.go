@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/domain/repositories"
+)
+
+// PostgresUserOperationRepository implementa UserOperationRepository usando PostgreSQL
+type PostgresUserOperationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserOperationRepository cria uma nova instância do repositório
+func NewPostgresUserOperationRepository(db *sql.DB) repositories.UserOperationRepository {
+	return &PostgresUserOperationRepository{db: db}
+}
+
+const userOperationColumns = `user_op_hash, entry_point, entry_point_version, tx_hash, block_number, log_index,
+	mined_at, sender, paymaster, bundler, beneficiary, factory, nonce, success, actual_gas_cost, actual_gas_used,
+	call_gas_limit, verification_gas_limit, pre_verification_gas, max_fee_per_gas, max_priority_fee_per_gas,
+	call_selector, revert_reason, created_at`
+
+// scanUserOperation lê uma UserOperation a partir de uma linha
+func scanUserOperation(scanner interface{ Scan(...interface{}) error }) (*entities.UserOperation, error) {
+	op := &entities.UserOperation{}
+	err := scanner.Scan(
+		&op.Hash, &op.EntryPoint, &op.EntryPointVersion, &op.TxHash, &op.BlockNumber, &op.LogIndex,
+		&op.MinedAt, &op.Sender, &op.Paymaster, &op.Bundler, &op.Beneficiary, &op.Factory, &op.Nonce, &op.Success,
+		&op.ActualGasCost, &op.ActualGasUsed, &op.CallGasLimit, &op.VerificationGasLimit, &op.PreVerificationGas,
+		&op.MaxFeePerGas, &op.MaxPriorityFeePerGas, &op.CallSelector, &op.RevertReason, &op.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// FindByHash busca uma UserOperation pelo hash
+func (r *PostgresUserOperationRepository) FindByHash(ctx context.Context, hash string) (*entities.UserOperation, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+userOperationColumns+` FROM user_operations WHERE user_op_hash = $1`,
+		strings.ToLower(hash))
+	op, err := scanUserOperation(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar UserOperation: %w", err)
+	}
+	return op, nil
+}
+
+// FindAll lista as UserOperations pelos filtros, da mais recente para a mais antiga
+func (r *PostgresUserOperationRepository) FindAll(ctx context.Context, filters *entities.UserOperationFilters, limit, offset int) ([]*entities.UserOperation, int64, bool, error) {
+	where := []string{"TRUE"}
+	var args []interface{}
+	if filters != nil {
+		for _, filter := range []struct{ column, value string }{
+			{"sender", filters.Sender},
+			{"paymaster", filters.Paymaster},
+			{"bundler", filters.Bundler},
+			{"entry_point", filters.EntryPoint},
+			{"tx_hash", filters.TxHash},
+		} {
+			if filter.value != "" {
+				args = append(args, strings.ToLower(filter.value))
+				where = append(where, fmt.Sprintf("%s = $%d", filter.column, len(args)))
+			}
+		}
+		if filters.Success != nil {
+			args = append(args, *filters.Success)
+			where = append(where, fmt.Sprintf("success = $%d", len(args)))
+		}
+		if filters.FromBlock != nil {
+			args = append(args, int64(*filters.FromBlock))
+			where = append(where, fmt.Sprintf("block_number >= $%d", len(args)))
+		}
+		if filters.ToBlock != nil {
+			args = append(args, int64(*filters.ToBlock))
+			where = append(where, fmt.Sprintf("block_number <= $%d", len(args)))
+		}
+	}
+	fromClause := "FROM user_operations WHERE " + strings.Join(where, " AND ")
+
+	total, approximate, err := EstimateCount(ctx, r.db, fromClause, args)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s %s ORDER BY block_number DESC, log_index DESC LIMIT $%d OFFSET $%d`,
+		userOperationColumns, fromClause, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("erro ao listar UserOperations: %w", err)
+	}
+	defer rows.Close()
+
+	var ops []*entities.UserOperation
+	for rows.Next() {
+		op, err := scanUserOperation(rows)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("erro ao ler UserOperation: %w", err)
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, total, approximate, rows.Err()
+}
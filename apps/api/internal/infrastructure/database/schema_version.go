@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// RequiredSchemaVersion é a menor versão de schema (migrations do worker) com a qual a API funciona.
+// Deve ser atualizada sempre que a API passar a depender de uma migration nova
+const RequiredSchemaVersion = 14
+
+// CheckSchemaVersion verifica se as migrations aplicadas no banco atendem à API.
+// Versões mais novas são aceitas: o worker aplica as migrations antes de a API ser atualizada
+func CheckSchemaVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	var exists bool
+	if err := db.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("erro ao verificar schema_migrations: %w", err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("tabela schema_migrations não encontrada: execute \"worker migrate up\" ou \"worker migrate baseline\"")
+	}
+
+	var version int64
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("erro ao ler versão do schema: %w", err)
+	}
+	if version < RequiredSchemaVersion {
+		return version, fmt.Errorf("schema na versão %d, a API exige a versão %d: execute \"worker migrate up\"", version, RequiredSchemaVersion)
+	}
+
+	return version, nil
+}
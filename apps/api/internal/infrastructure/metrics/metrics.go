@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "besuscan_api"
+
+var (
+	// HTTPRequestDuration mede a latência das requisições HTTP por método, rota do Gin e status
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latência das requisições HTTP por método, rota e status",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestsInFlight indica quantas requisições HTTP estão em andamento
+	HTTPRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Requisições HTTP em andamento",
+	})
+)
+
+// RegisterWebSocketClients expõe a quantidade de clientes WebSocket conectados
+func RegisterWebSocketClients(count func() int) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "websocket_clients",
+		Help:      "Clientes WebSocket conectados ao hub",
+	}, func() float64 { return float64(count()) })
+}
+
+// RegisterDatabase expõe as estatísticas do pool de conexões do PostgreSQL
+func RegisterDatabase(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "explorer"))
+}
+
+// Handler retorna o handler HTTP que serve as métricas no formato do Prometheus
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
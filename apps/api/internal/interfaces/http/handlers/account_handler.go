@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -1132,11 +1133,16 @@ func (h *AccountHandler) GetAccountTransactions(c *gin.Context) {
 			filters.Limit = limit
 		}
 	}
+	filters.Cursor = c.Query("cursor")
 
 	// Buscar transações usando o serviço com filtros
 	result, err := h.accountService.GetAccountTransactionsWithFilters(c.Request.Context(), address, filters)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
 			"success": false,
 			"error":   "Failed to fetch account transactions: " + err.Error(),
 		})
@@ -1147,10 +1153,12 @@ func (h *AccountHandler) GetAccountTransactions(c *gin.Context) {
 		"success": true,
 		"data":    result.Data,
 		"pagination": gin.H{
-			"page":        result.Page,
-			"limit":       result.Limit,
-			"total":       result.Total,
-			"total_pages": result.TotalPages,
+			"page":              result.Page,
+			"limit":             result.Limit,
+			"total":             result.Total,
+			"total_pages":       result.TotalPages,
+			"total_approximate": result.TotalApproximate,
+			"next_cursor":       result.NextCursor,
 		},
 	})
 }
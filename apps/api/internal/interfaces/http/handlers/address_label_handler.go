@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAddressLabelImportSize limita o tamanho do CSV de labels importado
+const maxAddressLabelImportSize = 8 << 20
+
+// AddressLabelHandler gerencia as rotas HTTP dos labels públicos de endereços
+type AddressLabelHandler struct {
+	labelService *services.AddressLabelService
+}
+
+// NewAddressLabelHandler cria uma nova instância do handler de labels
+func NewAddressLabelHandler(labelService *services.AddressLabelService) *AddressLabelHandler {
+	return &AddressLabelHandler{
+		labelService: labelService,
+	}
+}
+
+// respondAddressLabelError converte erros do serviço em respostas HTTP
+func (h *AddressLabelHandler) respondAddressLabelError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAddressLabelNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidAddressLabel):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAddressLabelConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseAddressLabelFilters lê os filtros comuns das listagens de labels
+func parseAddressLabelFilters(c *gin.Context) (*entities.AddressLabelFilters, bool) {
+	filters := &entities.AddressLabelFilters{
+		Address:  c.Query("address"),
+		Category: c.Query("category"),
+		Query:    strings.TrimSpace(c.Query("q")),
+	}
+	if verified := c.Query("verified"); verified != "" {
+		value, err := strconv.ParseBool(verified)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'verified' inválido"})
+			return nil, false
+		}
+		filters.Verified = &value
+	}
+	return filters, true
+}
+
+// respondAddressLabelPage envia uma página de labels
+func respondAddressLabelPage(c *gin.Context, result *services.PaginatedResult[*entities.AddressLabel]) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// GetAddressLabels lista os labels aprovados
+// GET /api/labels?category=bridge&q=ponte&verified=true&address=0x...&page=1&limit=20
+func (h *AddressLabelHandler) GetAddressLabels(c *gin.Context) {
+	page, limit := parseAlertPagination(c)
+	filters, ok := parseAddressLabelFilters(c)
+	if !ok {
+		return
+	}
+	filters.Status = string(entities.AddressLabelApproved)
+
+	result, err := h.labelService.ListLabels(c.Request.Context(), filters, page, limit)
+	if err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+
+	respondAddressLabelPage(c, result)
+}
+
+// GetAddressLabelReviewQueue lista os labels por estado de moderação (padrão: sugestões pendentes)
+// GET /api/labels/review?status=pending&page=1&limit=20
+func (h *AddressLabelHandler) GetAddressLabelReviewQueue(c *gin.Context) {
+	page, limit := parseAlertPagination(c)
+	filters, ok := parseAddressLabelFilters(c)
+	if !ok {
+		return
+	}
+	filters.Status = c.DefaultQuery("status", string(entities.AddressLabelPending))
+
+	result, err := h.labelService.ListLabels(c.Request.Context(), filters, page, limit)
+	if err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+
+	respondAddressLabelPage(c, result)
+}
+
+// GetAddressLabel retorna um label. Labels não aprovados só são visíveis para admins e para quem os sugeriu
+// GET /api/labels/:id
+func (h *AddressLabelHandler) GetAddressLabel(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	label, err := h.labelService.GetLabel(c.Request.Context(), id)
+	if err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+	if label.Status != entities.AddressLabelApproved && !middleware.IsAdmin(c) &&
+		label.SubmittedBy != strconv.Itoa(middleware.GetCurrentUserID(c)) {
+		h.respondAddressLabelError(c, services.ErrAddressLabelNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    label,
+	})
+}
+
+// CreateAddressLabel cria um label aprovado, substituindo o label aprovado anterior do endereço
+// POST /api/labels
+func (h *AddressLabelHandler) CreateAddressLabel(c *gin.Context) {
+	var request entities.AddressLabelRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	actor := strconv.Itoa(middleware.GetCurrentUserID(c))
+	label, err := h.labelService.CreateLabel(c.Request.Context(), &request, actor)
+	if err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    label,
+	})
+}
+
+// SuggestAddressLabel registra a sugestão de label de um usuário para moderação
+// POST /api/labels/suggestions
+func (h *AddressLabelHandler) SuggestAddressLabel(c *gin.Context) {
+	var request entities.AddressLabelRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	actor := strconv.Itoa(middleware.GetCurrentUserID(c))
+	label, err := h.labelService.SuggestLabel(c.Request.Context(), &request, actor)
+	if err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    label,
+	})
+}
+
+// ImportAddressLabels importa labels aprovados de um CSV address,name,category[,description,source,url,verified]
+// (corpo da requisição ou campo multipart 'file')
+// POST /api/labels/import
+func (h *AddressLabelHandler) ImportAddressLabels(c *gin.Context) {
+	body := io.Reader(c.Request.Body)
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Campo 'file' obrigatório"})
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao abrir o arquivo: " + err.Error()})
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	content, err := io.ReadAll(io.LimitReader(body, maxAddressLabelImportSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao ler o arquivo: " + err.Error()})
+		return
+	}
+	if len(content) > maxAddressLabelImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Arquivo maior que 8 MB"})
+		return
+	}
+
+	actor := strconv.Itoa(middleware.GetCurrentUserID(c))
+	result, err := h.labelService.ImportLabels(c.Request.Context(), content, actor)
+	if err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateAddressLabel altera nome, categoria, descrição, fonte, URL ou verificação de um label
+// PUT /api/labels/:id
+func (h *AddressLabelHandler) UpdateAddressLabel(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.UpdateAddressLabelRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	label, err := h.labelService.UpdateLabel(c.Request.Context(), id, &request)
+	if err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    label,
+	})
+}
+
+// ApproveAddressLabel aprova uma sugestão pendente
+// POST /api/labels/:id/approve
+func (h *AddressLabelHandler) ApproveAddressLabel(c *gin.Context) {
+	h.reviewAddressLabel(c, true)
+}
+
+// RejectAddressLabel rejeita uma sugestão pendente
+// POST /api/labels/:id/reject
+func (h *AddressLabelHandler) RejectAddressLabel(c *gin.Context) {
+	h.reviewAddressLabel(c, false)
+}
+
+// reviewAddressLabel registra a decisão de moderação com a nota opcional
+func (h *AddressLabelHandler) reviewAddressLabel(c *gin.Context, approve bool) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.ReviewAddressLabelRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Dados inválidos: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	actor := strconv.Itoa(middleware.GetCurrentUserID(c))
+	label, err := h.labelService.ReviewLabel(c.Request.Context(), id, approve, request.Note, actor)
+	if err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    label,
+	})
+}
+
+// DeleteAddressLabel remove um label
+// DELETE /api/labels/:id
+func (h *AddressLabelHandler) DeleteAddressLabel(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.labelService.DeleteLabel(c.Request.Context(), id); err != nil {
+		h.respondAddressLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Label removido",
+	})
+}
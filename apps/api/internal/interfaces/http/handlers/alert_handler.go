@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertHandler gerencia as rotas HTTP de regras de alerta (webhooks)
+type AlertHandler struct {
+	alertService *services.AlertService
+}
+
+// NewAlertHandler cria uma nova instância do handler de alertas
+func NewAlertHandler(alertService *services.AlertService) *AlertHandler {
+	return &AlertHandler{
+		alertService: alertService,
+	}
+}
+
+// currentOwner monta o dono das regras a partir do usuário autenticado
+func (h *AlertHandler) currentOwner(c *gin.Context) services.AlertOwner {
+	return services.AlertOwner{
+		UserID:  strconv.Itoa(middleware.GetCurrentUserID(c)),
+		IsAdmin: middleware.IsAdmin(c),
+	}
+}
+
+// respondAlertError converte erros do serviço em respostas HTTP
+func (h *AlertHandler) respondAlertError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAlertRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidAlertRule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseAlertID lê um parâmetro numérico da rota
+func parseAlertID(c *gin.Context, param string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro '" + param + "' inválido"})
+		return 0, false
+	}
+	return id, true
+}
+
+// parseAlertPagination lê page e limit da query string
+func parseAlertPagination(c *gin.Context) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+// GetAlertRules lista as regras de alerta do usuário
+// GET /api/alerts?page=1&limit=20
+func (h *AlertHandler) GetAlertRules(c *gin.Context) {
+	page, limit := parseAlertPagination(c)
+
+	result, err := h.alertService.ListRules(c.Request.Context(), h.currentOwner(c), page, limit)
+	if err != nil {
+		h.respondAlertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// CreateAlertRule cria uma nova regra de alerta
+// POST /api/alerts
+func (h *AlertHandler) CreateAlertRule(c *gin.Context) {
+	var request entities.CreateAlertRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.alertService.CreateRule(c.Request.Context(), h.currentOwner(c), &request)
+	if err != nil {
+		h.respondAlertError(c, err)
+		return
+	}
+
+	// O segredo é retornado apenas na criação para configuração do receptor
+	c.JSON(http.StatusCreated, gin.H{
+		"success":        true,
+		"data":           rule,
+		"webhook_secret": rule.WebhookSecret,
+	})
+}
+
+// GetAlertRule retorna uma regra de alerta específica
+// GET /api/alerts/:id
+func (h *AlertHandler) GetAlertRule(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	rule, err := h.alertService.GetRule(c.Request.Context(), h.currentOwner(c), id)
+	if err != nil {
+		h.respondAlertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rule,
+	})
+}
+
+// UpdateAlertRule atualiza uma regra de alerta
+// PUT /api/alerts/:id
+func (h *AlertHandler) UpdateAlertRule(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.UpdateAlertRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.alertService.UpdateRule(c.Request.Context(), h.currentOwner(c), id, &request)
+	if err != nil {
+		h.respondAlertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rule,
+	})
+}
+
+// DeleteAlertRule remove uma regra de alerta
+// DELETE /api/alerts/:id
+func (h *AlertHandler) DeleteAlertRule(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.alertService.DeleteRule(c.Request.Context(), h.currentOwner(c), id); err != nil {
+		h.respondAlertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Regra de alerta removida",
+	})
+}
+
+// GetAlertDeliveries retorna o log de entregas de uma regra
+// GET /api/alerts/:id/deliveries?status=failed&page=1&limit=20
+func (h *AlertHandler) GetAlertDeliveries(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", "pending", "delivered", "failed":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Status inválido. Use: pending, delivered, failed",
+		})
+		return
+	}
+
+	page, limit := parseAlertPagination(c)
+
+	result, err := h.alertService.ListDeliveries(c.Request.Context(), h.currentOwner(c), id, status, page, limit)
+	if err != nil {
+		h.respondAlertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// RetryAlertDelivery reagenda uma entrega para nova tentativa
+// POST /api/alerts/:id/deliveries/:deliveryId/retry
+func (h *AlertHandler) RetryAlertDelivery(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseAlertID(c, "deliveryId")
+	if !ok {
+		return
+	}
+
+	if err := h.alertService.RetryDelivery(c.Request.Context(), h.currentOwner(c), id, deliveryID); err != nil {
+		h.respondAlertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Entrega reagendada",
+	})
+}
@@ -64,6 +64,7 @@ func (h *BlockHandler) GetBlocks(c *gin.Context) {
 
 // GetBlocksWithFilters retorna blocos com filtros avançados
 // GET /api/blocks/search?miner=0x...&min_gas_used=1000&order_by=timestamp&page=1&limit=20
+// Paginação por keyset: repassar next_cursor em ?cursor= (ordenação por bloco)
 func (h *BlockHandler) GetBlocksWithFilters(c *gin.Context) {
 	// Construir filtros a partir dos query parameters
 	filters := &services.BlockFilters{}
@@ -143,6 +144,7 @@ func (h *BlockHandler) GetBlocksWithFilters(c *gin.Context) {
 			filters.Limit = limit
 		}
 	}
+	filters.Cursor = c.Query("cursor")
 
 	// Buscar blocos com filtros
 	result, err := h.blockService.GetBlocksWithFilters(c.Request.Context(), filters)
@@ -157,10 +159,12 @@ func (h *BlockHandler) GetBlocksWithFilters(c *gin.Context) {
 		"success": true,
 		"data":    result.Data,
 		"pagination": gin.H{
-			"page":        result.Page,
-			"limit":       result.Limit,
-			"total":       result.Total,
-			"total_pages": result.TotalPages,
+			"page":              result.Page,
+			"limit":             result.Limit,
+			"total":             result.Total,
+			"total_pages":       result.TotalPages,
+			"total_approximate": result.TotalApproximate,
+			"next_cursor":       result.NextCursor,
 		},
 		"filters": filters,
 	})
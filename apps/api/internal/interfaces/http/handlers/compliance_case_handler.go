@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ComplianceCaseHandler gerencia as rotas HTTP dos casos de compliance
+type ComplianceCaseHandler struct {
+	caseService *services.ComplianceCaseService
+}
+
+// NewComplianceCaseHandler cria uma nova instância do handler de casos de compliance
+func NewComplianceCaseHandler(caseService *services.ComplianceCaseService) *ComplianceCaseHandler {
+	return &ComplianceCaseHandler{
+		caseService: caseService,
+	}
+}
+
+// currentActor identifica o usuário autenticado no histórico dos casos
+func (h *ComplianceCaseHandler) currentActor(c *gin.Context) string {
+	return strconv.Itoa(middleware.GetCurrentUserID(c))
+}
+
+// respondCaseError converte erros do serviço em respostas HTTP
+func (h *ComplianceCaseHandler) respondCaseError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrComplianceCaseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidComplianceCase):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrComplianceCaseConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetComplianceCases lista os casos de compliance
+// GET /api/compliance/cases?state=open&priority=high&origin=screening&assigned_to=3&address=0x...&page=1&limit=20
+func (h *ComplianceCaseHandler) GetComplianceCases(c *gin.Context) {
+	page, limit := parseAlertPagination(c)
+
+	filters := &entities.ComplianceCaseFilters{
+		State:    c.Query("state"),
+		Priority: c.Query("priority"),
+		Origin:   c.Query("origin"),
+		Address:  c.Query("address"),
+	}
+	if assignedTo := c.Query("assigned_to"); assignedTo != "" {
+		userID, err := strconv.Atoi(assignedTo)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'assigned_to' inválido"})
+			return
+		}
+		filters.AssignedTo = &userID
+	}
+
+	result, err := h.caseService.ListCases(c.Request.Context(), filters, page, limit)
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// GetComplianceCase retorna um caso com endereços, transações e notas
+// GET /api/compliance/cases/:id
+func (h *ComplianceCaseHandler) GetComplianceCase(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	cc, err := h.caseService.GetCase(c.Request.Context(), id)
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    cc,
+	})
+}
+
+// GetComplianceCaseHistory lista o histórico completo de alterações de um caso
+// GET /api/compliance/cases/:id/history?page=1&limit=20
+func (h *ComplianceCaseHandler) GetComplianceCaseHistory(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+	page, limit := parseAlertPagination(c)
+
+	result, err := h.caseService.ListEvents(c.Request.Context(), id, page, limit)
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// CreateComplianceCase abre um caso manualmente
+// POST /api/compliance/cases
+func (h *ComplianceCaseHandler) CreateComplianceCase(c *gin.Context) {
+	var request entities.CreateComplianceCaseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	cc, err := h.caseService.CreateCase(c.Request.Context(), &request, h.currentActor(c))
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    cc,
+	})
+}
+
+// TransitionComplianceCase muda o estado do caso e o status de compliance das accounts investigadas
+// POST /api/compliance/cases/:id/transition
+func (h *ComplianceCaseHandler) TransitionComplianceCase(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.TransitionComplianceCaseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	cc, err := h.caseService.TransitionCase(c.Request.Context(), id, &request, h.currentActor(c))
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    cc,
+	})
+}
+
+// AssignComplianceCase atribui o caso a um usuário
+// PUT /api/compliance/cases/:id/assignee
+func (h *ComplianceCaseHandler) AssignComplianceCase(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.AssignComplianceCaseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	cc, err := h.caseService.AssignCase(c.Request.Context(), id, request.AssignedTo, h.currentActor(c))
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    cc,
+	})
+}
+
+// AddComplianceCaseAddress vincula um endereço ao caso
+// POST /api/compliance/cases/:id/addresses
+func (h *ComplianceCaseHandler) AddComplianceCaseAddress(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.CaseAddressRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	cc, err := h.caseService.AddAddress(c.Request.Context(), id, &request, h.currentActor(c))
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    cc,
+	})
+}
+
+// AddComplianceCaseTransaction vincula uma transação ao caso
+// POST /api/compliance/cases/:id/transactions
+func (h *ComplianceCaseHandler) AddComplianceCaseTransaction(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.CaseTransactionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	cc, err := h.caseService.AddTransaction(c.Request.Context(), id, &request, h.currentActor(c))
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    cc,
+	})
+}
+
+// AddComplianceCaseNote adiciona uma nota de investigação
+// POST /api/compliance/cases/:id/notes
+func (h *ComplianceCaseHandler) AddComplianceCaseNote(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.AddComplianceCaseNoteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	note, err := h.caseService.AddNote(c.Request.Context(), id, request.Body, h.currentActor(c))
+	if err != nil {
+		h.respondCaseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    note,
+	})
+}
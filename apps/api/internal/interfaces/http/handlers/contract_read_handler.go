@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContractReadHandler gerencia a leitura de funções view/pure dos contratos pela API
+type ContractReadHandler struct {
+	contractCallService *services.ContractCallService
+}
+
+// NewContractReadHandler cria uma nova instância do handler de leitura de contratos
+func NewContractReadHandler(contractCallService *services.ContractCallService) *ContractReadHandler {
+	return &ContractReadHandler{
+		contractCallService: contractCallService,
+	}
+}
+
+// respondContractCallError converte erros das chamadas a contratos em respostas HTTP. Reverts retornam 422
+// com o motivo decodificado
+func respondContractCallError(c *gin.Context, err error) {
+	var revert *services.ContractRevertError
+	switch {
+	case errors.As(err, &revert):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "revert": revert.Reason})
+	case errors.Is(err, services.ErrContractNotFound), errors.Is(err, services.ErrContractABIUnavailable):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidContractCall):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRPCUnavailable):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// ReadContract executa uma função view/pure do contrato com o ABI gravado (combinado com o da implementação
+// em proxies) e retorna os valores nomeados
+// POST /api/smart-contracts/:address/read {"function": "balanceOf", "args": ["0x..."], "block": "latest"}
+func (h *ContractReadHandler) ReadContract(c *gin.Context) {
+	var request entities.ContractReadRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.contractCallService.ReadContract(c.Request.Context(), c.Param("address"), &request)
+	if err != nil {
+		respondContractCallError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ReadContractViews retorna os valores de todas as funções view/pure sem argumentos do contrato
+// GET /api/smart-contracts/:address/read?block=latest
+func (h *ContractReadHandler) ReadContractViews(c *gin.Context) {
+	result, err := h.contractCallService.ReadContractViews(c.Request.Context(), c.Param("address"), c.Query("block"))
+	if err != nil {
+		respondContractCallError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -29,9 +30,13 @@ func (h *EventHandler) GetEvents(c *gin.Context) {
 	filters := h.parseEventFilters(c)
 
 	// Buscar eventos
-	events, total, err := h.eventService.GetEvents(c.Request.Context(), filters)
+	result, err := h.eventService.GetEvents(c.Request.Context(), filters)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
 			"success": false,
 			"error":   "Erro ao buscar eventos: " + err.Error(),
 		})
@@ -39,23 +44,23 @@ func (h *EventHandler) GetEvents(c *gin.Context) {
 	}
 
 	// Garantir que events nunca seja nil (para evitar null no JSON)
+	events := result.Data
 	if events == nil {
 		events = []*entities.EventSummary{}
 	}
 
-	// Calcular paginação
-	totalPages := (int(total) + filters.Limit - 1) / filters.Limit
-
 	// Resposta
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    events,
 		"count":   len(events),
 		"pagination": gin.H{
-			"page":        filters.Page,
-			"limit":       filters.Limit,
-			"total":       total,
-			"total_pages": totalPages,
+			"page":              result.Page,
+			"limit":             result.Limit,
+			"total":             result.Total,
+			"total_pages":       result.TotalPages,
+			"total_approximate": result.TotalApproximate,
+			"next_cursor":       result.NextCursor,
 		},
 	})
 }
@@ -120,9 +125,13 @@ func (h *EventHandler) SearchEvents(c *gin.Context) {
 	filters := h.parseEventFilters(c)
 
 	// Buscar eventos com filtros
-	events, total, err := h.eventService.GetEvents(c.Request.Context(), filters)
+	result, err := h.eventService.GetEvents(c.Request.Context(), filters)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
 			"success": false,
 			"error":   "Erro ao buscar eventos: " + err.Error(),
 		})
@@ -130,23 +139,23 @@ func (h *EventHandler) SearchEvents(c *gin.Context) {
 	}
 
 	// Garantir que events nunca seja nil (para evitar null no JSON)
+	events := result.Data
 	if events == nil {
 		events = []*entities.EventSummary{}
 	}
 
-	// Calcular paginação
-	totalPages := (int(total) + filters.Limit - 1) / filters.Limit
-
 	// Resposta
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    events,
 		"count":   len(events),
 		"pagination": gin.H{
-			"page":        filters.Page,
-			"limit":       filters.Limit,
-			"total":       total,
-			"total_pages": totalPages,
+			"page":              result.Page,
+			"limit":             result.Limit,
+			"total":             result.Total,
+			"total_pages":       result.TotalPages,
+			"total_approximate": result.TotalApproximate,
+			"next_cursor":       result.NextCursor,
 		},
 	})
 }
@@ -366,6 +375,10 @@ func (h *EventHandler) parseEventFilters(c *gin.Context) entities.EventFilters {
 		}
 	}
 
+	if cursor := c.Query("cursor"); cursor != "" {
+		filters.Cursor = &cursor
+	}
+
 	// Ordenação
 	if orderBy := c.Query("order_by"); orderBy != "" {
 		filters.OrderBy = orderBy
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"explorer-api/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportHandler gerencia as rotas de exportação em streaming (CSV/NDJSON)
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+// NewExportHandler cria uma nova instância do handler de exportação
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportAccountTransactions exporta as transações de uma account
+// GET /api/accounts/:address/transactions/export?format=csv&from_block=1&to_date=2024-12-31&columns=transaction_hash,value
+func (h *ExportHandler) ExportAccountTransactions(c *gin.Context) {
+	h.export(c, services.ExportAccountTransactions)
+}
+
+// ExportAccountEvents exporta os eventos de uma account
+// GET /api/accounts/:address/events/export?format=ndjson
+func (h *ExportHandler) ExportAccountEvents(c *gin.Context) {
+	h.export(c, services.ExportAccountEvents)
+}
+
+// ExportAccountTokenTransfers exporta as transferências de tokens de uma account
+// GET /api/accounts/:address/token-transfers/export?format=csv
+func (h *ExportHandler) ExportAccountTokenTransfers(c *gin.Context) {
+	h.export(c, services.ExportAccountTokenTransfers)
+}
+
+// ExportContractEvents exporta os eventos emitidos por um smart contract
+// GET /api/smart-contracts/:address/events/export?format=csv&columns=event_name,decoded.from
+func (h *ExportHandler) ExportContractEvents(c *gin.Context) {
+	h.export(c, services.ExportContractEvents)
+}
+
+// export valida os parâmetros e transmite a exportação diretamente para a resposta
+func (h *ExportHandler) export(c *gin.Context, dataset services.ExportDataset) {
+	filters, err := parseExportFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	job, err := h.exportService.PrepareExport(c.Request.Context(), dataset, c.Param("address"), filters)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidExport) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":             err.Error(),
+				"available_columns": services.ExportColumns(dataset),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", job.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, job.Filename))
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	started := time.Now()
+	rows, err := h.exportService.Stream(c.Request.Context(), job, c.Writer)
+	if err != nil {
+		// O cabeçalho já foi enviado; apenas registrar a falha e interromper o stream
+		log.Printf("❌ Exportação %s interrompida após %d linhas: %v", job.Filename, rows, err)
+		c.Abort()
+		return
+	}
+
+	log.Printf("📤 Exportação %s concluída: %d linhas em %v", job.Filename, rows, time.Since(started).Round(time.Millisecond))
+}
+
+// parseExportFilters lê os filtros de exportação da query string
+func parseExportFilters(c *gin.Context) (*services.ExportFilters, error) {
+	filters := &services.ExportFilters{
+		Format:  services.ExportFormat(c.DefaultQuery("format", string(services.ExportFormatCSV))),
+		Columns: services.ParseExportColumns(c.Query("columns")),
+	}
+
+	if value := c.Query("from_block"); value != "" {
+		block, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || block < 0 {
+			return nil, fmt.Errorf("parâmetro 'from_block' inválido")
+		}
+		filters.FromBlock = &block
+	}
+
+	if value := c.Query("to_block"); value != "" {
+		block, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || block < 0 {
+			return nil, fmt.Errorf("parâmetro 'to_block' inválido")
+		}
+		filters.ToBlock = &block
+	}
+
+	if value := c.Query("from_date"); value != "" {
+		date, err := parseExportDate(value, false)
+		if err != nil {
+			return nil, fmt.Errorf("parâmetro 'from_date' inválido (use YYYY-MM-DD ou RFC3339)")
+		}
+		filters.FromDate = &date
+	}
+
+	if value := c.Query("to_date"); value != "" {
+		date, err := parseExportDate(value, true)
+		if err != nil {
+			return nil, fmt.Errorf("parâmetro 'to_date' inválido (use YYYY-MM-DD ou RFC3339)")
+		}
+		filters.ToDate = &date
+	}
+
+	return filters, nil
+}
+
+// parseExportDate aceita RFC3339 ou YYYY-MM-DD; datas sem horário no fim do intervalo cobrem o dia inteiro
+func parseExportDate(value string, endOfDay bool) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Nanosecond)
+	}
+	return date, nil
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"explorer-api/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlowHandler gerencia a rota do grafo de fluxo de fundos
+type FlowHandler struct {
+	flowService *services.FlowService
+}
+
+// NewFlowHandler cria uma nova instância do handler de fluxo de fundos
+func NewFlowHandler(flowService *services.FlowService) *FlowHandler {
+	return &FlowHandler{
+		flowService: flowService,
+	}
+}
+
+// GetAccountFlow retorna o grafo de transferências (moeda nativa e ERC-20) agregadas por contraparte.
+// Com export=json ou export=graphml o grafo é enviado como arquivo para ferramentas externas
+// GET /api/accounts/:address/flow?direction=out&hops=2&token=native&from=2024-01-01&to=2024-01-31&min_value=1000000000000000000
+func (h *FlowHandler) GetAccountFlow(c *gin.Context) {
+	query, err := parseFlowQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	export := strings.ToLower(c.Query("export"))
+	if export != "" && export != "json" && export != "graphml" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Parâmetro 'export' inválido (use json ou graphml)",
+		})
+		return
+	}
+
+	graph, err := h.flowService.BuildGraph(c.Request.Context(), *query)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFlow) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if export == "" {
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    graph,
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("flow_%s_%s.%s", graph.Root, time.Now().UTC().Format("20060102T150405Z"), export)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Cache-Control", "no-store")
+
+	if export == "json" {
+		c.JSON(http.StatusOK, graph)
+		return
+	}
+
+	c.Header("Content-Type", "application/graphml+xml; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := graph.WriteGraphML(c.Writer); err != nil {
+		log.Printf("❌ Erro ao exportar grafo %s: %v", filename, err)
+		c.Abort()
+	}
+}
+
+// parseFlowQuery lê os parâmetros do grafo da query string
+func parseFlowQuery(c *gin.Context) (*services.FlowQuery, error) {
+	query := &services.FlowQuery{
+		Address:   c.Param("address"),
+		Direction: services.FlowDirection(strings.ToLower(c.Query("direction"))),
+		Token:     c.Query("token"),
+	}
+
+	intParams := []struct {
+		name  string
+		value *int
+	}{
+		{"hops", &query.Hops},
+		{"max_counterparties", &query.MaxCounterparties},
+		{"max_nodes", &query.MaxNodes},
+	}
+	for _, param := range intParams {
+		if value := c.Query(param.name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				return nil, fmt.Errorf("parâmetro '%s' inválido", param.name)
+			}
+			*param.value = parsed
+		}
+	}
+
+	if value := c.Query("min_tx_count"); value != "" {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("parâmetro 'min_tx_count' inválido")
+		}
+		query.MinTxCount = count
+	}
+
+	if value := c.Query("min_value"); value != "" {
+		minValue, ok := new(big.Int).SetString(value, 10)
+		if !ok || minValue.Sign() < 0 {
+			return nil, fmt.Errorf("parâmetro 'min_value' inválido (use um inteiro em unidades mínimas, ex.: wei)")
+		}
+		query.MinValue = minValue
+	}
+
+	if value := c.Query("from"); value != "" {
+		date, err := parseExportDate(value, false)
+		if err != nil {
+			return nil, fmt.Errorf("parâmetro 'from' inválido (use YYYY-MM-DD ou RFC3339)")
+		}
+		query.FromDate = &date
+	}
+
+	if value := c.Query("to"); value != "" {
+		date, err := parseExportDate(value, true)
+		if err != nil {
+			return nil, fmt.Errorf("parâmetro 'to' inválido (use YYYY-MM-DD ou RFC3339)")
+		}
+		query.ToDate = &date
+	}
+
+	return query, nil
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxRiskPolicySize limita o tamanho do documento da política enviado
+const maxRiskPolicySize = 1 << 20
+
+// RiskPolicyHandler gerencia as rotas HTTP das versões da política de risco
+type RiskPolicyHandler struct {
+	riskPolicyService *services.RiskPolicyService
+}
+
+// NewRiskPolicyHandler cria uma nova instância do handler de políticas de risco
+func NewRiskPolicyHandler(riskPolicyService *services.RiskPolicyService) *RiskPolicyHandler {
+	return &RiskPolicyHandler{
+		riskPolicyService: riskPolicyService,
+	}
+}
+
+// respondRiskPolicyError converte erros do serviço em respostas HTTP
+func (h *RiskPolicyHandler) respondRiskPolicyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrRiskPolicyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidRiskPolicy):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// riskPolicyFormat define o formato pelo parâmetro format ou pelo Content-Type (padrão: YAML)
+func riskPolicyFormat(c *gin.Context) (entities.RiskPolicyFormat, bool) {
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		if strings.Contains(c.ContentType(), "json") {
+			format = string(entities.RiskPolicyFormatJSON)
+		} else {
+			format = string(entities.RiskPolicyFormatYAML)
+		}
+	}
+
+	switch entities.RiskPolicyFormat(format) {
+	case entities.RiskPolicyFormatYAML, entities.RiskPolicyFormatJSON:
+		return entities.RiskPolicyFormat(format), true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'format' inválido (use yaml ou json)"})
+		return "", false
+	}
+}
+
+// GetRiskPolicies lista as versões da política de risco
+// GET /api/risk-policies?page=1&limit=20
+func (h *RiskPolicyHandler) GetRiskPolicies(c *gin.Context) {
+	page, limit := parseAlertPagination(c)
+
+	result, err := h.riskPolicyService.ListPolicies(c.Request.Context(), page, limit)
+	if err != nil {
+		h.respondRiskPolicyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// GetActiveRiskPolicy retorna a versão em uso pelo worker
+// GET /api/risk-policies/active
+func (h *RiskPolicyHandler) GetActiveRiskPolicy(c *gin.Context) {
+	policy, err := h.riskPolicyService.GetActivePolicy(c.Request.Context())
+	if err != nil {
+		h.respondRiskPolicyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    policy,
+	})
+}
+
+// GetRiskPolicy retorna uma versão da política
+// GET /api/risk-policies/:version
+func (h *RiskPolicyHandler) GetRiskPolicy(c *gin.Context) {
+	version, ok := parseAlertID(c, "version")
+	if !ok {
+		return
+	}
+
+	policy, err := h.riskPolicyService.GetPolicy(c.Request.Context(), version)
+	if err != nil {
+		h.respondRiskPolicyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    policy,
+	})
+}
+
+// CreateRiskPolicy grava uma nova versão a partir do documento YAML ou JSON no corpo
+// POST /api/risk-policies?format=yaml&activate=true
+func (h *RiskPolicyHandler) CreateRiskPolicy(c *gin.Context) {
+	format, ok := riskPolicyFormat(c)
+	if !ok {
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(c.Request.Body, maxRiskPolicySize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao ler a política: " + err.Error()})
+		return
+	}
+	if len(content) > maxRiskPolicySize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Política maior que 1 MB"})
+		return
+	}
+
+	activate := c.Query("activate") == "true"
+	createdBy := strconv.Itoa(middleware.GetCurrentUserID(c))
+
+	policy, err := h.riskPolicyService.CreatePolicy(c.Request.Context(), content, format, createdBy, activate)
+	if err != nil {
+		h.respondRiskPolicyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    policy,
+	})
+}
+
+// ActivateRiskPolicy torna uma versão ativa; o worker reavalia as accounts afetadas
+// POST /api/risk-policies/:version/activate
+func (h *RiskPolicyHandler) ActivateRiskPolicy(c *gin.Context) {
+	version, ok := parseAlertID(c, "version")
+	if !ok {
+		return
+	}
+
+	policy, err := h.riskPolicyService.ActivatePolicy(c.Request.Context(), version)
+	if err != nil {
+		h.respondRiskPolicyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    policy,
+	})
+}
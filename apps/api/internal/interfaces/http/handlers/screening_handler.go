@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxScreeningListSize limita o tamanho do arquivo de lista enviado
+const maxScreeningListSize = 32 << 20
+
+// ScreeningHandler gerencia as rotas HTTP das listas de triagem e da exposição das accounts
+type ScreeningHandler struct {
+	screeningService *services.ScreeningService
+}
+
+// NewScreeningHandler cria uma nova instância do handler de triagem
+func NewScreeningHandler(screeningService *services.ScreeningService) *ScreeningHandler {
+	return &ScreeningHandler{
+		screeningService: screeningService,
+	}
+}
+
+// respondScreeningError converte erros do serviço em respostas HTTP
+func (h *ScreeningHandler) respondScreeningError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrScreeningListNotFound), errors.Is(err, services.ErrExposureAccountNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidScreeningList):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// screeningListFormat define o formato pelo parâmetro format, pela extensão do arquivo ou pelo Content-Type
+// (padrão: CSV)
+func screeningListFormat(c *gin.Context, filename string) (entities.ScreeningListFormat, bool) {
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		switch {
+		case strings.EqualFold(filepath.Ext(filename), ".json"):
+			format = string(entities.ScreeningListFormatJSON)
+		case filename == "" && strings.Contains(c.ContentType(), "json"):
+			format = string(entities.ScreeningListFormatJSON)
+		default:
+			format = string(entities.ScreeningListFormatCSV)
+		}
+	}
+
+	switch entities.ScreeningListFormat(format) {
+	case entities.ScreeningListFormatCSV, entities.ScreeningListFormatJSON:
+		return entities.ScreeningListFormat(format), true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'format' inválido (use csv ou json)"})
+		return "", false
+	}
+}
+
+// readScreeningListFile lê o arquivo do campo multipart 'file' ou, na falta dele, o corpo da requisição
+func readScreeningListFile(c *gin.Context) ([]byte, string, bool) {
+	body := io.Reader(c.Request.Body)
+	filename := ""
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Campo 'file' obrigatório"})
+			return nil, "", false
+		}
+		file, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao abrir o arquivo: " + err.Error()})
+			return nil, "", false
+		}
+		defer file.Close()
+		body, filename = file, header.Filename
+	}
+
+	content, err := io.ReadAll(io.LimitReader(body, maxScreeningListSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao ler a lista: " + err.Error()})
+		return nil, "", false
+	}
+	if len(content) > maxScreeningListSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Lista maior que 32 MB"})
+		return nil, "", false
+	}
+	return content, filename, true
+}
+
+// GetScreeningLists lista as versões importadas das listas
+// GET /api/screening/lists?name=ofac&page=1&limit=20
+func (h *ScreeningHandler) GetScreeningLists(c *gin.Context) {
+	page, limit := parseAlertPagination(c)
+
+	result, err := h.screeningService.ListLists(c.Request.Context(), c.Query("name"), page, limit)
+	if err != nil {
+		h.respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// GetScreeningList retorna uma versão de lista
+// GET /api/screening/lists/:id
+func (h *ScreeningHandler) GetScreeningList(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	list, err := h.screeningService.GetList(c.Request.Context(), id)
+	if err != nil {
+		h.respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    list,
+	})
+}
+
+// GetScreeningListEntries lista os endereços de uma versão
+// GET /api/screening/lists/:id/entries?page=1&limit=20
+func (h *ScreeningHandler) GetScreeningListEntries(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+	page, limit := parseAlertPagination(c)
+
+	result, err := h.screeningService.ListEntries(c.Request.Context(), id, page, limit)
+	if err != nil {
+		h.respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// ImportScreeningList importa uma nova versão ativa de lista a partir de CSV ou JSON
+// (corpo da requisição ou campo multipart 'file')
+// POST /api/screening/lists?name=ofac&source=OFAC%20SDN&version=2024-05-01&format=csv
+func (h *ScreeningHandler) ImportScreeningList(c *gin.Context) {
+	content, filename, ok := readScreeningListFile(c)
+	if !ok {
+		return
+	}
+	format, ok := screeningListFormat(c, filename)
+	if !ok {
+		return
+	}
+
+	meta := services.ScreeningListMetadata{
+		Name:    c.Query("name"),
+		Source:  c.Query("source"),
+		Version: c.Query("version"),
+	}
+	importedBy := strconv.Itoa(middleware.GetCurrentUserID(c))
+
+	list, err := h.screeningService.ImportList(c.Request.Context(), content, format, meta, importedBy)
+	if err != nil {
+		h.respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    list,
+	})
+}
+
+// ActivateScreeningList ativa uma versão, substituindo a versão ativa com o mesmo nome
+// POST /api/screening/lists/:id/activate
+func (h *ScreeningHandler) ActivateScreeningList(c *gin.Context) {
+	h.setScreeningListActive(c, true)
+}
+
+// DeactivateScreeningList desativa uma versão; o worker libera as accounts expostas apenas por ela
+// POST /api/screening/lists/:id/deactivate
+func (h *ScreeningHandler) DeactivateScreeningList(c *gin.Context) {
+	h.setScreeningListActive(c, false)
+}
+
+// setScreeningListActive altera o estado de uma versão
+func (h *ScreeningHandler) setScreeningListActive(c *gin.Context, active bool) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	list, err := h.screeningService.SetListActive(c.Request.Context(), id, active)
+	if err != nil {
+		h.respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    list,
+	})
+}
+
+// GetAccountExposure retorna as listas em que a account consta e sua exposição nas listas ativas
+// GET /api/accounts/:address/exposure
+func (h *ScreeningHandler) GetAccountExposure(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Endereço é obrigatório"})
+		return
+	}
+
+	report, err := h.screeningService.GetExposureReport(c.Request.Context(), address)
+	if err != nil {
+		h.respondScreeningError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
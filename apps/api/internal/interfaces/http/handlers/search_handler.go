@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"explorer-api/internal/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler gerencia a rota de busca universal
+type SearchHandler struct {
+	searchService *services.SearchService
+}
+
+// NewSearchHandler cria uma nova instância do handler de busca
+func NewSearchHandler(searchService *services.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// Search classifica o termo digitado e retorna sugestões tipadas e ordenadas (type-ahead)
+// GET /api/search?q=USDC&limit=10
+func (h *SearchHandler) Search(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	result, err := h.searchService.Search(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"query":   result.Query,
+		"kind":    result.Kind,
+		"data":    result.Results,
+		"count":   len(result.Results),
+		"partial": result.Partial,
+	})
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SimulationHandler gerencia a simulação de transações pela API
+type SimulationHandler struct {
+	simulationService *services.SimulationService
+}
+
+// NewSimulationHandler cria uma nova instância do handler de simulação
+func NewSimulationHandler(simulationService *services.SimulationService) *SimulationHandler {
+	return &SimulationHandler{
+		simulationService: simulationService,
+	}
+}
+
+// Simulate executa a transação sem enviá-la à rede e retorna revert decodificado, retorno, gas estimado,
+// logs e árvore de chamadas. Uma transação que reverte retorna 200 com success=false no resultado
+// POST /api/simulate {"from": "0x...", "to": "0x...", "function": "transfer", "args": ["0x...", "1000"], "value": "0"}
+func (h *SimulationHandler) Simulate(c *gin.Context) {
+	var request entities.SimulationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	result, err := h.simulationService.Simulate(c.Request.Context(), &request)
+	if err != nil {
+		respondContractCallError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"explorer-api/internal/app/services"
 	"explorer-api/internal/infrastructure/cache"
@@ -18,8 +19,8 @@ type StatsHandler struct {
 	transactionService   *services.TransactionService
 	smartContractService *services.SmartContractService
 	accountService       *services.AccountService
+	networkStatsService  *services.NetworkStatsService
 	redisCache           *cache.RedisCache
-	db                   *sql.DB
 }
 
 // NewStatsHandler cria uma nova instância do handler de estatísticas
@@ -28,15 +29,15 @@ func NewStatsHandler(
 	transactionService *services.TransactionService,
 	smartContractService *services.SmartContractService,
 	accountService *services.AccountService,
-	db *sql.DB,
+	networkStatsService *services.NetworkStatsService,
 ) *StatsHandler {
 	return &StatsHandler{
 		blockService:         blockService,
 		transactionService:   transactionService,
 		smartContractService: smartContractService,
 		accountService:       accountService,
+		networkStatsService:  networkStatsService,
 		redisCache:           cache.NewRedisCache(),
-		db:                   db,
 	}
 }
 
@@ -57,34 +58,38 @@ type GeneralStatsResponse struct {
 // GET /api/stats
 func (h *StatsHandler) GetGeneralStats(c *gin.Context) {
 	// Buscar estatísticas de diferentes serviços
-	stats := &GeneralStatsResponse{}
+	stats := &GeneralStatsResponse{NetworkUtilization: "0.0%"}
 
 	// 1. Estatísticas de blocos
 	if blockStats, err := h.blockService.GetBlocksStats(c.Request.Context()); err == nil {
 		stats.TotalBlocks = blockStats.TotalBlocks
 		stats.LatestBlockNumber = int64(blockStats.LatestBlockNumber)
-		// Campos que não existem no BlockStats - usar valores padrão ou deixar zero
-		stats.AvgBlockTime = 2.0         // QBFT default
-		stats.NetworkUtilization = "75%" // Placeholder
-		stats.AvgGasUsed = 21000         // Placeholder
 	}
 
-	// 2. Estatísticas de transações
+	// 2. Tempo de bloco, utilização e gas médio das últimas 24h (rollups do worker)
+	now := time.Now()
+	if summary, err := h.networkStatsService.GetSummary(c.Request.Context(), now.Add(-24*time.Hour), now); err == nil {
+		stats.AvgBlockTime = summary.AvgBlockTime
+		stats.NetworkUtilization = fmt.Sprintf("%.1f%%", summary.GasUtilization)
+		stats.AvgGasUsed = summary.AvgGasUsed
+	}
+
+	// 3. Estatísticas de transações
 	if txStats, err := h.transactionService.GetTransactionStats(c.Request.Context()); err == nil {
 		stats.TotalTransactions = txStats.TotalTransactions
 	}
 
-	// 3. Estatísticas de smart contracts
+	// 4. Estatísticas de smart contracts
 	if contractStats, err := h.smartContractService.GetSmartContractStats(); err == nil {
 		stats.TotalContracts = contractStats.TotalContracts
 	}
 
-	// 4. Top métodos de account_method_stats
+	// 5. Top métodos de account_method_stats
 	if methodStats, err := h.accountService.GetTopMethodStats(c.Request.Context(), 10); err == nil {
 		stats.TopMethods = methodStats
 	}
 
-	// 5. Número de validadores ativos (usando placeholder por enquanto)
+	// 6. Número de validadores ativos (usando placeholder por enquanto)
 	stats.ActiveValidators = 4 // QBFT default
 
 	c.JSON(http.StatusOK, gin.H{
@@ -111,93 +116,79 @@ func (h *StatsHandler) GetRecentActivity(c *gin.Context) {
 	})
 }
 
-// getRecentActivityData busca dados de atividade das últimas 24h
+// getRecentActivityData monta a atividade das últimas 24h a partir dos rollups por hora e por dia
 func (h *StatsHandler) getRecentActivityData(ctx context.Context) (map[string]interface{}, error) {
-	activity := make(map[string]interface{})
-
-	// 1. Growth das últimas 24h (transações)
-	growthQuery := `
-		WITH yesterday AS (
-			SELECT COUNT(*) as count
-			FROM transactions t
-			JOIN blocks b ON t.block_hash = b.hash
-			WHERE b.timestamp >= CURRENT_TIMESTAMP - INTERVAL '2 days'
-				AND b.timestamp < CURRENT_TIMESTAMP - INTERVAL '1 day'
-		),
-		today AS (
-			SELECT COUNT(*) as count
-			FROM transactions t
-			JOIN blocks b ON t.block_hash = b.hash
-			WHERE b.timestamp >= CURRENT_TIMESTAMP - INTERVAL '1 day'
-		)
-		SELECT
-			t.count as today_count,
-			y.count as yesterday_count,
-			CASE
-				WHEN y.count = 0 THEN 100.0
-				ELSE ROUND(((t.count - y.count) * 100.0 / y.count), 1)
-			END as growth_percentage
-		FROM today t, yesterday y`
-
-	var todayCount, yesterdayCount int64
-	var growthPercentage float64
-	err := h.db.QueryRowContext(ctx, growthQuery).Scan(&todayCount, &yesterdayCount, &growthPercentage)
+	now := time.Now()
+
+	today, err := h.networkStatsService.GetSummary(ctx, now.Add(-24*time.Hour), now)
 	if err != nil {
-		growthPercentage = 0.0
+		return nil, err
 	}
-
-	activity["last_24h_growth"] = fmt.Sprintf("%+.1f%%", growthPercentage)
-
-	// 2. Peak TPS das últimas 24h
-	tpsQuery := `
-		WITH hourly_tx AS (
-			SELECT
-				EXTRACT(HOUR FROM b.timestamp) as hour,
-				COUNT(*) as tx_count
-			FROM transactions t
-			JOIN blocks b ON t.block_hash = b.hash
-			WHERE b.timestamp >= CURRENT_TIMESTAMP - INTERVAL '1 day'
-			GROUP BY EXTRACT(HOUR FROM b.timestamp)
-		)
-		SELECT COALESCE(MAX(tx_count), 0) / 3600.0 as peak_tps
-		FROM hourly_tx`
-
-	var peakTPS float64
-	err = h.db.QueryRowContext(ctx, tpsQuery).Scan(&peakTPS)
+	yesterday, err := h.networkStatsService.GetSummary(ctx, now.Add(-48*time.Hour), now.Add(-24*time.Hour))
 	if err != nil {
-		peakTPS = 0.0
+		return nil, err
 	}
-
-	activity["peak_tps"] = int64(peakTPS)
-
-	// 3. Novos contratos das últimas 24h
-	newContractsQuery := `
-		SELECT COUNT(*)
-		FROM smart_contracts
-		WHERE creation_timestamp >= CURRENT_TIMESTAMP - INTERVAL '1 day'`
-
-	var newContracts int64
-	err = h.db.QueryRowContext(ctx, newContractsQuery).Scan(&newContracts)
+	activeAddresses, err := h.networkStatsService.GetDailyActiveAddresses(ctx, now)
 	if err != nil {
-		newContracts = 0
+		return nil, err
 	}
 
-	activity["new_contracts"] = newContracts
+	// 1. Crescimento de transações em relação às 24h anteriores
+	growthPercentage := 100.0
+	if yesterday.TxCount > 0 {
+		growthPercentage = float64(today.TxCount-yesterday.TxCount) * 100.0 / float64(yesterday.TxCount)
+	}
 
-	// 4. Endereços únicos ativos nas últimas 24h
-	activeAddressesQuery := `
-		SELECT COUNT(DISTINCT t.from_address)
-		FROM transactions t
-		JOIN blocks b ON t.block_hash = b.hash
-		WHERE b.timestamp >= CURRENT_TIMESTAMP - INTERVAL '1 day'`
+	return map[string]interface{}{
+		"last_24h_growth":  fmt.Sprintf("%+.1f%%", growthPercentage),
+		"peak_tps":         float64(today.PeakHourTxCount) / 3600, // 2. Média de TPS da hora mais movimentada
+		"new_contracts":    today.ContractsDeployed,               // 3. Contratos implantados nas últimas 24h
+		"active_addresses": activeAddresses,                       // 4. Endereços ativos no dia (UTC)
+	}, nil
+}
 
-	var activeAddresses int64
-	err = h.db.QueryRowContext(ctx, activeAddressesQuery).Scan(&activeAddresses)
-	if err != nil {
-		activeAddresses = 0
+// GetCharts retorna a série temporal de uma métrica dos rollups de estatísticas
+// GET /api/stats/charts?metric=tx_count&interval=hour&from=2024-01-01&to=2024-01-02
+func (h *StatsHandler) GetCharts(c *gin.Context) {
+	metric := c.DefaultQuery("metric", "tx_count")
+	interval := services.ChartInterval(c.DefaultQuery("interval", string(services.ChartIntervalHour)))
+
+	var from, to *time.Time
+	if value := c.Query("from"); value != "" {
+		date, err := parseExportDate(value, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from inválido (use RFC3339 ou YYYY-MM-DD)"})
+			return
+		}
+		from = &date
+	}
+	if value := c.Query("to"); value != "" {
+		date, err := parseExportDate(value, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to inválido (use RFC3339 ou YYYY-MM-DD)"})
+			return
+		}
+		to = &date
 	}
 
-	activity["active_addresses"] = activeAddresses
+	series, err := h.networkStatsService.GetChart(c.Request.Context(), metric, interval, from, to)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidChart) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":             err.Error(),
+				"available_metrics": services.ChartMetrics(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Erro ao buscar série de estatísticas",
+			"details": err.Error(),
+		})
+		return
+	}
 
-	return activity, nil
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    series,
+	})
 }
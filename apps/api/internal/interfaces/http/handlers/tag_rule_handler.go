@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+	"explorer-api/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagRuleHandler gerencia as rotas HTTP das regras de tags automáticas
+type TagRuleHandler struct {
+	tagRuleService *services.TagRuleService
+}
+
+// NewTagRuleHandler cria uma nova instância do handler de regras de tags
+func NewTagRuleHandler(tagRuleService *services.TagRuleService) *TagRuleHandler {
+	return &TagRuleHandler{
+		tagRuleService: tagRuleService,
+	}
+}
+
+// respondTagRuleError converte erros do serviço em respostas HTTP
+func (h *TagRuleHandler) respondTagRuleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTagRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidTagRule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// GetTagRules lista as regras de tags
+// GET /api/tag-rules?tag=whale&active=true&page=1&limit=20
+func (h *TagRuleHandler) GetTagRules(c *gin.Context) {
+	page, limit := parseAlertPagination(c)
+
+	filters := &entities.TagRuleFilters{Tag: c.Query("tag")}
+	if active := c.Query("active"); active != "" {
+		isActive, err := strconv.ParseBool(active)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'active' inválido"})
+			return
+		}
+		filters.IsActive = &isActive
+	}
+
+	result, err := h.tagRuleService.ListRules(c.Request.Context(), filters, page, limit)
+	if err != nil {
+		h.respondTagRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":        result.Page,
+			"limit":       result.Limit,
+			"total":       result.Total,
+			"total_pages": result.TotalPages,
+		},
+	})
+}
+
+// GetTagRule retorna uma regra de tag
+// GET /api/tag-rules/:id
+func (h *TagRuleHandler) GetTagRule(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	rule, err := h.tagRuleService.GetRule(c.Request.Context(), id)
+	if err != nil {
+		h.respondTagRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rule,
+	})
+}
+
+// CreateTagRule cria uma regra de tag
+// POST /api/tag-rules
+func (h *TagRuleHandler) CreateTagRule(c *gin.Context) {
+	var request entities.CreateTagRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	actor := strconv.Itoa(middleware.GetCurrentUserID(c))
+	rule, err := h.tagRuleService.CreateRule(c.Request.Context(), &request, actor)
+	if err != nil {
+		h.respondTagRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    rule,
+	})
+}
+
+// UpdateTagRule altera descrição, condições ou estado de uma regra de tag
+// PUT /api/tag-rules/:id
+func (h *TagRuleHandler) UpdateTagRule(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request entities.UpdateTagRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	actor := strconv.Itoa(middleware.GetCurrentUserID(c))
+	rule, err := h.tagRuleService.UpdateRule(c.Request.Context(), id, &request, actor)
+	if err != nil {
+		h.respondTagRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rule,
+	})
+}
+
+// DeleteTagRule remove uma regra de tag
+// DELETE /api/tag-rules/:id
+func (h *TagRuleHandler) DeleteTagRule(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.tagRuleService.DeleteRule(c.Request.Context(), id); err != nil {
+		h.respondTagRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Regra de tag removida",
+	})
+}
+
+// PreviewTagRule simula condições sem gravá-las e retorna as accounts que casariam
+// POST /api/tag-rules/preview
+func (h *TagRuleHandler) PreviewTagRule(c *gin.Context) {
+	var request entities.PreviewTagRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	preview, err := h.tagRuleService.PreviewRule(c.Request.Context(), &request)
+	if err != nil {
+		h.respondTagRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    preview,
+	})
+}
+
+// PreviewStoredTagRule simula uma regra gravada e retorna as accounts que ganhariam ou perderiam a tag
+// GET /api/tag-rules/:id/preview
+func (h *TagRuleHandler) PreviewStoredTagRule(c *gin.Context) {
+	id, ok := parseAlertID(c, "id")
+	if !ok {
+		return
+	}
+
+	preview, err := h.tagRuleService.PreviewStoredRule(c.Request.Context(), id)
+	if err != nil {
+		h.respondTagRuleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    preview,
+	})
+}
@@ -76,6 +76,7 @@ func (h *TransactionHandler) GetTransactions(c *gin.Context) {
 
 // GetTransactionsWithFilters retorna transações com filtros avançados
 // GET /api/transactions/search?from=0x...&status=success&page=1&limit=20
+// Paginação por keyset: repassar next_cursor em ?cursor= (ordenação por bloco)
 func (h *TransactionHandler) GetTransactionsWithFilters(c *gin.Context) {
 	// Construir filtros a partir dos query parameters
 	filters := &services.TransactionFilters{}
@@ -162,6 +163,7 @@ func (h *TransactionHandler) GetTransactionsWithFilters(c *gin.Context) {
 			filters.Limit = limit
 		}
 	}
+	filters.Cursor = c.Query("cursor")
 
 	// Buscar transações com filtros
 	result, err := h.transactionService.GetTransactionsWithFilters(c.Request.Context(), filters)
@@ -176,10 +178,12 @@ func (h *TransactionHandler) GetTransactionsWithFilters(c *gin.Context) {
 		"success": true,
 		"data":    result.Data,
 		"pagination": gin.H{
-			"page":        result.Page,
-			"limit":       result.Limit,
-			"total":       result.Total,
-			"total_pages": result.TotalPages,
+			"page":              result.Page,
+			"limit":             result.Limit,
+			"total":             result.Total,
+			"total_pages":       result.TotalPages,
+			"total_approximate": result.TotalApproximate,
+			"next_cursor":       result.NextCursor,
 		},
 		"filters": filters,
 	})
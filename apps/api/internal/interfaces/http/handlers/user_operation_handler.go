@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"explorer-api/internal/app/services"
+	"explorer-api/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserOperationHandler gerencia as rotas HTTP das UserOperations ERC-4337
+type UserOperationHandler struct {
+	userOpService *services.UserOperationService
+}
+
+// NewUserOperationHandler cria uma nova instância do handler de UserOperations
+func NewUserOperationHandler(userOpService *services.UserOperationService) *UserOperationHandler {
+	return &UserOperationHandler{
+		userOpService: userOpService,
+	}
+}
+
+// respondUserOperationError converte erros do serviço em respostas HTTP
+func (h *UserOperationHandler) respondUserOperationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserOperationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidUserOperationQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseUserOperationFilters lê os filtros da listagem de UserOperations
+func parseUserOperationFilters(c *gin.Context) (*entities.UserOperationFilters, bool) {
+	filters := &entities.UserOperationFilters{
+		Sender:     c.Query("sender"),
+		Paymaster:  c.Query("paymaster"),
+		Bundler:    c.Query("bundler"),
+		EntryPoint: c.Query("entry_point"),
+		TxHash:     c.Query("tx_hash"),
+	}
+	if success := c.Query("success"); success != "" {
+		value, err := strconv.ParseBool(success)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'success' inválido"})
+			return nil, false
+		}
+		filters.Success = &value
+	}
+	for name, target := range map[string]**uint64{"from_block": &filters.FromBlock, "to_block": &filters.ToBlock} {
+		if raw := c.Query(name); raw != "" {
+			value, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro '" + name + "' inválido"})
+				return nil, false
+			}
+			*target = &value
+		}
+	}
+	return filters, true
+}
+
+// respondUserOperationPage envia uma página de UserOperations
+func (h *UserOperationHandler) respondUserOperationPage(c *gin.Context, filters *entities.UserOperationFilters) {
+	page, limit := parseAlertPagination(c)
+	result, err := h.userOpService.ListUserOperations(c.Request.Context(), filters, page, limit)
+	if err != nil {
+		h.respondUserOperationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Data,
+		"pagination": gin.H{
+			"page":              result.Page,
+			"limit":             result.Limit,
+			"total":             result.Total,
+			"total_pages":       result.TotalPages,
+			"total_approximate": result.TotalApproximate,
+		},
+	})
+}
+
+// GetUserOperations lista as UserOperations indexadas
+// GET /api/userops?sender=0x...&paymaster=0x...&bundler=0x...&entry_point=0x...&tx_hash=0x...&success=false&from_block=1&to_block=100&page=1&limit=20
+func (h *UserOperationHandler) GetUserOperations(c *gin.Context) {
+	filters, ok := parseUserOperationFilters(c)
+	if !ok {
+		return
+	}
+	h.respondUserOperationPage(c, filters)
+}
+
+// GetUserOperation retorna uma UserOperation pelo hash
+// GET /api/userops/:hash
+func (h *UserOperationHandler) GetUserOperation(c *gin.Context) {
+	op, err := h.userOpService.GetUserOperation(c.Request.Context(), c.Param("hash"))
+	if err != nil {
+		h.respondUserOperationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    op,
+	})
+}
+
+// GetAccountUserOperations lista as UserOperations de uma account: enviadas pela smart account (padrão),
+// patrocinadas pelo paymaster ou incluídas pelo bundler
+// GET /api/accounts/:address/userops?role=sender|paymaster|bundler&success=true&page=1&limit=20
+func (h *UserOperationHandler) GetAccountUserOperations(c *gin.Context) {
+	filters, ok := parseUserOperationFilters(c)
+	if !ok {
+		return
+	}
+
+	address := c.Param("address")
+	switch c.DefaultQuery("role", "sender") {
+	case "sender":
+		filters.Sender = address
+	case "paymaster":
+		filters.Paymaster = address
+	case "bundler":
+		filters.Bundler = address
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro 'role' deve ser sender, paymaster ou bundler"})
+		return
+	}
+
+	h.respondUserOperationPage(c, filters)
+}
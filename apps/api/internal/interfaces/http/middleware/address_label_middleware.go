@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"explorer-api/internal/app/services"
+)
+
+const (
+	// maxLabeledAddresses limita os endereços distintos consultados por resposta
+	maxLabeledAddresses = 1000
+	// addressLabelsHeader leva os labels das respostas cujo corpo é um array no nível raiz
+	addressLabelsHeader = "X-Address-Labels"
+	// maxLabelsHeaderSize mantém o cabeçalho abaixo do limite usual de proxies (8 KB para todos os cabeçalhos)
+	maxLabelsHeaderSize = 6 * 1024
+)
+
+// responseAddressPattern encontra endereços e hashes hexadecimais no corpo; só os de 20 bytes são endereços
+var responseAddressPattern = regexp.MustCompile(`0x[0-9a-fA-F]{40,}`)
+
+// labelResponseWriter retém o corpo das respostas JSON para incluir os labels antes de enviá-lo
+type labelResponseWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	decided   bool
+	buffering bool
+}
+
+// decide retém apenas respostas JSON; exportações e downloads seguem em streaming
+func (w *labelResponseWriter) decide() {
+	if !w.decided {
+		w.decided = true
+		w.buffering = strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+	}
+}
+
+func (w *labelResponseWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *labelResponseWriter) WriteString(s string) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *labelResponseWriter) Flush() {
+	if !w.buffering {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// AddressLabels inclui em toda resposta JSON que contém endereços o objeto "labels", com os labels aprovados
+// indexados pelo endereço em minúsculas: {"labels": {"0xabc...": {"name": "Bridge", "category": "bridge", ...}}}.
+// Respostas cujo corpo é um array no nível raiz mantêm o formato e recebem o mesmo objeto no cabeçalho
+// X-Address-Labels (omitido se passar de maxLabelsHeaderSize)
+func AddressLabels(labelService *services.AddressLabelService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &labelResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if !writer.buffering {
+			return
+		}
+
+		body := writer.body.Bytes()
+		if labeled := embedAddressLabels(c, labelService, body); labeled != nil {
+			body = labeled
+		}
+		if _, err := writer.ResponseWriter.Write(body); err != nil {
+			log.Printf("⚠️ Erro ao enviar resposta: %v", err)
+		}
+	}
+}
+
+// embedAddressLabels retorna o corpo com o objeto "labels" ou nil se não houver labels a incluir no corpo.
+// Para arrays no nível raiz, os labels vão no cabeçalho X-Address-Labels e o corpo não muda
+func embedAddressLabels(c *gin.Context, labelService *services.AddressLabelService, body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) < 2 {
+		return nil
+	}
+	isObject := trimmed[0] == '{' && trimmed[len(trimmed)-1] == '}'
+	isArray := trimmed[0] == '[' && trimmed[len(trimmed)-1] == ']'
+	if !isObject && !isArray {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var addresses []string
+	for _, match := range responseAddressPattern.FindAll(trimmed, -1) {
+		if len(match) != 42 {
+			continue
+		}
+		address := strings.ToLower(string(match))
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+			if len(addresses) == maxLabeledAddresses {
+				break
+			}
+		}
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	labels, err := labelService.LabelsFor(c.Request.Context(), addresses)
+	if err != nil {
+		log.Printf("⚠️ Erro ao buscar labels de endereços: %v", err)
+		return nil
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(labels)
+	if err != nil {
+		log.Printf("⚠️ Erro ao serializar labels de endereços: %v", err)
+		return nil
+	}
+
+	if isArray {
+		if len(encoded) > maxLabelsHeaderSize {
+			log.Printf("⚠️ Labels de %d endereços omitidos do cabeçalho %s (%d bytes)", len(labels), addressLabelsHeader, len(encoded))
+			return nil
+		}
+		c.Writer.Header().Set(addressLabelsHeader, asciiJSON(encoded))
+		return nil
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(trimmed) + len(encoded) + 12)
+	out.Write(trimmed[:len(trimmed)-1])
+	if len(bytes.TrimSpace(trimmed[1:len(trimmed)-1])) > 0 {
+		out.WriteByte(',')
+	}
+	out.WriteString(`"labels":`)
+	out.Write(encoded)
+	out.WriteByte('}')
+	return out.Bytes()
+}
+
+// asciiJSON escapa os caracteres não ASCII do JSON como \uXXXX, já que valores de cabeçalho HTTP são ASCII
+func asciiJSON(encoded []byte) string {
+	var out strings.Builder
+	out.Grow(len(encoded))
+	for _, r := range string(encoded) {
+		switch {
+		case r < utf8.RuneSelf:
+			out.WriteRune(r)
+		case r > 0xFFFF:
+			r1, r2 := utf16.EncodeRune(r)
+			fmt.Fprintf(&out, "\\u%04x\\u%04x", r1, r2)
+		default:
+			fmt.Fprintf(&out, "\\u%04x", r)
+		}
+	}
+	return out.String()
+}
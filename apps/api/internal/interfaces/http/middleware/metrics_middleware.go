@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"explorer-api/internal/infrastructure/metrics"
+)
+
+// Metrics middleware que registra a latência de cada requisição por rota do Gin.
+// Usa o template da rota (ex: /api/blocks/:id) para manter a cardinalidade baixa
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == "/metrics" {
+			c.Next()
+			return
+		}
+
+		metrics.HTTPRequestsInFlight.Inc()
+		start := time.Now()
+
+		c.Next()
+
+		metrics.HTTPRequestsInFlight.Dec()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
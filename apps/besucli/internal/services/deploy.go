@@ -4,18 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/hubweb3/besucli/internal/blockchain"
 	"github.com/hubweb3/besucli/internal/models"
@@ -139,24 +143,8 @@ func (s *DeployService) DeployContract(deployment *models.ContractDeployment) (s
 			log.Error("Transaction trace", "trace", traceResult)
 		}
 
-		// Try to simulate transaction to get specific error
-		log.Info("Simulating transaction to get error...")
-		callMsg := ethereum.CallMsg{
-			From:     s.client.GetFromAddress(),
-			To:       nil, // Deploy
-			Gas:      tx.Gas(),
-			GasPrice: tx.GasPrice(),
-			Value:    tx.Value(),
-			Data:     tx.Data(),
-		}
-
-		_, err = s.client.GetClient().CallContract(context.Background(), callMsg, receipt.BlockNumber)
-		if err != nil {
-			log.Error("Simulated error", "error", err)
-		}
-
 		// Try to get revert reason
-		revertReason, err := s.getRevertReason(tx.Hash().Hex())
+		revertReason, err := s.getRevertReason(tx.Hash().Hex(), &contractABI)
 		if err != nil {
 			log.Warning("Failed to get revert reason", "error", err)
 		} else if revertReason != "" {
@@ -280,9 +268,128 @@ func (s *DeployService) getTransactionTrace(txHash string) (string, error) {
 	return "", nil
 }
 
-func (s *DeployService) getRevertReason(txHash string) (string, error) {
-	// Implementation for getting revert reason
-	return "", fmt.Errorf("not implemented")
+// getRevertReason explains why a mined transaction failed. It prefers the reason decoded by the explorer
+// worker (GET /transactions/:hash) and falls back to replaying the transaction with eth_call on the parent
+// block, decoding Error(string), Panic(uint256) and the custom errors of contractABI
+func (s *DeployService) getRevertReason(txHash string, contractABI *abi.ABI) (string, error) {
+	if reason := s.getIndexedRevertReason(txHash); reason != "" {
+		return reason, nil
+	}
+
+	ctx := context.Background()
+	client := s.client.GetClient()
+	hash := common.HexToHash(txHash)
+
+	tx, _, err := client.TransactionByHash(ctx, hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get transaction: %w", err)
+	}
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get receipt: %w", err)
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return "", nil
+	}
+	if receipt.BlockNumber == nil || receipt.BlockNumber.Sign() == 0 {
+		return "", fmt.Errorf("transaction has no parent block to replay on")
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to recover sender: %w", err)
+	}
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	_, err = client.CallContract(ctx, msg, parent)
+	if err == nil {
+		if receipt.GasUsed >= tx.Gas() {
+			return "out of gas", nil
+		}
+		return "", fmt.Errorf("transaction did not revert when replayed on block %s; the failure depends on earlier transactions of the same block", parent)
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if text, ok := dataErr.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(text); decodeErr == nil && len(data) > 0 {
+				return decodeRevertData(data, contractABI), nil
+			}
+		}
+	}
+	return err.Error(), nil
+}
+
+// getIndexedRevertReason returns the revert reason stored by the explorer, or "" when the transaction
+// was not indexed yet or the API is unavailable
+func (s *DeployService) getIndexedRevertReason(txHash string) string {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	resp, err := httpClient.Get(fmt.Sprintf("%s/transactions/%s", s.apiURL, txHash))
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	var response struct {
+		Data struct {
+			RevertReason *struct {
+				Kind    string `json:"kind"`
+				Message string `json:"message"`
+			} `json:"revert_reason"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil || response.Data.RevertReason == nil {
+		return ""
+	}
+	// Without revert data the worker can only report that the replay failed; the local replay may do better
+	if response.Data.RevertReason.Kind == "unavailable" {
+		return ""
+	}
+	return response.Data.RevertReason.Message
+}
+
+// decodeRevertData decodes Error(string), Panic(uint256) or a custom error declared in contractABI
+func decodeRevertData(data []byte, contractABI *abi.ABI) string {
+	if len(data) < 4 {
+		return fmt.Sprintf("invalid revert data %s", hexutil.Encode(data))
+	}
+
+	selector := data[:4]
+	if bytes.Equal(selector, []byte{0x4e, 0x48, 0x7b, 0x71}) && len(data) == 36 {
+		return fmt.Sprintf("Panic(0x%s)", new(big.Int).SetBytes(data[4:]).Text(16))
+	}
+	if message, err := abi.UnpackRevert(data); err == nil {
+		return message
+	}
+
+	if contractABI != nil {
+		for _, abiError := range contractABI.Errors {
+			if !bytes.Equal(abiError.ID[:4], selector) {
+				continue
+			}
+			values, err := abiError.Inputs.Unpack(data[4:])
+			if err != nil {
+				continue
+			}
+			args := make([]string, len(values))
+			for i, value := range values {
+				args[i] = fmt.Sprintf("%s=%v", abiError.Inputs[i].Name, value)
+			}
+			return fmt.Sprintf("%s(%s)", abiError.Name, strings.Join(args, ", "))
+		}
+	}
+
+	return fmt.Sprintf("unknown error (selector %s)", hexutil.Encode(selector))
 }
 
 // Remove the entire Deploy function from line 290-364
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+// runBackfill executa o subcomando "backfill" (indexer backfill --from N --to M). O backfill grava direto no
+// PostgreSQL pelos repositórios em lote do worker, então é delegado ao binário do worker (WORKER_BIN, padrão
+// "worker" no PATH) com os mesmos argumentos; a saída e o código de saída são os do worker
+func runBackfill(args []string) error {
+	workerBin := os.Getenv("WORKER_BIN")
+	if workerBin == "" {
+		workerBin = "worker"
+	}
+
+	path, err := exec.LookPath(workerBin)
+	if err != nil {
+		return fmt.Errorf("binário do worker não encontrado (defina WORKER_BIN): %w", err)
+	}
+
+	log.Printf("🚚 Delegando backfill histórico para %s backfill", path)
+
+	cmd := exec.Command(path, append([]string{"backfill"}, args...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("erro ao iniciar o worker: %w", err)
+	}
+
+	// Repassar Ctrl+C e SIGTERM: o worker libera as faixas em andamento para a próxima execução
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-sigChan:
+				cmd.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err = cmd.Wait()
+	signal.Stop(sigChan)
+	close(done)
+	if err != nil {
+		return fmt.Errorf("worker backfill: %w", err)
+	}
+	return nil
+}
@@ -7,11 +7,14 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/hubweb3/indexer/internal/metrics"
 	"github.com/hubweb3/indexer/internal/modules/block"
-	"github.com/hubweb3/indexer/internal/modules/events"
 	"github.com/hubweb3/indexer/internal/modules/mempool"
 	"github.com/hubweb3/indexer/internal/modules/transaction"
+	"github.com/hubweb3/indexer/internal/rpcpool"
+	"github.com/hubweb3/indexer/internal/tracing"
 	"github.com/joho/godotenv"
 )
 
@@ -22,15 +25,26 @@ func main() {
 		log.Printf("⚠️ Arquivo .env não encontrado, usando variáveis do sistema: %v", err)
 	}
 
+	// Subcomando de backfill histórico: indexer backfill --from N --to M (executado pelo worker)
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		if err := runBackfill(os.Args[2:]); err != nil {
+			log.Fatalf("❌ Backfill falhou: %v", err)
+		}
+		return
+	}
+
 	log.Println("📡 Iniciando Block Explorer Indexer...")
 
 	// Verificar variáveis de ambiente essenciais
-	requiredEnvs := []string{"ETH_WS_URL", "RABBITMQ_URL", "RABBITMQ_EXCHANGE"}
+	requiredEnvs := []string{"RABBITMQ_URL", "RABBITMQ_EXCHANGE"}
 	for _, env := range requiredEnvs {
 		if os.Getenv(env) == "" {
 			log.Fatalf("❌ Variável de ambiente obrigatória não definida: %s", env)
 		}
 	}
+	if os.Getenv("ETH_WS_URL") == "" && os.Getenv("ETH_WS_URLS") == "" {
+		log.Fatalf("❌ Variável de ambiente obrigatória não definida: ETH_WS_URL (ou ETH_WS_URLS)")
+	}
 
 	// Configurar canal para capturar sinais de encerramento
 	sigChan := make(chan os.Signal, 1)
@@ -40,15 +54,38 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Configurar tracing distribuído (OpenTelemetry)
+	shutdownTracing, err := tracing.Init(ctx, "besuscan-indexer")
+	if err != nil {
+		log.Fatalf("❌ Falha ao configurar tracing: %v", err)
+	}
+	defer func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Printf("⚠️ Erro ao finalizar tracing: %v", err)
+		}
+	}()
+
 	// WaitGroup para aguardar todos os módulos terminarem
 	var wg sync.WaitGroup
 
+	// Pool RPC compartilhado entre os módulos (vários nodes com health check e failover)
+	rpcPool, err := rpcpool.New(ctx, rpcpool.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("❌ Falha ao criar pool RPC: %v", err)
+	}
+	defer rpcPool.Close()
+
+	// Expor métricas Prometheus (lag de blocos, filas e RPC)
+	go metrics.StartServer(ctx)
+
 	// Iniciar módulo de blocos (listener + publisher)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		log.Println("🔄 Iniciando Block Listener...")
-		block.RunBlockListener()
+		block.RunBlockListener(rpcPool)
 	}()
 
 	// Iniciar módulo de transações (listener)
@@ -56,7 +93,7 @@ func main() {
 	go func() {
 		defer wg.Done()
 		log.Println("🔄 Iniciando Transaction Indexer...")
-		transaction.RunTxIndexer()
+		transaction.RunTxIndexer(rpcPool)
 	}()
 
 	// Iniciar módulo de mempool (listener)
@@ -64,16 +101,11 @@ func main() {
 	go func() {
 		defer wg.Done()
 		log.Println("🔄 Iniciando Mempool Listener...")
-		mempool.RunMempoolListener(ctx)
+		mempool.RunMempoolListener(ctx, rpcPool)
 	}()
 
-	// Iniciar módulo de eventos (listener)
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		log.Println("🔄 Iniciando Event Listener...")
-		events.RunEventListener()
-	}()
+	// Eventos de smart contracts não têm listener próprio: os logs seguem nos receipts do payload do bloco
+	// e o worker grava os eventos a partir deles
 
 	// TODO: Adicionar outros módulos conforme necessário
 	// - Gas tracking
@@ -82,9 +114,9 @@ func main() {
 	log.Println("🚀 Indexer iniciado com sucesso. Pressione Ctrl+C para encerrar.")
 	log.Println("📊 Módulos ativos:")
 	log.Println("  • Block Listener - Monitora novos blocos")
-	log.Println("  • Transaction Indexer - Monitora transações")
+	log.Println("  • Transaction Indexer - Publica blocos com transações, receipts e logs")
 	log.Println("  • Mempool Listener - Monitora transações pendentes")
-	log.Println("  • Event Listener - Monitora eventos de smart contracts")
+	log.Println("  • Metrics - Expõe /metrics para o Prometheus")
 
 	// Aguardar sinal de encerramento
 	<-sigChan
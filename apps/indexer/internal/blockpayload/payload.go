@@ -0,0 +1,125 @@
+package blockpayload
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Version é a versão atual do formato de bloco enriquecido
+const Version = 1
+
+// EncodingGzip identifica o payload comprimido com gzip
+const EncodingGzip = "gzip"
+
+// Message é a mensagem publicada na fila block-processed.
+// Number, Hash e Timestamp mantêm compatibilidade com o BlockJob; o bloco completo
+// vai comprimido em Payload ou, se for grande demais, no blob store referenciado por Ref
+type Message struct {
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	Timestamp int64  `json:"timestamp"`
+
+	Version  int    `json:"version,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Payload  []byte `json:"payload,omitempty"`
+	Ref      string `json:"ref,omitempty"`
+	Size     int    `json:"size,omitempty"`
+}
+
+// Block é o bloco enriquecido: cabeçalho, transações e receipts (com os logs)
+type Block struct {
+	Hash         string        `json:"hash"`
+	Header       *types.Header `json:"header"`
+	Size         uint64        `json:"size"`
+	UncleCount   int           `json:"uncleCount"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// Transaction é uma transação do bloco com remetente e receipt já resolvidos
+type Transaction struct {
+	Raw     hexutil.Bytes   `json:"raw"`
+	From    common.Address  `json:"from"`
+	Receipt json.RawMessage `json:"receipt,omitempty"`
+}
+
+// NewBlock monta o bloco enriquecido a partir do bloco, dos remetentes e dos receipts já buscados
+func NewBlock(block *types.Block, senders map[common.Hash]common.Address, receipts map[common.Hash]json.RawMessage) (*Block, error) {
+	enriched := &Block{
+		Hash:         block.Hash().Hex(),
+		Header:       block.Header(),
+		Size:         block.Size(),
+		UncleCount:   len(block.Uncles()),
+		Transactions: make([]Transaction, 0, len(block.Transactions())),
+	}
+
+	for _, tx := range block.Transactions() {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao serializar transação %s: %w", tx.Hash().Hex(), err)
+		}
+		enriched.Transactions = append(enriched.Transactions, Transaction{
+			Raw:     raw,
+			From:    senders[tx.Hash()],
+			Receipt: receipts[tx.Hash()],
+		})
+	}
+
+	return enriched, nil
+}
+
+// Encoder serializa blocos enriquecidos e desvia payloads grandes para o blob store
+type Encoder struct {
+	store     Store
+	maxInline int
+}
+
+// NewEncoder cria um encoder; sem store, todos os payloads vão inline na mensagem
+func NewEncoder(store Store, maxInline int) *Encoder {
+	return &Encoder{store: store, maxInline: maxInline}
+}
+
+// Encode comprime o bloco e monta a mensagem, gravando o payload no blob store quando
+// o tamanho comprimido passa de maxInline
+func (e *Encoder) Encode(ctx context.Context, number uint64, block *Block) (*Message, error) {
+	raw, err := json.Marshal(block)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar bloco %d: %w", number, err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return nil, fmt.Errorf("erro ao comprimir bloco %d: %w", number, err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao comprimir bloco %d: %w", number, err)
+	}
+
+	msg := &Message{
+		Number:    number,
+		Hash:      block.Hash,
+		Timestamp: int64(block.Header.Time),
+		Version:   Version,
+		Encoding:  EncodingGzip,
+		Size:      buf.Len(),
+	}
+
+	if e.store == nil || e.maxInline <= 0 || buf.Len() <= e.maxInline {
+		msg.Payload = buf.Bytes()
+		return msg, nil
+	}
+
+	ref, err := e.store.Put(ctx, fmt.Sprintf("block-payload:%d:%s", number, block.Hash), buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gravar payload do bloco %d no blob store: %w", number, err)
+	}
+	msg.Ref = ref
+	return msg, nil
+}
@@ -0,0 +1,129 @@
+package blockpayload
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisRefPrefix = "redis:"
+	fileRefPrefix  = "file:"
+
+	// defaultMaxInline é o maior payload comprimido enviado dentro da própria mensagem (512 KiB)
+	defaultMaxInline = 512 * 1024
+)
+
+// Store guarda payloads grandes fora do RabbitMQ e devolve a referência usada na mensagem
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) (string, error)
+}
+
+// NewEncoderFromEnv cria o encoder configurado por BLOCK_PAYLOAD_STORE (none, redis ou file),
+// BLOCK_PAYLOAD_MAX_INLINE (bytes), BLOCK_PAYLOAD_TTL (redis), REDIS_URL e BLOCK_PAYLOAD_DIR (file)
+func NewEncoderFromEnv() (*Encoder, error) {
+	maxInline := defaultMaxInline
+	if value := os.Getenv("BLOCK_PAYLOAD_MAX_INLINE"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("BLOCK_PAYLOAD_MAX_INLINE inválido: %w", err)
+		}
+		maxInline = parsed
+	}
+
+	var store Store
+	switch kind := strings.ToLower(os.Getenv("BLOCK_PAYLOAD_STORE")); kind {
+	case "", "none":
+	case "redis":
+		ttl := 24 * time.Hour
+		if value := os.Getenv("BLOCK_PAYLOAD_TTL"); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("BLOCK_PAYLOAD_TTL inválido: %w", err)
+			}
+			ttl = parsed
+		}
+		redisStore, err := NewRedisStore(os.Getenv("REDIS_URL"), ttl)
+		if err != nil {
+			return nil, err
+		}
+		store = redisStore
+	case "file":
+		dir := os.Getenv("BLOCK_PAYLOAD_DIR")
+		if dir == "" {
+			dir = "/var/lib/besuscan/block-payloads"
+		}
+		fileStore, err := NewFileStore(dir)
+		if err != nil {
+			return nil, err
+		}
+		store = fileStore
+	default:
+		return nil, fmt.Errorf("blob store de payload desconhecido: %s", kind)
+	}
+
+	if store != nil {
+		log.Printf("📦 Payloads de bloco acima de %d bytes vão para o blob store (%s)", maxInline, os.Getenv("BLOCK_PAYLOAD_STORE"))
+	}
+	return NewEncoder(store, maxInline), nil
+}
+
+// RedisStore guarda payloads no Redis com expiração
+type RedisStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisStore conecta no Redis informado (padrão redis://redis:6379)
+func NewRedisStore(redisURL string, ttl time.Duration) (*RedisStore, error) {
+	if redisURL == "" {
+		redisURL = "redis://redis:6379"
+	}
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao parsear Redis URL: %w", err)
+	}
+	return &RedisStore{client: redis.NewClient(opt), ttl: ttl}, nil
+}
+
+// Put grava o payload na chave informada
+func (s *RedisStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	if err := s.client.Set(ctx, key, data, s.ttl).Err(); err != nil {
+		return "", err
+	}
+	return redisRefPrefix + key, nil
+}
+
+// FileStore guarda payloads em um diretório compartilhado entre indexer e worker
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore cria o diretório do store se necessário
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de payloads %s: %w", dir, err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Put grava o payload em um arquivo temporário e o renomeia para o worker nunca ler um arquivo incompleto
+func (s *FileStore) Put(_ context.Context, key string, data []byte) (string, error) {
+	path := filepath.Join(s.dir, strings.ReplaceAll(key, ":", "-")+".json.gz")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return fileRefPrefix + filepath.Base(path), nil
+}
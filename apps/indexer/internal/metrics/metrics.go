@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "besuscan_indexer"
+
+var (
+	chainHead    atomic.Uint64
+	lastIndexed  atomic.Uint64
+	lastObserved atomic.Int64
+
+	// QueuePublished conta as mensagens publicadas com sucesso por fila
+	QueuePublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_published_total",
+		Help:      "Mensagens publicadas no RabbitMQ por fila",
+	}, []string{"queue"})
+
+	// QueuePublishErrors conta as falhas de publicação por fila
+	QueuePublishErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_publish_errors_total",
+		Help:      "Falhas ao publicar mensagens no RabbitMQ por fila",
+	}, []string{"queue"})
+
+	// QueueConsumed conta as mensagens consumidas por fila e resultado (ack, nack, requeue, reject)
+	QueueConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_consumed_total",
+		Help:      "Mensagens consumidas do RabbitMQ por fila e resultado",
+	}, []string{"queue", "result"})
+
+	// RPCDuration mede a latência das chamadas JSON-RPC por método
+	RPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "Latência das chamadas JSON-RPC ao node por método",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method"})
+
+	// RPCErrors conta as chamadas JSON-RPC que falharam por método
+	RPCErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "Chamadas JSON-RPC ao node que retornaram erro por método",
+	}, []string{"method"})
+)
+
+func init() {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chain_head_block",
+		Help:      "Último bloco conhecido do node (head da chain)",
+	}, func() float64 { return float64(chainHead.Load()) })
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_indexed_block",
+		Help:      "Último bloco processado pelo indexer e publicado para o worker",
+	}, func() float64 { return float64(lastIndexed.Load()) })
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "block_lag",
+		Help:      "Diferença entre o head da chain e o último bloco indexado",
+	}, blockLag)
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chain_head_timestamp_seconds",
+		Help:      "Momento (unix) em que o último head da chain foi observado",
+	}, func() float64 { return float64(lastObserved.Load()) })
+}
+
+// SetChainHead registra um novo head da chain; valores menores que o atual são ignorados
+func SetChainHead(number uint64) {
+	for {
+		current := chainHead.Load()
+		if number <= current {
+			return
+		}
+		if chainHead.CompareAndSwap(current, number) {
+			lastObserved.Store(time.Now().Unix())
+			return
+		}
+	}
+}
+
+// SetLastIndexed registra o último bloco processado; valores menores que o atual são ignorados
+func SetLastIndexed(number uint64) {
+	for {
+		current := lastIndexed.Load()
+		if number <= current || lastIndexed.CompareAndSwap(current, number) {
+			return
+		}
+	}
+}
+
+// blockLag calcula o atraso em blocos; retorna 0 enquanto nenhum bloco foi indexado
+func blockLag() float64 {
+	head, indexed := chainHead.Load(), lastIndexed.Load()
+	if indexed == 0 || head <= indexed {
+		return 0
+	}
+	return float64(head - indexed)
+}
+
+// ObserveRPC executa uma chamada JSON-RPC registrando latência e erro para o método informado
+func ObserveRPC(method string, call func() error) error {
+	start := time.Now()
+	err := call()
+	RPCDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		RPCErrors.WithLabelValues(method).Inc()
+	}
+	return err
+}
+
+// StartServer expõe /metrics no endereço de METRICS_ADDR (padrão :9101) até o contexto ser cancelado
+func StartServer(ctx context.Context) {
+	addr := os.Getenv("METRICS_ADDR")
+	if addr == "" {
+		addr = ":9101"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("📊 Métricas Prometheus disponíveis em %s/metrics", addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("❌ Erro no servidor de métricas: %v", err)
+	}
+}
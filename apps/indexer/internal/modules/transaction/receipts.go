@@ -0,0 +1,122 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hubweb3/indexer/internal/tracing"
+)
+
+// receiptBatchSize limita quantas chamadas vão em cada batch JSON-RPC de receipts
+const receiptBatchSize = 100
+
+// blockReceiptsUnsupported evita repetir eth_getBlockReceipts em nodes que não implementam o método
+var blockReceiptsUnsupported atomic.Bool
+
+// fetchBlockReceipts busca os receipts de todas as transações do bloco em uma única chamada
+// (eth_getBlockReceipts) e, se o node não suportar, em batches de eth_getTransactionReceipt.
+// Os receipts voltam como JSON bruto indexado pelo hash da transação para serem repassados ao worker
+func fetchBlockReceipts(ctx context.Context, client *rpc.Client, block *types.Block) (map[common.Hash]json.RawMessage, error) {
+	if len(block.Transactions()) == 0 {
+		return map[common.Hash]json.RawMessage{}, nil
+	}
+
+	if !blockReceiptsUnsupported.Load() {
+		receipts, err := fetchReceiptsByBlock(ctx, client, block.NumberU64())
+		if err == nil {
+			return receipts, nil
+		}
+		if !isMethodNotFound(err) {
+			return nil, err
+		}
+		blockReceiptsUnsupported.Store(true)
+		log.Printf("[tx_indexer] ⚠️ Node não suporta eth_getBlockReceipts, usando batch de eth_getTransactionReceipt")
+	}
+
+	return fetchReceiptsBatch(ctx, client, block.Transactions())
+}
+
+// fetchReceiptsByBlock chama eth_getBlockReceipts para o número do bloco
+func fetchReceiptsByBlock(ctx context.Context, client *rpc.Client, number uint64) (map[common.Hash]json.RawMessage, error) {
+	var raw []json.RawMessage
+	err := tracing.ObserveRPC(ctx, "eth_getBlockReceipts", func(ctx context.Context) error {
+		return client.CallContext(ctx, &raw, "eth_getBlockReceipts", hexutil.EncodeUint64(number))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make(map[common.Hash]json.RawMessage, len(raw))
+	for _, receipt := range raw {
+		var ref struct {
+			TransactionHash common.Hash `json:"transactionHash"`
+		}
+		if err := json.Unmarshal(receipt, &ref); err != nil {
+			return nil, fmt.Errorf("erro ao ler receipt do bloco %d: %w", number, err)
+		}
+		receipts[ref.TransactionHash] = receipt
+	}
+	return receipts, nil
+}
+
+// fetchReceiptsBatch busca os receipts em batches JSON-RPC de eth_getTransactionReceipt
+func fetchReceiptsBatch(ctx context.Context, client *rpc.Client, txs types.Transactions) (map[common.Hash]json.RawMessage, error) {
+	receipts := make(map[common.Hash]json.RawMessage, len(txs))
+
+	for start := 0; start < len(txs); start += receiptBatchSize {
+		end := start + receiptBatchSize
+		if end > len(txs) {
+			end = len(txs)
+		}
+
+		results := make([]json.RawMessage, end-start)
+		batch := make([]rpc.BatchElem, end-start)
+		for i, tx := range txs[start:end] {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []interface{}{tx.Hash()},
+				Result: &results[i],
+			}
+		}
+
+		err := tracing.ObserveRPC(ctx, "eth_getTransactionReceipt_batch", func(ctx context.Context) error {
+			return client.BatchCallContext(ctx, batch)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i, elem := range batch {
+			if elem.Error != nil || len(results[i]) == 0 || string(results[i]) == "null" {
+				// Receipt ausente: o worker busca individualmente
+				continue
+			}
+			receipts[txs[start+i].Hash()] = results[i]
+		}
+	}
+
+	return receipts, nil
+}
+
+// isMethodNotFound identifica o erro JSON-RPC -32601 (método inexistente)
+func isMethodNotFound(err error) bool {
+	if rpcErr, ok := err.(rpc.Error); ok && rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "method not found") ||
+		strings.Contains(strings.ToLower(err.Error()), "does not exist")
+}
+
+// senderFor recupera o remetente pela assinatura, sem chamada RPC
+func senderFor(chainID *big.Int, tx *types.Transaction) (common.Address, error) {
+	return types.Sender(types.LatestSignerForChainID(chainID), tx)
+}
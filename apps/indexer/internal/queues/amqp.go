@@ -1,6 +1,10 @@
 package queues
 
 import (
+	"context"
+
+	"github.com/hubweb3/indexer/internal/metrics"
+	"github.com/hubweb3/indexer/internal/tracing"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -34,16 +38,30 @@ func NewPublisher(amqpURL string) (*Publisher, error) {
 }
 
 func (p *Publisher) Publish(queue string, body []byte) error {
-	return p.channel.Publish(
+	return p.PublishWithContext(context.Background(), queue, body)
+}
+
+// PublishWithContext publica a mensagem propagando o trace context do ctx nos headers AMQP
+func (p *Publisher) PublishWithContext(ctx context.Context, queue string, body []byte) error {
+	span, headers := startPublishSpan(ctx, queue)
+	err := p.channel.Publish(
 		"",    // exchange
 		queue, // routing key (queue name)
 		false, // mandatory
 		false, // immediate
 		amqp.Publishing{
 			ContentType: "application/json",
+			Headers:     headers,
 			Body:        body,
 		},
 	)
+	tracing.End(span, err)
+	if err != nil {
+		metrics.QueuePublishErrors.WithLabelValues(queue).Inc()
+		return err
+	}
+	metrics.QueuePublished.WithLabelValues(queue).Inc()
+	return nil
 }
 
 func (p *Publisher) DeclareQueue(decl QueueDeclaration) error {
@@ -94,7 +112,10 @@ func (c *Consumer) Consume(queue string) (<-chan amqp.Delivery, error) {
 	msgs, err := c.channel.Consume(
 		queue, "", false, false, false, false, nil, // Mudou auto-ack de true para false
 	)
-	return msgs, err
+	if err != nil {
+		return nil, err
+	}
+	return instrumentDeliveries(queue, msgs), nil
 }
 
 func (c *Consumer) Close() {
@@ -0,0 +1,46 @@
+package queues
+
+import (
+	"github.com/hubweb3/indexer/internal/metrics"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// countingAcknowledger registra em métricas o resultado (ack/nack/reject) de cada mensagem consumida
+type countingAcknowledger struct {
+	amqp.Acknowledger
+	queue string
+}
+
+func (a *countingAcknowledger) Ack(tag uint64, multiple bool) error {
+	metrics.QueueConsumed.WithLabelValues(a.queue, "ack").Inc()
+	return a.Acknowledger.Ack(tag, multiple)
+}
+
+func (a *countingAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	result := "nack"
+	if requeue {
+		result = "requeue"
+	}
+	metrics.QueueConsumed.WithLabelValues(a.queue, result).Inc()
+	return a.Acknowledger.Nack(tag, multiple, requeue)
+}
+
+func (a *countingAcknowledger) Reject(tag uint64, requeue bool) error {
+	metrics.QueueConsumed.WithLabelValues(a.queue, "reject").Inc()
+	return a.Acknowledger.Reject(tag, requeue)
+}
+
+// instrumentDeliveries repassa as mensagens da fila trocando o Acknowledger por um que contabiliza os resultados
+func instrumentDeliveries(queue string, msgs <-chan amqp.Delivery) <-chan amqp.Delivery {
+	out := make(chan amqp.Delivery)
+	go func() {
+		defer close(out)
+		for d := range msgs {
+			if d.Acknowledger != nil {
+				d.Acknowledger = &countingAcknowledger{Acknowledger: d.Acknowledger, queue: queue}
+			}
+			out <- d
+		}
+	}()
+	return out
+}
@@ -0,0 +1,471 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrNoEndpoints indica que nenhum endpoint RPC foi configurado
+var ErrNoEndpoints = errors.New("nenhum endpoint RPC configurado")
+
+// routedURL é o destino fictício usado pelo cliente roteado; o transporte troca pela URL do endpoint escolhido
+const routedURL = "http://rpcpool.local"
+
+// Config define os endpoints e os critérios de saúde do pool
+type Config struct {
+	HTTPURLs       []string
+	WSURLs         []string
+	HealthInterval time.Duration
+	MaxBlockLag    uint64
+	MaxErrorRate   float64
+	RequestTimeout time.Duration
+}
+
+// ConfigFromEnv lê a configuração do ambiente.
+// ETH_RPC_URLS / ETH_WS_URLS aceitam listas separadas por vírgula; ETH_RPC_URL / ETH_WS_URL continuam aceitos
+func ConfigFromEnv() Config {
+	return Config{
+		HTTPURLs:       splitURLs(os.Getenv("ETH_RPC_URLS"), os.Getenv("ETH_RPC_URL")),
+		WSURLs:         splitURLs(os.Getenv("ETH_WS_URLS"), os.Getenv("ETH_WS_URL")),
+		HealthInterval: envDuration("RPC_HEALTH_INTERVAL", 5*time.Second),
+		MaxBlockLag:    uint64(envInt("RPC_MAX_BLOCK_LAG", 3)),
+		MaxErrorRate:   0.5,
+		RequestTimeout: envDuration("RPC_REQUEST_TIMEOUT", 30*time.Second),
+	}
+}
+
+// EndpointStatus é o retrato da saúde de um endpoint
+type EndpointStatus struct {
+	URL       string        `json:"url"`
+	WebSocket bool          `json:"websocket"`
+	Head      uint64        `json:"head"`
+	Lag       uint64        `json:"lag"`
+	Latency   time.Duration `json:"latency"`
+	ErrorRate float64       `json:"error_rate"`
+	Healthy   bool          `json:"healthy"`
+	LastError string        `json:"last_error,omitempty"`
+}
+
+// endpoint guarda a conexão e as estatísticas de um node
+type endpoint struct {
+	url string
+	ws  bool
+
+	mu        sync.RWMutex
+	client    *rpc.Client
+	up        bool
+	head      uint64
+	latency   time.Duration
+	errorRate float64
+	lastErr   error
+	healthy   bool
+}
+
+// Pool distribui as chamadas RPC entre vários nodes, priorizando os saudáveis e sincronizados
+type Pool struct {
+	cfg       Config
+	endpoints []*endpoint
+
+	mu      sync.RWMutex
+	maxHead uint64
+
+	httpClient *http.Client
+	routed     *rpc.Client
+	offline    *rpc.Client // Cliente sem métodos devolvido enquanto nenhum node está disponível
+
+	cancel context.CancelFunc
+}
+
+// New cria o pool, faz a primeira checagem de saúde e inicia o monitoramento periódico
+func New(ctx context.Context, cfg Config) (*Pool, error) {
+	if len(cfg.HTTPURLs)+len(cfg.WSURLs) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	if cfg.HealthInterval <= 0 {
+		cfg.HealthInterval = 5 * time.Second
+	}
+	if cfg.MaxErrorRate <= 0 {
+		cfg.MaxErrorRate = 0.5
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = 30 * time.Second
+	}
+
+	p := &Pool{cfg: cfg, offline: rpc.DialInProc(rpc.NewServer())}
+	for _, url := range cfg.HTTPURLs {
+		p.endpoints = append(p.endpoints, &endpoint{url: url})
+	}
+	for _, url := range cfg.WSURLs {
+		p.endpoints = append(p.endpoints, &endpoint{url: url, ws: true})
+	}
+
+	p.httpClient = &http.Client{
+		Transport: &routingTransport{pool: p, base: http.DefaultTransport},
+		Timeout:   cfg.RequestTimeout,
+	}
+	if len(cfg.HTTPURLs) > 0 {
+		routed, err := rpc.DialOptions(ctx, routedURL, rpc.WithHTTPClient(p.httpClient))
+		if err != nil {
+			p.offline.Close()
+			return nil, fmt.Errorf("erro ao criar cliente RPC roteado: %w", err)
+		}
+		p.routed = routed
+	}
+
+	p.checkAll(ctx)
+
+	healthy := 0
+	for _, status := range p.Status() {
+		if status.Healthy {
+			healthy++
+		}
+	}
+	log.Printf("[rpc_pool] ✅ Pool RPC iniciado com %d endpoints (%d saudáveis, head %d)", len(p.endpoints), healthy, p.Head())
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go p.healthLoop(loopCtx)
+
+	return p, nil
+}
+
+// Client retorna um cliente Ethereum para leituras.
+// Com endpoints HTTP o cliente é estável e cada requisição vai para o melhor node disponível;
+// sem eles, retorna o cliente WebSocket mais saudável no momento
+func (p *Pool) Client() *ethclient.Client {
+	return ethclient.NewClient(p.RPC())
+}
+
+// RPC retorna o cliente JSON-RPC bruto com a mesma política de roteamento de Client
+func (p *Pool) RPC() *rpc.Client {
+	if p.routed != nil {
+		return p.routed
+	}
+	candidates := p.candidates(true)
+	for _, ep := range candidates {
+		if client := ep.rpcClient(); client != nil {
+			return client
+		}
+	}
+
+	// Nenhum node conectado no momento: tentar conectar o melhor candidato imediatamente
+	for _, ep := range candidates {
+		ctx, cancel := context.WithTimeout(context.Background(), p.cfg.HealthInterval)
+		client, err := ep.connect(ctx)
+		cancel()
+		if err == nil {
+			return client
+		}
+	}
+
+	// Todos indisponíveis: cliente sem métodos compartilhado, as chamadas falham até o health check reconectar
+	log.Println("[rpc_pool] ⚠️ Nenhum endpoint RPC disponível")
+	return p.offline
+}
+
+// Head retorna o maior bloco conhecido entre os endpoints
+func (p *Pool) Head() uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.maxHead
+}
+
+// Status retorna a situação de cada endpoint
+func (p *Pool) Status() []EndpointStatus {
+	head := p.Head()
+	statuses := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		ep.mu.RLock()
+		status := EndpointStatus{
+			URL:       ep.url,
+			WebSocket: ep.ws,
+			Head:      ep.head,
+			Latency:   ep.latency,
+			ErrorRate: ep.errorRate,
+			Healthy:   ep.healthy,
+		}
+		if head > ep.head {
+			status.Lag = head - ep.head
+		}
+		if ep.lastErr != nil {
+			status.LastError = ep.lastErr.Error()
+		}
+		ep.mu.RUnlock()
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Close encerra o monitoramento e as conexões
+func (p *Pool) Close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	if p.routed != nil {
+		p.routed.Close()
+	}
+	p.offline.Close()
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		if ep.client != nil {
+			ep.client.Close()
+			ep.client = nil
+		}
+		ep.mu.Unlock()
+	}
+}
+
+// healthLoop repete a checagem de saúde no intervalo configurado
+func (p *Pool) healthLoop(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkAll(ctx)
+		}
+	}
+}
+
+// checkAll consulta eth_blockNumber em todos os endpoints em paralelo e recalcula a saúde
+func (p *Pool) checkAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, ep := range p.endpoints {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			p.check(ctx, ep)
+		}(ep)
+	}
+	wg.Wait()
+
+	var maxHead uint64
+	for _, ep := range p.endpoints {
+		ep.mu.RLock()
+		if ep.up && ep.head > maxHead {
+			maxHead = ep.head
+		}
+		ep.mu.RUnlock()
+	}
+
+	// O head de referência é o maior entre os nodes que responderam nesta rodada
+	p.mu.Lock()
+	if maxHead > 0 {
+		p.maxHead = maxHead
+	}
+	head := p.maxHead
+	p.mu.Unlock()
+
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		healthy := ep.up && ep.errorRate < p.cfg.MaxErrorRate && head <= ep.head+p.cfg.MaxBlockLag
+		changed := healthy != ep.healthy
+		ep.healthy = healthy
+		lag := uint64(0)
+		if head > ep.head {
+			lag = head - ep.head
+		}
+		ep.mu.Unlock()
+
+		if changed {
+			if healthy {
+				log.Printf("[rpc_pool] 🟢 Endpoint %s saudável (head %d)", ep.url, head-lag)
+			} else {
+				log.Printf("[rpc_pool] 🔴 Endpoint %s fora de rotação (lag %d blocos, erro: %v)", ep.url, lag, ep.lastError())
+			}
+		}
+	}
+}
+
+// check conecta o endpoint se necessário e atualiza head e latência
+func (p *Pool) check(ctx context.Context, ep *endpoint) {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.HealthInterval)
+	defer cancel()
+
+	client, err := ep.connect(ctx)
+	if err != nil {
+		ep.record(0, err)
+		ep.markDown(err)
+		return
+	}
+
+	start := time.Now()
+	head, err := ethclient.NewClient(client).BlockNumber(ctx)
+	ep.record(time.Since(start), err)
+	if err != nil {
+		ep.markDown(err)
+		return
+	}
+
+	ep.mu.Lock()
+	ep.up = true
+	ep.head = head
+	ep.mu.Unlock()
+}
+
+// candidates retorna os endpoints do tipo pedido ordenados do melhor para o pior.
+// Endpoints fora de rotação ficam no fim da lista como último recurso
+func (p *Pool) candidates(ws bool) []*endpoint {
+	type ranked struct {
+		ep        *endpoint
+		healthy   bool
+		head      uint64
+		errorRate float64
+		latency   time.Duration
+	}
+
+	list := make([]ranked, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		if ep.ws != ws {
+			continue
+		}
+		ep.mu.RLock()
+		list = append(list, ranked{ep: ep, healthy: ep.healthy, head: ep.head, errorRate: ep.errorRate, latency: ep.latency})
+		ep.mu.RUnlock()
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.healthy != b.healthy {
+			return a.healthy
+		}
+		if a.head != b.head {
+			return a.head > b.head
+		}
+		if a.errorRate != b.errorRate {
+			return a.errorRate < b.errorRate
+		}
+		return a.latency < b.latency
+	})
+
+	endpoints := make([]*endpoint, len(list))
+	for i, item := range list {
+		endpoints[i] = item.ep
+	}
+	return endpoints
+}
+
+// isHealthy informa se o endpoint está em rotação
+func (ep *endpoint) isHealthy() bool {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.healthy
+}
+
+// rpcClient retorna a conexão atual do endpoint (nil se ainda não conectado)
+func (ep *endpoint) rpcClient() *rpc.Client {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.client
+}
+
+// connect retorna a conexão do endpoint, discando se necessário
+func (ep *endpoint) connect(ctx context.Context) (*rpc.Client, error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	if ep.client != nil {
+		return ep.client, nil
+	}
+	client, err := rpc.DialContext(ctx, ep.url)
+	if err != nil {
+		return nil, err
+	}
+	ep.client = client
+	return client, nil
+}
+
+// lastError retorna o último erro observado
+func (ep *endpoint) lastError() error {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.lastErr
+}
+
+// record atualiza as médias móveis de latência e taxa de erro
+func (ep *endpoint) record(latency time.Duration, err error) {
+	const alpha = 0.2
+
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	failed := 0.0
+	if err != nil {
+		failed = 1
+		ep.lastErr = err
+	}
+	ep.errorRate = ep.errorRate*(1-alpha) + failed*alpha
+
+	if err == nil && latency > 0 {
+		if ep.latency == 0 {
+			ep.latency = latency
+		} else {
+			ep.latency = time.Duration(float64(ep.latency)*(1-alpha) + float64(latency)*alpha)
+		}
+	}
+}
+
+// markDown tira o endpoint de rotação até a próxima checagem bem-sucedida
+func (ep *endpoint) markDown(err error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	ep.up = false
+	ep.lastErr = err
+	// Conexões WebSocket quebradas precisam ser refeitas
+	if ep.ws && ep.client != nil {
+		ep.client.Close()
+		ep.client = nil
+	}
+}
+
+// splitURLs junta as listas de URLs informadas, ignorando vazios e duplicados
+func splitURLs(values ...string) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, value := range values {
+		for _, url := range strings.Split(value, ",") {
+			url = strings.TrimSpace(url)
+			if url == "" || seen[url] {
+				continue
+			}
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// envDuration lê uma duração do ambiente ou retorna o padrão
+func envDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+// envInt lê um inteiro do ambiente ou retorna o padrão
+func envInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
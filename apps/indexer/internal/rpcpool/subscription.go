@@ -0,0 +1,201 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// errEndpointUnhealthy encerra a assinatura de um node que saiu de rotação para forçar o failover
+var errEndpointUnhealthy = errors.New("endpoint saiu de rotação")
+
+// subscribeFunc abre a assinatura em um endpoint específico
+type subscribeFunc func(ctx context.Context, ep *endpoint) (ethereum.Subscription, error)
+
+// maxHeadGap limita quantos headers perdidos são buscados de uma vez ao retomar a assinatura newHeads
+const maxHeadGap = 1000
+
+// SubscribeNewHead assina novos headers com failover transparente entre os endpoints WebSocket.
+// Headers que não chegaram enquanto a assinatura era refeita são buscados e entregues em ordem
+func (p *Pool) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	heads := make(chan *types.Header)
+	sub, err := p.subscribe(ctx, "newHeads", func(ctx context.Context, ep *endpoint) (ethereum.Subscription, error) {
+		return ethclient.NewClient(ep.rpcClient()).SubscribeNewHead(ctx, heads)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.relayHeads(sub, heads, ch), nil
+}
+
+// SubscribeFilterLogs assina logs com failover transparente entre os endpoints WebSocket
+func (p *Pool) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return p.subscribe(ctx, "logs", func(ctx context.Context, ep *endpoint) (ethereum.Subscription, error) {
+		return ethclient.NewClient(ep.rpcClient()).SubscribeFilterLogs(ctx, query, ch)
+	})
+}
+
+// SubscribePendingTransactions assina hashes de transações pendentes com failover entre os endpoints WebSocket
+func (p *Pool) SubscribePendingTransactions(ctx context.Context, ch chan<- string) (ethereum.Subscription, error) {
+	return p.subscribe(ctx, "newPendingTransactions", func(ctx context.Context, ep *endpoint) (ethereum.Subscription, error) {
+		return ep.rpcClient().EthSubscribe(ctx, ch, "newPendingTransactions")
+	})
+}
+
+// subscribe mantém a assinatura viva: quando o node atual falha ou sai de rotação,
+// assina novamente no melhor endpoint disponível sem encerrar a assinatura do chamador
+func (p *Pool) subscribe(ctx context.Context, name string, open subscribeFunc) (ethereum.Subscription, error) {
+	if len(p.cfg.WSURLs) == 0 {
+		return nil, fmt.Errorf("assinatura %s requer ao menos um endpoint WebSocket (ETH_WS_URL/ETH_WS_URLS)", name)
+	}
+
+	// A primeira assinatura é síncrona para que erros de configuração apareçam para o chamador
+	first, firstEp, err := p.openOnBest(ctx, open)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao assinar %s: %w", name, err)
+	}
+	log.Printf("[rpc_pool] 🔔 Assinatura %s ativa em %s", name, firstEp.url)
+
+	return event.ResubscribeErr(10*time.Second, func(ctx context.Context, lastErr error) (event.Subscription, error) {
+		if first != nil {
+			sub, ep := first, firstEp
+			first, firstEp = nil, nil
+			return p.watch(sub, ep), nil
+		}
+
+		if lastErr != nil {
+			log.Printf("[rpc_pool] ⚠️ Assinatura %s interrompida (%v), refazendo em outro endpoint...", name, lastErr)
+		}
+		sub, ep, err := p.openOnBest(ctx, open)
+		if err != nil {
+			log.Printf("[rpc_pool] ❌ Falha ao refazer assinatura %s: %v", name, err)
+			return nil, err
+		}
+		log.Printf("[rpc_pool] 🔔 Assinatura %s ativa em %s", name, ep.url)
+		return p.watch(sub, ep), nil
+	}), nil
+}
+
+// openOnBest tenta abrir a assinatura nos endpoints WebSocket do melhor para o pior
+func (p *Pool) openOnBest(ctx context.Context, open subscribeFunc) (ethereum.Subscription, *endpoint, error) {
+	var lastErr error
+	for _, ep := range p.candidates(true) {
+		if _, err := ep.connect(ctx); err != nil {
+			ep.record(0, err)
+			lastErr = err
+			continue
+		}
+		sub, err := open(ctx, ep)
+		if err != nil {
+			ep.record(0, err)
+			ep.markDown(err)
+			lastErr = err
+			continue
+		}
+		return sub, ep, nil
+	}
+	if lastErr == nil {
+		lastErr = ErrNoEndpoints
+	}
+	return nil, nil, lastErr
+}
+
+// watch repassa o erro da assinatura e a encerra quando o endpoint sai de rotação
+// enquanto existe outro endpoint WebSocket saudável para assumir
+func (p *Pool) watch(sub ethereum.Subscription, ep *endpoint) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(p.cfg.HealthInterval)
+		defer ticker.Stop()
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case err := <-sub.Err():
+				if err == nil {
+					// Canal fechado sem erro: o node encerrou a assinatura, refazer mesmo assim
+					err = errors.New("assinatura encerrada pelo node")
+				}
+				ep.record(0, err)
+				return err
+			case <-quit:
+				return nil
+			case <-ticker.C:
+				if !ep.isHealthy() && p.hasHealthyAlternative(ep) {
+					return fmt.Errorf("%s: %w", ep.url, errEndpointUnhealthy)
+				}
+			}
+		}
+	})
+}
+
+// hasHealthyAlternative informa se outro endpoint WebSocket saudável pode assumir a assinatura
+func (p *Pool) hasHealthyAlternative(current *endpoint) bool {
+	for _, ep := range p.endpoints {
+		if ep != current && ep.ws && ep.isHealthy() {
+			return true
+		}
+	}
+	return false
+}
+
+// relayHeads repassa os headers da assinatura e preenche lacunas de numeração (failover ou node que
+// pulou notificações) com eth_getBlockByNumber antes de entregar o header seguinte
+func (p *Pool) relayHeads(sub ethereum.Subscription, heads <-chan *types.Header, ch chan<- *types.Header) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+
+		deliver := func(header *types.Header) bool {
+			select {
+			case ch <- header:
+				return true
+			case <-quit:
+				return false
+			}
+		}
+
+		var last uint64
+		for {
+			select {
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			case header := <-heads:
+				number := header.Number.Uint64()
+				if last > 0 && number > last+1 {
+					from := last + 1
+					if number-from > maxHeadGap {
+						log.Printf("[rpc_pool] ⚠️ Lacuna de %d headers na assinatura newHeads, recuperando apenas os últimos %d", number-from, maxHeadGap)
+						from = number - maxHeadGap
+					}
+					for missing := from; missing < number; missing++ {
+						ctx, cancel := context.WithTimeout(context.Background(), p.cfg.RequestTimeout)
+						gap, err := p.Client().HeaderByNumber(ctx, new(big.Int).SetUint64(missing))
+						cancel()
+						if err != nil {
+							log.Printf("[rpc_pool] ⚠️ Erro ao recuperar header %d perdido na assinatura newHeads: %v", missing, err)
+							continue
+						}
+						if !deliver(gap) {
+							return nil
+						}
+					}
+				}
+				if !deliver(header) {
+					return nil
+				}
+				if number > last {
+					last = number
+				}
+			}
+		}
+	})
+}
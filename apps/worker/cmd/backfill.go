@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application"
+	"github.com/hubweb3/worker/internal/application/handlers"
+	"github.com/hubweb3/worker/internal/config"
+	"github.com/hubweb3/worker/internal/tracing"
+)
+
+// runBackfill executa o subcomando "backfill": sincroniza uma faixa histórica direto no PostgreSQL,
+// com checkpoint por faixa, podendo rodar junto com o worker e o indexer em tempo real
+func runBackfill(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	from := flags.Uint64("from", 0, "primeiro bloco")
+	to := flags.Uint64("to", 0, "último bloco (padrão: anterior ao menor bloco já indexado, ou head da chain)")
+	rangeSize := flags.Uint64("range-size", 100, "blocos por faixa (checkpoint e batch JSON-RPC)")
+	minWorkers := flags.Int("workers", 4, "concorrência inicial e mínima")
+	maxWorkers := flags.Int("max-workers", 32, "concorrência máxima")
+	targetLatency := flags.Duration("target-latency", 2*time.Second, "latência de busca de uma faixa acima da qual a concorrência é reduzida")
+	lease := flags.Duration("lease", 10*time.Minute, "tempo após o qual uma faixa abandonada por outro processo pode ser retomada")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *rangeSize == 0 {
+		return fmt.Errorf("--range-size deve ser maior que zero")
+	}
+
+	log.Println("🚚 Iniciando backfill histórico...")
+
+	shutdownTracing, err := tracing.Init(context.Background(), "besuscan-backfill")
+	if err != nil {
+		return fmt.Errorf("erro ao configurar tracing: %w", err)
+	}
+	defer func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Printf("⚠️ Erro ao finalizar tracing: %v", err)
+		}
+	}()
+
+	container, err := application.NewBackfillContainer(cfg)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	// Ctrl+C interrompe o backfill; faixas em andamento ficam livres para a próxima execução
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return container.GetBackfillHandler().Run(ctx, handlers.BackfillOptions{
+		From:          *from,
+		To:            *to,
+		RangeSize:     *rangeSize,
+		MinWorkers:    *minWorkers,
+		MaxWorkers:    *maxWorkers,
+		TargetLatency: *targetLatency,
+		Lease:         *lease,
+	})
+}
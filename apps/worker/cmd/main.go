@@ -13,6 +13,8 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/hubweb3/worker/internal/application"
 	"github.com/hubweb3/worker/internal/config"
+	"github.com/hubweb3/worker/internal/metrics"
+	"github.com/hubweb3/worker/internal/tracing"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/joho/godotenv"
 )
@@ -29,6 +31,59 @@ func main() {
 	// Carregar configurações
 	cfg := config.Load()
 
+	// Subcomando de backfill histórico: worker backfill --from N --to M
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		if err := runBackfill(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("❌ Backfill falhou: %v", err)
+		}
+		return
+	}
+
+	// Subcomando de migrations do schema: worker migrate up|down|status|baseline
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("❌ Migrate falhou: %v", err)
+		}
+		return
+	}
+
+	// Subcomando de rollups de estatísticas: worker stats rebuild|update
+	if len(os.Args) > 1 && os.Args[1] == "stats" {
+		if err := runStats(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("❌ Stats falhou: %v", err)
+		}
+		return
+	}
+
+	// Subcomando de avaliação de risco: worker risk enqueue-all|evaluate
+	if len(os.Args) > 1 && os.Args[1] == "risk" {
+		if err := runRisk(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("❌ Risk falhou: %v", err)
+		}
+		return
+	}
+
+	// Subcomando de triagem de listas de sanções: worker screening rescreen [--all]
+	if len(os.Args) > 1 && os.Args[1] == "screening" {
+		if err := runScreening(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("❌ Screening falhou: %v", err)
+		}
+		return
+	}
+
+	// Configurar tracing distribuído (OpenTelemetry)
+	shutdownTracing, err := tracing.Init(context.Background(), "besuscan-worker")
+	if err != nil {
+		log.Fatalf("❌ Falha ao configurar tracing: %v", err)
+	}
+	defer func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Printf("⚠️ Erro ao finalizar tracing: %v", err)
+		}
+	}()
+
 	// Inicializar container de dependências
 	container, err := application.NewContainer(cfg)
 	if err != nil {
@@ -48,6 +103,10 @@ func main() {
 	// WaitGroup para aguardar todos os handlers terminarem
 	var wg sync.WaitGroup
 
+	// Expor métricas Prometheus e acompanhar o head da chain para o cálculo de lag
+	go metrics.StartServer(ctx, cfg.MetricsAddr)
+	go metrics.TrackChainHead(ctx, container.GetEthClient(), cfg.MetricsChainHeadInterval)
+
 	// Iniciar Block Handler (nova arquitetura)
 	wg.Add(1)
 	go func() {
@@ -108,6 +167,86 @@ func main() {
 		}
 	}()
 
+	// Iniciar Compliance Handler
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		complianceHandler := container.GetComplianceHandler()
+		if err := complianceHandler.Start(ctx); err != nil {
+			log.Printf("❌ Erro no Compliance Handler: %v", err)
+		}
+	}()
+
+	// Iniciar Alert Dispatcher (entrega de webhooks)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		alertDispatcher := container.GetAlertDispatcherHandler()
+		if err := alertDispatcher.Start(ctx); err != nil {
+			log.Printf("❌ Erro no Alert Dispatcher: %v", err)
+		}
+	}()
+
+	// Iniciar Partition Manager (criação e retenção de partições)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		partitionManager := container.GetPartitionManagerHandler()
+		if err := partitionManager.Start(ctx); err != nil {
+			log.Printf("❌ Erro no Partition Manager: %v", err)
+		}
+	}()
+
+	// Iniciar Stats Rollup (estatísticas da rede por hora e por dia)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		statsRollup := container.GetStatsRollupHandler()
+		if err := statsRollup.Start(ctx); err != nil {
+			log.Printf("❌ Erro no Stats Rollup: %v", err)
+		}
+	}()
+
+	// Iniciar Risk Evaluation (política de risco versionada)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		riskEvaluation := container.GetRiskEvaluationHandler()
+		if err := riskEvaluation.Start(ctx); err != nil {
+			log.Printf("❌ Erro no Risk Evaluation: %v", err)
+		}
+	}()
+
+	// Iniciar Screening (listas de sanções e denylists)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		screening := container.GetScreeningHandler()
+		if err := screening.Start(ctx); err != nil {
+			log.Printf("❌ Erro no Screening: %v", err)
+		}
+	}()
+
+	// Iniciar Tag Rules (reavaliação das regras de tags automáticas)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		tagRules := container.GetTagRulesHandler()
+		if err := tagRules.Start(ctx); err != nil {
+			log.Printf("❌ Erro no Tag Rules: %v", err)
+		}
+	}()
+
+	// Iniciar Revert Reasons (motivo de falha das transações gravadas sem ele)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		revertReasons := container.GetRevertReasonHandler()
+		if err := revertReasons.Start(ctx); err != nil {
+			log.Printf("❌ Erro no Revert Reasons: %v", err)
+		}
+	}()
+
 	go func() {
 		time.Sleep(10 * time.Second) // Aguardar inicialização completa
 		cleanupIncorrectContracts(ctx, container.GetDBPool(), container.GetEthClient())
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hubweb3/worker/internal/config"
+	"github.com/hubweb3/worker/internal/infrastructure/migrations"
+)
+
+const migrateUsage = "uso: worker migrate up [--to N] | down [--steps N] | status | baseline --version N"
+
+// runMigrate executa o subcomando "migrate": aplica, reverte e inspeciona as migrations
+// versionadas embutidas no binário
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(migrateUsage)
+	}
+
+	action := args[0]
+	flags := flag.NewFlagSet("migrate "+action, flag.ContinueOnError)
+	to := flags.Int64("to", 0, "versão alvo do up (padrão: a mais recente)")
+	steps := flags.Int("steps", 1, "quantidade de migrations revertidas pelo down")
+	version := flags.Int64("version", 0, "última versão já presente no banco (baseline)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir conexão com PostgreSQL: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("erro ao conectar ao PostgreSQL: %w", err)
+	}
+
+	runner, err := migrations.NewRunner(db)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		applied, err := runner.Up(ctx, *to)
+		for _, migration := range applied {
+			log.Printf("✅ Migration %04d_%s aplicada", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			log.Println("✅ Schema já está atualizado")
+		}
+	case "down":
+		reverted, err := runner.Down(ctx, *steps)
+		for _, migration := range reverted {
+			log.Printf("↩️ Migration %04d_%s revertida", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			log.Println("ℹ️ Nenhuma migration aplicada para reverter")
+		}
+	case "status":
+		statuses, unknown, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			switch {
+			case status.Baseline:
+				fmt.Printf("✅ %04d_%s (baseline em %s)\n", status.Version, status.Name, status.AppliedAt.Format("2006-01-02 15:04:05"))
+			case status.Applied:
+				fmt.Printf("✅ %04d_%s (aplicada em %s)\n", status.Version, status.Name, status.AppliedAt.Format("2006-01-02 15:04:05"))
+			default:
+				fmt.Printf("⏳ %04d_%s (pendente)\n", status.Version, status.Name)
+			}
+		}
+		for _, row := range unknown {
+			fmt.Printf("⚠️ %04d_%s (aplicada no banco, desconhecida por este binário)\n", row.Version, row.Name)
+		}
+	case "baseline":
+		if *version == 0 {
+			return fmt.Errorf("--version é obrigatório no baseline")
+		}
+		marked, err := runner.Baseline(ctx, *version)
+		if err != nil {
+			return err
+		}
+		for _, migration := range marked {
+			log.Printf("📌 Migration %04d_%s marcada como aplicada", migration.Version, migration.Name)
+		}
+	default:
+		return fmt.Errorf("ação desconhecida %q; %s", action, migrateUsage)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hubweb3/worker/internal/application/services"
+	"github.com/hubweb3/worker/internal/config"
+	"github.com/hubweb3/worker/internal/infrastructure/database"
+)
+
+const riskUsage = "uso: worker risk enqueue-all | evaluate --address 0x..."
+
+// runRisk executa o subcomando "risk": enfileira ou avalia accounts com a política de risco ativa
+func runRisk(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(riskUsage)
+	}
+
+	action := args[0]
+	flags := flag.NewFlagSet("risk "+action, flag.ContinueOnError)
+	address := flags.String("address", "", "account avaliada pelo evaluate")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir conexão com PostgreSQL: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("erro ao conectar ao PostgreSQL: %w", err)
+	}
+
+	// Sem alertas: mudanças de status disparadas aqui não geram webhooks, mas abrem casos de compliance
+	caseService := services.NewComplianceCaseService(database.NewPostgresComplianceCaseRepository(db))
+	riskService := services.NewRiskService(database.NewPostgresRiskRepository(db), nil, caseService)
+
+	switch action {
+	case "enqueue-all":
+		queued, err := riskService.MarkAllPending(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("✅ %d accounts enfileiradas para avaliação de risco", queued)
+	case "evaluate":
+		if *address == "" {
+			return fmt.Errorf("--address é obrigatório; %s", riskUsage)
+		}
+		if _, err := riskService.RefreshPolicy(ctx); err != nil {
+			return err
+		}
+		evaluation, err := riskService.EvaluateAccount(ctx, *address, services.RiskTriggerManual)
+		if err != nil {
+			return err
+		}
+		log.Printf("✅ Account %s avaliada pela política v%d: score %d (%s)", evaluation.Address, evaluation.PolicyVersion, evaluation.Score, evaluation.Status)
+		for _, rule := range evaluation.Breakdown.Rules {
+			if rule.Matched {
+				log.Printf("   • %s: %+g", rule.RuleID, rule.Contribution)
+			}
+		}
+	default:
+		return fmt.Errorf("ação desconhecida %q; %s", action, riskUsage)
+	}
+
+	return nil
+}
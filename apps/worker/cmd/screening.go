@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hubweb3/worker/internal/application/services"
+	"github.com/hubweb3/worker/internal/config"
+	"github.com/hubweb3/worker/internal/infrastructure/database"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const screeningUsage = "uso: worker screening rescreen [--all]"
+
+// runScreening executa o subcomando "screening": aplica as listas pendentes de triagem
+func runScreening(cfg *config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "rescreen" {
+		return fmt.Errorf(screeningUsage)
+	}
+	all := len(args) > 1 && args[1] == "--all"
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := pgxpool.Connect(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao PostgreSQL: %w", err)
+	}
+	defer db.Close()
+
+	sqlDB, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir conexão com PostgreSQL: %w", err)
+	}
+	defer sqlDB.Close()
+	caseService := services.NewComplianceCaseService(database.NewPostgresComplianceCaseRepository(sqlDB))
+
+	// Sem alertas nem traces: transferências internas só entram pelo processamento de blocos.
+	// Mudanças de status continuam abrindo casos de compliance
+	screening := services.NewScreeningService(db, nil, nil, caseService, services.ScreeningPolicy{
+		MaxHops:      cfg.ScreeningMaxHops,
+		Decay:        cfg.ScreeningDecay,
+		FlagWeight:   cfg.ScreeningFlagWeight,
+		ReviewWeight: cfg.ScreeningReviewWeight,
+	})
+
+	if all {
+		// Necessário depois de um backfill, que não passa pela triagem por bloco
+		marked, err := screening.MarkAllForRescreen(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("📋 %d listas ativas agendadas para retriagem", marked)
+	}
+
+	processed, err := screening.Rescreen(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ %d listas de triagem aplicadas", processed)
+	return nil
+}
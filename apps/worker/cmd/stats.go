@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hubweb3/worker/internal/application/services"
+	"github.com/hubweb3/worker/internal/config"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const statsUsage = "uso: worker stats rebuild [--from N] [--to M] | update"
+
+// runStats executa o subcomando "stats": recalcula ou atualiza os rollups de estatísticas da rede
+func runStats(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(statsUsage)
+	}
+
+	action := args[0]
+	flags := flag.NewFlagSet("stats "+action, flag.ContinueOnError)
+	from := flags.Int64("from", 0, "primeiro bloco do rebuild")
+	to := flags.Int64("to", 0, "último bloco do rebuild (padrão: cursor dos rollups)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := pgxpool.Connect(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao PostgreSQL: %w", err)
+	}
+	defer db.Close()
+
+	statsRollup := services.NewStatsRollupService(db, services.StatsRollupPolicy{
+		LagBlocks:   cfg.StatsRollupLagBlocks,
+		BatchBlocks: cfg.StatsRollupBatchBlocks,
+	})
+
+	switch action {
+	case "rebuild":
+		if *to == 0 {
+			*to = math.MaxInt64
+		}
+		if *to < *from {
+			return fmt.Errorf("--to deve ser maior ou igual a --from")
+		}
+		if err := statsRollup.Rebuild(ctx, *from, *to); err != nil {
+			return err
+		}
+		log.Println("✅ Rollups de estatísticas recalculados")
+	case "update":
+		processed, err := statsRollup.Update(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("✅ %d blocos incorporados aos rollups de estatísticas", processed)
+	default:
+		return fmt.Errorf("ação desconhecida %q; %s", action, statsUsage)
+	}
+
+	return nil
+}
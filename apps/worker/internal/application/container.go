@@ -13,7 +13,10 @@ import (
 	"github.com/hubweb3/worker/internal/config"
 	"github.com/hubweb3/worker/internal/domain/repositories"
 	domainServices "github.com/hubweb3/worker/internal/domain/services"
+	"github.com/hubweb3/worker/internal/infrastructure/blockpayload"
 	"github.com/hubweb3/worker/internal/infrastructure/database"
+	"github.com/hubweb3/worker/internal/infrastructure/migrations"
+	"github.com/hubweb3/worker/internal/infrastructure/rpcpool"
 	"github.com/hubweb3/worker/internal/queues"
 	"github.com/jackc/pgx/v4/pgxpool"
 	_ "github.com/lib/pq"
@@ -26,12 +29,15 @@ type Container struct {
 	// Infrastructure
 	db                  *sql.DB
 	dbPool              *pgxpool.Pool
+	rpcPool             *rpcpool.Pool
+	payloadStore        blockpayload.Store
 	ethClient           *ethclient.Client
 	blockConsumer       *queues.Consumer // Consumer dedicado para blocos
 	transactionConsumer *queues.Consumer // Consumer dedicado para transações
 	accountConsumer     *queues.Consumer // Consumer dedicado para accounts
 	pendingTxConsumer   *queues.Consumer // Consumer dedicado para pending transactions
 	eventConsumer       *queues.Consumer // Consumer dedicado para eventos
+	complianceConsumer  *queues.Consumer // Consumer dedicado para atualizações de compliance
 	publisher           *queues.Publisher
 
 	// Repositories
@@ -41,6 +47,11 @@ type Container struct {
 	validatorRepo repositories.ValidatorRepository
 	eventRepo     repositories.EventRepository
 	contractRepo  repositories.SmartContractRepository
+	alertRepo     repositories.AlertRepository
+	backfillRepo  repositories.BackfillRepository
+	bulkWriter    repositories.BulkWriter
+	riskRepo      repositories.RiskRepository
+	caseRepo      repositories.ComplianceCaseRepository
 
 	// Services
 	blockService                *domainServices.BlockService
@@ -48,6 +59,15 @@ type Container struct {
 	contractMetricsService      *services.SmartContractMetricsService
 	accountTransactionProcessor *services.AccountTransactionProcessor
 	validatorService            *domainServices.ValidatorService
+	alertService                *services.AlertService
+	partitionService            *services.PartitionService
+	statsRollupService          *services.StatsRollupService
+	riskService                 *services.RiskService
+	screeningService            *services.ScreeningService
+	caseService                 *services.ComplianceCaseService
+	taggingService              *services.AccountTaggingService
+	userOperationService        *services.UserOperationService
+	revertReasonService         *services.RevertReasonService
 
 	// Handlers
 	blockHandler       *handlers.BlockHandler
@@ -56,6 +76,15 @@ type Container struct {
 	validatorHandler   *handlers.ValidatorHandler
 	pendingTxHandler   *handlers.PendingTxHandler
 	eventHandler       *handlers.EventHandler
+	complianceHandler  *handlers.ComplianceHandler
+	alertDispatcher    *handlers.AlertDispatcherHandler
+	partitionManager   *handlers.PartitionManagerHandler
+	statsRollup        *handlers.StatsRollupHandler
+	riskEvaluation     *handlers.RiskEvaluationHandler
+	screening          *handlers.ScreeningHandler
+	tagRules           *handlers.TagRulesHandler
+	revertReasons      *handlers.RevertReasonHandler
+	backfillHandler    *handlers.BackfillHandler
 }
 
 // NewContainer cria uma nova instância do container
@@ -75,8 +104,41 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	return container, nil
 }
 
+// NewBackfillContainer cria o container do backfill histórico: apenas PostgreSQL, pool RPC e repositórios,
+// sem conexões com o RabbitMQ
+func NewBackfillContainer(cfg *config.Config) (*Container, error) {
+	container := &Container{
+		config: cfg,
+	}
+
+	if err := container.initializeDatabase(); err != nil {
+		return nil, fmt.Errorf("erro ao inicializar infraestrutura: %w", err)
+	}
+	if err := container.initializeRPC(); err != nil {
+		return nil, fmt.Errorf("erro ao inicializar infraestrutura: %w", err)
+	}
+
+	container.initializeRepositories()
+	container.statsRollupService = container.newStatsRollupService()
+	container.userOperationService = services.NewUserOperationService(container.dbPool, cfg.EntryPointAddresses)
+	container.backfillHandler = handlers.NewBackfillHandler(container.bulkWriter, container.backfillRepo, container.ethClient, container.statsRollupService, container.userOperationService)
+
+	return container, nil
+}
+
 // initializeInfrastructure inicializa as dependências de infraestrutura
 func (c *Container) initializeInfrastructure() error {
+	if err := c.initializeDatabase(); err != nil {
+		return err
+	}
+	if err := c.initializeRPC(); err != nil {
+		return err
+	}
+	return c.initializeQueues()
+}
+
+// initializeDatabase conecta ao PostgreSQL (database/sql e pgxpool)
+func (c *Container) initializeDatabase() error {
 	var err error
 
 	// Conectar ao PostgreSQL com retry
@@ -106,6 +168,16 @@ func (c *Container) initializeInfrastructure() error {
 
 	c.db = db
 
+	// Recusar iniciar com um schema diferente do esperado pelo binário
+	runner, err := migrations.NewRunner(db)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar migrations: %w", err)
+	}
+	if err := runner.Check(context.Background()); err != nil {
+		return fmt.Errorf("schema do banco incompatível com o worker: %w", err)
+	}
+	log.Printf("✅ Schema do banco na versão %d", migrations.Latest(runner.Migrations()))
+
 	// Conectar ao PostgreSQL com pgxpool para o TransactionMethodService
 	var dbPool *pgxpool.Pool
 	for i := 0; i < maxRetriesDB; i++ {
@@ -131,14 +203,37 @@ func (c *Container) initializeInfrastructure() error {
 	}
 
 	c.dbPool = dbPool
+	return nil
+}
 
-	// Conectar ao Ethereum
-	ethClient, err := ethclient.Dial(c.config.EthereumRPCURL)
+// initializeRPC cria o pool RPC e o blob store de payloads de blocos
+func (c *Container) initializeRPC() error {
+	// Conectar ao Ethereum através do pool RPC (vários nodes com health check e failover)
+	rpcPool, err := rpcpool.New(context.Background(), rpcpool.Config{
+		HTTPURLs:       c.config.EthereumRPCURLs,
+		HealthInterval: c.config.RPCHealthInterval,
+		MaxBlockLag:    c.config.RPCMaxBlockLag,
+		RequestTimeout: c.config.RPCRequestTimeout,
+	})
 	if err != nil {
 		return fmt.Errorf("erro ao conectar ao Ethereum: %w", err)
 	}
 
-	c.ethClient = ethClient
+	c.rpcPool = rpcPool
+	c.ethClient = rpcPool.Client()
+
+	// Blob store dos payloads de blocos que não couberam na mensagem
+	payloadStore, err := blockpayload.NewStore(c.config.BlockPayloadStore, c.config.RedisURL, c.config.BlockPayloadDir)
+	if err != nil {
+		return fmt.Errorf("erro ao configurar blob store de payloads: %w", err)
+	}
+	c.payloadStore = payloadStore
+	return nil
+}
+
+// initializeQueues conecta os consumers e o publisher do RabbitMQ
+func (c *Container) initializeQueues() error {
+	var err error
 
 	// Conectar ao RabbitMQ com retry
 	var blockConsumer *queues.Consumer
@@ -146,6 +241,7 @@ func (c *Container) initializeInfrastructure() error {
 	var accountConsumer *queues.Consumer
 	var pendingTxConsumer *queues.Consumer
 	var eventConsumer *queues.Consumer
+	var complianceConsumer *queues.Consumer
 	maxRetriesRMQ := 10
 	for i := 0; i < maxRetriesRMQ; i++ {
 		blockConsumer, err = queues.NewConsumer(c.config.RabbitMQURL)
@@ -158,7 +254,10 @@ func (c *Container) initializeInfrastructure() error {
 					if err == nil {
 						eventConsumer, err = queues.NewConsumer(c.config.RabbitMQURL)
 						if err == nil {
-							break
+							complianceConsumer, err = queues.NewConsumer(c.config.RabbitMQURL)
+							if err == nil {
+								break
+							}
 						}
 					}
 				}
@@ -180,6 +279,7 @@ func (c *Container) initializeInfrastructure() error {
 	c.accountConsumer = accountConsumer
 	c.pendingTxConsumer = pendingTxConsumer
 	c.eventConsumer = eventConsumer
+	c.complianceConsumer = complianceConsumer
 
 	// Conectar ao RabbitMQ Publisher
 	publisher, err := queues.NewPublisher(c.config.RabbitMQURL)
@@ -200,6 +300,11 @@ func (c *Container) initializeRepositories() {
 	c.validatorRepo = database.NewPostgresValidatorRepository(c.db)
 	c.eventRepo = database.NewPostgresEventRepository(c.db)
 	c.contractRepo = database.NewPostgresSmartContractRepository(c.db)
+	c.alertRepo = database.NewPostgresAlertRepository(c.db)
+	c.backfillRepo = database.NewPostgresBackfillRepository(c.db)
+	c.riskRepo = database.NewPostgresRiskRepository(c.db)
+	c.caseRepo = database.NewPostgresComplianceCaseRepository(c.db)
+	c.bulkWriter = database.NewPostgresBulkWriter(c.dbPool)
 }
 
 // initializeServices inicializa os serviços de domínio
@@ -207,21 +312,68 @@ func (c *Container) initializeServices() {
 	c.blockService = domainServices.NewBlockService(c.blockRepo, c.txRepo)
 	c.transactionMethodService = services.NewTransactionMethodService(c.dbPool)
 	c.contractMetricsService = services.NewSmartContractMetricsService(c.dbPool)
-	c.accountTransactionProcessor = services.NewAccountTransactionProcessor(c.dbPool, c.ethClient)
 	c.validatorService = domainServices.NewValidatorService(c.validatorRepo)
+	c.alertService = services.NewAlertService(
+		c.alertRepo,
+		c.config.AlertMaxAttempts,
+		c.config.AlertRetryBaseDelay,
+		c.config.AlertDeliveryTimeout,
+		c.config.AlertRulesRefresh,
+	)
+	c.caseService = services.NewComplianceCaseService(c.caseRepo)
+	c.riskService = services.NewRiskService(c.riskRepo, c.alertService, c.caseService)
+	c.screeningService = services.NewScreeningService(c.dbPool, c.ethClient, c.alertService, c.caseService, c.screeningPolicy())
+	c.taggingService = services.NewAccountTaggingService(c.dbPool, c.config.NativeTokenDecimals, c.config.TagRulesRefresh)
+	c.userOperationService = services.NewUserOperationService(c.dbPool, c.config.EntryPointAddresses)
+	c.revertReasonService = services.NewRevertReasonService(c.dbPool, c.ethClient)
+	c.accountTransactionProcessor = services.NewAccountTransactionProcessor(c.dbPool, c.bulkWriter, c.ethClient, c.taggingService, c.screeningService, c.userOperationService)
+	c.partitionService = services.NewPartitionService(c.dbPool, services.PartitionPolicy{
+		BlockRange:      c.config.PartitionBlockRange,
+		Premake:         c.config.PartitionPremake,
+		RetentionBlocks: c.config.PartitionRetentionBlocks,
+		RetentionMonths: c.config.PartitionRetentionMonths,
+		RetentionAction: c.config.PartitionRetentionAction,
+	})
+	c.statsRollupService = c.newStatsRollupService()
+}
+
+// screeningPolicy monta a política de propagação da triagem configurada
+func (c *Container) screeningPolicy() services.ScreeningPolicy {
+	return services.ScreeningPolicy{
+		MaxHops:       c.config.ScreeningMaxHops,
+		Decay:         c.config.ScreeningDecay,
+		FlagWeight:    c.config.ScreeningFlagWeight,
+		ReviewWeight:  c.config.ScreeningReviewWeight,
+		TraceInternal: c.config.ScreeningTraceInternal,
+	}
+}
+
+// newStatsRollupService cria o serviço de rollups de estatísticas com a política configurada
+func (c *Container) newStatsRollupService() *services.StatsRollupService {
+	return services.NewStatsRollupService(c.dbPool, services.StatsRollupPolicy{
+		LagBlocks:   c.config.StatsRollupLagBlocks,
+		BatchBlocks: c.config.StatsRollupBatchBlocks,
+	})
 }
 
 // initializeHandlers inicializa os handlers de aplicação
 func (c *Container) initializeHandlers() {
-	c.blockHandler = handlers.NewBlockHandler(c.blockService, c.ethClient, c.blockConsumer, c.publisher)
-	c.transactionHandler = handlers.NewTransactionHandler(c.blockService, c.txRepo, c.ethClient, c.transactionConsumer, c.publisher, c.transactionMethodService, c.contractMetricsService, c.accountTransactionProcessor)
+	c.transactionHandler = handlers.NewTransactionHandler(c.blockService, c.txRepo, c.bulkWriter, c.ethClient, c.transactionConsumer, c.publisher, c.transactionMethodService, c.contractMetricsService, c.accountTransactionProcessor, c.alertService, c.revertReasonService, c.config.AccountBlockFlushInterval)
+	c.eventHandler = handlers.NewEventHandler(c.eventRepo, c.bulkWriter, c.contractRepo, c.eventConsumer, c.publisher, c.accountTransactionProcessor, c.alertService)
+	c.blockHandler = handlers.NewBlockHandler(c.bulkWriter, c.ethClient, c.blockConsumer, c.publisher, c.payloadStore, c.transactionHandler, c.eventHandler)
 	c.accountHandler = handlers.NewAccountHandler(c.accountRepo, c.accountConsumer, c.publisher)
 	c.pendingTxHandler = handlers.NewPendingTxHandler(c.pendingTxConsumer, c.publisher)
-	c.eventHandler = handlers.NewEventHandler(c.eventRepo, c.contractRepo, c.eventConsumer, c.publisher, c.accountTransactionProcessor)
-
-	// Obter URL do RPC Besu para validadores
-	besuRPCURL := c.config.EthereumRPCURL
-	c.validatorHandler = handlers.NewValidatorHandler(c.validatorService, c.publisher, besuRPCURL)
+	c.complianceHandler = handlers.NewComplianceHandler(c.accountRepo, c.complianceConsumer, c.alertService, c.caseService)
+	c.alertDispatcher = handlers.NewAlertDispatcherHandler(c.alertService, c.config.AlertDispatchInterval)
+	c.partitionManager = handlers.NewPartitionManagerHandler(c.partitionService, c.config.PartitionManagerInterval)
+	c.statsRollup = handlers.NewStatsRollupHandler(c.statsRollupService, c.config.StatsRollupInterval)
+	c.riskEvaluation = handlers.NewRiskEvaluationHandler(c.riskService, c.config.RiskEvaluationInterval, c.config.RiskEvaluationBatchSize)
+	c.screening = handlers.NewScreeningHandler(c.screeningService, c.config.ScreeningInterval)
+	c.tagRules = handlers.NewTagRulesHandler(c.taggingService, c.config.TagReevaluationInterval)
+	c.revertReasons = handlers.NewRevertReasonHandler(c.revertReasonService, c.config.RevertReasonInterval, c.config.RevertReasonBatchSize)
+
+	// Chamadas QBFT dos validadores também passam pelo pool RPC
+	c.validatorHandler = handlers.NewValidatorHandler(c.validatorService, c.publisher, c.rpcPool)
 }
 
 // GetBlockHandler retorna o handler de blocos
@@ -254,6 +406,51 @@ func (c *Container) GetEventHandler() *handlers.EventHandler {
 	return c.eventHandler
 }
 
+// GetComplianceHandler retorna o handler de atualizações de compliance
+func (c *Container) GetComplianceHandler() *handlers.ComplianceHandler {
+	return c.complianceHandler
+}
+
+// GetAlertDispatcherHandler retorna o handler de entrega de alertas
+func (c *Container) GetAlertDispatcherHandler() *handlers.AlertDispatcherHandler {
+	return c.alertDispatcher
+}
+
+// GetPartitionManagerHandler retorna o handler de manutenção de partições
+func (c *Container) GetPartitionManagerHandler() *handlers.PartitionManagerHandler {
+	return c.partitionManager
+}
+
+// GetStatsRollupHandler retorna o handler de rollups de estatísticas da rede
+func (c *Container) GetStatsRollupHandler() *handlers.StatsRollupHandler {
+	return c.statsRollup
+}
+
+// GetRiskEvaluationHandler retorna o handler de avaliação de risco de accounts
+func (c *Container) GetRiskEvaluationHandler() *handlers.RiskEvaluationHandler {
+	return c.riskEvaluation
+}
+
+// GetScreeningHandler retorna o handler de retriagem das listas de sanções
+func (c *Container) GetScreeningHandler() *handlers.ScreeningHandler {
+	return c.screening
+}
+
+// GetTagRulesHandler retorna o handler de reavaliação das regras de tags
+func (c *Container) GetTagRulesHandler() *handlers.TagRulesHandler {
+	return c.tagRules
+}
+
+// GetRevertReasonHandler retorna o handler de motivos de falha das transações
+func (c *Container) GetRevertReasonHandler() *handlers.RevertReasonHandler {
+	return c.revertReasons
+}
+
+// GetBackfillHandler retorna o handler de backfill histórico (apenas no container de backfill)
+func (c *Container) GetBackfillHandler() *handlers.BackfillHandler {
+	return c.backfillHandler
+}
+
 // GetBlockService retorna o serviço de blocos
 func (c *Container) GetBlockService() *domainServices.BlockService {
 	return c.blockService
@@ -279,11 +476,16 @@ func (c *Container) GetDBPool() *pgxpool.Pool {
 	return c.dbPool
 }
 
-// GetEthClient retorna o cliente Ethereum
+// GetEthClient retorna o cliente Ethereum (roteado pelo pool RPC)
 func (c *Container) GetEthClient() *ethclient.Client {
 	return c.ethClient
 }
 
+// GetRPCPool retorna o pool de endpoints RPC
+func (c *Container) GetRPCPool() *rpcpool.Pool {
+	return c.rpcPool
+}
+
 // GetBlockConsumer retorna o consumer de blocos
 func (c *Container) GetBlockConsumer() *queues.Consumer {
 	return c.blockConsumer
@@ -313,8 +515,8 @@ func (c *Container) Close() error {
 		c.dbPool.Close()
 	}
 
-	if c.ethClient != nil {
-		c.ethClient.Close()
+	if c.rpcPool != nil {
+		c.rpcPool.Close()
 	}
 
 	if c.blockConsumer != nil {
@@ -337,6 +539,10 @@ func (c *Container) Close() error {
 		c.eventConsumer.Close()
 	}
 
+	if c.complianceConsumer != nil {
+		c.complianceConsumer.Close()
+	}
+
 	if c.publisher != nil {
 		c.publisher.Close()
 	}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application/services"
+)
+
+// AlertDispatcherHandler entrega periodicamente os webhooks de alertas pendentes
+type AlertDispatcherHandler struct {
+	alertService     *services.AlertService
+	dispatchInterval time.Duration
+}
+
+// NewAlertDispatcherHandler cria uma nova instância do handler de entrega de alertas
+func NewAlertDispatcherHandler(alertService *services.AlertService, dispatchInterval time.Duration) *AlertDispatcherHandler {
+	return &AlertDispatcherHandler{
+		alertService:     alertService,
+		dispatchInterval: dispatchInterval,
+	}
+}
+
+// Start inicia o loop de entrega de webhooks
+func (h *AlertDispatcherHandler) Start(ctx context.Context) error {
+	log.Println("🔄 Iniciando Alert Dispatcher Handler...")
+
+	ticker := time.NewTicker(h.dispatchInterval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Alert Dispatcher Handler iniciado, verificando entregas a cada %v", h.dispatchInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Alert Dispatcher Handler encerrado")
+			return nil
+		case <-ticker.C:
+			// Esvaziar a fila de entregas vencidas antes de aguardar o próximo ciclo
+			for {
+				processed, err := h.alertService.DispatchDue(ctx)
+				if err != nil {
+					log.Printf("❌ Erro ao entregar alertas: %v", err)
+					break
+				}
+				if processed == 0 || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,528 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hubweb3/worker/internal/application/services"
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+	"github.com/hubweb3/worker/internal/tracing"
+)
+
+// backfillBatchSize limita quantas chamadas vão em cada batch JSON-RPC (o Besu recusa batches acima de
+// 1024 chamadas; o fallback de receipts faz uma chamada por transação da faixa)
+const backfillBatchSize = 100
+
+// BackfillOptions configura uma execução do backfill histórico
+type BackfillOptions struct {
+	From          uint64        // Primeiro bloco
+	To            uint64        // Último bloco (0 = bloco anterior ao menor já indexado, ou head da chain)
+	RangeSize     uint64        // Blocos por faixa (checkpoint e batch JSON-RPC)
+	MinWorkers    int           // Concorrência inicial e mínima
+	MaxWorkers    int           // Concorrência máxima
+	TargetLatency time.Duration // Latência de busca de uma faixa acima da qual a concorrência é reduzida
+	Lease         time.Duration // Tempo após o qual uma faixa "running" abandonada pode ser reservada de novo
+}
+
+// BackfillHandler sincroniza faixas históricas buscando blocos em batch no node e
+// gravando cada faixa com o bulk writer, sem passar pelas filas
+type BackfillHandler struct {
+	bulkWriter   repositories.BulkWriter
+	backfillRepo repositories.BackfillRepository
+	ethClient    *ethclient.Client
+	statsRollup  *services.StatsRollupService
+	userOps      *services.UserOperationService
+	owner        string
+	decoder      *EventHandler // Apenas para decodificar eventos conhecidos (Transfer, Approval...)
+
+	blockReceiptsUnsupported atomic.Bool
+}
+
+// NewBackfillHandler cria uma nova instância do handler de backfill
+func NewBackfillHandler(
+	bulkWriter repositories.BulkWriter,
+	backfillRepo repositories.BackfillRepository,
+	ethClient *ethclient.Client,
+	statsRollup *services.StatsRollupService,
+	userOps *services.UserOperationService,
+) *BackfillHandler {
+	hostname, _ := os.Hostname()
+	return &BackfillHandler{
+		bulkWriter:   bulkWriter,
+		backfillRepo: backfillRepo,
+		ethClient:    ethClient,
+		statsRollup:  statsRollup,
+		userOps:      userOps,
+		owner:        fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		decoder:      &EventHandler{},
+	}
+}
+
+// backfillProgress acumula os números da execução para o log de progresso
+type backfillProgress struct {
+	ranges  atomic.Int64
+	skipped atomic.Int64
+	failed  atomic.Int64
+	blocks  atomic.Int64
+	txs     atomic.Int64
+}
+
+// Run executa o backfill de From até To e retorna erro se alguma faixa falhar.
+// Faixas já concluídas, reservadas por outro processo ou já gravadas pelo pipeline em tempo real são puladas
+func (h *BackfillHandler) Run(ctx context.Context, opts BackfillOptions) error {
+	if opts.RangeSize == 0 {
+		opts.RangeSize = 100
+	}
+	if opts.MinWorkers <= 0 {
+		opts.MinWorkers = 1
+	}
+	if opts.MaxWorkers < opts.MinWorkers {
+		opts.MaxWorkers = opts.MinWorkers
+	}
+
+	if opts.To == 0 {
+		to, err := h.defaultTo(ctx)
+		if err != nil {
+			return err
+		}
+		opts.To = to
+	}
+	if opts.To < opts.From {
+		log.Printf("ℹ️ [backfill] Nada a fazer: faixa %d-%d vazia", opts.From, opts.To)
+		return nil
+	}
+
+	log.Printf("🚚 [backfill] Iniciando blocos %d-%d (faixas de %d, concorrência %d-%d, latência alvo %s)",
+		opts.From, opts.To, opts.RangeSize, opts.MinWorkers, opts.MaxWorkers, opts.TargetLatency)
+
+	limiter := newAdaptiveLimiter(opts.MinWorkers, opts.MaxWorkers, opts.TargetLatency)
+	progress := &backfillProgress{}
+	started := time.Now()
+
+	stopReport := make(chan struct{})
+	go h.reportProgress(progress, opts, started, limiter, stopReport)
+	defer close(stopReport)
+
+	var wg sync.WaitGroup
+	for start := opts.From; start <= opts.To; start += opts.RangeSize {
+		end := start + opts.RangeSize - 1
+		if end > opts.To || end < start {
+			end = opts.To
+		}
+
+		if err := limiter.Acquire(ctx); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(rng *entities.BackfillRange) {
+			defer wg.Done()
+			fetchDuration, err := h.processRange(ctx, rng, opts.Lease, progress)
+			limiter.Release(fetchDuration, err)
+			if err != nil {
+				if ctx.Err() == nil {
+					progress.failed.Add(1)
+					log.Printf("❌ [backfill] Faixa %d-%d falhou: %v", rng.Start, rng.End, err)
+				}
+				// Liberar a faixa para a próxima execução sem esperar o lease vencer
+				if failErr := h.backfillRepo.FailRange(context.Background(), rng, err); failErr != nil {
+					log.Printf("⚠️ [backfill] %v", failErr)
+				}
+			}
+		}(&entities.BackfillRange{Start: start, End: end, Owner: h.owner})
+
+		if end == opts.To {
+			break
+		}
+	}
+	wg.Wait()
+
+	elapsed := time.Since(started)
+	log.Printf("✅ [backfill] Concluído em %s: %d faixas, %d puladas, %d falhas, %d blocos, %d transações",
+		elapsed.Round(time.Second), progress.ranges.Load(), progress.skipped.Load(), progress.failed.Load(),
+		progress.blocks.Load(), progress.txs.Load())
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("backfill interrompido: %w", err)
+	}
+	if failed := progress.failed.Load(); failed > 0 {
+		return fmt.Errorf("%d faixas falharam; execute o backfill novamente para reprocessá-las", failed)
+	}
+
+	// Blocos gravados abaixo do cursor dos rollups só entram nas estatísticas recalculando seus dias
+	if h.statsRollup != nil && progress.blocks.Load() > 0 {
+		if err := h.statsRollup.Rebuild(ctx, int64(opts.From), int64(opts.To)); err != nil {
+			return fmt.Errorf("erro ao recalcular rollups de estatísticas: %w", err)
+		}
+	}
+	return nil
+}
+
+// defaultTo escolhe o fim do backfill: o bloco anterior ao menor já indexado pelo pipeline em tempo real,
+// ou o head da chain quando o banco está vazio
+func (h *BackfillHandler) defaultTo(ctx context.Context) (uint64, error) {
+	lowest, ok, err := h.backfillRepo.LowestIndexedBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		if lowest == 0 {
+			return 0, nil
+		}
+		return lowest - 1, nil
+	}
+
+	var head uint64
+	err = tracing.ObserveRPC(ctx, "eth_blockNumber", func(ctx context.Context) (callErr error) {
+		head, callErr = h.ethClient.BlockNumber(ctx)
+		return callErr
+	})
+	if err != nil {
+		return 0, fmt.Errorf("erro ao obter head da chain: %w", err)
+	}
+	return head, nil
+}
+
+// processRange reserva, busca e grava uma faixa; retorna a duração da busca no node para o limitador
+func (h *BackfillHandler) processRange(ctx context.Context, rng *entities.BackfillRange, lease time.Duration, progress *backfillProgress) (time.Duration, error) {
+	claimed, err := h.backfillRepo.ClaimRange(ctx, rng, lease)
+	if err != nil {
+		return 0, err
+	}
+	if !claimed {
+		progress.skipped.Add(1)
+		return 0, nil
+	}
+
+	// Faixa já gravada pelo pipeline em tempo real (ou por um backfill anterior sem checkpoint)
+	indexed, err := h.backfillRepo.CountIndexedBlocks(ctx, rng.Start, rng.End)
+	if err != nil {
+		return 0, err
+	}
+	if indexed == rng.Size() {
+		progress.skipped.Add(1)
+		return 0, h.backfillRepo.CompleteRange(ctx, rng)
+	}
+
+	fetchStart := time.Now()
+	bundles, logs, err := h.fetchRange(ctx, rng.Start, rng.End)
+	fetchDuration := time.Since(fetchStart)
+	if err != nil {
+		return fetchDuration, err
+	}
+
+	// Blocos, transações, eventos e contas da faixa em uma única transação
+	if err := h.bulkWriter.WriteBlocks(ctx, bundles); err != nil {
+		return fetchDuration, fmt.Errorf("erro ao gravar faixa em lote: %w", err)
+	}
+	if err := h.indexUserOperations(ctx, bundles, logs); err != nil {
+		return fetchDuration, err
+	}
+
+	rng.BlockCount = len(bundles)
+	for _, bundle := range bundles {
+		rng.TxCount += len(bundle.Transactions)
+	}
+	if err := h.backfillRepo.CompleteRange(ctx, rng); err != nil {
+		return fetchDuration, err
+	}
+
+	progress.ranges.Add(1)
+	progress.blocks.Add(int64(rng.BlockCount))
+	progress.txs.Add(int64(rng.TxCount))
+	return fetchDuration, nil
+}
+
+// rpcBlock contém os campos do eth_getBlockByNumber que não fazem parte do types.Header
+type rpcBlock struct {
+	Hash         common.Hash       `json:"hash"`
+	Size         hexutil.Uint64    `json:"size"`
+	Uncles       []common.Hash     `json:"uncles"`
+	Transactions []json.RawMessage `json:"transactions"`
+}
+
+// indexUserOperations indexa as UserOperations ERC-4337 da faixa, como o FinishBlock faz no pipeline em
+// tempo real. Roda depois da gravação da faixa; a reexecução de uma faixa falha apenas regrava as mesmas
+// UserOperations (upsert pelo userOpHash)
+func (h *BackfillHandler) indexUserOperations(ctx context.Context, bundles []*entities.BlockBundle, logs map[string][]*types.Log) error {
+	if h.userOps == nil {
+		return nil
+	}
+	for _, bundle := range bundles {
+		blockTxs := make([]*services.BlockTransaction, len(bundle.Transactions))
+		for i, tx := range bundle.Transactions {
+			blockTxs[i] = &services.BlockTransaction{Transaction: tx, Logs: logs[tx.Hash]}
+		}
+		if err := h.userOps.IndexBlock(ctx, blockTxs, logs); err != nil {
+			return fmt.Errorf("erro ao indexar UserOperations do bloco %d: %w", bundle.Block.Number, err)
+		}
+	}
+	return nil
+}
+
+// fetchRange busca blocos (com transações) e receipts da faixa em batches JSON-RPC. Retorna também os logs
+// dos receipts por hash de transação
+func (h *BackfillHandler) fetchRange(ctx context.Context, from, to uint64) ([]*entities.BlockBundle, map[string][]*types.Log, error) {
+	count := int(to-from) + 1
+	rawBlocks := make([]json.RawMessage, count)
+	batch := make([]rpc.BatchElem, count)
+	for i := range batch {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(from + uint64(i)), true},
+			Result: &rawBlocks[i],
+		}
+	}
+	if err := h.batchCall(ctx, "eth_getBlockByNumber_batch", batch); err != nil {
+		return nil, nil, err
+	}
+
+	receipts, err := h.fetchReceipts(ctx, from, rawBlocks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bundles := make([]*entities.BlockBundle, 0, count)
+	logs := make(map[string][]*types.Log)
+	for i, raw := range rawBlocks {
+		number := from + uint64(i)
+		if len(raw) == 0 || string(raw) == "null" {
+			return nil, nil, fmt.Errorf("bloco %d não encontrado no node", number)
+		}
+
+		header := new(types.Header)
+		if err := json.Unmarshal(raw, header); err != nil {
+			return nil, nil, fmt.Errorf("erro ao decodificar cabeçalho do bloco %d: %w", number, err)
+		}
+		var body rpcBlock
+		if err := json.Unmarshal(raw, &body); err != nil {
+			return nil, nil, fmt.Errorf("erro ao decodificar bloco %d: %w", number, err)
+		}
+
+		blockHash := body.Hash.Hex()
+		bundle := &entities.BlockBundle{
+			Block: blockEntityFromHeader(blockHash, header, uint64(body.Size), len(body.Transactions), len(body.Uncles)),
+		}
+
+		for _, rawTx := range body.Transactions {
+			tx := new(types.Transaction)
+			if err := json.Unmarshal(rawTx, tx); err != nil {
+				return nil, nil, fmt.Errorf("erro ao decodificar transação do bloco %d: %w", number, err)
+			}
+			var sender struct {
+				From common.Address `json:"from"`
+			}
+			if err := json.Unmarshal(rawTx, &sender); err != nil {
+				return nil, nil, fmt.Errorf("erro ao decodificar remetente da transação do bloco %d: %w", number, err)
+			}
+
+			receipt, ok := receipts[tx.Hash()]
+			if !ok {
+				return nil, nil, fmt.Errorf("receipt da transação %s não encontrado", tx.Hash().Hex())
+			}
+			txEntity := transactionEntity(tx, receipt, sender.From.Hex(), number, blockHash, header.Time)
+			bundle.Transactions = append(bundle.Transactions, txEntity)
+			logs[txEntity.Hash] = receipt.Logs
+			for _, vLog := range receipt.Logs {
+				bundle.Events = append(bundle.Events, h.decoder.eventEntity(vLog, txEntity, bundle.Block.Timestamp))
+			}
+		}
+		bundles = append(bundles, bundle)
+	}
+
+	return bundles, logs, nil
+}
+
+// fetchReceipts busca os receipts de todos os blocos com eth_getBlockReceipts e, se o node
+// não suportar o método, com eth_getTransactionReceipt em batch
+func (h *BackfillHandler) fetchReceipts(ctx context.Context, from uint64, rawBlocks []json.RawMessage) (map[common.Hash]*types.Receipt, error) {
+	receipts := make(map[common.Hash]*types.Receipt)
+
+	if !h.blockReceiptsUnsupported.Load() {
+		results := make([][]*types.Receipt, len(rawBlocks))
+		batch := make([]rpc.BatchElem, len(rawBlocks))
+		for i := range batch {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getBlockReceipts",
+				Args:   []interface{}{hexutil.EncodeUint64(from + uint64(i))},
+				Result: &results[i],
+			}
+		}
+		if err := h.batchCall(ctx, "eth_getBlockReceipts_batch", batch); err == nil {
+			for _, blockReceipts := range results {
+				for _, receipt := range blockReceipts {
+					receipts[receipt.TxHash] = receipt
+				}
+			}
+			return receipts, nil
+		} else if !isMethodNotFound(err) {
+			return nil, err
+		}
+		h.blockReceiptsUnsupported.Store(true)
+		log.Printf("⚠️ [backfill] Node não suporta eth_getBlockReceipts, usando batch de eth_getTransactionReceipt")
+	}
+
+	var batch []rpc.BatchElem
+	for _, raw := range rawBlocks {
+		var body struct {
+			Transactions []struct {
+				Hash common.Hash `json:"hash"`
+			} `json:"transactions"`
+		}
+		if err := json.Unmarshal(raw, &body); err != nil {
+			return nil, fmt.Errorf("erro ao ler hashes das transações: %w", err)
+		}
+		for _, tx := range body.Transactions {
+			receipt := new(types.Receipt)
+			batch = append(batch, rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []interface{}{tx.Hash},
+				Result: receipt,
+			})
+		}
+	}
+	if len(batch) == 0 {
+		return receipts, nil
+	}
+	if err := h.batchCall(ctx, "eth_getTransactionReceipt_batch", batch); err != nil {
+		return nil, err
+	}
+	for _, elem := range batch {
+		receipt := elem.Result.(*types.Receipt)
+		receipts[receipt.TxHash] = receipt
+	}
+	return receipts, nil
+}
+
+// batchCall executa as chamadas em lotes de backfillBatchSize e devolve o primeiro erro, seja da
+// requisição ou de um elemento
+func (h *BackfillHandler) batchCall(ctx context.Context, method string, batch []rpc.BatchElem) error {
+	for start := 0; start < len(batch); start += backfillBatchSize {
+		chunk := batch[start:min(start+backfillBatchSize, len(batch))]
+		err := tracing.ObserveRPC(ctx, method, func(ctx context.Context) error {
+			if err := h.ethClient.Client().BatchCallContext(ctx, chunk); err != nil {
+				return err
+			}
+			for _, elem := range chunk {
+				if elem.Error != nil {
+					return fmt.Errorf("%s: %w", elem.Method, elem.Error)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isMethodNotFound identifica o erro JSON-RPC -32601 (método inexistente)
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "method not found") || strings.Contains(msg, "does not exist")
+}
+
+// reportProgress registra o progresso a cada 30 segundos
+func (h *BackfillHandler) reportProgress(progress *backfillProgress, opts BackfillOptions, started time.Time, limiter *adaptiveLimiter, stop <-chan struct{}) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	total := opts.To - opts.From + 1
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			blocks := progress.blocks.Load()
+			rate := float64(blocks) / time.Since(started).Seconds()
+			log.Printf("📈 [backfill] %d faixas (%d puladas, %d falhas), %d/%d blocos, %d transações, %.1f blocos/s, concorrência %d",
+				progress.ranges.Load(), progress.skipped.Load(), progress.failed.Load(),
+				blocks, total, progress.txs.Load(), rate, limiter.Limit())
+		}
+	}
+}
+
+// adaptiveLimiter controla a concorrência do backfill pela latência do node:
+// aumenta em um enquanto as faixas são buscadas abaixo da latência alvo e corta pela metade
+// quando a latência passa do dobro do alvo ou o node retorna erro
+type adaptiveLimiter struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	limit    int
+	inFlight int
+	min      int
+	max      int
+	target   time.Duration
+}
+
+// newAdaptiveLimiter cria o limitador começando pela concorrência mínima
+func newAdaptiveLimiter(min, max int, target time.Duration) *adaptiveLimiter {
+	l := &adaptiveLimiter{limit: min, min: min, max: max, target: target}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// Acquire aguarda uma vaga de execução ou o cancelamento do contexto
+func (l *adaptiveLimiter) Acquire(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		l.mu.Lock()
+		l.cond.Broadcast()
+		l.mu.Unlock()
+	})
+	defer stop()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.inFlight >= l.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.cond.Wait()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.inFlight++
+	return nil
+}
+
+// Release libera a vaga e ajusta o limite pela latência observada
+func (l *adaptiveLimiter) Release(latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	switch {
+	case err != nil || (l.target > 0 && latency > 2*l.target):
+		l.limit = max(l.min, l.limit/2)
+	case latency > 0 && (l.target == 0 || latency < l.target):
+		l.limit = min(l.max, l.limit+1)
+	}
+	l.cond.Broadcast()
+}
+
+// Limit retorna a concorrência atual
+func (l *adaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
@@ -3,47 +3,60 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
-	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hubweb3/worker/internal/application/services"
 	"github.com/hubweb3/worker/internal/domain/entities"
-	"github.com/hubweb3/worker/internal/domain/services"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+	"github.com/hubweb3/worker/internal/infrastructure/blockpayload"
 	"github.com/hubweb3/worker/internal/infrastructure/cache"
+	"github.com/hubweb3/worker/internal/metrics"
 	"github.com/hubweb3/worker/internal/queues"
+	"github.com/hubweb3/worker/internal/tracing"
 )
 
 // BlockHandler gerencia o processamento de eventos de blocos
 type BlockHandler struct {
-	blockService *services.BlockService
+	bulkWriter   repositories.BulkWriter
 	ethClient    *ethclient.Client
 	consumer     *queues.Consumer
 	publisher    *queues.Publisher
 	redisCache   *cache.RedisCache
+	payloadStore blockpayload.Store
 
-	// Batching configuration
-	batchSize    int
-	batchTimeout time.Duration
-	blockBatch   []*entities.Block
-	batchMutex   sync.Mutex
-	batchTimer   *time.Timer
+	// Transações e eventos do bloco são montados a partir do payload pelos mesmos passos das filas por
+	// transação e gravados junto com o bloco pelo BulkWriter
+	transactions *TransactionHandler
+	events       *EventHandler
+
+	blockReceiptsUnsupported atomic.Bool
 }
 
+// receiptBatchSize limita quantas chamadas eth_getTransactionReceipt vão em cada batch JSON-RPC (o Besu
+// recusa batches acima de 1024 chamadas)
+const receiptBatchSize = 100
+
 // NewBlockHandler cria uma nova instância do handler de blocos
-func NewBlockHandler(blockService *services.BlockService, ethClient *ethclient.Client, consumer *queues.Consumer, publisher *queues.Publisher) *BlockHandler {
+func NewBlockHandler(bulkWriter repositories.BulkWriter, ethClient *ethclient.Client, consumer *queues.Consumer, publisher *queues.Publisher, payloadStore blockpayload.Store, transactions *TransactionHandler, events *EventHandler) *BlockHandler {
 	return &BlockHandler{
-		blockService: blockService,
+		bulkWriter:   bulkWriter,
 		ethClient:    ethClient,
 		consumer:     consumer,
 		publisher:    publisher,
 		redisCache:   cache.NewRedisCache(),
-		batchSize:    10,              // Process 10 blocks at once (otimizado para PostgreSQL)
-		batchTimeout: 5 * time.Second, // Timeout de 5 segundos (PostgreSQL)
-		blockBatch:   make([]*entities.Block, 0),
+		payloadStore: payloadStore,
+		transactions: transactions,
+		events:       events,
 	}
 }
 
@@ -115,8 +128,12 @@ func (h *BlockHandler) startConsumption(ctx context.Context) error {
 				return fmt.Errorf("canal de mensagens fechado")
 			}
 
-			// Processar mensagem com acknowledgment manual
-			if err := h.HandleBlockEvent(ctx, msg.Body); err != nil {
+			// Processar mensagem com acknowledgment manual, continuando o trace do indexer. A mensagem só é
+			// confirmada depois que as transações, os eventos e as accounts do bloco foram gravados
+			msgCtx, span := queues.StartConsumeSpan(ctx, queues.BlockProcessedQueue.Name, msg)
+			err := h.HandleBlockEvent(msgCtx, msg.Body, msg.Redelivered)
+			tracing.End(span, err)
+			if err != nil {
 				log.Printf("❌ Erro ao processar evento de bloco: %v", err)
 				// Rejeitar mensagem e reenviar para fila
 				if nackErr := msg.Nack(false, true); nackErr != nil {
@@ -126,66 +143,14 @@ func (h *BlockHandler) startConsumption(ctx context.Context) error {
 				// Confirmar processamento bem-sucedido
 				if ackErr := msg.Ack(false); ackErr != nil {
 					log.Printf("⚠️ Erro ao fazer ACK da mensagem: %v", ackErr)
+				} else {
+					h.releasePayload(ctx, msg.Body)
 				}
 			}
 		}
 	}
 }
 
-// addToBatch adds a block to the batch for processing
-func (h *BlockHandler) addToBatch(block *entities.Block) {
-	h.batchMutex.Lock()
-	defer h.batchMutex.Unlock()
-
-	h.blockBatch = append(h.blockBatch, block)
-
-	// If batch is full, process immediately
-	if len(h.blockBatch) >= h.batchSize {
-		h.processBatch()
-		return
-	}
-
-	// Reset timer for timeout-based processing
-	if h.batchTimer != nil {
-		h.batchTimer.Stop()
-	}
-	h.batchTimer = time.AfterFunc(h.batchTimeout, func() {
-		h.batchMutex.Lock()
-		defer h.batchMutex.Unlock()
-		if len(h.blockBatch) > 0 {
-			h.processBatch()
-		}
-	})
-}
-
-// processBatch processes all blocks in the current batch
-func (h *BlockHandler) processBatch() {
-	if len(h.blockBatch) == 0 {
-		return
-	}
-
-	batchToProcess := make([]*entities.Block, len(h.blockBatch))
-	copy(batchToProcess, h.blockBatch)
-	h.blockBatch = h.blockBatch[:0] // Clear the batch
-
-	log.Printf("🚀 Processando lote de %d blocos", len(batchToProcess))
-
-	// Process batch using bulk operations
-	start := time.Now()
-	if err := h.blockService.ProcessBlocksBatch(context.Background(), batchToProcess); err != nil {
-		log.Printf("❌ Erro ao processar lote de blocos: %v", err)
-		// TODO: Implement retry mechanism for failed batches
-		return
-	}
-
-	duration := time.Since(start)
-	log.Printf("✅ Lote de %d blocos processado em %v (%.2f blocos/seg)",
-		len(batchToProcess), duration, float64(len(batchToProcess))/duration.Seconds())
-
-	// 🚀 CACHE REDIS: Atualizar dados críticos no cache
-	h.updateRedisCache(batchToProcess)
-}
-
 // updateRedisCacheInstant atualiza o cache Redis INSTANTANEAMENTE para um bloco
 func (h *BlockHandler) updateRedisCacheInstant(block *entities.Block) {
 	// 1. Cache do último bloco (TTL: 30 segundos - para teste)
@@ -238,91 +203,17 @@ func (h *BlockHandler) updateRedisCacheInstant(block *entities.Block) {
 	}
 }
 
-// updateRedisCache atualiza o cache Redis com dados críticos (BATCH - mantido para compatibilidade)
-func (h *BlockHandler) updateRedisCache(blocks []*entities.Block) {
-	if len(blocks) == 0 {
-		return
-	}
-
-	// Encontrar o bloco mais recente do lote
-	latestBlock := blocks[0]
-	for _, block := range blocks {
-		if block.Number > latestBlock.Number {
-			latestBlock = block
-		}
+// HandleBlockEvent processa um evento de bloco: grava o bloco com as suas transações, eventos e dados de
+// accounts. redelivered indica uma reentrega, em que transações e eventos já gravados voltam a gerar
+// o processamento de accounts e as notificações que podem ter falhado
+func (h *BlockHandler) HandleBlockEvent(ctx context.Context, body []byte, redelivered bool) error {
+	// Bloco enriquecido (formato versionado): cabeçalho, transações e receipts já vêm do indexer
+	var message blockpayload.Message
+	if err := json.Unmarshal(body, &message); err == nil && message.Version > 0 {
+		return h.handleEnrichedBlock(ctx, &message, redelivered)
 	}
 
-	// 1. Cache do último bloco (TTL: 2 segundos)
-	if err := h.redisCache.SetLatestBlock(
-		int64(latestBlock.Number),
-		latestBlock.Hash,
-		latestBlock.Timestamp.Unix(),
-	); err != nil {
-		log.Printf("⚠️ Erro ao cachear último bloco: %v", err)
-	} else {
-		log.Printf("📦 Cache atualizado - Último bloco: %d", latestBlock.Number)
-	}
-
-	// 2. Cache de blocos individuais (TTL: 30 minutos - dados imutáveis)
-	for _, block := range blocks {
-		blockData := map[string]interface{}{
-			"number":       block.Number,
-			"hash":         block.Hash,
-			"parent_hash":  block.ParentHash,
-			"timestamp":    block.Timestamp.Unix(),
-			"miner":        block.Miner,
-			"difficulty":   block.Difficulty.String(),
-			"size":         block.Size,
-			"gas_limit":    block.GasLimit,
-			"gas_used":     block.GasUsed,
-			"tx_count":     block.TxCount,
-			"uncle_count":  block.UncleCount,
-			"base_fee":     block.BaseFeePerGas.String(),
-			"bloom":        block.Bloom,
-			"extra_data":   block.ExtraData,
-			"mix_digest":   block.MixDigest,
-			"nonce":        block.Nonce,
-			"receipt_hash": block.ReceiptHash,
-			"state_root":   block.StateRoot,
-			"tx_hash":      block.TxHash,
-		}
-
-		if err := h.redisCache.SetBlock(int64(block.Number), blockData); err != nil {
-			log.Printf("⚠️ Erro ao cachear bloco %d: %v", block.Number, err)
-		}
-	}
-
-	// 3. Atualizar estatísticas da rede (estimativa baseada no lote)
-	// Calcular tempo médio entre blocos do lote
-	if len(blocks) > 1 {
-		var totalTime int64
-		for i := 1; i < len(blocks); i++ {
-			timeDiff := blocks[i].Timestamp.Unix() - blocks[i-1].Timestamp.Unix()
-			if timeDiff > 0 {
-				totalTime += timeDiff
-			}
-		}
-
-		avgBlockTime := float64(totalTime) / float64(len(blocks)-1)
-		if avgBlockTime <= 0 {
-			avgBlockTime = 4.0 // Default para Besu
-		}
-
-		// Estimativa de totais (baseada no último bloco)
-		totalBlocks := int64(latestBlock.Number)
-		estimatedTotalTx := totalBlocks * 10 // Estimativa conservadora
-
-		if err := h.redisCache.SetNetworkStats(totalBlocks, estimatedTotalTx, avgBlockTime); err != nil {
-			log.Printf("⚠️ Erro ao cachear estatísticas da rede: %v", err)
-		} else {
-			log.Printf("📊 Cache atualizado - Stats: %d blocos, avg: %.1fs", totalBlocks, avgBlockTime)
-		}
-	}
-}
-
-// HandleBlockEvent processa um evento de bloco
-func (h *BlockHandler) HandleBlockEvent(ctx context.Context, body []byte) error {
-	// Tentar deserializar como BlockEvent primeiro (novo formato do indexer)
+	// Tentar deserializar como BlockEvent primeiro (formato sem payload)
 	var event BlockEvent
 	if err := json.Unmarshal(body, &event); err != nil {
 		// Se falhar, tentar como BlockEventLegacy (formato anterior)
@@ -353,32 +244,319 @@ func (h *BlockHandler) HandleBlockEvent(ctx context.Context, body []byte) error
 		log.Printf("📦 Processando bloco: %d (hash: %s)", event.Number, event.Hash)
 	}
 
-	// Buscar dados completos do bloco na blockchain
+	return h.handleNodeBlock(ctx, &event, redelivered)
+}
+
+// handleNodeBlock processa o bloco buscando o cabeçalho, as transações e os receipts no node. Usado
+// quando a mensagem não traz payload (o indexer não conseguiu montá-lo) ou quando o payload referenciado
+// não está mais no blob store
+func (h *BlockHandler) handleNodeBlock(ctx context.Context, event *BlockEvent, redelivered bool) error {
 	ethBlock, err := h.ethClient.BlockByNumber(ctx, big.NewInt(int64(event.Number)))
 	if err != nil {
 		return err
 	}
 
 	// Converter para entidade de domínio
-	block := h.convertToEntity(ethBlock, &event)
+	block := h.convertToEntity(ethBlock, event)
+
+	txs, err := h.nodeTransactions(ctx, ethBlock, block)
+	if err != nil {
+		return err
+	}
+	return h.processBlock(ctx, block, txs, redelivered)
+}
+
+// handleEnrichedBlock processa o bloco enriquecido sem consultar o node (exceto por receipts que o
+// indexer não conseguiu buscar)
+func (h *BlockHandler) handleEnrichedBlock(ctx context.Context, message *blockpayload.Message, redelivered bool) error {
+	enriched, err := blockpayload.Decode(ctx, message, h.payloadStore)
+	if errors.Is(err, blockpayload.ErrPayloadNotFound) {
+		// Payload expirado ou já removido (ex.: reentrega após a remoção): reprocessar a partir do node em
+		// vez de devolver à fila uma mensagem que nunca mais poderá ser decodificada
+		log.Printf("⚠️ Payload do bloco %d indisponível (%v), buscando o bloco no node", message.Number, err)
+		return h.handleNodeBlock(ctx, &BlockEvent{Number: message.Number, Hash: message.Hash, Timestamp: message.Timestamp}, redelivered)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("📦 Processando bloco: %d (hash: %s, payload v%d, %d bytes)", message.Number, message.Hash, message.Version, message.Size)
+
+	block := blockEntityFromHeader(enriched.Hash, enriched.Header, enriched.Size, len(enriched.Transactions), enriched.UncleCount)
+
+	txs, err := h.payloadTransactions(ctx, enriched, block)
+	if err != nil {
+		return err
+	}
+	return h.processBlock(ctx, block, txs, redelivered)
+}
+
+// releasePayload remove do blob store o payload referenciado pela mensagem. Só é chamado depois do ACK:
+// enquanto a mensagem puder ser reentregue o payload precisa continuar disponível
+func (h *BlockHandler) releasePayload(ctx context.Context, body []byte) {
+	var message blockpayload.Message
+	if err := json.Unmarshal(body, &message); err != nil || message.Ref == "" || h.payloadStore == nil {
+		return
+	}
+	if err := h.payloadStore.Delete(ctx, message.Ref); err != nil {
+		log.Printf("⚠️ Erro ao remover payload %s do blob store: %v", message.Ref, err)
+	}
+}
+
+// processBlock grava o bloco, as transações, os eventos e as escritas de accounts em uma única transação
+// do banco pelo BulkWriter e só então conclui as accounts, avalia os alertas e publica as notificações. Em
+// caso de erro a mensagem volta à fila; a reentrega regrava o bloco sem aplicar de novo as accounts já gravadas
+func (h *BlockHandler) processBlock(ctx context.Context, block *entities.Block, txs []*blockTransaction, redelivered bool) error {
+	// Eventos derivados dos logs dos receipts, usados também pelo processamento de accounts de cada transação
+	var events []*entities.Event
+	txEvents := make(map[string][]*entities.Event, len(txs))
+	for _, bt := range txs {
+		for _, vLog := range bt.receipt.Logs {
+			event := h.events.eventEntity(vLog, bt.entity, block.Timestamp)
+			events = append(events, event)
+			txEvents[bt.entity.Hash] = append(txEvents[bt.entity.Hash], event)
+		}
+	}
+	newEvents, notifyEvents, err := h.events.prepareBlockEvents(ctx, block.Number, events, redelivered)
+	if err != nil {
+		return err
+	}
+
+	prepared, err := h.transactions.prepareBlockAccounts(ctx, block.Number, txs, txEvents)
+	if err != nil {
+		return fmt.Errorf("erro ao processar dados de accounts do bloco %d: %w", block.Number, err)
+	}
+
+	// Transações aplicadas agora: as que ainda não tinham accounts gravadas
+	applied := appliedTransactions(txs, prepared)
+	for _, bt := range applied {
+		// Motivo da falha: revertReason do receipt (Besu) ou reexecução no bloco pai
+		if bt.entity.Status == entities.StatusFailed {
+			h.transactions.resolveRevertReason(ctx, bt.entity, bt.rawReceipt)
+		}
+	}
+
+	bundle := &entities.BlockBundle{Block: block, Transactions: make([]*entities.Transaction, len(txs)), Events: newEvents}
+	for i, bt := range txs {
+		bundle.Transactions[i] = bt.entity
+	}
+	opts := repositories.BulkWriteOptions{SkipAccountStages: true}
+	if prepared != nil {
+		opts.Statements = prepared.Statements
+	}
+
+	started := time.Now()
+	err = tracing.WithSpan(ctx, "BulkWriter.WriteBlocksWith", func(ctx context.Context) error {
+		return h.bulkWriter.WriteBlocksWith(ctx, []*entities.BlockBundle{bundle}, opts)
+	})
+	metrics.ObserveBlockBatch(1, time.Since(started), err)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar bloco %d: %w", block.Number, err)
+	}
+	metrics.SetLastIndexed(block.Number)
+
+	for _, bt := range applied {
+		h.transactions.recordTransactionDetails(ctx, bt.tx, bt.receipt, bt.entity)
+	}
+	if err := h.transactions.finishBlockAccounts(ctx, block.Number, prepared); err != nil {
+		return fmt.Errorf("erro ao processar dados de accounts do bloco %d: %w", block.Number, err)
+	}
+
+	// Em uma reentrega, as notificações que podem ter falhado saem de novo para todas as transações
+	notify := applied
+	if redelivered {
+		notify = txs
+	}
+	for _, bt := range notify {
+		h.transactions.notifyTransaction(ctx, bt.entity)
+	}
+	h.events.notifyEvents(ctx, notifyEvents)
+
+	log.Printf("✅ Bloco %d gravado em %v: %d transações (%d novas) e %d eventos (%d novos)",
+		block.Number, time.Since(started), len(txs), len(applied), len(events), len(newEvents))
 
 	// 🚀 CACHE REDIS INSTANTÂNEO: Atualizar cache imediatamente
 	h.updateRedisCacheInstant(block)
+	return nil
+}
 
-	// Adicionar ao batch para PostgreSQL
-	h.addToBatch(block)
+// appliedTransactions retorna as transações do bloco cujas accounts são gravadas por prepared
+func appliedTransactions(txs []*blockTransaction, prepared *services.PreparedBlock) []*blockTransaction {
+	if prepared == nil {
+		return nil
+	}
+
+	pending := make(map[string]bool, len(prepared.Pending))
+	for _, bt := range prepared.Pending {
+		pending[bt.Transaction.Hash] = true
+	}
 
-	// Eventos de transações são publicados pelo indexer/transaction-listener
-	// Removido para evitar duplicação e eventos malformados
+	applied := make([]*blockTransaction, 0, len(prepared.Pending))
+	for _, bt := range txs {
+		if pending[bt.entity.Hash] {
+			applied = append(applied, bt)
+		}
+	}
+	return applied
+}
 
-	// WebSocket publishing removido - não é performático via RabbitMQ
+// payloadTransactions decodifica as transações e os receipts do bloco enriquecido. Receipts ausentes (o
+// indexer publica o bloco mesmo quando a busca falha) são buscados no node em um batch
+func (h *BlockHandler) payloadTransactions(ctx context.Context, enriched *blockpayload.Block, block *entities.Block) ([]*blockTransaction, error) {
+	txs := make([]*types.Transaction, len(enriched.Transactions))
+	senders := make([]string, len(enriched.Transactions))
+	receipts := make(map[common.Hash]json.RawMessage, len(enriched.Transactions))
+	var missing []common.Hash
+	for i, payloadTx := range enriched.Transactions {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(payloadTx.Raw); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar transação %d do bloco %d: %w", i, block.Number, err)
+		}
+		txs[i] = tx
+		senders[i] = payloadTx.From.Hex()
+		if len(payloadTx.Receipt) == 0 {
+			missing = append(missing, tx.Hash())
+			continue
+		}
+		receipts[tx.Hash()] = payloadTx.Receipt
+	}
 
-	log.Printf("📦 Bloco %d adicionado ao batch", event.Number)
+	if len(missing) > 0 {
+		log.Printf("⚠️ Payload do bloco %d sem %d receipts, buscando no node", block.Number, len(missing))
+		if err := h.fetchReceipts(ctx, block, missing, receipts); err != nil {
+			return nil, err
+		}
+	}
+
+	return blockTransactions(block, txs, senders, receipts)
+}
+
+// nodeTransactions busca os receipts do bloco lido no node e monta as transações
+func (h *BlockHandler) nodeTransactions(ctx context.Context, ethBlock *types.Block, block *entities.Block) ([]*blockTransaction, error) {
+	txs := ethBlock.Transactions()
+	senders := make([]string, len(txs))
+	hashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		// O remetente vem da resposta do eth_getBlockByNumber; sem ele, transactionEntity o recupera pela assinatura
+		if sender, err := h.ethClient.TransactionSender(ctx, tx, ethBlock.Hash(), uint(i)); err == nil {
+			senders[i] = sender.Hex()
+		}
+		hashes[i] = tx.Hash()
+	}
+
+	receipts := make(map[common.Hash]json.RawMessage, len(txs))
+	if err := h.fetchReceipts(ctx, block, hashes, receipts); err != nil {
+		return nil, err
+	}
+	return blockTransactions(block, txs, senders, receipts)
+}
+
+// fetchReceipts busca os receipts das transações mantendo o JSON original: com eth_getBlockReceipts e, se o
+// node não suportar o método (ou não devolver algum receipt), com eth_getTransactionReceipt em batches de
+// receiptBatchSize
+func (h *BlockHandler) fetchReceipts(ctx context.Context, block *entities.Block, hashes []common.Hash, receipts map[common.Hash]json.RawMessage) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	if !h.blockReceiptsUnsupported.Load() {
+		missing, err := h.fetchBlockReceipts(ctx, block, hashes, receipts)
+		switch {
+		case err == nil:
+			hashes = missing
+		case isMethodNotFound(err):
+			h.blockReceiptsUnsupported.Store(true)
+			log.Printf("⚠️ Node não suporta eth_getBlockReceipts, usando batch de eth_getTransactionReceipt")
+		default:
+			return fmt.Errorf("erro ao buscar receipts do bloco %d: %w", block.Number, err)
+		}
+	}
+
+	for start := 0; start < len(hashes); start += receiptBatchSize {
+		chunk := hashes[start:min(start+receiptBatchSize, len(hashes))]
+		results := make([]json.RawMessage, len(chunk))
+		batch := make([]rpc.BatchElem, len(chunk))
+		for i, hash := range chunk {
+			batch[i] = rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []interface{}{hash}, Result: &results[i]}
+		}
+
+		err := tracing.ObserveRPC(ctx, "eth_getTransactionReceipt_batch", func(ctx context.Context) error {
+			if err := h.ethClient.Client().BatchCallContext(ctx, batch); err != nil {
+				return err
+			}
+			for _, elem := range batch {
+				if elem.Error != nil {
+					return elem.Error
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("erro ao buscar receipts: %w", err)
+		}
+
+		for i, hash := range chunk {
+			if len(results[i]) == 0 || string(results[i]) == "null" {
+				return fmt.Errorf("receipt da transação %s não encontrado", hash.Hex())
+			}
+			receipts[hash] = results[i]
+		}
+	}
 	return nil
 }
 
-// publishTransactionEvents foi removida para evitar duplicação
-// Os eventos de transação são publicados pelo indexer/transaction-listener
+// fetchBlockReceipts busca todos os receipts do bloco em uma chamada, guarda os das transações pedidas e
+// retorna as que ficaram sem receipt
+func (h *BlockHandler) fetchBlockReceipts(ctx context.Context, block *entities.Block, hashes []common.Hash, receipts map[common.Hash]json.RawMessage) ([]common.Hash, error) {
+	var results []json.RawMessage
+	err := tracing.ObserveRPC(ctx, "eth_getBlockReceipts", func(ctx context.Context) error {
+		return h.ethClient.Client().CallContext(ctx, &results, "eth_getBlockReceipts", hexutil.EncodeUint64(block.Number))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byHash := make(map[common.Hash]json.RawMessage, len(results))
+	for _, raw := range results {
+		var receipt struct {
+			TransactionHash common.Hash `json:"transactionHash"`
+		}
+		if err := json.Unmarshal(raw, &receipt); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar receipts do bloco %d: %w", block.Number, err)
+		}
+		byHash[receipt.TransactionHash] = raw
+	}
+
+	var missing []common.Hash
+	for _, hash := range hashes {
+		if raw, ok := byHash[hash]; ok {
+			receipts[hash] = raw
+		} else {
+			missing = append(missing, hash)
+		}
+	}
+	return missing, nil
+}
+
+// blockTransactions decodifica os receipts e converte as transações do bloco em entidades
+func blockTransactions(block *entities.Block, txs []*types.Transaction, senders []string, receipts map[common.Hash]json.RawMessage) ([]*blockTransaction, error) {
+	blockTime := uint64(block.Timestamp.Unix())
+	result := make([]*blockTransaction, len(txs))
+	for i, tx := range txs {
+		rawReceipt := receipts[tx.Hash()]
+		receipt := new(types.Receipt)
+		if err := json.Unmarshal(rawReceipt, receipt); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar receipt da transação %s: %w", tx.Hash().Hex(), err)
+		}
+
+		result[i] = &blockTransaction{
+			tx:         tx,
+			receipt:    receipt,
+			rawReceipt: rawReceipt,
+			entity:     transactionEntity(tx, receipt, senders[i], block.Number, block.Hash, blockTime),
+		}
+	}
+	return result, nil
+}
 
 // convertToEntity converte dados da blockchain para entidade de domínio
 func (h *BlockHandler) convertToEntity(ethBlock interface{}, event *BlockEvent) *entities.Block {
@@ -394,35 +572,40 @@ func (h *BlockHandler) convertToEntity(ethBlock interface{}, event *BlockEvent)
 		return entities.NewBlock(event.Number, event.Hash, timestamp)
 	}
 
+	return blockEntityFromHeader(block.Hash().Hex(), block.Header(), uint64(block.Size()), len(block.Transactions()), len(block.Uncles()))
+}
+
+// blockEntityFromHeader monta a entidade de bloco a partir do cabeçalho e das contagens do corpo
+func blockEntityFromHeader(hash string, header *types.Header, size uint64, txCount, uncleCount int) *entities.Block {
 	// Extrair dados completos do bloco
-	timestamp := time.Unix(int64(block.Time()), 0)
+	timestamp := time.Unix(int64(header.Time), 0)
 
 	// Criar entidade com dados completos
-	entity := entities.NewBlock(block.NumberU64(), block.Hash().Hex(), timestamp)
+	entity := entities.NewBlock(header.Number.Uint64(), hash, timestamp)
 
 	// Preencher campos básicos
-	entity.ParentHash = block.ParentHash().Hex()
-	entity.Miner = block.Coinbase().Hex()
-	entity.Difficulty = block.Difficulty()
-	entity.Size = uint64(block.Size())
-	entity.GasLimit = block.GasLimit()
-	entity.GasUsed = block.GasUsed()
-	entity.TxCount = len(block.Transactions())
-	entity.UncleCount = len(block.Uncles())
+	entity.ParentHash = header.ParentHash.Hex()
+	entity.Miner = header.Coinbase.Hex()
+	entity.Difficulty = header.Difficulty
+	entity.Size = size
+	entity.GasLimit = header.GasLimit
+	entity.GasUsed = header.GasUsed
+	entity.TxCount = txCount
+	entity.UncleCount = uncleCount
 
 	// BaseFeePerGas (EIP-1559)
-	if block.BaseFee() != nil {
-		entity.BaseFeePerGas = block.BaseFee()
+	if header.BaseFee != nil {
+		entity.BaseFeePerGas = header.BaseFee
 	}
 
 	// Novos campos extraídos
-	entity.Bloom = fmt.Sprintf("0x%x", block.Bloom())     // Bloom filter (conversão correta)
-	entity.ExtraData = fmt.Sprintf("0x%x", block.Extra()) // Dados extras em hex
-	entity.MixDigest = block.MixDigest().Hex()            // Mix digest
-	entity.Nonce = block.Nonce()                          // Nonce
-	entity.ReceiptHash = block.ReceiptHash().Hex()        // Hash das receipts
-	entity.StateRoot = block.Root().Hex()                 // Root do estado
-	entity.TxHash = block.TxHash().Hex()                  // Hash das transações
+	entity.Bloom = fmt.Sprintf("0x%x", header.Bloom)     // Bloom filter (conversão correta)
+	entity.ExtraData = fmt.Sprintf("0x%x", header.Extra) // Dados extras em hex
+	entity.MixDigest = header.MixDigest.Hex()            // Mix digest
+	entity.Nonce = header.Nonce.Uint64()                 // Nonce
+	entity.ReceiptHash = header.ReceiptHash.Hex()        // Hash das receipts
+	entity.StateRoot = header.Root.Hex()                 // Root do estado
+	entity.TxHash = header.TxHash.Hex()                  // Hash das transações
 
 	log.Printf("📊 Bloco %d: %d transações, %d gas usado, minerador: %s, tamanho: %d bytes",
 		entity.Number, entity.TxCount, entity.GasUsed, entity.Miner, entity.Size)
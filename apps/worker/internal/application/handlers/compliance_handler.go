@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application/services"
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/infrastructure/database"
+	"github.com/hubweb3/worker/internal/queues"
+)
+
+// ComplianceHandler processa atualizações de compliance enviadas pela API
+type ComplianceHandler struct {
+	accountRepo  *database.PostgresAccountRepository
+	consumer     *queues.Consumer
+	alertService *services.AlertService
+	caseService  *services.ComplianceCaseService
+}
+
+// NewComplianceHandler cria uma nova instância do ComplianceHandler
+func NewComplianceHandler(
+	accountRepo *database.PostgresAccountRepository,
+	consumer *queues.Consumer,
+	alertService *services.AlertService,
+	caseService *services.ComplianceCaseService,
+) *ComplianceHandler {
+	return &ComplianceHandler{
+		accountRepo:  accountRepo,
+		consumer:     consumer,
+		alertService: alertService,
+		caseService:  caseService,
+	}
+}
+
+// Start inicia o processamento da fila de compliance
+func (h *ComplianceHandler) Start(ctx context.Context) error {
+	log.Println("🚀 Starting Compliance Handler...")
+
+	// Loop principal com retry automático
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Compliance Handler encerrado")
+			return nil
+		default:
+			if err := h.startConsumption(ctx); err != nil {
+				log.Printf("❌ Erro no Compliance Handler: %v", err)
+				log.Println("⏳ Aguardando 5 segundos antes de tentar novamente...")
+
+				// Aguardar antes de tentar novamente
+				select {
+				case <-ctx.Done():
+					log.Println("🛑 Compliance Handler encerrado durante retry")
+					return nil
+				case <-time.After(5 * time.Second):
+					continue
+				}
+			}
+		}
+	}
+}
+
+// startConsumption inicia o consumo de mensagens com tratamento de erro
+func (h *ComplianceHandler) startConsumption(ctx context.Context) error {
+	if err := h.consumer.DeclareQueue(queues.AccountComplianceUpdateQueue); err != nil {
+		return fmt.Errorf("erro ao declarar fila account-compliance-update: %w", err)
+	}
+
+	msgs, err := h.consumer.Consume(queues.AccountComplianceUpdateQueue.Name)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar consumo: %w", err)
+	}
+
+	log.Printf("✅ Compliance Handler iniciado, aguardando mensagens na fila '%s'", queues.AccountComplianceUpdateQueue.Name)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgs:
+			if !ok {
+				log.Println("⚠️ Canal de mensagens fechado, reiniciando...")
+				return fmt.Errorf("canal de mensagens fechado")
+			}
+
+			if err := h.handleComplianceUpdate(ctx, msg.Body); err != nil {
+				log.Printf("❌ Erro ao processar atualização de compliance: %v", err)
+				if nackErr := msg.Nack(false, true); nackErr != nil {
+					log.Printf("❌ Erro ao fazer NACK da mensagem: %v", nackErr)
+				}
+			} else {
+				if ackErr := msg.Ack(false); ackErr != nil {
+					log.Printf("⚠️ Erro ao fazer ACK da mensagem: %v", ackErr)
+				}
+			}
+		}
+	}
+}
+
+// handleComplianceUpdate aplica o novo status de compliance, avalia regras de alerta e registra a alteração
+// nos casos de compliance ativos da account
+func (h *ComplianceHandler) handleComplianceUpdate(ctx context.Context, body []byte) error {
+	var msg entities.AccountComplianceUpdateMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		// Mensagem malformada não deve voltar para a fila
+		log.Printf("⚠️ Mensagem de compliance inválida descartada: %v", err)
+		return nil
+	}
+
+	// Status desconhecido não deve marcar a account como compliant nem voltar para a fila
+	if !msg.IsValidComplianceStatus() {
+		log.Printf("⚠️ Mensagem de compliance com status inválido descartada: %s (%q)", msg.Address, msg.ComplianceStatus)
+		return nil
+	}
+	status, _ := msg.ComplianceStatusValue()
+
+	address := strings.ToLower(msg.Address)
+
+	log.Printf("📝 Atualizando compliance da account %s para %s", address, status)
+
+	previous, err := h.accountRepo.UpdateCompliance(ctx, address, status, msg.ComplianceNotes, msg.RiskScore)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Printf("⚠️ Account %s não encontrada, ignorando atualização de compliance", address)
+			return nil
+		}
+		return err
+	}
+
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	h.alertService.EvaluateComplianceChange(ctx, &msg, previous, status)
+	msg.Address = address
+	h.caseService.RecordStatusOverride(ctx, &msg, previous, status)
+
+	log.Printf("✅ Compliance da account %s atualizada (%s → %s)", address, previous, status)
+	return nil
+}
@@ -6,13 +6,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/hubweb3/worker/internal/application/services"
 	"github.com/hubweb3/worker/internal/domain/entities"
 	"github.com/hubweb3/worker/internal/domain/repositories"
 	"github.com/hubweb3/worker/internal/queues"
+	"github.com/hubweb3/worker/internal/tracing"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -35,26 +39,32 @@ type ABIInput struct {
 // EventHandler processa eventos de smart contracts
 type EventHandler struct {
 	eventRepo                   repositories.EventRepository
+	bulkWriter                  repositories.BulkWriter
 	contractRepo                repositories.SmartContractRepository
 	consumer                    *queues.Consumer
 	publisher                   *queues.Publisher
 	accountTransactionProcessor *services.AccountTransactionProcessor
+	alertService                *services.AlertService
 }
 
 // NewEventHandler cria um novo handler de eventos
 func NewEventHandler(
 	eventRepo repositories.EventRepository,
+	bulkWriter repositories.BulkWriter,
 	contractRepo repositories.SmartContractRepository,
 	consumer *queues.Consumer,
 	publisher *queues.Publisher,
 	accountTransactionProcessor *services.AccountTransactionProcessor,
+	alertService *services.AlertService,
 ) *EventHandler {
 	return &EventHandler{
 		eventRepo:                   eventRepo,
+		bulkWriter:                  bulkWriter,
 		contractRepo:                contractRepo,
 		consumer:                    consumer,
 		publisher:                   publisher,
 		accountTransactionProcessor: accountTransactionProcessor,
+		alertService:                alertService,
 	}
 }
 
@@ -132,7 +142,12 @@ func (h *EventHandler) startConsumption(ctx context.Context) error {
 func (h *EventHandler) processEventMessage(msg amqp.Delivery) error {
 	log.Printf("[event_handler] 🔍 DEBUG: Mensagem recebida da fila (tamanho: %d bytes)", len(msg.Body))
 	log.Printf("[event_handler] 🔍 DEBUG: Conteúdo da mensagem: %s", string(msg.Body))
-	return h.ProcessEventDiscovered(context.Background(), msg.Body)
+
+	// Continuar o trace iniciado no event listener do indexer
+	ctx, span := queues.StartConsumeSpan(context.Background(), queues.EventDiscoveredQueue.Name, msg)
+	err := h.ProcessEventDiscovered(ctx, msg.Body)
+	tracing.End(span, err)
+	return err
 }
 
 // ProcessEventDiscovered processa eventos descobertos pelo indexer
@@ -222,13 +237,16 @@ func (h *EventHandler) ProcessEventDiscovered(ctx context.Context, message []byt
 	}
 
 	// Salvar evento no banco
-	if err := h.eventRepo.Create(ctx, event); err != nil {
+	if err := h.saveEvents(ctx, []*entities.Event{event}); err != nil {
 		return fmt.Errorf("erro ao salvar evento: %w", err)
 	}
 
 	log.Printf("[event_handler] ✅ Evento %s processado (contrato: %s, tipo: %s)",
 		event.ID, event.ContractAddress[:10]+"...", event.EventName)
 
+	// Avaliar regras de alerta de eventos e transferências de tokens
+	h.alertService.EvaluateEvent(ctx, event)
+
 	// Publicar evento processado para WebSocket
 	if err := h.publishEventProcessed(event); err != nil {
 		log.Printf("[event_handler] ⚠️ Erro ao publicar evento processado: %v", err)
@@ -238,6 +256,150 @@ func (h *EventHandler) ProcessEventDiscovered(ctx context.Context, message []byt
 	return nil
 }
 
+// eventEntity converte um log do receipt no evento gravado em events. É usado pelo bloco enriquecido
+// e pelo backfill, que derivam os eventos dos receipts em vez de buscá-los log a log
+func (h *EventHandler) eventEntity(vLog *types.Log, tx *entities.Transaction, timestamp time.Time) *entities.Event {
+	topics := make(entities.TopicsArray, len(vLog.Topics))
+	for i, topic := range vLog.Topics {
+		topics[i] = topic.Hex()
+	}
+
+	event := &entities.Event{
+		ID:               vLog.TxHash.Hex() + "-" + strconv.Itoa(int(vLog.Index)),
+		ContractAddress:  vLog.Address.Hex(),
+		EventName:        "Unknown",
+		TransactionHash:  vLog.TxHash.Hex(),
+		BlockNumber:      vLog.BlockNumber,
+		BlockHash:        vLog.BlockHash.Hex(),
+		LogIndex:         uint64(vLog.Index),
+		TransactionIndex: uint64(vLog.TxIndex),
+		FromAddress:      tx.From,
+		ToAddress:        tx.To,
+		Topics:           topics,
+		Data:             vLog.Data,
+		GasUsed:          tx.Gas,
+		GasPrice:         "0",
+		Status:           "success",
+		Removed:          vLog.Removed,
+		Timestamp:        timestamp,
+	}
+	if tx.GasPrice != nil {
+		event.GasPrice = tx.GasPrice.String()
+	}
+	if len(topics) > 0 {
+		event.EventSignature = topics[0]
+		if name, ok := knownEventSignatures[topics[0]]; ok {
+			event.EventName = name
+		}
+	}
+	event.DecodedData = h.tryDecodeEventData(event.EventName, topics, vLog.Data)
+	return event
+}
+
+// prepareBlockEvents identifica os eventos de um bloco (nome pela ABI verificada e nome do contrato) sem
+// gravá-los. Retorna os que ainda não estão no banco, que o BlockHandler grava com o resto do bloco, e os que
+// seguem para as notificações: os novos e, em uma reentrega do bloco, também os já gravados
+func (h *EventHandler) prepareBlockEvents(ctx context.Context, blockNumber uint64, events []*entities.Event, redelivered bool) (pending, notify []*entities.Event, err error) {
+	if len(events) == 0 {
+		return nil, nil, nil
+	}
+
+	ids := make([]string, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	existing, err := h.eventRepo.ExistingInBlock(ctx, blockNumber, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao verificar eventos já gravados do bloco %d: %w", blockNumber, err)
+	}
+
+	abis := make(map[string]*abi.ABI)
+	contractNames := make(map[string]string)
+	for _, event := range events {
+		// Eventos fora da lista conhecida são identificados pela ABI verificada do contrato. Os já gravados
+		// também são identificados: o processamento de accounts usa os eventos de todas as transações do bloco
+		h.resolveEventName(ctx, abis, event)
+
+		name, ok := contractNames[event.ContractAddress]
+		if !ok {
+			name = h.getContractName(event.ContractAddress)
+			contractNames[event.ContractAddress] = name
+		}
+		if name != "" {
+			event.ContractName = &name
+		}
+
+		if !existing[event.ID] {
+			pending = append(pending, event)
+		}
+		if !existing[event.ID] || redelivered {
+			notify = append(notify, event)
+		}
+	}
+
+	return pending, notify, nil
+}
+
+// saveEvents grava os eventos das filas de compatibilidade pelo BulkWriter
+func (h *EventHandler) saveEvents(ctx context.Context, events []*entities.Event) error {
+	bundle := &entities.BlockBundle{Events: events}
+	return h.bulkWriter.WriteBlocksWith(ctx, []*entities.BlockBundle{bundle}, repositories.BulkWriteOptions{SkipAccountStages: true})
+}
+
+// notifyEvents avalia as regras de alerta e publica os eventos processados para o WebSocket
+func (h *EventHandler) notifyEvents(ctx context.Context, events []*entities.Event) {
+	for _, event := range events {
+		h.alertService.EvaluateEvent(ctx, event)
+		if err := h.publishEventProcessed(event); err != nil {
+			log.Printf("[event_handler] ⚠️ Erro ao publicar evento processado %s: %v", event.ID, err)
+		}
+	}
+}
+
+// resolveEventName identifica um evento desconhecido pela ABI verificada do contrato (em cache por bloco)
+// e decodifica os dados de novo com o nome encontrado
+func (h *EventHandler) resolveEventName(ctx context.Context, abis map[string]*abi.ABI, event *entities.Event) {
+	if event.EventName != "Unknown" || event.EventSignature == "" {
+		return
+	}
+
+	contractABI, ok := abis[event.ContractAddress]
+	if !ok {
+		contractABI = h.contractABI(ctx, event.ContractAddress)
+		abis[event.ContractAddress] = contractABI
+	}
+	if contractABI == nil {
+		return
+	}
+
+	for name, abiEvent := range contractABI.Events {
+		if abiEvent.ID.Hex() == event.EventSignature {
+			event.EventName = name
+			event.DecodedData = h.tryDecodeEventData(name, event.Topics, event.Data)
+			return
+		}
+	}
+}
+
+// contractABI busca e interpreta a ABI verificada do contrato; nil se não houver
+func (h *EventHandler) contractABI(ctx context.Context, contractAddress string) *abi.ABI {
+	text, err := h.contractRepo.GetContractABI(ctx, contractAddress)
+	if err != nil {
+		log.Printf("[event_handler] ⚠️ Erro ao buscar ABI do contrato %s: %v", contractAddress, err)
+		return nil
+	}
+	if text == "" {
+		return nil
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(text))
+	if err != nil {
+		log.Printf("[event_handler] ⚠️ ABI gravada do contrato %s inválida: %v", contractAddress, err)
+		return nil
+	}
+	return &contractABI
+}
+
 // tryDecodeEventData usa ABI do contrato para decodificar eventos inteligentemente
 func (h *EventHandler) tryDecodeEventData(eventName string, topics []string, data []byte) *entities.DecodedData {
 	decoded := make(entities.DecodedData)
@@ -274,15 +436,25 @@ func (h *EventHandler) tryDecodeEventData(eventName string, topics []string, dat
 	return &decoded
 }
 
+// knownEventSignatures mapeia o topic0 dos eventos conhecidos para o nome do evento
+var knownEventSignatures = map[string]string{
+	"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef": "Transfer",
+	"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925": "Approval",
+	"0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31": "ApprovalForAll",
+	"0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0": "OwnershipTransferred",
+	"0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258": "Paused",
+	"0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa": "Unpaused",
+	"0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d": "RoleGranted",
+	"0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b": "RoleRevoked",
+	"0x0f6798a560793a54c3bcfe86a93cde1e73087d944c0ea20544137d4121396885": "Mint",
+	"0xcc16f5dbb4873280815c1ee09dbd06736cffcc184412cf7a71a0fdb75d397ca5": "Burn",
+	"0x9ec8254969d1974eac8c74afb0c03595b4ffe0a1d7ad8a7f82ed31b9c8542591": "NumberSet",
+	"0x209c6035516d19d8e68fcdb2bf5bd0a95b70e35f6ca85925c34b9cdfdd713960": "NumberIncremented",
+}
+
 // identifyEventBySignature identifica evento pela assinatura usando ABIs conhecidas
 func (h *EventHandler) identifyEventBySignature(signature string) string {
-	// Mapeamento de assinaturas conhecidas
-	knownSignatures := map[string]string{
-		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef": "Transfer",
-		"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925": "Approval",
-	}
-
-	if name, exists := knownSignatures[signature]; exists {
+	if name, exists := knownEventSignatures[signature]; exists {
 		return name
 	}
 
@@ -526,14 +698,15 @@ func (h *EventHandler) ProcessEventBatch(ctx context.Context, messages [][]byte)
 
 	// Salvar todos os eventos em lote
 	if len(events) > 0 {
-		if err := h.eventRepo.BulkCreate(ctx, events); err != nil {
+		if err := h.saveEvents(ctx, events); err != nil {
 			return fmt.Errorf("erro ao salvar lote de eventos: %w", err)
 		}
 
 		log.Printf("[event_handler] ✅ Lote de %d eventos processado com sucesso", len(events))
 
-		// Publicar eventos processados para notificações
+		// Avaliar alertas e publicar eventos processados para notificações
 		for _, event := range processedEvents {
+			h.alertService.EvaluateEvent(ctx, event)
 			if err := h.publishEventProcessed(event); err != nil {
 				log.Printf("[event_handler] ⚠️ Erro ao publicar evento processado %s: %v", event.ID, err)
 			}
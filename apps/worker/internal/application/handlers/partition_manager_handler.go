@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application/services"
+)
+
+// PartitionManagerHandler mantém periodicamente as partições das tabelas grandes
+type PartitionManagerHandler struct {
+	partitionService *services.PartitionService
+	interval         time.Duration
+}
+
+// NewPartitionManagerHandler cria uma nova instância do handler de partições
+func NewPartitionManagerHandler(partitionService *services.PartitionService, interval time.Duration) *PartitionManagerHandler {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &PartitionManagerHandler{
+		partitionService: partitionService,
+		interval:         interval,
+	}
+}
+
+// Start executa a manutenção na inicialização e depois a cada intervalo
+func (h *PartitionManagerHandler) Start(ctx context.Context) error {
+	log.Println("🔄 Iniciando Partition Manager Handler...")
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Partition Manager Handler iniciado, verificando partições a cada %v", h.interval)
+
+	// A partição do bloco atual precisa existir antes de o worker gravar nela
+	h.maintain(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Partition Manager Handler encerrado")
+			return nil
+		case <-ticker.C:
+			h.maintain(ctx)
+		}
+	}
+}
+
+// maintain executa um ciclo de manutenção das partições
+func (h *PartitionManagerHandler) maintain(ctx context.Context) {
+	if err := h.partitionService.Maintain(ctx); err != nil {
+		log.Printf("❌ Erro na manutenção de partições: %v", err)
+	}
+}
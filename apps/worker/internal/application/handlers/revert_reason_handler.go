@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application/services"
+)
+
+// RevertReasonHandler preenche periodicamente o motivo das transações com falha gravadas sem ele (backfill
+// histórico, pipeline em lote ou nó indisponível no momento do processamento)
+type RevertReasonHandler struct {
+	revertReasonService *services.RevertReasonService
+	interval            time.Duration
+	batchSize           int
+}
+
+// NewRevertReasonHandler cria uma nova instância do handler de motivos de falha
+func NewRevertReasonHandler(revertReasonService *services.RevertReasonService, interval time.Duration, batchSize int) *RevertReasonHandler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &RevertReasonHandler{
+		revertReasonService: revertReasonService,
+		interval:            interval,
+		batchSize:           batchSize,
+	}
+}
+
+// Start executa um ciclo na inicialização e depois a cada intervalo
+func (h *RevertReasonHandler) Start(ctx context.Context) error {
+	log.Println("🔄 Iniciando Revert Reason Handler...")
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Revert Reason Handler iniciado, buscando transações com falha sem motivo a cada %v", h.interval)
+
+	h.run(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Revert Reason Handler encerrado")
+			return nil
+		case <-ticker.C:
+			h.run(ctx)
+		}
+	}
+}
+
+// run processa lotes até não restarem transações com falha sem motivo ou o contexto ser cancelado
+func (h *RevertReasonHandler) run(ctx context.Context) {
+	var resolved int
+	for ctx.Err() == nil {
+		n, err := h.revertReasonService.ProcessPending(ctx, h.batchSize)
+		resolved += n
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("❌ Erro ao resolver motivos de falha de transações: %v", err)
+			}
+			break
+		}
+		if n < h.batchSize {
+			break
+		}
+	}
+
+	if resolved > 0 {
+		log.Printf("🧯 Motivo de falha resolvido para %d transações", resolved)
+	}
+}
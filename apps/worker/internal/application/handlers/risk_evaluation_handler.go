@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application/services"
+)
+
+// RiskEvaluationHandler avalia periodicamente o risco das accounts com atividade nova e reavalia
+// as accounts afetadas quando a política de risco ativa muda
+type RiskEvaluationHandler struct {
+	riskService *services.RiskService
+	interval    time.Duration
+	batchSize   int
+}
+
+// NewRiskEvaluationHandler cria uma nova instância do handler de avaliação de risco
+func NewRiskEvaluationHandler(riskService *services.RiskService, interval time.Duration, batchSize int) *RiskEvaluationHandler {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 200
+	}
+	return &RiskEvaluationHandler{
+		riskService: riskService,
+		interval:    interval,
+		batchSize:   batchSize,
+	}
+}
+
+// Start executa um ciclo de avaliação na inicialização e depois a cada intervalo
+func (h *RiskEvaluationHandler) Start(ctx context.Context) error {
+	log.Println("🔄 Iniciando Risk Evaluation Handler...")
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Risk Evaluation Handler iniciado, avaliando accounts a cada %v", h.interval)
+
+	h.run(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Risk Evaluation Handler encerrado")
+			return nil
+		case <-ticker.C:
+			h.run(ctx)
+		}
+	}
+}
+
+// run recarrega a política e processa lotes até esvaziar as filas ou o contexto ser cancelado
+func (h *RiskEvaluationHandler) run(ctx context.Context) {
+	changed, err := h.riskService.RefreshPolicy(ctx)
+	if err != nil {
+		log.Printf("❌ Erro ao atualizar política de risco: %v", err)
+	}
+	if changed {
+		log.Println("📜 Nova política de risco ativa, reavaliando accounts afetadas")
+	}
+
+	var reevaluated, evaluated int
+	for ctx.Err() == nil {
+		n, err := h.riskService.ReevaluateOutdated(ctx, h.batchSize)
+		if err != nil {
+			log.Printf("❌ Erro ao reavaliar accounts pela nova política: %v", err)
+			break
+		}
+		reevaluated += n
+		if n == 0 {
+			break
+		}
+	}
+	for ctx.Err() == nil {
+		n, err := h.riskService.ProcessPending(ctx, h.batchSize)
+		if err != nil {
+			log.Printf("❌ Erro ao avaliar accounts pendentes: %v", err)
+			break
+		}
+		evaluated += n
+		if n == 0 {
+			break
+		}
+	}
+
+	if reevaluated > 0 || evaluated > 0 {
+		log.Printf("🛡️ Risco avaliado: %d accounts com atividade nova, %d reavaliadas pela política", evaluated, reevaluated)
+	}
+}
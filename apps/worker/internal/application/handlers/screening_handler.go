@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application/services"
+)
+
+// ScreeningHandler aplica periodicamente as listas de sanções importadas, ativadas ou desativadas pela API.
+// A triagem da atividade nova é feita no processamento de accounts de cada bloco
+type ScreeningHandler struct {
+	screeningService *services.ScreeningService
+	interval         time.Duration
+}
+
+// NewScreeningHandler cria uma nova instância do handler de triagem
+func NewScreeningHandler(screeningService *services.ScreeningService, interval time.Duration) *ScreeningHandler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &ScreeningHandler{
+		screeningService: screeningService,
+		interval:         interval,
+	}
+}
+
+// Start executa uma retriagem na inicialização e depois a cada intervalo
+func (h *ScreeningHandler) Start(ctx context.Context) error {
+	log.Println("🔄 Iniciando Screening Handler...")
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Screening Handler iniciado, verificando listas a cada %v", h.interval)
+
+	h.run(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Screening Handler encerrado")
+			return nil
+		case <-ticker.C:
+			h.run(ctx)
+		}
+	}
+}
+
+// run aplica as listas pendentes
+func (h *ScreeningHandler) run(ctx context.Context) {
+	processed, err := h.screeningService.Rescreen(ctx)
+	if err != nil {
+		log.Printf("❌ Erro na retriagem das listas: %v", err)
+	}
+	if processed > 0 {
+		log.Printf("🚫 %d listas de triagem aplicadas", processed)
+	}
+}
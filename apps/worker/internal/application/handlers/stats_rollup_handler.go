@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application/services"
+)
+
+// StatsRollupHandler incorpora periodicamente os novos blocos aos rollups de estatísticas da rede
+type StatsRollupHandler struct {
+	statsRollupService *services.StatsRollupService
+	interval           time.Duration
+}
+
+// NewStatsRollupHandler cria uma nova instância do handler de rollups de estatísticas
+func NewStatsRollupHandler(statsRollupService *services.StatsRollupService, interval time.Duration) *StatsRollupHandler {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	return &StatsRollupHandler{
+		statsRollupService: statsRollupService,
+		interval:           interval,
+	}
+}
+
+// Start atualiza os rollups na inicialização e depois a cada intervalo
+func (h *StatsRollupHandler) Start(ctx context.Context) error {
+	log.Println("🔄 Iniciando Stats Rollup Handler...")
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Stats Rollup Handler iniciado, atualizando rollups a cada %v", h.interval)
+
+	h.update(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Stats Rollup Handler encerrado")
+			return nil
+		case <-ticker.C:
+			h.update(ctx)
+		}
+	}
+}
+
+// update executa um ciclo de atualização dos rollups
+func (h *StatsRollupHandler) update(ctx context.Context) {
+	processed, err := h.statsRollupService.Update(ctx)
+	if err != nil {
+		log.Printf("❌ Erro ao atualizar rollups de estatísticas: %v", err)
+		return
+	}
+	if processed > 0 {
+		log.Printf("📊 %d blocos incorporados aos rollups de estatísticas", processed)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hubweb3/worker/internal/application/services"
+)
+
+// TagRulesHandler reavalia periodicamente as regras de tags: aplica regras novas ou editadas a todas as accounts,
+// atualiza as accounts com atividade recente e remove tags de regras desativadas
+type TagRulesHandler struct {
+	taggingService *services.AccountTaggingService
+	interval       time.Duration
+}
+
+// NewTagRulesHandler cria uma nova instância do handler de regras de tags
+func NewTagRulesHandler(taggingService *services.AccountTaggingService, interval time.Duration) *TagRulesHandler {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return &TagRulesHandler{
+		taggingService: taggingService,
+		interval:       interval,
+	}
+}
+
+// Start reavalia as regras na inicialização e depois a cada intervalo
+func (h *TagRulesHandler) Start(ctx context.Context) error {
+	log.Println("🔄 Iniciando Tag Rules Handler...")
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	log.Printf("✅ Tag Rules Handler iniciado, reavaliando tags a cada %v", h.interval)
+
+	h.reevaluate(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Tag Rules Handler encerrado")
+			return nil
+		case <-ticker.C:
+			h.reevaluate(ctx)
+		}
+	}
+}
+
+// reevaluate executa um ciclo de reavaliação das tags
+func (h *TagRulesHandler) reevaluate(ctx context.Context) {
+	if err := h.taggingService.UpdateTagsBasedOnAnalytics(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("❌ Erro ao reavaliar regras de tags: %v", err)
+	}
+}
@@ -6,53 +6,86 @@ import (
 	"fmt"
 	"log"
 	"math/big"
-	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hubweb3/worker/internal/application/services"
 	"github.com/hubweb3/worker/internal/domain/entities"
 	"github.com/hubweb3/worker/internal/domain/repositories"
 	domainServices "github.com/hubweb3/worker/internal/domain/services"
 	"github.com/hubweb3/worker/internal/queues"
+	"github.com/hubweb3/worker/internal/tracing"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // TransactionHandler processa transações dos blocos
 type TransactionHandler struct {
 	blockService                *domainServices.BlockService
 	txRepo                      repositories.TransactionRepository
+	bulkWriter                  repositories.BulkWriter
 	ethClient                   *ethclient.Client
 	consumer                    *queues.Consumer
 	publisher                   *queues.Publisher
 	transactionMethodService    *services.TransactionMethodService
 	contractMetricsService      *services.SmartContractMetricsService
 	accountTransactionProcessor *services.AccountTransactionProcessor
+	alertService                *services.AlertService
+	revertReasonService         *services.RevertReasonService
 	processedCount              int64 // Contador de transações processadas
+
+	// Processamento de accounts por bloco: as transações de um bloco são acumuladas e processadas
+	// juntas quando chega uma transação de outro bloco ou a fila fica ociosa por accountFlushInterval.
+	// As mensagens só são confirmadas depois que as accounts do bloco são gravadas
+	accountFlushInterval time.Duration
+	accountBatch         []*queuedTransaction
+	accountBatchBlock    uint64
+	accountBatchUpdated  time.Time
+}
+
+// queuedTransaction é uma transação gravada aguardando o processamento de accounts do seu bloco
+type queuedTransaction struct {
+	ctx   context.Context // contexto do trace da mensagem
+	msg   amqp.Delivery
+	block *services.BlockTransaction
 }
 
 // NewTransactionHandler cria uma nova instância do handler de transações
 func NewTransactionHandler(
 	blockService *domainServices.BlockService,
 	txRepo repositories.TransactionRepository,
+	bulkWriter repositories.BulkWriter,
 	ethClient *ethclient.Client,
 	consumer *queues.Consumer,
 	publisher *queues.Publisher,
 	transactionMethodService *services.TransactionMethodService,
 	contractMetricsService *services.SmartContractMetricsService,
 	accountTransactionProcessor *services.AccountTransactionProcessor,
+	alertService *services.AlertService,
+	revertReasonService *services.RevertReasonService,
+	accountFlushInterval time.Duration,
 ) *TransactionHandler {
+	if accountFlushInterval <= 0 {
+		accountFlushInterval = 500 * time.Millisecond
+	}
+
 	return &TransactionHandler{
 		blockService:                blockService,
 		txRepo:                      txRepo,
+		bulkWriter:                  bulkWriter,
 		ethClient:                   ethClient,
 		consumer:                    consumer,
 		publisher:                   publisher,
 		transactionMethodService:    transactionMethodService,
 		contractMetricsService:      contractMetricsService,
 		accountTransactionProcessor: accountTransactionProcessor,
+		alertService:                alertService,
+		revertReasonService:         revertReasonService,
+		accountFlushInterval:        accountFlushInterval,
 	}
 }
 
@@ -99,24 +132,38 @@ func (h *TransactionHandler) startConsumption(ctx context.Context) error {
 
 	log.Printf("✅ Transaction Handler iniciado, aguardando mensagens na fila '%s'", queues.TransactionMinedQueue.Name)
 
+	// Gravar as accounts das transações acumuladas (e confirmar as mensagens) antes de sair ou reiniciar o consumo
+	defer h.flushAccountBatch()
+
+	flushTicker := time.NewTicker(h.accountFlushInterval)
+	defer flushTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
+		case <-flushTicker.C:
+			// Fila ociosa: não há mais transações do bloco a caminho
+			if time.Since(h.accountBatchUpdated) >= h.accountFlushInterval {
+				h.flushAccountBatch()
+			}
 		case msg, ok := <-messages:
 			if !ok {
 				log.Println("⚠️ Canal de mensagens fechado, reiniciando...")
 				return fmt.Errorf("canal de mensagens fechado")
 			}
 
-			// Processar mensagem com acknowledgment manual
-			if err := h.processTransactionMessage(msg); err != nil {
+			// Processar mensagem com acknowledgment manual; mensagens de transações acumuladas para o
+			// processamento de accounts são confirmadas por flushAccountBatch
+			queued, err := h.processTransactionMessage(msg)
+			switch {
+			case err != nil:
 				log.Printf("❌ Erro ao processar transação: %v", err)
 				// Rejeitar mensagem e reenviar para fila
 				if nackErr := msg.Nack(false, true); nackErr != nil {
 					log.Printf("❌ Erro ao fazer NACK da mensagem: %v", nackErr)
 				}
-			} else {
+			case !queued:
 				// Confirmar processamento bem-sucedido
 				if ackErr := msg.Ack(false); ackErr != nil {
 					log.Printf("⚠️ Erro ao fazer ACK da mensagem: %v", ackErr)
@@ -126,193 +173,370 @@ func (h *TransactionHandler) startConsumption(ctx context.Context) error {
 	}
 }
 
-// processTransactionMessage processa uma mensagem de transação
-func (h *TransactionHandler) processTransactionMessage(msg amqp.Delivery) error {
-	var txEvent struct {
-		Hash        string `json:"hash"`
-		BlockNumber uint64 `json:"block_number"`
-		BlockHash   string `json:"block_hash"`
-		From        string `json:"from"`
-		To          string `json:"to"`
-		Value       string `json:"value"`
-		Gas         uint64 `json:"gas"`
-		GasPrice    string `json:"gas_price"`
-		Nonce       uint64 `json:"nonce"`
-	}
+// processTransactionMessage processa uma mensagem de transação. Retorna queued=true quando a transação
+// ficou aguardando o processamento de accounts do bloco, que confirma a mensagem
+func (h *TransactionHandler) processTransactionMessage(msg amqp.Delivery) (queued bool, err error) {
+	// Continuar o trace iniciado no indexer (traceparent nos headers AMQP)
+	ctx, span := queues.StartConsumeSpan(context.Background(), queues.TransactionMinedQueue.Name, msg)
+	defer func() { tracing.End(span, err) }()
 
+	var txEvent transactionEvent
 	if err := json.Unmarshal(msg.Body, &txEvent); err != nil {
 		log.Printf("❌ Erro ao deserializar mensagem de transação: %v", err)
-		return err
+		return false, err
 	}
 
 	// VALIDAÇÃO: Rejeitar mensagens com dados inválidos
 	if txEvent.Hash == "" {
 		log.Printf("⚠️ Mensagem rejeitada: hash vazio. A mensagem será descartada.")
-		return nil
+		return false, nil
 	}
 
 	if txEvent.BlockHash == "" || txEvent.BlockNumber == 0 {
 		log.Printf("⚠️ Mensagem rejeitada para tx %s: block_hash='%s', block_number=%d. A mensagem será descartada.",
 			txEvent.Hash, txEvent.BlockHash, txEvent.BlockNumber)
-		return nil
+		return false, nil
 	}
 
 	// Verificar se a transação já existe no banco antes de processar
-	exists, err := h.txRepo.Exists(context.Background(), txEvent.Hash)
+	exists, err := h.txRepo.Exists(ctx, txEvent.Hash)
 	if err != nil {
 		log.Printf("❌ Erro ao verificar existência da transação %s: %v", txEvent.Hash, err)
-		return err
+		return false, err
 	}
 
-	if exists {
+	// Uma reentrega de transação já gravada vem de um bloco cujas accounts falharam: só o processamento
+	// de accounts é refeito (o processador ignora as transações cujas accounts já foram gravadas)
+	if exists && !msg.Redelivered {
 		log.Printf("✅ Transação %s já existe no banco, pulando processamento", txEvent.Hash)
-		return nil // Não é erro, apenas pula o processamento
+		return false, nil // Não é erro, apenas pula o processamento
 	}
 
 	log.Printf("💰 [RECEBIDO] Transação: %s (bloco: %d, hash: %s)", txEvent.Hash, txEvent.BlockNumber, txEvent.BlockHash)
+	span.SetAttributes(
+		attribute.String("tx.hash", txEvent.Hash),
+		attribute.Int64("block.number", int64(txEvent.BlockNumber)),
+	)
 
-	// Buscar dados completos da transação com retry
-	txHash := common.HexToHash(txEvent.Hash)
-
-	var tx *types.Transaction
-	var isPending bool
-
-	// Tentar buscar a transação com retry (máximo 3 tentativas)
-	for attempt := 1; attempt <= 3; attempt++ {
-		tx, isPending, err = h.ethClient.TransactionByHash(context.Background(), txHash)
-		if err == nil {
-			break
+	// Usar os dados enviados pelo indexer; buscar via RPC apenas mensagens antigas ou incompletas
+	tx, receipt, blockTime, ok := decodeForwardedTransaction(&txEvent)
+	if !ok {
+		var isPending bool
+		tx, receipt, blockTime, isPending, err = h.fetchTransactionData(ctx, &txEvent)
+		if err != nil {
+			log.Printf("❌ Erro ao buscar dados da transação %s: %v", txEvent.Hash, err)
+			return false, err
 		}
-
-		if attempt < 3 {
-			log.Printf("⏳ Tentativa %d falhou para transação %s, tentando novamente em 1s...", attempt, txEvent.Hash)
-			time.Sleep(1 * time.Second)
+		if isPending {
+			log.Printf("⏳ Transação %s ainda está pendente", txEvent.Hash)
+			return false, nil
 		}
 	}
 
-	if err != nil {
-		log.Printf("❌ Erro ao buscar transação %s após 3 tentativas: %v", txEvent.Hash, err)
-		return fmt.Errorf("erro ao buscar transação após 3 tentativas: %w", err)
-	}
+	// Converter para entidade de domínio
+	transaction := transactionEntity(tx, receipt, txEvent.From, txEvent.BlockNumber, txEvent.BlockHash, blockTime)
 
-	if isPending {
-		log.Printf("⏳ Transação %s ainda está pendente", txEvent.Hash)
-		return nil
+	if exists {
+		log.Printf("🔁 Transação %s já gravada (reentrega), refazendo o processamento de accounts", txEvent.Hash)
+		h.queueAccountTransaction(ctx, msg, txEvent.BlockNumber, transaction, receipt)
+		return true, nil
 	}
 
-	// Buscar receipt da transação com retry
-	var receipt *types.Receipt
-	for attempt := 1; attempt <= 3; attempt++ {
-		receipt, err = h.ethClient.TransactionReceipt(context.Background(), txHash)
-		if err == nil {
-			break
-		}
-
-		if attempt < 3 {
-			log.Printf("⏳ Tentativa %d falhou para receipt %s, tentando novamente em 1s...", attempt, txEvent.Hash)
-			time.Sleep(1 * time.Second)
-		}
+	// Gravar a transação com o motivo da falha, o método identificado e as métricas de contrato
+	if err := h.storeTransaction(ctx, tx, receipt, txEvent.Receipt, transaction); err != nil {
+		return false, err
 	}
 
-	if err != nil {
-		log.Printf("❌ Erro ao buscar receipt %s após 3 tentativas: %v", txEvent.Hash, err)
-		return fmt.Errorf("erro ao buscar receipt após 3 tentativas: %w", err)
-	}
+	// Incrementar contador
+	h.processedCount++
+	log.Printf("✅ [SALVO] Transação %s salva com sucesso no banco (Total processadas: %d)", txEvent.Hash, h.processedCount)
 
-	// Buscar bloco para obter timestamp
-	block, err := h.ethClient.BlockByHash(context.Background(), common.HexToHash(txEvent.BlockHash))
-	if err != nil {
-		// Se não conseguir buscar por hash, tentar por número
-		log.Printf("⚠️ Erro ao buscar bloco por hash %s, tentando por número %d", txEvent.BlockHash, txEvent.BlockNumber)
-		block, err = h.ethClient.BlockByNumber(context.Background(), big.NewInt(int64(txEvent.BlockNumber)))
-		if err != nil {
-			log.Printf("❌ Erro ao buscar bloco %d: %v", txEvent.BlockNumber, err)
-			return fmt.Errorf("erro ao buscar bloco: %w", err)
-		}
-	}
+	// Processar dados de accounts junto com as demais transações do mesmo bloco; alertas e o evento de
+	// transação processada saem depois que as accounts do bloco são gravadas
+	h.queueAccountTransaction(ctx, msg, txEvent.BlockNumber, transaction, receipt)
+	return true, nil
+}
 
-	// Converter para entidade de domínio
-	transaction := h.convertToTransaction(tx, receipt, block)
+// storeTransaction grava a transação: motivo da falha, a própria transação, o método identificado e as
+// métricas de smart contracts. Só a gravação da transação falha o processamento
+func (h *TransactionHandler) storeTransaction(ctx context.Context, tx *types.Transaction, receipt *types.Receipt, rawReceipt json.RawMessage, transaction *entities.Transaction) error {
+	// Motivo da falha: revertReason do receipt (Besu) ou reexecução no bloco pai
+	if transaction.Status == entities.StatusFailed {
+		h.resolveRevertReason(ctx, transaction, rawReceipt)
+	}
 
 	// Salvar transação usando o repositório diretamente
-	if err := h.saveTransaction(context.Background(), transaction); err != nil {
-		log.Printf("❌ Erro ao salvar transação %s: %v", txEvent.Hash, err)
+	if err := tracing.WithSpan(ctx, "TransactionHandler.saveTransaction", func(ctx context.Context) error {
+		return h.saveTransaction(ctx, transaction)
+	}); err != nil {
+		log.Printf("❌ Erro ao salvar transação %s: %v", transaction.Hash, err)
 		return err
 	}
 
+	h.recordTransactionDetails(ctx, tx, receipt, transaction)
+	return nil
+}
+
+// recordTransactionDetails grava o método identificado da transação e atualiza as métricas de smart
+// contracts. Falhas só são registradas no log
+func (h *TransactionHandler) recordTransactionDetails(ctx context.Context, tx *types.Transaction, receipt *types.Receipt, transaction *entities.Transaction) {
 	// Identificar e salvar método da transação
-	if err := h.identifyAndSaveTransactionMethod(context.Background(), tx, receipt, transaction); err != nil {
-		log.Printf("⚠️ Erro ao identificar método da transação %s: %v", txEvent.Hash, err)
+	if err := tracing.WithSpan(ctx, "TransactionHandler.identifyAndSaveTransactionMethod", func(ctx context.Context) error {
+		return h.identifyAndSaveTransactionMethod(ctx, tx, receipt, transaction)
+	}); err != nil {
+		log.Printf("⚠️ Erro ao identificar método da transação %s: %v", transaction.Hash, err)
 		// Não retornar erro para não falhar o processamento da transação
 	}
 
 	// Atualizar métricas de smart contracts
-	if err := h.contractMetricsService.UpdateContractMetricsFromTransaction(context.Background(), transaction); err != nil {
-		log.Printf("⚠️ Erro ao atualizar métricas de smart contract para transação %s: %v", txEvent.Hash, err)
+	if err := h.contractMetricsService.UpdateContractMetricsFromTransaction(ctx, transaction); err != nil {
+		log.Printf("⚠️ Erro ao atualizar métricas de smart contract para transação %s: %v", transaction.Hash, err)
 		// Não retornar erro para não falhar o processamento da transação
 	}
+}
 
-	// Processar dados de accounts relacionados à transação
-	if err := h.accountTransactionProcessor.ProcessTransaction(context.Background(), transaction); err != nil {
-		log.Printf("⚠️ Erro ao processar dados de accounts para transação %s: %v", txEvent.Hash, err)
-		// Não retornar erro para não falhar o processamento da transação
+// transactionEvent é a mensagem da fila de transações mineradas. O indexer atual não a publica mais (as
+// transações seguem no payload do bloco em block-processed); a fila é consumida para mensagens de versões
+// anteriores. RawTx, Receipt e BlockTimestamp só existem em mensagens de indexers com busca de receipts por bloco
+type transactionEvent struct {
+	Hash             string          `json:"hash"`
+	BlockNumber      uint64          `json:"block_number"`
+	BlockHash        string          `json:"block_hash"`
+	BlockTimestamp   uint64          `json:"block_timestamp"`
+	TransactionIndex uint            `json:"transaction_index"`
+	From             string          `json:"from"`
+	To               string          `json:"to"`
+	Value            string          `json:"value"`
+	Gas              uint64          `json:"gas"`
+	GasPrice         string          `json:"gas_price"`
+	Nonce            uint64          `json:"nonce"`
+	RawTx            string          `json:"raw_tx"`
+	Receipt          json.RawMessage `json:"receipt"`
+}
+
+// queueAccountTransaction acumula a transação para o processamento de accounts do bloco. Uma transação
+// de outro bloco processa antes as transações acumuladas
+func (h *TransactionHandler) queueAccountTransaction(ctx context.Context, msg amqp.Delivery, blockNumber uint64, tx *entities.Transaction, receipt *types.Receipt) {
+	if len(h.accountBatch) > 0 && h.accountBatchBlock != blockNumber {
+		h.flushAccountBatch()
 	}
 
-	// Incrementar contador
-	h.processedCount++
-	log.Printf("✅ [SALVO] Transação %s salva com sucesso no banco (Total processadas: %d)", txEvent.Hash, h.processedCount)
+	// Logs vazios (e não nil) evitam que o processador busque o receipt de novo
+	logs := receipt.Logs
+	if logs == nil {
+		logs = []*types.Log{}
+	}
+
+	h.accountBatch = append(h.accountBatch, &queuedTransaction{
+		ctx:   ctx,
+		msg:   msg,
+		block: &services.BlockTransaction{Transaction: tx, Logs: logs},
+	})
+	h.accountBatchBlock = blockNumber
+	h.accountBatchUpdated = time.Now()
+}
+
+// flushAccountBatch processa os dados de accounts das transações acumuladas do bloco. Com as accounts
+// gravadas, avalia os alertas, publica as transações processadas e confirma as mensagens; se o bloco
+// falhar, as mensagens voltam à fila
+func (h *TransactionHandler) flushAccountBatch() {
+	if len(h.accountBatch) == 0 {
+		return
+	}
+
+	batch, blockNumber := h.accountBatch, h.accountBatchBlock
+	h.accountBatch = nil
+
+	blockTxs := make([]*services.BlockTransaction, len(batch))
+	for i, queued := range batch {
+		blockTxs[i] = queued.block
+	}
+
+	if err := tracing.WithSpan(context.Background(), "AccountTransactionProcessor.ProcessBlock", func(ctx context.Context) error {
+		return h.accountTransactionProcessor.ProcessBlock(ctx, blockTxs)
+	}, attribute.Int64("block.number", int64(blockNumber)), attribute.Int("block.transactions", len(batch))); err != nil {
+		log.Printf("❌ Erro ao processar dados de accounts do bloco %d, devolvendo %d transações à fila: %v", blockNumber, len(batch), err)
+		for _, queued := range batch {
+			if nackErr := queued.msg.Nack(false, true); nackErr != nil {
+				log.Printf("❌ Erro ao fazer NACK da mensagem: %v", nackErr)
+			}
+		}
+		return
+	}
+
+	for _, queued := range batch {
+		h.notifyTransaction(queued.ctx, queued.block.Transaction)
+
+		if ackErr := queued.msg.Ack(false); ackErr != nil {
+			log.Printf("⚠️ Erro ao fazer ACK da mensagem: %v", ackErr)
+		}
+	}
+}
+
+// blockTransaction é uma transação de um bloco processado pelo BlockHandler, com o receipt decodificado e o
+// JSON original do receipt (o revertReason do Besu não faz parte do types.Receipt)
+type blockTransaction struct {
+	tx         *types.Transaction
+	receipt    *types.Receipt
+	rawReceipt json.RawMessage
+	entity     *entities.Transaction
+}
+
+// accountBlockTransactions monta as transações do bloco para o processamento de accounts
+func accountBlockTransactions(txs []*blockTransaction) []*services.BlockTransaction {
+	blockTxs := make([]*services.BlockTransaction, len(txs))
+	for i, bt := range txs {
+		// Logs vazios (e não nil) evitam que o processador busque o receipt de novo
+		logs := bt.receipt.Logs
+		if logs == nil {
+			logs = []*types.Log{}
+		}
+		blockTxs[i] = &services.BlockTransaction{Transaction: bt.entity, Logs: logs}
+	}
+	return blockTxs
+}
+
+// prepareBlockAccounts monta as escritas de accounts das transações de um bloco com os eventos já
+// identificados, agrupados por transação
+func (h *TransactionHandler) prepareBlockAccounts(ctx context.Context, blockNumber uint64, txs []*blockTransaction, events map[string][]*entities.Event) (prepared *services.PreparedBlock, err error) {
+	err = tracing.WithSpan(ctx, "AccountTransactionProcessor.PrepareBlock", func(ctx context.Context) error {
+		prepared, err = h.accountTransactionProcessor.PrepareBlock(ctx, accountBlockTransactions(txs), events)
+		return err
+	}, attribute.Int64("block.number", int64(blockNumber)), attribute.Int("block.transactions", len(txs)))
+	return prepared, err
+}
+
+// finishBlockAccounts conclui o processamento de accounts de um bloco já gravado
+func (h *TransactionHandler) finishBlockAccounts(ctx context.Context, blockNumber uint64, prepared *services.PreparedBlock) error {
+	return tracing.WithSpan(ctx, "AccountTransactionProcessor.FinishBlock", func(ctx context.Context) error {
+		return h.accountTransactionProcessor.FinishBlock(ctx, prepared)
+	}, attribute.Int64("block.number", int64(blockNumber)))
+}
+
+// notifyTransaction avalia as regras de alerta e publica a transação processada
+func (h *TransactionHandler) notifyTransaction(ctx context.Context, transaction *entities.Transaction) {
+	// Avaliar regras de alerta de transações
+	h.alertService.EvaluateTransaction(ctx, transaction)
 
 	// Publicar evento de transação processada
-	if err := h.publishTransactionProcessed(transaction); err != nil {
+	if err := h.publishTransactionProcessed(ctx, transaction); err != nil {
 		log.Printf("⚠️ Erro ao publicar evento de transação processada: %v", err)
 	}
-
-	return nil
 }
 
-// saveTransaction salva a transação no banco de dados
-func (h *TransactionHandler) saveTransaction(ctx context.Context, tx *entities.Transaction) error {
-	log.Printf("🔄 Salvando transação %s no banco de dados", tx.Hash)
+// decodeForwardedTransaction decodifica a transação e o receipt enviados pelo indexer.
+// Retorna ok=false quando a mensagem não traz os dados completos
+func decodeForwardedTransaction(event *transactionEvent) (*types.Transaction, *types.Receipt, uint64, bool) {
+	if event.RawTx == "" || len(event.Receipt) == 0 || event.BlockTimestamp == 0 {
+		return nil, nil, 0, false
+	}
 
-	// Verificar se a transação já existe
-	exists, err := h.txRepo.Exists(ctx, tx.Hash)
+	raw, err := hexutil.Decode(event.RawTx)
 	if err != nil {
-		return err
+		log.Printf("⚠️ raw_tx inválido para %s: %v", event.Hash, err)
+		return nil, nil, 0, false
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		log.Printf("⚠️ Erro ao decodificar raw_tx de %s: %v", event.Hash, err)
+		return nil, nil, 0, false
 	}
 
-	if exists {
-		log.Printf("⚠️ Transação %s já existe, atualizando...", tx.Hash)
-		if err := h.txRepo.Update(ctx, tx); err != nil {
-			// Se o erro for de constraint de duplicata, apenas logar e continuar
-			if strings.Contains(err.Error(), "unique_tx_per_block") {
-				log.Printf("⚠️ Transação %s já existe com mesmo block_hash e transaction_index, ignorando...", tx.Hash)
-				return nil
+	receipt := new(types.Receipt)
+	if err := json.Unmarshal(event.Receipt, receipt); err != nil {
+		log.Printf("⚠️ Erro ao decodificar receipt de %s: %v", event.Hash, err)
+		return nil, nil, 0, false
+	}
+
+	return tx, receipt, event.BlockTimestamp, true
+}
+
+// fetchTransactionData busca transação, receipt e cabeçalho do bloco em um único batch JSON-RPC (com retry)
+func (h *TransactionHandler) fetchTransactionData(ctx context.Context, event *transactionEvent) (tx *types.Transaction, receipt *types.Receipt, blockTime uint64, isPending bool, err error) {
+	txHash := common.HexToHash(event.Hash)
+
+	var rawTx, rawReceipt json.RawMessage
+	var header *types.Header
+	batch := []rpc.BatchElem{
+		{Method: "eth_getTransactionByHash", Args: []interface{}{txHash}, Result: &rawTx},
+		{Method: "eth_getTransactionReceipt", Args: []interface{}{txHash}, Result: &rawReceipt},
+		{Method: "eth_getBlockByHash", Args: []interface{}{common.HexToHash(event.BlockHash), false}, Result: &header},
+	}
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		err = tracing.ObserveRPC(ctx, "eth_transaction_batch", func(ctx context.Context) error {
+			return h.ethClient.Client().BatchCallContext(ctx, batch)
+		})
+		if err == nil && batch[0].Error == nil && batch[1].Error == nil {
+			break
+		}
+		if err == nil {
+			err = batch[0].Error
+			if err == nil {
+				err = batch[1].Error
 			}
-			return err
 		}
-		return nil
+
+		if attempt < 3 {
+			log.Printf("⏳ Tentativa %d falhou para transação %s, tentando novamente em 1s...", attempt, event.Hash)
+			time.Sleep(1 * time.Second)
+		}
+	}
+	if err != nil {
+		return nil, nil, 0, false, fmt.Errorf("erro ao buscar transação após 3 tentativas: %w", err)
 	}
 
-	// Salvar nova transação
-	if err := h.txRepo.Save(ctx, tx); err != nil {
-		// Se o erro for de constraint de duplicata, apenas logar e continuar
-		if strings.Contains(err.Error(), "unique_tx_per_block") {
-			log.Printf("⚠️ Transação %s já existe com mesmo block_hash e transaction_index, ignorando...", tx.Hash)
-			return nil
+	if len(rawTx) == 0 || string(rawTx) == "null" {
+		return nil, nil, 0, false, fmt.Errorf("transação %s não encontrada", event.Hash)
+	}
+	var pending struct {
+		BlockNumber *string `json:"blockNumber"`
+	}
+	if err := json.Unmarshal(rawTx, &pending); err == nil && pending.BlockNumber == nil {
+		return nil, nil, 0, true, nil
+	}
+
+	tx = new(types.Transaction)
+	if err := json.Unmarshal(rawTx, tx); err != nil {
+		return nil, nil, 0, false, fmt.Errorf("erro ao decodificar transação: %w", err)
+	}
+
+	if len(rawReceipt) == 0 || string(rawReceipt) == "null" {
+		return nil, nil, 0, false, fmt.Errorf("receipt da transação %s não encontrado", event.Hash)
+	}
+	receipt = new(types.Receipt)
+	if err := json.Unmarshal(rawReceipt, receipt); err != nil {
+		return nil, nil, 0, false, fmt.Errorf("erro ao decodificar receipt: %w", err)
+	}
+
+	if batch[2].Error != nil || header == nil {
+		// Se não conseguir buscar por hash, tentar por número
+		log.Printf("⚠️ Erro ao buscar bloco por hash %s, tentando por número %d", event.BlockHash, event.BlockNumber)
+		header, err = h.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(event.BlockNumber))
+		if err != nil {
+			return nil, nil, 0, false, fmt.Errorf("erro ao buscar bloco: %w", err)
 		}
-		return err
 	}
 
-	return nil
+	return tx, receipt, header.Time, false, nil
 }
 
-// convertToTransaction converte dados da blockchain para entidade de domínio
-func (h *TransactionHandler) convertToTransaction(tx *types.Transaction, receipt *types.Receipt, block *types.Block) *entities.Transaction {
-	var fromAddr string
+// saveTransaction grava a transação pelo BulkWriter, que atualiza pelo hash uma transação já gravada
+// (inclusive pendente) ou insere a nova
+func (h *TransactionHandler) saveTransaction(ctx context.Context, tx *entities.Transaction) error {
+	log.Printf("🔄 Salvando transação %s no banco de dados", tx.Hash)
+
+	bundle := &entities.BlockBundle{Transactions: []*entities.Transaction{tx}}
+	return h.bulkWriter.WriteBlocksWith(ctx, []*entities.BlockBundle{bundle}, repositories.BulkWriteOptions{SkipAccountStages: true})
+}
+
+// transactionEntity converte dados da blockchain para entidade de domínio
+func transactionEntity(tx *types.Transaction, receipt *types.Receipt, sender string, blockNumber uint64, blockHash string, blockTime uint64) *entities.Transaction {
 	var toAddr *string
 
-	// Extrair endereço do remetente
-	if from, err := types.Sender(types.NewEIP155Signer(tx.ChainId()), tx); err == nil {
+	// Extrair endereço do remetente; usar o informado pelo indexer se a assinatura não puder ser verificada
+	fromAddr := sender
+	if from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
 		fromAddr = from.Hex()
 	}
 
@@ -345,11 +569,9 @@ func (h *TransactionHandler) convertToTransaction(tx *types.Transaction, receipt
 	}
 
 	// Converter valores
-	blockNumber := block.Number().Uint64()
-	blockHash := block.Hash().Hex()
 	txIndex := uint64(receipt.TransactionIndex)
 	gasUsed := receipt.GasUsed
-	minedAt := time.Unix(int64(block.Time()), 0)
+	minedAt := time.Unix(int64(blockTime), 0)
 
 	return &entities.Transaction{
 		Hash:                 tx.Hash().Hex(),
@@ -376,8 +598,33 @@ func (h *TransactionHandler) convertToTransaction(tx *types.Transaction, receipt
 	}
 }
 
+// resolveRevertReason preenche o motivo da falha a partir do revertReason do receipt. Sem ele a transação é
+// gravada sem motivo e o RevertReasonHandler faz a reexecução no bloco pai, fora do caminho do consumo
+func (h *TransactionHandler) resolveRevertReason(ctx context.Context, transaction *entities.Transaction, rawReceipt json.RawMessage) {
+	var receiptReason []byte
+	if len(rawReceipt) > 0 {
+		var besuReceipt struct {
+			RevertReason string `json:"revertReason"`
+		}
+		if err := json.Unmarshal(rawReceipt, &besuReceipt); err == nil && besuReceipt.RevertReason != "" {
+			receiptReason, _ = hexutil.Decode(besuReceipt.RevertReason)
+		}
+	}
+	if len(receiptReason) == 0 {
+		return
+	}
+
+	reason, err := h.revertReasonService.Resolve(ctx, transaction, receiptReason)
+	if err != nil {
+		log.Printf("⚠️ Erro ao obter motivo da falha da transação %s: %v", transaction.Hash, err)
+		return
+	}
+	transaction.RevertReason = reason
+	log.Printf("🧯 Transação %s falhou: %s", transaction.Hash, reason.Message)
+}
+
 // publishTransactionProcessed publica evento de transação processada
-func (h *TransactionHandler) publishTransactionProcessed(tx *entities.Transaction) error {
+func (h *TransactionHandler) publishTransactionProcessed(ctx context.Context, tx *entities.Transaction) error {
 	event := map[string]interface{}{
 		"type":        "transaction-processed",
 		"hash":        tx.Hash,
@@ -395,7 +642,7 @@ func (h *TransactionHandler) publishTransactionProcessed(tx *entities.Transactio
 		return err
 	}
 
-	return h.publisher.Publish("transaction-processed", eventData)
+	return h.publisher.PublishWithContext(ctx, "transaction-processed", eventData)
 }
 
 // identifyAndSaveTransactionMethod identifica e salva o método da transação
@@ -1,18 +1,16 @@
 package handlers
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"math/big"
-	"net/http"
 	"time"
 
 	"github.com/hubweb3/worker/internal/domain/entities"
 	"github.com/hubweb3/worker/internal/domain/services"
+	"github.com/hubweb3/worker/internal/infrastructure/rpcpool"
 	"github.com/hubweb3/worker/internal/queues"
 )
 
@@ -20,21 +18,17 @@ import (
 type ValidatorHandler struct {
 	validatorService *services.ValidatorService
 	publisher        *queues.Publisher
-	rpcURL           string
-	httpClient       *http.Client
+	rpcPool          *rpcpool.Pool
 	syncInterval     time.Duration
 }
 
 // NewValidatorHandler cria uma nova instância do handler de validadores
-func NewValidatorHandler(validatorService *services.ValidatorService, publisher *queues.Publisher, rpcURL string) *ValidatorHandler {
+func NewValidatorHandler(validatorService *services.ValidatorService, publisher *queues.Publisher, rpcPool *rpcpool.Pool) *ValidatorHandler {
 	return &ValidatorHandler{
 		validatorService: validatorService,
 		publisher:        publisher,
-		rpcURL:           rpcURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		syncInterval: 30 * time.Second, // Sincronizar a cada 30 segundos
+		rpcPool:          rpcPool,
+		syncInterval:     30 * time.Second, // Sincronizar a cada 30 segundos
 	}
 }
 
@@ -224,33 +218,16 @@ func (h *ValidatorHandler) getQBFTSignerMetrics(ctx context.Context) ([]*QBFTSig
 	return metrics, nil
 }
 
-// makeRPCCall faz uma chamada JSON-RPC
+// makeRPCCall faz uma chamada JSON-RPC no node mais saudável do pool
 func (h *ValidatorHandler) makeRPCCall(ctx context.Context, request JSONRPCRequest, response *JSONRPCResponse) error {
 	jsonData, err := json.Marshal(request)
 	if err != nil {
 		return fmt.Errorf("erro ao fazer marshal da requisição: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", h.rpcURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("erro ao criar requisição HTTP: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := h.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("erro ao fazer requisição HTTP: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("status HTTP inválido: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	body, err := h.rpcPool.CallRaw(ctx, jsonData)
 	if err != nil {
-		return fmt.Errorf("erro ao ler resposta: %w", err)
+		return err
 	}
 
 	err = json.Unmarshal(body, response)
@@ -0,0 +1,1210 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+	"github.com/hubweb3/worker/internal/tracing"
+)
+
+// rpcBatchSize limita o número de chamadas em cada batch JSON-RPC
+const rpcBatchSize = 200
+
+// erc20TransferTopic é o topic0 de Transfer(address,address,uint256)
+const erc20TransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// zeroAddress é a origem de mints e o destino de burns
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// BlockTransaction é uma transação minerada com os logs do seu receipt
+type BlockTransaction struct {
+	Transaction *entities.Transaction
+	// Logs do receipt; quando nil, o receipt é buscado no RPC (em batch com o resto do bloco)
+	Logs []*types.Log
+}
+
+// accountBlockState guarda o que o processamento de um bloco resolve uma única vez por endereço:
+// status de contrato, saldo, bytecode, nomes de contrato, ABIs e informações de tokens
+type accountBlockState struct {
+	logs       map[string][]*types.Log
+	contracts  map[string]bool
+	balances   map[string]string
+	codes      map[string][]byte
+	names      map[string]string
+	abis       map[string]*abi.ABI
+	tokenInfos map[string]*TokenInfo
+}
+
+// blockStatement é uma escrita do processamento de um bloco
+type blockStatement struct {
+	query string
+	args  []interface{}
+	label string
+}
+
+// PreparedBlock são as escritas de accounts de um bloco, montadas por PrepareBlock para serem gravadas
+// pelo BulkWriter junto com o resto do bloco e concluídas por FinishBlock
+type PreparedBlock struct {
+	// Statements são as escritas dos passos 1 a 5 e 7 a 9, na ordem das transações do bloco
+	Statements []repositories.BulkStatement
+	// Pending são as transações cujas accounts ainda não estavam gravadas, as únicas com escritas
+	Pending []*BlockTransaction
+
+	blockTxs []*BlockTransaction
+	state    *accountBlockState
+	started  time.Time
+}
+
+// ProcessBlock processa os dados de accounts de um conjunto de transações do mesmo bloco. Os endereços
+// tocados são resolvidos uma vez (status de contrato em uma query; saldos, bytecode e receipts em batch
+// JSON-RPC), mas as escritas são as mesmas do processamento transação a transação e seguem a sua ordem:
+// cada transação gera as escritas dos passos 1 a 9, inclusive a reavaliação das tags das suas accounts.
+// Todas as escritas do bloco vão em uma única transação do banco pelo BulkWriter; se qualquer uma falhar,
+// nada é gravado e o erro é retornado para que as mensagens voltem à fila. Transações cujas accounts já
+// foram gravadas (reentrega de um bloco que falhou nos passos seguintes) não são aplicadas de novo
+func (p *AccountTransactionProcessor) ProcessBlock(ctx context.Context, blockTxs []*BlockTransaction) error {
+	prepared, err := p.PrepareBlock(ctx, blockTxs, nil)
+	if err != nil {
+		return err
+	}
+	if prepared == nil {
+		return nil
+	}
+
+	if len(prepared.Statements) > 0 {
+		opts := repositories.BulkWriteOptions{SkipAccountStages: true, Statements: prepared.Statements}
+		if err := p.bulkWriter.WriteBlocksWith(ctx, nil, opts); err != nil {
+			return fmt.Errorf("erro ao gravar dados de accounts do bloco %s: %w", blockLabel(blockTxs), err)
+		}
+	}
+
+	return p.FinishBlock(ctx, prepared)
+}
+
+// PrepareBlock resolve o estado do bloco e monta as escritas de accounts das transações ainda não
+// aplicadas, sem gravá-las. events são os eventos das transações agrupados por hash; quando nil, são
+// lidos do banco. Retorna nil se não houver transações
+func (p *AccountTransactionProcessor) PrepareBlock(ctx context.Context, blockTxs []*BlockTransaction, events map[string][]*entities.Event) (*PreparedBlock, error) {
+	if len(blockTxs) == 0 {
+		return nil, nil
+	}
+
+	started := time.Now()
+	block := blockLabel(blockTxs)
+	log.Printf("🔄 Processando dados de accounts de %d transações do bloco %s", len(blockTxs), block)
+
+	state := p.loadBlockState(ctx, blockTxs)
+
+	pending, err := p.pendingTransactions(ctx, blockTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared := &PreparedBlock{Pending: pending, blockTxs: blockTxs, state: state, started: started}
+	if len(pending) == 0 {
+		return prepared, nil
+	}
+
+	// 1 a 5 e 7 a 9: escritas de cada transação, na ordem do bloco
+	stmts, err := p.blockStatements(ctx, state, pending, events)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar dados de accounts do bloco %s: %w", block, err)
+	}
+
+	prepared.Statements = make([]repositories.BulkStatement, len(stmts))
+	for i, stmt := range stmts {
+		prepared.Statements[i] = repositories.BulkStatement{Query: stmt.query, Args: stmt.args, Label: stmt.label}
+	}
+	return prepared, nil
+}
+
+// FinishBlock conclui o processamento de um bloco cujas escritas de PrepareBlock já foram gravadas:
+// dados de smart contract, avaliação de risco, triagem e UserOperations
+func (p *AccountTransactionProcessor) FinishBlock(ctx context.Context, prepared *PreparedBlock) error {
+	if prepared == nil {
+		return nil
+	}
+	block := blockLabel(prepared.blockTxs)
+
+	// 6. Atualizar dados de smart contract se aplicável
+	for _, bt := range prepared.Pending {
+		if err := p.processSmartContractData(ctx, prepared.state, bt.Transaction); err != nil {
+			log.Printf("❌ Erro ao processar dados de smart contract da transação %s: %v", bt.Transaction.Hash, err)
+		}
+	}
+
+	// 10. Enfileirar accounts para avaliação de risco
+	if err := p.enqueueBlockRiskEvaluation(ctx, prepared.blockTxs); err != nil {
+		return fmt.Errorf("erro ao enfileirar avaliação de risco do bloco %s: %w", block, err)
+	}
+
+	// 11. Triagem contra listas de sanções e denylists
+	if p.screeningService != nil {
+		if err := p.screeningService.ScreenBlock(ctx, prepared.blockTxs, prepared.state.logs); err != nil {
+			return fmt.Errorf("erro na triagem do bloco %s: %w", block, err)
+		}
+	}
+
+	// 12. Indexar UserOperations ERC-4337 das EntryPoints configuradas
+	if p.userOperationService != nil {
+		if err := p.userOperationService.IndexBlock(ctx, prepared.blockTxs, prepared.state.logs); err != nil {
+			return fmt.Errorf("erro ao indexar UserOperations do bloco %s: %w", block, err)
+		}
+	}
+
+	log.Printf("✅ Dados de accounts processados para %d transações do bloco %s (%d já gravadas) em %v",
+		len(prepared.blockTxs), block, len(prepared.blockTxs)-len(prepared.Pending), time.Since(prepared.started))
+	return nil
+}
+
+// pendingTransactions retorna as transações cujas accounts ainda não foram gravadas. account_transactions
+// é escrita na mesma transação do banco que os demais passos, então uma linha da transação ali indica que
+// o bloco já foi aplicado
+func (p *AccountTransactionProcessor) pendingTransactions(ctx context.Context, blockTxs []*BlockTransaction) ([]*BlockTransaction, error) {
+	hashes := make([]string, len(blockTxs))
+	for i, bt := range blockTxs {
+		hashes[i] = bt.Transaction.Hash
+	}
+
+	rows, err := p.db.Query(ctx, `
+		SELECT DISTINCT transaction_hash FROM account_transactions
+		WHERE transaction_hash = ANY($1) AND block_number = ANY($2)`, hashes, blockNumbers(blockTxs))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar transações já gravadas: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("erro ao ler transação já gravada: %w", err)
+		}
+		applied[hash] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao verificar transações já gravadas: %w", err)
+	}
+
+	pending := make([]*BlockTransaction, 0, len(blockTxs))
+	for _, bt := range blockTxs {
+		if !applied[bt.Transaction.Hash] {
+			pending = append(pending, bt)
+		}
+	}
+	return pending, nil
+}
+
+// blockNumbers retorna os blocos das transações, usados como filtro de block_number para que as consultas
+// em account_transactions e events leiam apenas as partições desses blocos
+func blockNumbers(blockTxs []*BlockTransaction) []int64 {
+	seen := make(map[uint64]bool, 1)
+	var numbers []int64
+	for _, bt := range blockTxs {
+		if bt.Transaction.BlockNumber == nil || seen[*bt.Transaction.BlockNumber] {
+			continue
+		}
+		seen[*bt.Transaction.BlockNumber] = true
+		numbers = append(numbers, int64(*bt.Transaction.BlockNumber))
+	}
+	return numbers
+}
+
+// blockStatements monta, transação a transação, as escritas dos passos 1 a 5 e 7 a 9. Sem events, os
+// eventos das transações são lidos do banco
+func (p *AccountTransactionProcessor) blockStatements(ctx context.Context, state *accountBlockState, blockTxs []*BlockTransaction, events map[string][]*entities.Event) ([]blockStatement, error) {
+	holdings, err := p.loadHoldings(ctx, state, blockTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		events, err = p.loadBlockEvents(ctx, blockTxs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var stmts []blockStatement
+	for _, bt := range blockTxs {
+		tx := bt.Transaction
+
+		// 1. Processar accounts envolvidas na transação
+		stmts = append(stmts, p.accountStatements(ctx, state, holdings, tx)...)
+
+		// 2. Processar analytics diárias
+		stmts = append(stmts, p.analyticsStatements(ctx, state, tx)...)
+
+		// 3. Processar interações com contratos
+		stmts = append(stmts, p.contractInteractionStatements(ctx, state, tx)...)
+
+		// 4. Processar token holdings
+		stmts = append(stmts, p.tokenHoldingStatements(ctx, state, holdings, tx)...)
+
+		// 5. Processar tags automáticas para as accounts envolvidas
+		tags, err := p.tagStatement(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, tags)
+
+		// 7. Processar transações detalhadas por conta
+		stmts = append(stmts, p.accountTransactionStatements(ctx, state, tx)...)
+
+		// 8. Processar estatísticas de métodos
+		stmts = append(stmts, p.methodStatsStatements(ctx, state, tx)...)
+
+		// 9. Processar eventos relacionados à transação
+		stmts = append(stmts, p.accountEventStatements(ctx, state, events[tx.Hash])...)
+	}
+
+	return stmts, nil
+}
+
+// loadBlockState resolve de uma vez os receipts que faltam, o status de contrato de todos os endereços
+// tocados e os saldos e bytecodes usados pelo bloco
+func (p *AccountTransactionProcessor) loadBlockState(ctx context.Context, blockTxs []*BlockTransaction) *accountBlockState {
+	state := &accountBlockState{
+		logs:       make(map[string][]*types.Log),
+		contracts:  make(map[string]bool),
+		balances:   make(map[string]string),
+		codes:      make(map[string][]byte),
+		names:      make(map[string]string),
+		abis:       make(map[string]*abi.ABI),
+		tokenInfos: make(map[string]*TokenInfo),
+	}
+
+	p.loadBlockLogs(ctx, state, blockTxs)
+
+	// Status de contrato de remetentes, destinatários, contratos criados e emissores de logs
+	var touched []string
+	seen := make(map[string]bool)
+	add := func(list *[]string, seen map[string]bool, address string) {
+		address = strings.ToLower(address)
+		if address != "" && !seen[address] {
+			seen[address] = true
+			*list = append(*list, address)
+		}
+	}
+	for _, bt := range blockTxs {
+		tx := bt.Transaction
+		add(&touched, seen, tx.From)
+		if tx.To != nil {
+			add(&touched, seen, *tx.To)
+		}
+		if tx.ContractAddress != nil {
+			add(&touched, seen, *tx.ContractAddress)
+		}
+		for _, logEntry := range state.logs[tx.Hash] {
+			add(&touched, seen, logEntry.Address.Hex())
+		}
+	}
+	p.loadContractStatus(ctx, state, touched)
+
+	// Saldos das accounts gravadas no passo 1 e bytecode dos contratos criados
+	var balanceAddrs, codeAddrs []string
+	balanceSeen, codeSeen := make(map[string]bool), make(map[string]bool)
+	for _, bt := range blockTxs {
+		tx := bt.Transaction
+		add(&balanceAddrs, balanceSeen, tx.From)
+		if tx.To != nil && *tx.To != "" && !p.isContractAddress(ctx, state, *tx.To) {
+			add(&balanceAddrs, balanceSeen, *tx.To)
+		}
+		if tx.ContractAddress != nil && *tx.ContractAddress != "" {
+			add(&balanceAddrs, balanceSeen, *tx.ContractAddress)
+			add(&codeAddrs, codeSeen, *tx.ContractAddress)
+		}
+	}
+	p.loadBalances(ctx, state, balanceAddrs)
+	p.loadCodes(ctx, state, codeAddrs)
+
+	return state
+}
+
+// loadBlockLogs guarda os logs recebidos e busca em um batch os receipts das transações sem logs
+func (p *AccountTransactionProcessor) loadBlockLogs(ctx context.Context, state *accountBlockState, blockTxs []*BlockTransaction) {
+	var missing []*entities.Transaction
+	for _, bt := range blockTxs {
+		switch {
+		case bt.Logs != nil:
+			state.logs[bt.Transaction.Hash] = bt.Logs
+		case bt.Transaction.BlockNumber != nil:
+			missing = append(missing, bt.Transaction)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	receipts := make([]*types.Receipt, len(missing))
+	elems := make([]rpc.BatchElem, len(missing))
+	for i, tx := range missing {
+		elems[i] = rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []interface{}{common.HexToHash(tx.Hash)}, Result: &receipts[i]}
+	}
+	p.batchCall(ctx, "eth_getTransactionReceipt_batch", elems)
+
+	for i, tx := range missing {
+		switch {
+		case elems[i].Error != nil:
+			log.Printf("❌ Erro ao buscar receipt da transação %s: %v", tx.Hash, elems[i].Error)
+		case receipts[i] == nil:
+			log.Printf("❌ Receipt da transação %s não encontrado", tx.Hash)
+		default:
+			state.logs[tx.Hash] = receipts[i].Logs
+		}
+	}
+}
+
+// loadContractStatus marca em uma única query quais endereços estão em smart_contracts
+func (p *AccountTransactionProcessor) loadContractStatus(ctx context.Context, state *accountBlockState, addresses []string) {
+	if len(addresses) == 0 {
+		return
+	}
+
+	rows, err := p.db.Query(ctx, `SELECT DISTINCT LOWER(address) FROM smart_contracts WHERE LOWER(address) = ANY($1)`, addresses)
+	if err != nil {
+		// Sem o status em lote, isContractAddress consulta cada endereço sob demanda
+		log.Printf("⚠️ Erro ao verificar contratos do bloco: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool)
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			log.Printf("⚠️ Erro ao ler contrato do bloco: %v", err)
+			return
+		}
+		found[address] = true
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("⚠️ Erro ao verificar contratos do bloco: %v", err)
+		return
+	}
+
+	for _, address := range addresses {
+		state.contracts[address] = found[address]
+	}
+}
+
+// loadBalances busca em batch o saldo atual dos endereços; erros viram saldo "0"
+func (p *AccountTransactionProcessor) loadBalances(ctx context.Context, state *accountBlockState, addresses []string) {
+	if len(addresses) == 0 {
+		return
+	}
+
+	results := make([]hexutil.Big, len(addresses))
+	elems := make([]rpc.BatchElem, len(addresses))
+	for i, address := range addresses {
+		elems[i] = rpc.BatchElem{Method: "eth_getBalance", Args: []interface{}{common.HexToAddress(address), "latest"}, Result: &results[i]}
+	}
+	p.batchCall(ctx, "eth_getBalance_batch", elems)
+
+	for i, address := range addresses {
+		if elems[i].Error != nil {
+			log.Printf("⚠️ Erro ao buscar saldo da conta %s: %v", address, elems[i].Error)
+			state.balances[address] = "0"
+			continue
+		}
+		state.balances[address] = results[i].ToInt().String()
+	}
+}
+
+// loadCodes busca em batch o bytecode dos endereços; erros viram bytecode vazio
+func (p *AccountTransactionProcessor) loadCodes(ctx context.Context, state *accountBlockState, addresses []string) {
+	if len(addresses) == 0 {
+		return
+	}
+
+	results := make([]hexutil.Bytes, len(addresses))
+	elems := make([]rpc.BatchElem, len(addresses))
+	for i, address := range addresses {
+		elems[i] = rpc.BatchElem{Method: "eth_getCode", Args: []interface{}{common.HexToAddress(address), "latest"}, Result: &results[i]}
+	}
+	p.batchCall(ctx, "eth_getCode_batch", elems)
+
+	for i, address := range addresses {
+		if elems[i].Error != nil {
+			log.Printf("⚠️ Erro ao buscar bytecode de %s: %v", address, elems[i].Error)
+			state.codes[address] = nil
+			continue
+		}
+		state.codes[address] = results[i]
+	}
+}
+
+// batchCall executa as chamadas em lotes de rpcBatchSize. Falha de transporte em um lote é
+// registrada em cada chamada do lote
+func (p *AccountTransactionProcessor) batchCall(ctx context.Context, method string, elems []rpc.BatchElem) {
+	for start := 0; start < len(elems); start += rpcBatchSize {
+		end := start + rpcBatchSize
+		if end > len(elems) {
+			end = len(elems)
+		}
+		chunk := elems[start:end]
+
+		err := tracing.ObserveRPC(ctx, method, func(ctx context.Context) error {
+			return p.ethClient.Client().BatchCallContext(ctx, chunk)
+		})
+		if err != nil {
+			for i := range chunk {
+				chunk[i].Error = err
+			}
+		}
+	}
+}
+
+// accountStatements grava as accounts da transação: remetente, destinatário que não é contrato e contrato
+// criado (que incrementa os deployments da conta criadora)
+func (p *AccountTransactionProcessor) accountStatements(ctx context.Context, state *accountBlockState, holdings *blockHoldings, tx *entities.Transaction) []blockStatement {
+	// Conta remetente (sempre é uma EOA, nunca contrato)
+	stmts := []blockStatement{p.accountStatement(ctx, state, tx.From, tx)}
+	holdings.existing[strings.ToLower(tx.From)] = true
+
+	// Conta destinatária APENAS se NÃO for contrato
+	if tx.To != nil && *tx.To != "" && !p.isContractAddress(ctx, state, *tx.To) {
+		stmts = append(stmts, p.accountStatement(ctx, state, *tx.To, tx))
+		holdings.existing[strings.ToLower(*tx.To)] = true
+	}
+
+	// Contrato criado (se aplicável)
+	if tx.ContractAddress != nil && *tx.ContractAddress != "" {
+		stmts = append(stmts, p.contractAccountStatement(ctx, state, *tx.ContractAddress, tx), blockStatement{
+			query: `
+				UPDATE accounts
+				SET smart_contract_deployments = smart_contract_deployments + 1, updated_at = NOW()
+				WHERE address = $1
+			`,
+			args:  []interface{}{tx.From},
+			label: "deployments da conta " + tx.From,
+		})
+		holdings.existing[strings.ToLower(*tx.ContractAddress)] = true
+	}
+
+	return stmts
+}
+
+// accountStatement monta o upsert de uma account tocada pela transação
+func (p *AccountTransactionProcessor) accountStatement(ctx context.Context, state *accountBlockState, address string, tx *entities.Transaction) blockStatement {
+	address = strings.ToLower(address)
+
+	// Determinar tipo de conta
+	accountType := "eoa"
+	isContract := false
+	if p.isContractAddress(ctx, state, address) {
+		accountType = "smart_account"
+		isContract = true
+	}
+
+	query := `
+		INSERT INTO accounts (
+			address, account_type, balance, nonce, transaction_count,
+			first_seen, last_activity, is_contract, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, 1, $5, $6, $7, NOW(), NOW()
+		)
+		ON CONFLICT (address) DO UPDATE SET
+			balance = EXCLUDED.balance,
+			transaction_count = accounts.transaction_count + 1,
+			last_activity = EXCLUDED.last_activity,
+			updated_at = NOW()
+	`
+
+	minedAt := transactionTime(tx)
+	return blockStatement{query: query, label: "account " + address, args: []interface{}{
+		address,                                  // $1
+		accountType,                              // $2
+		p.getAccountBalance(ctx, state, address), // $3
+		tx.Nonce,                                 // $4
+		minedAt,                                  // $5 - first_seen
+		minedAt,                                  // $6 - last_activity
+		isContract,                               // $7
+	}}
+}
+
+// contractAccountStatement monta o upsert da account de um contrato criado pela transação
+func (p *AccountTransactionProcessor) contractAccountStatement(ctx context.Context, state *accountBlockState, contractAddress string, tx *entities.Transaction) blockStatement {
+	contractAddress = strings.ToLower(contractAddress)
+
+	query := `
+		INSERT INTO accounts (
+			address, account_type, balance, transaction_count,
+			first_seen, last_activity, is_contract, contract_type,
+			smart_contract_deployments, created_at, updated_at
+		) VALUES (
+			$1, 'smart_account', $2, 1, $3, $4, true, $5, 1, NOW(), NOW()
+		)
+		ON CONFLICT (address) DO UPDATE SET
+			balance = EXCLUDED.balance,
+			transaction_count = accounts.transaction_count + 1,
+			last_activity = EXCLUDED.last_activity,
+			contract_type = COALESCE(EXCLUDED.contract_type, accounts.contract_type),
+			updated_at = NOW()
+	`
+
+	balance := p.getAccountBalance(ctx, state, contractAddress)
+	contractType := p.detectContractType(ctx, state, contractAddress)
+	minedAt := transactionTime(tx)
+	return blockStatement{query: query, label: "contrato criado " + contractAddress, args: []interface{}{
+		contractAddress, // $1
+		balance,         // $2
+		minedAt,         // $3 - first_seen
+		minedAt,         // $4 - last_activity
+		contractType,    // $5
+	}}
+}
+
+// analyticsStatements atualiza as analytics diárias do remetente e do destinatário que não é contrato.
+// Uma escrita por transação mantém o arredondamento de success_rate (NUMERIC(5,4)) a cada transação
+func (p *AccountTransactionProcessor) analyticsStatements(ctx context.Context, state *accountBlockState, tx *entities.Transaction) []blockStatement {
+	date := transactionTime(tx).Format("2006-01-02")
+
+	gasUsed := "0"
+	if tx.GasUsed != nil {
+		gasUsed = fmt.Sprintf("%d", *tx.GasUsed)
+	}
+
+	valueTransferred := "0"
+	if tx.Value != nil {
+		valueTransferred = tx.Value.String()
+	}
+
+	successRate := 0.0
+	if tx.Status == entities.StatusSuccess {
+		successRate = 1.0
+	}
+
+	isContractCall := tx.To != nil && p.isContractAddress(ctx, state, *tx.To)
+	contractCallsCount := 0
+	if isContractCall {
+		contractCallsCount = 1
+	}
+
+	query := `
+		INSERT INTO account_analytics (
+			address, date, transactions_count, gas_used, value_transferred,
+			success_rate, contract_calls_count, created_at, updated_at
+		) VALUES (
+			$1, $2, 1, $3, $4, $5, $6, NOW(), NOW()
+		)
+		ON CONFLICT (address, date) DO UPDATE SET
+			transactions_count = account_analytics.transactions_count + 1,
+			gas_used = (account_analytics.gas_used::BIGINT + $3::BIGINT)::TEXT,
+			value_transferred = (account_analytics.value_transferred::NUMERIC + $4::NUMERIC)::TEXT,
+			success_rate = (
+				(account_analytics.success_rate * account_analytics.transactions_count + $5) /
+				(account_analytics.transactions_count + 1)
+			),
+			contract_calls_count = account_analytics.contract_calls_count + $6,
+			updated_at = NOW()
+	`
+
+	statement := func(address string) blockStatement {
+		address = strings.ToLower(address)
+		return blockStatement{query: query, label: "analytics de " + address, args: []interface{}{
+			address,            // $1
+			date,               // $2
+			gasUsed,            // $3
+			valueTransferred,   // $4
+			successRate,        // $5
+			contractCallsCount, // $6
+		}}
+	}
+
+	// Conta remetente (sempre é EOA)
+	stmts := []blockStatement{statement(tx.From)}
+
+	// Conta destinatária APENAS se NÃO for contrato
+	if tx.To != nil && *tx.To != "" && !isContractCall {
+		stmts = append(stmts, statement(*tx.To))
+	}
+
+	return stmts
+}
+
+// contractInteractionStatements registra a chamada do remetente ao contrato e incrementa o seu contador
+// de interações
+func (p *AccountTransactionProcessor) contractInteractionStatements(ctx context.Context, state *accountBlockState, tx *entities.Transaction) []blockStatement {
+	// Só processar se a transação tem destinatário e é para um contrato
+	if tx.To == nil || *tx.To == "" || !p.isContractAddress(ctx, state, *tx.To) {
+		return nil
+	}
+
+	contractAddress := strings.ToLower(*tx.To)
+	accountAddress := strings.ToLower(tx.From)
+
+	gasUsed := "0"
+	if tx.GasUsed != nil {
+		gasUsed = fmt.Sprintf("%d", *tx.GasUsed)
+	}
+
+	valueSent := "0"
+	if tx.Value != nil {
+		valueSent = tx.Value.String()
+	}
+
+	contractName := p.getContractName(ctx, state, contractAddress)
+	method := p.identifyMethod(ctx, state, tx.Data, contractAddress)
+	minedAt := transactionTime(tx)
+
+	query := `
+		INSERT INTO contract_interactions (
+			account_address, contract_address, contract_name, method,
+			interactions_count, last_interaction, first_interaction,
+			total_gas_used, total_value_sent, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, 1, $5, $6, $7, $8, NOW(), NOW()
+		)
+		ON CONFLICT (account_address, contract_address, method) DO UPDATE SET
+			interactions_count = contract_interactions.interactions_count + 1,
+			last_interaction = EXCLUDED.last_interaction,
+			total_gas_used = (contract_interactions.total_gas_used::BIGINT + $7::BIGINT)::TEXT,
+			total_value_sent = (contract_interactions.total_value_sent::NUMERIC + $8::NUMERIC)::TEXT,
+			updated_at = NOW()
+	`
+
+	return []blockStatement{
+		{query: query, label: "interação de " + accountAddress + " com " + contractAddress, args: []interface{}{
+			accountAddress,  // $1
+			contractAddress, // $2
+			contractName,    // $3
+			method,          // $4
+			minedAt,         // $5 - last_interaction
+			minedAt,         // $6 - first_interaction
+			gasUsed,         // $7
+			valueSent,       // $8
+		}},
+		{
+			query: `
+				UPDATE accounts
+				SET contract_interactions = contract_interactions + 1, updated_at = NOW()
+				WHERE address = $1
+			`,
+			args:  []interface{}{accountAddress},
+			label: "interações da conta " + accountAddress,
+		},
+	}
+}
+
+// holdingKey identifica o saldo de um token de uma account
+type holdingKey struct {
+	account string
+	token   string
+}
+
+// blockHoldings são os saldos de tokens das accounts tocadas pelo bloco, atualizados em memória à medida
+// que os Transfers são aplicados, e as accounts que já existem no ponto do bloco em que se está
+type blockHoldings struct {
+	balances map[holdingKey]*big.Int
+	existing map[string]bool
+}
+
+// tokenTransfer é um Transfer ERC-20 lido dos logs do bloco
+type tokenTransfer struct {
+	token string
+	from  string
+	to    string
+	value *big.Int
+}
+
+// tokenTransfers lê os Transfers ERC-20 dos logs da transação
+func tokenTransfers(logs []*types.Log) []tokenTransfer {
+	var transfers []tokenTransfer
+	for _, logEntry := range logs {
+		// Transfer(address indexed from, address indexed to, uint256 value)
+		if len(logEntry.Topics) != 3 || logEntry.Topics[0].Hex() != erc20TransferTopic {
+			continue
+		}
+
+		transfers = append(transfers, tokenTransfer{
+			token: strings.ToLower(logEntry.Address.Hex()),
+			from:  strings.ToLower(common.HexToAddress(logEntry.Topics[1].Hex()).Hex()),
+			to:    strings.ToLower(common.HexToAddress(logEntry.Topics[2].Hex()).Hex()),
+			value: new(big.Int).SetBytes(logEntry.Data),
+		})
+	}
+	return transfers
+}
+
+// loadHoldings busca em duas queries os saldos atuais de token_holdings e as accounts já gravadas dos
+// endereços que aparecem nos Transfers do bloco
+func (p *AccountTransactionProcessor) loadHoldings(ctx context.Context, state *accountBlockState, blockTxs []*BlockTransaction) (*blockHoldings, error) {
+	holdings := &blockHoldings{balances: make(map[holdingKey]*big.Int), existing: make(map[string]bool)}
+
+	var keys []holdingKey
+	var holders []string
+	seenKeys, seenHolders := make(map[holdingKey]bool), make(map[string]bool)
+	add := func(account, token string) {
+		if account == zeroAddress {
+			return
+		}
+		if key := (holdingKey{account: account, token: token}); !seenKeys[key] {
+			seenKeys[key] = true
+			keys = append(keys, key)
+		}
+		if !seenHolders[account] {
+			seenHolders[account] = true
+			holders = append(holders, account)
+		}
+	}
+	for _, bt := range blockTxs {
+		for _, transfer := range tokenTransfers(state.logs[bt.Transaction.Hash]) {
+			add(transfer.from, transfer.token)
+			add(transfer.to, transfer.token)
+		}
+	}
+	if len(keys) == 0 {
+		return holdings, nil
+	}
+
+	balances, err := p.loadTokenBalances(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	holdings.balances = balances
+
+	// token_holdings referencia accounts: holdings de endereços sem account não são gravados
+	existing, err := p.loadExistingAccounts(ctx, holders)
+	if err != nil {
+		return nil, err
+	}
+	holdings.existing = existing
+
+	return holdings, nil
+}
+
+// loadTokenBalances busca em uma query os saldos atuais de token_holdings; saldos inválidos ficam de fora
+func (p *AccountTransactionProcessor) loadTokenBalances(ctx context.Context, keys []holdingKey) (map[holdingKey]*big.Int, error) {
+	accounts := make([]string, len(keys))
+	tokens := make([]string, len(keys))
+	for i, key := range keys {
+		accounts[i], tokens[i] = key.account, key.token
+	}
+
+	query := `
+		SELECT th.account_address, th.token_address, th.balance
+		FROM token_holdings th
+		JOIN UNNEST($1::text[], $2::text[]) AS k(account_address, token_address)
+			ON th.account_address = k.account_address AND th.token_address = k.token_address
+	`
+
+	rows, err := p.db.Query(ctx, query, accounts, tokens)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar saldos de tokens: %w", err)
+	}
+	defer rows.Close()
+
+	balances := make(map[holdingKey]*big.Int)
+	for rows.Next() {
+		var key holdingKey
+		var balance string
+		if err := rows.Scan(&key.account, &key.token, &balance); err != nil {
+			return nil, fmt.Errorf("erro ao ler saldo de token: %w", err)
+		}
+		if value, ok := new(big.Int).SetString(balance, 10); ok {
+			balances[key] = value
+		}
+	}
+
+	return balances, rows.Err()
+}
+
+// loadExistingAccounts retorna quais dos endereços já têm account
+func (p *AccountTransactionProcessor) loadExistingAccounts(ctx context.Context, addresses []string) (map[string]bool, error) {
+	rows, err := p.db.Query(ctx, `SELECT address FROM accounts WHERE address = ANY($1)`, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar accounts: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, fmt.Errorf("erro ao ler account: %w", err)
+		}
+		existing[address] = true
+	}
+
+	return existing, rows.Err()
+}
+
+// tokenHoldingStatements aplica os Transfers ERC-20 da transação, em ordem, sobre os saldos do bloco e
+// grava o novo saldo de cada (account, token) afetado
+func (p *AccountTransactionProcessor) tokenHoldingStatements(ctx context.Context, state *accountBlockState, holdings *blockHoldings, tx *entities.Transaction) []blockStatement {
+	query := `
+		INSERT INTO token_holdings (
+			account_address, token_address, token_symbol, token_name,
+			token_decimals, balance, last_updated, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, NOW(), NOW(), NOW()
+		)
+		ON CONFLICT (account_address, token_address) DO UPDATE SET
+			balance = EXCLUDED.balance,
+			token_symbol = EXCLUDED.token_symbol,
+			token_name = EXCLUDED.token_name,
+			token_decimals = EXCLUDED.token_decimals,
+			last_updated = NOW(),
+			updated_at = NOW()
+	`
+
+	var stmts []blockStatement
+	apply := func(account string, transfer tokenTransfer, tokenInfo *TokenInfo, isIncrease bool) {
+		key := holdingKey{account: account, token: transfer.token}
+
+		newBalance := new(big.Int)
+		if current, ok := holdings.balances[key]; ok {
+			newBalance.Set(current)
+		}
+		if isIncrease {
+			newBalance.Add(newBalance, transfer.value)
+		} else {
+			newBalance.Sub(newBalance, transfer.value)
+			// Garantir que não fique negativo
+			if newBalance.Sign() < 0 {
+				newBalance.SetInt64(0)
+			}
+		}
+
+		if !holdings.existing[account] {
+			log.Printf("⚠️ Erro ao atualizar holding de %s no token %s: account não encontrada", account, transfer.token)
+			return
+		}
+		holdings.balances[key] = newBalance
+
+		// Usar nome mais descritivo se disponível
+		displayName := tokenInfo.Name
+		if tokenInfo.Description != "" && tokenInfo.Description != tokenInfo.Name {
+			displayName = fmt.Sprintf("%s (%s)", tokenInfo.Name, tokenInfo.Description)
+		}
+
+		stmts = append(stmts, blockStatement{query: query, label: "holding de " + account + " no token " + transfer.token, args: []interface{}{
+			account,             // $1
+			transfer.token,      // $2
+			tokenInfo.Symbol,    // $3
+			displayName,         // $4 - Nome mais descritivo
+			tokenInfo.Decimals,  // $5
+			newBalance.String(), // $6
+		}})
+	}
+
+	for _, transfer := range tokenTransfers(state.logs[tx.Hash]) {
+		tokenInfo, err := p.getTokenInfo(ctx, state, transfer.token)
+		if err != nil {
+			log.Printf("⚠️ Erro ao buscar informações do token %s: %v", transfer.token, err)
+			continue
+		}
+
+		// Atualizar holdings do remetente (diminuir) e do destinatário (aumentar)
+		if transfer.from != zeroAddress {
+			apply(transfer.from, transfer, tokenInfo, false)
+		}
+		if transfer.to != zeroAddress {
+			apply(transfer.to, transfer, tokenInfo, true)
+		}
+	}
+
+	return stmts
+}
+
+// tagStatement reavalia as regras de tags das accounts da transação (remetente, destinatário e contrato
+// criado) com o estado gravado até ela
+func (p *AccountTransactionProcessor) tagStatement(ctx context.Context, tx *entities.Transaction) (blockStatement, error) {
+	addresses := []string{tx.From}
+	if tx.To != nil && *tx.To != "" {
+		addresses = append(addresses, *tx.To)
+	}
+	if tx.ContractAddress != nil && *tx.ContractAddress != "" {
+		addresses = append(addresses, *tx.ContractAddress)
+	}
+
+	query, args, err := p.taggingService.SyncStatement(ctx, addresses)
+	if err != nil {
+		return blockStatement{}, err
+	}
+	return blockStatement{query: query, args: args, label: "tags da transação " + tx.Hash}, nil
+}
+
+// enqueueBlockRiskEvaluation coloca as accounts tocadas pelo bloco na fila de avaliação de risco
+func (p *AccountTransactionProcessor) enqueueBlockRiskEvaluation(ctx context.Context, blockTxs []*BlockTransaction) error {
+	seen := make(map[string]bool)
+	var addresses []string
+	add := func(address string) {
+		address = strings.ToLower(address)
+		if address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	for _, bt := range blockTxs {
+		tx := bt.Transaction
+		add(tx.From)
+		if tx.To != nil {
+			add(*tx.To)
+		}
+		if tx.ContractAddress != nil {
+			add(*tx.ContractAddress)
+		}
+	}
+
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO account_risk_pending (address)
+		SELECT UNNEST($1::text[])
+		ON CONFLICT (address) DO NOTHING`, addresses)
+	return err
+}
+
+// accountTransactionStatements registra a transação para cada conta envolvida
+func (p *AccountTransactionProcessor) accountTransactionStatements(ctx context.Context, state *accountBlockState, tx *entities.Transaction) []blockStatement {
+	// Determinar endereço do contrato para decodificação (sempre tentar, independente de estar registrado)
+	var contractAddress string
+	if tx.To != nil && *tx.To != "" {
+		contractAddress = *tx.To
+	}
+	if tx.ContractAddress != nil && *tx.ContractAddress != "" {
+		contractAddress = *tx.ContractAddress
+	}
+
+	// Determinar método executado (sempre tentar decodificar via ABI)
+	methodName, methodSignature := p.extractMethodInfo(ctx, state, tx.Data, contractAddress)
+
+	// Buscar nome do contrato apenas se estiver registrado
+	var contractName string
+	if contractAddress != "" && p.isContractAddress(ctx, state, contractAddress) {
+		contractName = p.getContractName(ctx, state, contractAddress)
+	}
+
+	timestamp := transactionTime(tx)
+
+	// Conta remetente
+	stmt := accountTransactionStatement(tx.From, tx, "sent", methodName, methodSignature, contractAddress, contractName, timestamp)
+	stmt.label = "transação do remetente " + tx.From
+	stmts := []blockStatement{stmt}
+
+	// Conta destinatária (se existir e for diferente do remetente)
+	if tx.To != nil && *tx.To != "" && !strings.EqualFold(*tx.To, tx.From) {
+		txType := "received"
+		if p.isContractAddress(ctx, state, *tx.To) {
+			txType = "contract_call"
+		}
+		stmt := accountTransactionStatement(*tx.To, tx, txType, methodName, methodSignature, contractAddress, contractName, timestamp)
+		stmt.label = "transação do destinatário " + *tx.To
+		stmts = append(stmts, stmt)
+	}
+
+	// Contrato criado (se aplicável)
+	if tx.ContractAddress != nil && *tx.ContractAddress != "" {
+		stmt := accountTransactionStatement(*tx.ContractAddress, tx, "contract_creation", methodName, methodSignature, contractAddress, contractName, timestamp)
+		stmt.label = "transação do contrato criado " + *tx.ContractAddress
+		stmts = append(stmts, stmt)
+	}
+
+	return stmts
+}
+
+// methodStatsStatements atualiza as estatísticas do método executado pelo remetente. O UPDATE e o INSERT
+// de quando a estatística ainda não existe vão em uma única escrita
+func (p *AccountTransactionProcessor) methodStatsStatements(ctx context.Context, state *accountBlockState, tx *entities.Transaction) []blockStatement {
+	// Determinar endereço do contrato para decodificação (sempre tentar)
+	var contractAddress string
+	if tx.To != nil && *tx.To != "" {
+		contractAddress = *tx.To
+	}
+
+	// Sempre tentar decodificar método
+	methodName, methodSignature := p.extractMethodInfo(ctx, state, tx.Data, contractAddress)
+	if methodName == "" {
+		return nil
+	}
+
+	// Buscar nome do contrato apenas se estiver registrado
+	var contractName string
+	if contractAddress != "" && p.isContractAddress(ctx, state, contractAddress) {
+		contractName = p.getContractName(ctx, state, contractAddress)
+	}
+
+	successIncrement, failedIncrement := int64(0), int64(1)
+	if tx.Status == entities.StatusSuccess {
+		successIncrement, failedIncrement = 1, 0
+	}
+
+	gasUsed := int64(0)
+	if tx.GasUsed != nil {
+		gasUsed = int64(*tx.GasUsed)
+	}
+
+	valueSent := "0"
+	if tx.Value != nil {
+		valueSent = tx.Value.String()
+	}
+
+	var contractAddr interface{}
+	contractFilter := "contract_address IS NULL"
+	if contractAddress != "" {
+		contractAddr = contractAddress
+		contractFilter = "contract_address = $4"
+	}
+
+	query := fmt.Sprintf(`
+		WITH updated AS (
+			UPDATE account_method_stats SET
+				execution_count = execution_count + 1,
+				success_count = success_count + $6::INTEGER,
+				failed_count = failed_count + $7::INTEGER,
+				total_gas_used = (total_gas_used::BIGINT + $8::BIGINT)::TEXT,
+				total_value_sent = (total_value_sent::NUMERIC + $9::NUMERIC)::TEXT,
+				avg_gas_used = (total_gas_used::BIGINT + $8::BIGINT) / (execution_count + 1),
+				last_executed_at = $10,
+				contract_name = COALESCE($5, contract_name),
+				updated_at = NOW()
+			WHERE account_address = $1 AND method_name = $2 AND %s
+			RETURNING 1
+		)
+		INSERT INTO account_method_stats (
+			account_address, method_name, method_signature, contract_address,
+			contract_name, execution_count, success_count, failed_count,
+			total_gas_used, total_value_sent, avg_gas_used, first_executed_at,
+			last_executed_at, created_at, updated_at
+		)
+		SELECT $1, $2, $3, $4::VARCHAR, $5, 1, $6::INTEGER, $7::INTEGER, $8::TEXT, $9::TEXT,
+			$8::BIGINT, $10, $10, NOW(), NOW()
+		WHERE NOT EXISTS (SELECT 1 FROM updated)
+	`, contractFilter)
+
+	accountAddress := strings.ToLower(tx.From)
+	return []blockStatement{{query: query, label: "estatísticas do método " + methodName + " de " + accountAddress, args: []interface{}{
+		accountAddress,             // $1
+		methodName,                 // $2
+		methodSignature,            // $3
+		contractAddr,               // $4
+		contractName,               // $5
+		successIncrement,           // $6
+		failedIncrement,            // $7
+		fmt.Sprintf("%d", gasUsed), // $8
+		valueSent,                  // $9
+		transactionTime(tx),        // $10 - first_executed_at / last_executed_at
+	}}}
+}
+
+// loadBlockEvents busca em uma query os eventos das transações do bloco, agrupados por transação
+func (p *AccountTransactionProcessor) loadBlockEvents(ctx context.Context, blockTxs []*BlockTransaction) (map[string][]*entities.Event, error) {
+	hashes := make([]string, len(blockTxs))
+	for i, bt := range blockTxs {
+		hashes[i] = bt.Transaction.Hash
+	}
+
+	query := `
+		SELECT id, contract_address, event_name, event_signature, transaction_hash,
+		       block_number, log_index, from_address, to_address, topics, decoded_data,
+		       data, timestamp
+		FROM events
+		WHERE transaction_hash = ANY($1) AND block_number = ANY($2)
+		ORDER BY block_number, log_index
+	`
+
+	rows, err := p.db.Query(ctx, query, hashes, blockNumbers(blockTxs))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar eventos das transações: %w", err)
+	}
+	defer rows.Close()
+
+	events := make(map[string][]*entities.Event)
+	for rows.Next() {
+		var event entities.Event
+		var topicsJSON []byte
+		var decodedDataJSON []byte
+		var dataBytes []byte
+
+		err := rows.Scan(
+			&event.ID,
+			&event.ContractAddress,
+			&event.EventName,
+			&event.EventSignature,
+			&event.TransactionHash,
+			&event.BlockNumber,
+			&event.LogIndex,
+			&event.FromAddress,
+			&event.ToAddress,
+			&topicsJSON,
+			&decodedDataJSON,
+			&dataBytes,
+			&event.Timestamp,
+		)
+		if err != nil {
+			log.Printf("⚠️ Erro ao escanear evento: %v", err)
+			continue
+		}
+
+		// Converter topics JSON para slice
+		if len(topicsJSON) > 0 {
+			var topics []string
+			if err := json.Unmarshal(topicsJSON, &topics); err == nil {
+				event.Topics = entities.TopicsArray(topics)
+			}
+		}
+
+		// Converter decoded data JSON
+		if len(decodedDataJSON) > 0 {
+			var decodedData entities.DecodedData
+			if err := json.Unmarshal(decodedDataJSON, &decodedData); err == nil {
+				event.DecodedData = &decodedData
+			}
+		}
+
+		event.Data = dataBytes
+		events[event.TransactionHash] = append(events[event.TransactionHash], &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao buscar eventos das transações: %w", err)
+	}
+
+	return events, nil
+}
+
+// accountEventStatements registra os eventos da transação em account_events para cada conta envolvida
+func (p *AccountTransactionProcessor) accountEventStatements(ctx context.Context, state *accountBlockState, events []*entities.Event) []blockStatement {
+	var stmts []blockStatement
+	for _, event := range events {
+		// Processar evento para todas as contas envolvidas
+		for _, accountAddress := range p.getInvolvedAccountsFromEvent(event) {
+			stmt, ok := p.accountEventStatement(ctx, state, accountAddress, event)
+			if !ok {
+				continue
+			}
+			stmt.label = fmt.Sprintf("evento %s para conta %s", event.ID, accountAddress)
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+// transactionTime retorna o horário de mineração da transação (ou o atual, se ausente)
+func transactionTime(tx *entities.Transaction) time.Time {
+	if tx.MinedAt != nil {
+		return *tx.MinedAt
+	}
+	return time.Now()
+}
+
+// blockLabel descreve o bloco das transações para os logs
+func blockLabel(blockTxs []*BlockTransaction) string {
+	for _, bt := range blockTxs {
+		if bt.Transaction.BlockNumber != nil {
+			return fmt.Sprintf("%d", *bt.Transaction.BlockNumber)
+		}
+	}
+	return "desconhecido"
+}
@@ -4,357 +4,388 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hubweb3/worker/internal/domain/entities"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
-// AccountTaggingService gerencia tags automáticas para accounts
+const (
+	// tagSyncBatchSize é o número de accounts reavaliadas por query na reavaliação periódica
+	tagSyncBatchSize = 500
+	// tagRecentActivityDays é a janela das accounts reavaliadas quando nenhuma regra mudou
+	tagRecentActivityDays = 7
+)
+
+// AccountTaggingService atribui tags automáticas às accounts a partir das regras de tag_rules. Tags atribuídas
+// por regras ficam com created_by = 'system'; as que deixam de casar são removidas e tags manuais não são tocadas
 type AccountTaggingService struct {
-	db *pgxpool.Pool
+	db              *pgxpool.Pool
+	nativeDecimals  int
+	refreshInterval time.Duration
+
+	mu          sync.RWMutex
+	rules       *compiledTagRules
+	rulesLoaded time.Time
 }
 
-// NewAccountTaggingService cria uma nova instância do serviço
-func NewAccountTaggingService(db *pgxpool.Pool) *AccountTaggingService {
+// compiledTagRules são as regras ativas convertidas em uma expressão SQL que retorna as tags que casam
+// para a account a (parâmetros a partir de $2)
+type compiledTagRules struct {
+	rules []*entities.TagRule
+	tags  []string
+	expr  string
+	args  []interface{}
+}
+
+// NewAccountTaggingService cria uma nova instância do serviço. nativeDecimals são as casas decimais da moeda
+// nativa usadas nas métricas de valor; as regras são recarregadas a cada refreshInterval
+func NewAccountTaggingService(db *pgxpool.Pool, nativeDecimals int, refreshInterval time.Duration) *AccountTaggingService {
+	if nativeDecimals < 0 {
+		nativeDecimals = 18
+	}
 	return &AccountTaggingService{
-		db: db,
+		db:              db,
+		nativeDecimals:  nativeDecimals,
+		refreshInterval: refreshInterval,
 	}
 }
 
-// ProcessAccountForTags analisa uma account e adiciona tags automáticas
-func (s *AccountTaggingService) ProcessAccountForTags(ctx context.Context, address string, tx *entities.Transaction) error {
-	address = strings.ToLower(address)
+// ProcessAccountForTags reavalia as regras de tags para uma account
+func (s *AccountTaggingService) ProcessAccountForTags(ctx context.Context, address string) error {
+	return s.ProcessBlockTags(ctx, []string{address})
+}
 
-	// Buscar dados da account
-	accountData, err := s.getAccountData(ctx, address)
-	if err != nil {
-		return fmt.Errorf("erro ao buscar dados da account: %w", err)
+// ProcessBlockTags reavalia as regras de tags para as accounts tocadas por um bloco em uma única query
+func (s *AccountTaggingService) ProcessBlockTags(ctx context.Context, addresses []string) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+	normalized := make([]string, len(addresses))
+	for i, address := range addresses {
+		normalized[i] = strings.ToLower(address)
 	}
 
-	// Analisar padrões e adicionar tags
-	tags := s.analyzeAccountPatterns(accountData, tx)
+	rules, err := s.getRules(ctx, false)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.syncTags(ctx, rules, normalized)
+	return err
+}
 
-	// Adicionar tags ao banco
-	for _, tag := range tags {
-		if err := s.addTag(ctx, address, tag); err != nil {
-			log.Printf("⚠️ Erro ao adicionar tag '%s' para account %s: %v", tag, address, err)
+// ProcessBatchTags processa tags para múltiplas accounts em lote
+func (s *AccountTaggingService) ProcessBatchTags(ctx context.Context, addresses []string) error {
+	for start := 0; start < len(addresses); start += tagSyncBatchSize {
+		end := start + tagSyncBatchSize
+		if end > len(addresses) {
+			end = len(addresses)
+		}
+		if err := s.ProcessBlockTags(ctx, addresses[start:end]); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
-// AccountData representa dados de uma account para análise
-type AccountData struct {
-	Address                   string
-	AccountType               string
-	Balance                   string
-	TransactionCount          int64
-	ContractInteractions      int64
-	SmartContractDeployments  int64
-	IsContract                bool
-	ContractType              string
-	LastActivity              *time.Time
-	UniqueContractsInteracted int64
-	AvgTransactionValue       string
-	MaxTransactionValue       string
-	TotalValueTransferred     string
-	SuccessRate               float64
-	MostUsedMethod            string
-	TokenHoldingsCount        int64
-	HighestValueTokenHolding  string
-}
-
-// getAccountData busca dados completos de uma account
-func (s *AccountTaggingService) getAccountData(ctx context.Context, address string) (*AccountData, error) {
-	data := &AccountData{Address: address}
-
-	// Buscar dados básicos da account
-	query := `
-		SELECT 
-			account_type, balance, transaction_count, contract_interactions,
-			smart_contract_deployments, is_contract, COALESCE(contract_type, ''),
-			last_activity
-		FROM accounts 
-		WHERE address = $1
-	`
-
-	err := s.db.QueryRow(ctx, query, address).Scan(
-		&data.AccountType, &data.Balance, &data.TransactionCount,
-		&data.ContractInteractions, &data.SmartContractDeployments,
-		&data.IsContract, &data.ContractType, &data.LastActivity,
-	)
-	if err != nil {
-		return nil, err
-	}
+// UpdateTagsBasedOnAnalytics reavalia as regras de tags. Regras novas ou alteradas desde a última passada
+// completa são avaliadas em todas as accounts; caso contrário, apenas as accounts com atividade recente.
+// Tags de sistema de regras removidas ou desativadas são apagadas
+func (s *AccountTaggingService) UpdateTagsBasedOnAnalytics(ctx context.Context) error {
+	started := time.Now()
 
-	// Buscar estatísticas de interações com contratos
-	contractStatsQuery := `
-		SELECT 
-			COUNT(DISTINCT contract_address) as unique_contracts,
-			COALESCE(MAX(interactions_count), 0) as max_interactions,
-			COALESCE(
-				(SELECT method FROM contract_interactions 
-				 WHERE account_address = $1 
-				 ORDER BY interactions_count DESC LIMIT 1), 
-				''
-			) as most_used_method
-		FROM contract_interactions 
-		WHERE account_address = $1
-	`
-
-	var maxInteractions int64
-	err = s.db.QueryRow(ctx, contractStatsQuery, address).Scan(
-		&data.UniqueContractsInteracted, &maxInteractions, &data.MostUsedMethod,
-	)
+	rules, err := s.getRules(ctx, true)
 	if err != nil {
-		// Se não há interações, usar valores padrão
-		data.UniqueContractsInteracted = 0
-		data.MostUsedMethod = ""
+		return err
 	}
 
-	// Buscar estatísticas de transações
-	txStatsQuery := `
-		SELECT 
-			COALESCE(AVG(value_transferred::NUMERIC), 0) as avg_value,
-			COALESCE(MAX(value_transferred::NUMERIC), 0) as max_value,
-			COALESCE(SUM(value_transferred::NUMERIC), 0) as total_value,
-			COALESCE(AVG(success_rate), 0) as success_rate
-		FROM account_analytics 
-		WHERE address = $1
-	`
-
-	err = s.db.QueryRow(ctx, txStatsQuery, address).Scan(
-		&data.AvgTransactionValue, &data.MaxTransactionValue,
-		&data.TotalValueTransferred, &data.SuccessRate,
-	)
+	tag, err := s.db.Exec(ctx, `
+		DELETE FROM account_tags WHERE created_by = 'system' AND NOT (tag = ANY($1::TEXT[]))
+	`, rules.tags)
 	if err != nil {
-		// Se não há analytics, usar valores padrão
-		data.AvgTransactionValue = "0"
-		data.MaxTransactionValue = "0"
-		data.TotalValueTransferred = "0"
-		data.SuccessRate = 0
+		return fmt.Errorf("erro ao remover tags de regras inativas: %w", err)
 	}
-
-	// Buscar estatísticas de token holdings
-	tokenStatsQuery := `
-		SELECT 
-			COUNT(*) as token_count,
-			COALESCE(MAX(balance::NUMERIC), 0) as highest_balance
-		FROM token_holdings 
-		WHERE account_address = $1 AND balance::NUMERIC > 0
-	`
-
-	err = s.db.QueryRow(ctx, tokenStatsQuery, address).Scan(
-		&data.TokenHoldingsCount, &data.HighestValueTokenHolding,
-	)
-	if err != nil {
-		// Se não há tokens, usar valores padrão
-		data.TokenHoldingsCount = 0
-		data.HighestValueTokenHolding = "0"
+	if removed := tag.RowsAffected(); removed > 0 {
+		log.Printf("🏷️ %d tags de regras removidas ou desativadas apagadas", removed)
 	}
 
-	return data, nil
-}
-
-// analyzeAccountPatterns analisa padrões da account e retorna tags apropriadas
-func (s *AccountTaggingService) analyzeAccountPatterns(data *AccountData, tx *entities.Transaction) []string {
-	var tags []string
-
-	// Tags baseadas no tipo de account
-	if data.IsContract {
-		tags = append(tags, "contract")
-
-		// Tags específicas por tipo de contrato
-		switch data.ContractType {
-		case "erc20":
-			tags = append(tags, "token", "erc20")
-		case "erc721":
-			tags = append(tags, "nft", "erc721")
-		case "erc1155":
-			tags = append(tags, "multi-token", "erc1155")
+	var changed []int64
+	for _, rule := range rules.rules {
+		if rule.EvaluatedAt == nil || rule.UpdatedAt.After(*rule.EvaluatedAt) {
+			changed = append(changed, rule.ID)
 		}
-	} else {
-		tags = append(tags, "eoa")
 	}
 
-	// Tags baseadas no volume de transações
-	if data.TransactionCount > 1000 {
-		tags = append(tags, "high-activity")
-	} else if data.TransactionCount > 100 {
-		tags = append(tags, "active")
-	} else if data.TransactionCount < 10 {
-		tags = append(tags, "low-activity")
+	// Passada completa: todas as accounts; incremental: accounts com analytics recentes
+	query := `SELECT address FROM accounts WHERE address > $1 ORDER BY address LIMIT $2`
+	if len(changed) == 0 {
+		query = fmt.Sprintf(`
+			SELECT DISTINCT address FROM account_analytics
+			WHERE date >= CURRENT_DATE - %d AND address > $1
+			ORDER BY address LIMIT $2`, tagRecentActivityDays)
 	}
 
-	// Tags baseadas no saldo
-	balance, ok := new(big.Int).SetString(data.Balance, 10)
-	if ok {
-		// Converter para ETH (dividir por 10^18)
-		ethBalance := new(big.Float).SetInt(balance)
-		ethBalance.Quo(ethBalance, big.NewFloat(1e18))
-
-		ethFloat, _ := ethBalance.Float64()
-
-		if ethFloat > 1000 {
-			tags = append(tags, "whale")
-		} else if ethFloat > 100 {
-			tags = append(tags, "high-balance")
-		} else if ethFloat > 10 {
-			tags = append(tags, "medium-balance")
-		} else if ethFloat < 0.01 {
-			tags = append(tags, "low-balance")
+	var accounts, added, removed int64
+	cursor := ""
+	for {
+		addresses, err := s.nextTagBatch(ctx, query, cursor)
+		if err != nil {
+			return err
+		}
+		if len(addresses) == 0 {
+			break
 		}
-	}
 
-	// Tags baseadas em interações com contratos
-	if data.ContractInteractions > 100 {
-		tags = append(tags, "defi-user")
+		batchAdded, batchRemoved, err := s.syncTags(ctx, rules, addresses)
+		if err != nil {
+			return err
+		}
+		accounts += int64(len(addresses))
+		added += batchAdded
+		removed += batchRemoved
+		cursor = addresses[len(addresses)-1]
 	}
 
-	if data.UniqueContractsInteracted > 20 {
-		tags = append(tags, "multi-protocol")
+	if len(changed) > 0 {
+		// Regras editadas durante a passada continuam pendentes para a próxima
+		if _, err := s.db.Exec(ctx, `
+			UPDATE tag_rules SET evaluated_at = $2 WHERE id = ANY($1) AND updated_at <= $2
+		`, changed, started); err != nil {
+			return fmt.Errorf("erro ao marcar regras de tags avaliadas: %w", err)
+		}
 	}
 
-	// Tags baseadas em deployments de contratos
-	if data.SmartContractDeployments > 0 {
-		tags = append(tags, "developer")
+	log.Printf("🏷️ Tags reavaliadas em %d accounts (%d regras alteradas): +%d/-%d em %v",
+		accounts, len(changed), added, removed, time.Since(started).Round(time.Millisecond))
+	return nil
+}
 
-		if data.SmartContractDeployments > 10 {
-			tags = append(tags, "prolific-developer")
-		}
+// nextTagBatch lê o próximo lote de endereços da reavaliação periódica
+func (s *AccountTaggingService) nextTagBatch(ctx context.Context, query, cursor string) ([]string, error) {
+	rows, err := s.db.Query(ctx, query, cursor, tagSyncBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar accounts para reavaliar tags: %w", err)
 	}
+	defer rows.Close()
 
-	// Tags baseadas no método mais usado
-	switch data.MostUsedMethod {
-	case "transfer":
-		tags = append(tags, "frequent-sender")
-	case "approve":
-		tags = append(tags, "defi-approver")
-	case "swap", "swapExactTokensForTokens":
-		tags = append(tags, "trader")
+	var addresses []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, fmt.Errorf("erro ao ler account: %w", err)
+		}
+		addresses = append(addresses, address)
 	}
+	return addresses, rows.Err()
+}
 
-	// Tags baseadas em holdings de tokens
-	if data.TokenHoldingsCount > 50 {
-		tags = append(tags, "token-collector")
-	} else if data.TokenHoldingsCount > 10 {
-		tags = append(tags, "token-holder")
+// SyncStatement monta a query que reavalia as regras de tags das accounts, para ser executada junto com
+// as demais escritas de um bloco (mesma query do syncTags)
+func (s *AccountTaggingService) SyncStatement(ctx context.Context, addresses []string) (string, []interface{}, error) {
+	normalized := make([]string, len(addresses))
+	for i, address := range addresses {
+		normalized[i] = strings.ToLower(address)
 	}
 
-	// Tags baseadas na taxa de sucesso
-	if data.SuccessRate > 0.95 {
-		tags = append(tags, "reliable")
-	} else if data.SuccessRate < 0.8 {
-		tags = append(tags, "error-prone")
+	rules, err := s.getRules(ctx, false)
+	if err != nil {
+		return "", nil, err
 	}
+	query, args := syncTagsQuery(rules, normalized)
+	return query, args, nil
+}
 
-	// Tags baseadas na transação atual
-	if tx != nil {
-		// Se é criação de contrato
-		if tx.ContractAddress != nil && *tx.ContractAddress != "" {
-			tags = append(tags, "contract-creator")
-		}
+// syncTags grava as tags que casam e remove as tags de sistema que deixaram de casar, em uma única query
+func (s *AccountTaggingService) syncTags(ctx context.Context, rules *compiledTagRules, addresses []string) (int64, int64, error) {
+	query, args := syncTagsQuery(rules, addresses)
 
-		// Se é transação de alto valor
-		if tx.Value != nil {
-			value := new(big.Float).SetInt(tx.Value)
-			value.Quo(value, big.NewFloat(1e18))
-			ethValue, _ := value.Float64()
+	var added, removed int64
+	if err := s.db.QueryRow(ctx, query, args...).Scan(&added, &removed); err != nil {
+		return 0, 0, fmt.Errorf("erro ao sincronizar tags de %d accounts: %w", len(addresses), err)
+	}
+	return added, removed, nil
+}
 
-			if ethValue > 100 {
-				tags = append(tags, "high-value-tx")
-			}
-		}
+// syncTagsQuery monta a query do syncTags: retorna o número de tags gravadas e removidas
+func syncTagsQuery(rules *compiledTagRules, addresses []string) (string, []interface{}) {
+	query := fmt.Sprintf(`
+		WITH matched AS (
+			SELECT a.address, m.tag
+			FROM accounts a
+			CROSS JOIN LATERAL UNNEST(%s) AS m(tag)
+			WHERE a.address = ANY($1)
+		),
+		removed AS (
+			DELETE FROM account_tags t
+			WHERE t.address = ANY($1) AND t.created_by = 'system'
+				AND NOT EXISTS (SELECT 1 FROM matched m WHERE m.address = t.address AND m.tag = t.tag)
+			RETURNING 1
+		),
+		added AS (
+			INSERT INTO account_tags (address, tag, created_by, created_at)
+			SELECT address, tag, 'system', NOW() FROM matched
+			ON CONFLICT (address, tag) DO NOTHING
+			RETURNING 1
+		)
+		SELECT (SELECT COUNT(*) FROM added), (SELECT COUNT(*) FROM removed)
+	`, rules.expr)
+
+	return query, append([]interface{}{addresses}, rules.args...)
+}
 
-		// Se falhou
-		if tx.Status == entities.StatusFailed {
-			tags = append(tags, "failed-tx")
-		}
+// getRules retorna as regras ativas compiladas em cache, recarregando após o intervalo configurado
+func (s *AccountTaggingService) getRules(ctx context.Context, force bool) (*compiledTagRules, error) {
+	s.mu.RLock()
+	if !force && s.rules != nil && time.Since(s.rulesLoaded) < s.refreshInterval {
+		rules := s.rules
+		s.mu.RUnlock()
+		return rules, nil
 	}
+	s.mu.RUnlock()
 
-	// Remover duplicatas
-	return s.removeDuplicates(tags)
-}
+	rules, err := s.loadRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := compileTagRules(rules, s.nativeDecimals, 2)
+	if err != nil {
+		return nil, err
+	}
 
-// addTag adiciona uma tag para uma account (se não existir)
-func (s *AccountTaggingService) addTag(ctx context.Context, address, tag string) error {
-	query := `
-		INSERT INTO account_tags (address, tag, created_by, created_at)
-		VALUES ($1, $2, 'system', NOW())
-		ON CONFLICT (address, tag) DO NOTHING
-	`
+	s.mu.Lock()
+	s.rules = compiled
+	s.rulesLoaded = time.Now()
+	s.mu.Unlock()
 
-	_, err := s.db.Exec(ctx, query, address, tag)
-	return err
+	return compiled, nil
 }
 
-// removeDuplicates remove tags duplicadas
-func (s *AccountTaggingService) removeDuplicates(tags []string) []string {
-	seen := make(map[string]bool)
-	result := []string{}
+// loadRules lê as regras ativas. Regras com condições inválidas são ignoradas
+func (s *AccountTaggingService) loadRules(ctx context.Context) ([]*entities.TagRule, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT id, tag, description, conditions, is_active, updated_at, evaluated_at
+		FROM tag_rules
+		WHERE is_active
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar regras de tags: %w", err)
+	}
+	defer rows.Close()
 
-	for _, tag := range tags {
-		if !seen[tag] {
-			seen[tag] = true
-			result = append(result, tag)
+	var rules []*entities.TagRule
+	for rows.Next() {
+		rule := &entities.TagRule{}
+		var conditions []byte
+		if err := rows.Scan(&rule.ID, &rule.Tag, &rule.Description, &conditions, &rule.IsActive, &rule.UpdatedAt, &rule.EvaluatedAt); err != nil {
+			return nil, fmt.Errorf("erro ao ler regra de tag: %w", err)
+		}
+		rule.Conditions, err = entities.ParseTagConditions(conditions)
+		if err != nil {
+			log.Printf("⚠️ Regra de tag %d (%s) ignorada: %v", rule.ID, rule.Tag, err)
+			continue
 		}
+		rules = append(rules, rule)
 	}
 
-	return result
+	return rules, rows.Err()
 }
 
-// ProcessBatchTags processa tags para múltiplas accounts em lote
-func (s *AccountTaggingService) ProcessBatchTags(ctx context.Context, addresses []string) error {
-	for _, address := range addresses {
-		if err := s.ProcessAccountForTags(ctx, address, nil); err != nil {
-			log.Printf("⚠️ Erro ao processar tags para account %s: %v", address, err)
+// compileTagRules converte as regras em uma expressão TEXT[] com as tags que casam para a account a.
+// Os parâmetros começam em $firstArg
+func compileTagRules(rules []*entities.TagRule, nativeDecimals, firstArg int) (*compiledTagRules, error) {
+	compiled := &compiledTagRules{rules: rules, tags: []string{}}
+	next := func(value interface{}) string {
+		compiled.args = append(compiled.args, value)
+		return fmt.Sprintf("$%d", firstArg+len(compiled.args)-1)
+	}
+
+	cases := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		conditions := make([]string, 0, len(rule.Conditions))
+		for i := range rule.Conditions {
+			condition, err := compileTagCondition(&rule.Conditions[i], nativeDecimals, next)
+			if err != nil {
+				return nil, fmt.Errorf("regra %s: %w", rule.Tag, err)
+			}
+			conditions = append(conditions, condition)
 		}
+		cases = append(cases, fmt.Sprintf("CASE WHEN %s THEN %s::TEXT END", strings.Join(conditions, " AND "), next(rule.Tag)))
+		compiled.tags = append(compiled.tags, rule.Tag)
 	}
-	return nil
+
+	if len(cases) == 0 {
+		compiled.expr = `ARRAY[]::TEXT[]`
+	} else {
+		compiled.expr = fmt.Sprintf(`ARRAY_REMOVE(ARRAY[%s], NULL)`, strings.Join(cases, ", "))
+	}
+	return compiled, nil
 }
 
-// UpdateTagsBasedOnAnalytics atualiza tags baseadas em analytics recentes
-func (s *AccountTaggingService) UpdateTagsBasedOnAnalytics(ctx context.Context) error {
-	// Buscar accounts com atividade recente
-	query := `
-		SELECT DISTINCT address 
-		FROM account_analytics 
-		WHERE date >= CURRENT_DATE - INTERVAL '7 days'
-		ORDER BY address
-	`
-
-	rows, err := s.db.Query(ctx, query)
-	if err != nil {
-		return err
+// compileTagCondition converte uma condição em uma expressão booleana sobre a account a
+func compileTagCondition(c *entities.TagCondition, nativeDecimals int, next func(interface{}) string) (string, error) {
+	native := func(column string) string {
+		return fmt.Sprintf(`(CASE WHEN %[1]s ~ '^[0-9]+$' THEN %[1]s::NUMERIC ELSE 0 END / 10::NUMERIC ^ %[2]d)`, column, nativeDecimals)
 	}
-	defer rows.Close()
 
-	var addresses []string
-	for rows.Next() {
-		var address string
-		if err := rows.Scan(&address); err != nil {
-			continue
-		}
-		addresses = append(addresses, address)
+	var metric string
+	switch c.Metric {
+	case "account.balance":
+		metric = native("a.balance")
+	case "account.transaction_count":
+		metric = `a.transaction_count`
+	case "account.contract_interactions":
+		metric = `a.contract_interactions`
+	case "account.contract_deployments":
+		metric = `a.smart_contract_deployments`
+	case "account.is_contract":
+		metric = `CASE WHEN a.is_contract THEN 1 ELSE 0 END`
+	case "account.age_days":
+		metric = `EXTRACT(EPOCH FROM NOW() - a.first_seen) / 86400`
+	case "account.inactive_days":
+		metric = `EXTRACT(EPOCH FROM NOW() - COALESCE(a.last_activity, a.first_seen)) / 86400`
+	case "account.type":
+		metric = `a.account_type`
+	case "account.contract_type":
+		metric = `a.contract_type`
+	case "methods.unique_contracts":
+		metric = `(SELECT COUNT(DISTINCT ci.contract_address) FROM contract_interactions ci WHERE ci.account_address = a.address)`
+	case "methods.calls":
+		metric = fmt.Sprintf(`(SELECT SUM(ci.interactions_count) FROM contract_interactions ci
+			WHERE ci.account_address = a.address AND ci.method = %s)`, next(c.Method))
+	case "methods.most_used":
+		metric = `(SELECT ci.method FROM contract_interactions ci WHERE ci.account_address = a.address
+			ORDER BY ci.interactions_count DESC, ci.method LIMIT 1)`
+	case "tokens.count":
+		metric = `(SELECT COUNT(*) FROM token_holdings th
+			WHERE th.account_address = a.address AND th.balance ~ '^[0-9]+$' AND th.balance::NUMERIC > 0)`
+	case "tokens.balance":
+		metric = fmt.Sprintf(`(SELECT SUM(th.balance::NUMERIC / 10::NUMERIC ^ th.token_decimals) FROM token_holdings th
+			WHERE th.account_address = a.address AND th.token_address = %s AND th.balance ~ '^[0-9]+$')`, next(strings.ToLower(c.Token)))
+	case "activity.success_rate":
+		metric = `(SELECT AVG(aa.success_rate) FROM account_analytics aa WHERE aa.address = a.address)`
+	case "activity.failed_count":
+		metric = `(SELECT COUNT(*) FROM account_transactions atx WHERE atx.account_address = a.address AND atx.status = 'failed')`
+	case "activity.max_tx_value":
+		metric = fmt.Sprintf(`(SELECT MAX(%s) FROM account_transactions atx WHERE atx.account_address = a.address)`, native("atx.value"))
+	default:
+		return "", fmt.Errorf("métrica desconhecida: %s", c.Metric)
 	}
 
-	// Processar em lotes de 100
-	batchSize := 100
-	for i := 0; i < len(addresses); i += batchSize {
-		end := i + batchSize
-		if end > len(addresses) {
-			end = len(addresses)
-		}
+	spec := entities.TagMetrics[c.Metric]
+	operator, ok := entities.TagOperators[spec.Kind][c.Op]
+	if !ok {
+		return "", fmt.Errorf("operador %s inválido para %s", c.Op, c.Metric)
+	}
 
-		batch := addresses[i:end]
-		if err := s.ProcessBatchTags(ctx, batch); err != nil {
-			log.Printf("⚠️ Erro ao processar lote de tags: %v", err)
+	if spec.Kind == entities.TagMetricText {
+		match := fmt.Sprintf(`COALESCE(%s, '') = ANY(%s::TEXT[])`, metric, next(c.Values))
+		if operator == "NOT IN" {
+			return "NOT (" + match + ")", nil
 		}
+		return "(" + match + ")", nil
 	}
-
-	return nil
+	return fmt.Sprintf(`(COALESCE(%s, 0)::NUMERIC %s %s::NUMERIC)`, metric, operator, next(*c.Value)), nil
 }
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+)
+
+const (
+	// maxAlertBackoff limita o intervalo entre tentativas de entrega
+	maxAlertBackoff = time.Hour
+	// alertDispatchBatchSize é o número máximo de entregas enviadas por ciclo
+	alertDispatchBatchSize = 50
+)
+
+// AlertService avalia regras de alerta e entrega webhooks assinados com HMAC-SHA256
+type AlertService struct {
+	alertRepo       repositories.AlertRepository
+	httpClient      *http.Client
+	maxAttempts     int
+	retryBaseDelay  time.Duration
+	refreshInterval time.Duration
+
+	mu          sync.RWMutex
+	rules       []*entities.AlertRule
+	rulesLoaded time.Time
+}
+
+// NewAlertService cria uma nova instância do serviço de alertas
+func NewAlertService(alertRepo repositories.AlertRepository, maxAttempts int, retryBaseDelay, timeout, refreshInterval time.Duration) *AlertService {
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	return &AlertService{
+		alertRepo:       alertRepo,
+		httpClient:      newWebhookClient(timeout),
+		maxAttempts:     maxAttempts,
+		retryBaseDelay:  retryBaseDelay,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// newWebhookClient cria o cliente HTTP dos webhooks. O IP de destino é verificado no momento da conexão
+// (depois da resolução DNS), de modo que um host que passou a resolver para um endereço interno depois do
+// cadastro da regra continua bloqueado; redirecionamentos não são seguidos e contam como falha de entrega
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isInternalIP(ip) {
+				return fmt.Errorf("destino do webhook não permitido: %s", host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isInternalIP indica se o IP pertence a uma faixa que não pode receber webhooks (loopback, redes
+// privadas, link-local como o endpoint de metadados 169.254.169.254, multicast e CGNAT)
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace é a faixa de CGNAT (RFC 6598), também usada em redes internas de provedores de nuvem
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// EvaluateTransaction avalia regras do tipo transaction para uma transação salva
+func (s *AlertService) EvaluateTransaction(ctx context.Context, tx *entities.Transaction) {
+	var to string
+	if tx.To != nil {
+		to = *tx.To
+	}
+
+	data := map[string]interface{}{
+		"hash":             tx.Hash,
+		"block_number":     tx.BlockNumber,
+		"from":             tx.From,
+		"to":               tx.To,
+		"value":            bigIntString(tx.Value),
+		"status":           string(tx.Status),
+		"gas_used":         tx.GasUsed,
+		"contract_address": tx.ContractAddress,
+	}
+	if tx.MinedAt != nil {
+		data["timestamp"] = tx.MinedAt.Unix()
+	}
+
+	s.evaluate(ctx, &entities.AlertSubject{
+		Type:   entities.AlertSubjectTransaction,
+		Key:    tx.Hash,
+		From:   tx.From,
+		To:     to,
+		Value:  tx.Value,
+		Status: string(tx.Status),
+		Data:   data,
+	})
+}
+
+// EvaluateEvent avalia regras do tipo event e, para eventos Transfer, regras do tipo token_transfer
+func (s *AlertService) EvaluateEvent(ctx context.Context, event *entities.Event) {
+	var to string
+	if event.ToAddress != nil {
+		to = *event.ToAddress
+	}
+
+	data := map[string]interface{}{
+		"id":               event.ID,
+		"contract_address": event.ContractAddress,
+		"event_name":       event.EventName,
+		"event_signature":  event.EventSignature,
+		"transaction_hash": event.TransactionHash,
+		"block_number":     event.BlockNumber,
+		"log_index":        event.LogIndex,
+		"from_address":     event.FromAddress,
+		"to_address":       event.ToAddress,
+		"decoded_data":     event.DecodedData,
+		"timestamp":        event.Timestamp.Unix(),
+	}
+
+	s.evaluate(ctx, &entities.AlertSubject{
+		Type:           entities.AlertSubjectEvent,
+		Key:            event.ID,
+		From:           event.FromAddress,
+		To:             to,
+		Contract:       event.ContractAddress,
+		EventName:      event.EventName,
+		EventSignature: event.EventSignature,
+		Status:         event.Status,
+		Data:           data,
+	})
+
+	s.EvaluateTokenTransfer(ctx, event)
+}
+
+// EvaluateTokenTransfer avalia regras do tipo token_transfer a partir de um evento Transfer decodificado.
+// Outros eventos com from/to (Approval, eventos customizados) são ignorados
+func (s *AlertService) EvaluateTokenTransfer(ctx context.Context, event *entities.Event) {
+	if event.DecodedData == nil || !isTransferEvent(event) {
+		return
+	}
+
+	decoded := *event.DecodedData
+	from, _ := decoded["from"].(string)
+	to, _ := decoded["to"].(string)
+	if from == "" && to == "" {
+		return
+	}
+
+	value := parseAlertValue(decoded["value"])
+	data := map[string]interface{}{
+		"token_address":    event.ContractAddress,
+		"from":             from,
+		"to":               to,
+		"value":            bigIntString(value),
+		"token_id":         decoded["tokenId"],
+		"transaction_hash": event.TransactionHash,
+		"block_number":     event.BlockNumber,
+		"log_index":        event.LogIndex,
+		"timestamp":        event.Timestamp.Unix(),
+	}
+
+	s.evaluate(ctx, &entities.AlertSubject{
+		Type:     entities.AlertSubjectTokenTransfer,
+		Key:      event.ID,
+		From:     from,
+		To:       to,
+		Contract: event.ContractAddress,
+		Value:    value,
+		Data:     data,
+	})
+}
+
+// EvaluateComplianceChange avalia regras do tipo compliance para uma mudança de status de uma account
+func (s *AlertService) EvaluateComplianceChange(ctx context.Context, msg *entities.AccountComplianceUpdateMessage, previousStatus, newStatus entities.ComplianceStatus) {
+	if previousStatus == newStatus {
+		return
+	}
+
+	address := strings.ToLower(msg.Address)
+	data := map[string]interface{}{
+		"address":           address,
+		"previous_status":   string(previousStatus),
+		"compliance_status": string(newStatus),
+		"compliance_notes":  msg.ComplianceNotes,
+		"risk_score":        msg.RiskScore,
+		"reviewed_by":       msg.ReviewedBy,
+		"review_reason":     msg.ReviewReason,
+		"source":            msg.Source,
+		"timestamp":         msg.Timestamp.Unix(),
+	}
+
+	s.evaluate(ctx, &entities.AlertSubject{
+		Type:             entities.AlertSubjectComplianceChange,
+		Key:              fmt.Sprintf("%s:%d", address, msg.Timestamp.UnixNano()),
+		From:             address,
+		To:               address,
+		ComplianceStatus: string(newStatus),
+		Data:             data,
+	})
+}
+
+// evaluate registra uma entrega pendente para cada regra ativa que casa com a ocorrência
+func (s *AlertService) evaluate(ctx context.Context, subject *entities.AlertSubject) {
+	rules, err := s.getRules(ctx)
+	if err != nil {
+		log.Printf("⚠️ [alerts] Erro ao carregar regras de alerta: %v", err)
+		return
+	}
+
+	for _, rule := range rules {
+		if rule.SubjectType != subject.Type || !rule.Conditions.Matches(subject) {
+			continue
+		}
+
+		now := time.Now()
+		payload, err := json.Marshal(map[string]interface{}{
+			"rule_id":      rule.ID,
+			"rule_name":    rule.Name,
+			"type":         string(subject.Type),
+			"key":          subject.Key,
+			"triggered_at": now.UTC().Format(time.RFC3339),
+			"data":         subject.Data,
+		})
+		if err != nil {
+			log.Printf("⚠️ [alerts] Erro ao serializar payload da regra %d: %v", rule.ID, err)
+			continue
+		}
+
+		delivery := &entities.AlertDelivery{
+			RuleID:      rule.ID,
+			SubjectType: subject.Type,
+			SubjectKey:  subject.Key,
+			Payload:     payload,
+		}
+
+		created, err := s.alertRepo.CreateDelivery(ctx, delivery)
+		if err != nil {
+			log.Printf("⚠️ [alerts] Erro ao registrar entrega da regra %d: %v", rule.ID, err)
+			continue
+		}
+		if !created {
+			continue // Ocorrência reprocessada, entrega já registrada
+		}
+
+		if err := s.alertRepo.MarkRuleTriggered(ctx, rule.ID, now); err != nil {
+			log.Printf("⚠️ [alerts] %v", err)
+		}
+
+		log.Printf("🔔 [alerts] Regra %d (%s) disparada por %s %s", rule.ID, rule.Name, subject.Type, subject.Key)
+	}
+}
+
+// getRules retorna as regras ativas em cache, recarregando após o intervalo configurado
+func (s *AlertService) getRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	s.mu.RLock()
+	if !s.rulesLoaded.IsZero() && time.Since(s.rulesLoaded) < s.refreshInterval {
+		rules := s.rules
+		s.mu.RUnlock()
+		return rules, nil
+	}
+	s.mu.RUnlock()
+
+	rules, err := s.alertRepo.GetActiveRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.rules = rules
+	s.rulesLoaded = time.Now()
+	s.mu.Unlock()
+
+	return rules, nil
+}
+
+// DispatchDue envia as entregas pendentes vencidas e retorna quantas foram processadas
+func (s *AlertService) DispatchDue(ctx context.Context) (int, error) {
+	// O lease cobre o timeout do envio para que a entrega não seja reservada duas vezes
+	lease := s.httpClient.Timeout + 30*time.Second
+
+	deliveries, err := s.alertRepo.ClaimDueDeliveries(ctx, alertDispatchBatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		s.deliver(ctx, delivery)
+
+		if err := s.alertRepo.UpdateDelivery(ctx, delivery); err != nil {
+			log.Printf("❌ [alerts] %v", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// deliver executa uma tentativa de entrega e atualiza o estado da entrega
+func (s *AlertService) deliver(ctx context.Context, delivery *entities.AlertDelivery) {
+	delivery.Attempts++
+
+	statusCode, err := s.send(ctx, delivery)
+	if statusCode > 0 {
+		delivery.ResponseStatus = &statusCode
+	}
+
+	now := time.Now()
+	if err == nil {
+		delivery.Status = entities.AlertDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+		delivery.NextAttemptAt = now
+		log.Printf("✅ [alerts] Entrega %d enviada (regra %d, tentativa %d)", delivery.ID, delivery.RuleID, delivery.Attempts)
+		return
+	}
+
+	errMsg := err.Error()
+	delivery.LastError = &errMsg
+
+	if delivery.Attempts >= s.maxAttempts {
+		delivery.Status = entities.AlertDeliveryFailed
+		delivery.NextAttemptAt = now
+		log.Printf("❌ [alerts] Entrega %d falhou definitivamente após %d tentativas: %v", delivery.ID, delivery.Attempts, err)
+		return
+	}
+
+	delivery.Status = entities.AlertDeliveryPending
+	delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts))
+	log.Printf("⚠️ [alerts] Entrega %d falhou (tentativa %d/%d), nova tentativa em %s: %v",
+		delivery.ID, delivery.Attempts, s.maxAttempts, delivery.NextAttemptAt.Sub(now).Round(time.Second), err)
+}
+
+// send envia o payload para o webhook com os headers de assinatura
+func (s *AlertService) send(ctx context.Context, delivery *entities.AlertDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.WebhookURL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("erro ao criar requisição: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "BesuScan-Alerts/1.0")
+	req.Header.Set("X-BesuScan-Event", string(delivery.SubjectType))
+	req.Header.Set("X-BesuScan-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-BesuScan-Timestamp", timestamp)
+	req.Header.Set("X-BesuScan-Signature", "sha256="+SignAlertPayload(delivery.WebhookSecret, timestamp, delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao enviar webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook respondeu com status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff calcula o intervalo exponencial até a próxima tentativa
+func (s *AlertService) backoff(attempts int) time.Duration {
+	delay := s.retryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxAlertBackoff {
+			return maxAlertBackoff
+		}
+	}
+	return delay
+}
+
+// SignAlertPayload calcula a assinatura HMAC-SHA256 de "<timestamp>.<payload>" em hexadecimal
+func SignAlertPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseAlertValue converte o valor decodificado (hex ou decimal) para big.Int
+func parseAlertValue(raw interface{}) *big.Int {
+	str, ok := raw.(string)
+	if !ok || str == "" {
+		return nil
+	}
+
+	value := new(big.Int)
+	if strings.HasPrefix(str, "0x") {
+		if _, ok := value.SetString(str[2:], 16); ok {
+			return value
+		}
+		return nil
+	}
+	if _, ok := value.SetString(str, 10); ok {
+		return value
+	}
+	return nil
+}
+
+// bigIntString converte um big.Int para string decimal
+func bigIntString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
+
+// transferTopic é o topic0 de Transfer(address,address,uint256), comum a ERC-20 e ERC-721
+const transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// isTransferEvent identifica um evento Transfer pelo topic0 ou, sem ele, pelo nome decodificado
+func isTransferEvent(event *entities.Event) bool {
+	if event.EventSignature != "" {
+		return strings.EqualFold(event.EventSignature, transferTopic)
+	}
+	return event.EventName == "Transfer"
+}
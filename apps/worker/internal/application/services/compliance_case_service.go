@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+)
+
+// ComplianceCaseService abre casos de compliance a partir das mudanças de status automáticas
+type ComplianceCaseService struct {
+	caseRepo repositories.ComplianceCaseRepository
+}
+
+// NewComplianceCaseService cria uma nova instância do serviço de casos
+func NewComplianceCaseService(caseRepo repositories.ComplianceCaseRepository) *ComplianceCaseService {
+	return &ComplianceCaseService{
+		caseRepo: caseRepo,
+	}
+}
+
+// HandleStatusChange abre um caso (ou registra o sinal no caso ativo) quando a política de risco ou a
+// triagem colocam a account em revisão ou a sinalizam. Falhas são registradas sem interromper a avaliação
+func (s *ComplianceCaseService) HandleStatusChange(ctx context.Context, origin entities.ComplianceCaseOrigin, originRef *string, address string, previous, status entities.ComplianceStatus, reason *string) {
+	if previous == status || status == entities.ComplianceStatusCompliant {
+		return
+	}
+
+	caseID, opened, err := s.caseRepo.OpenForSignal(ctx, &entities.ComplianceCaseSignal{
+		Address:    address,
+		Origin:     origin,
+		OriginRef:  originRef,
+		Previous:   previous,
+		Status:     status,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf("❌ Erro ao abrir caso de compliance para %s: %v", address, err)
+		return
+	}
+
+	if opened {
+		log.Printf("📂 Caso de compliance #%d aberto para %s (%s, %s)", caseID, address, origin, status)
+	} else {
+		log.Printf("📎 Sinal de %s registrado no caso de compliance #%d da account %s", origin, caseID, address)
+	}
+}
+
+// RecordStatusOverride registra nos casos ativos uma alteração manual de status feita fora do caso
+func (s *ComplianceCaseService) RecordStatusOverride(ctx context.Context, msg *entities.AccountComplianceUpdateMessage, previous, status entities.ComplianceStatus) {
+	actor := "api"
+	if msg.ReviewedBy != nil && *msg.ReviewedBy != "" {
+		actor = *msg.ReviewedBy
+	}
+	reason := msg.ReviewReason
+	if reason == nil {
+		reason = msg.ComplianceNotes
+	}
+
+	if err := s.caseRepo.RecordStatusOverride(ctx, msg.Address, previous, status, actor, msg.Source, reason); err != nil {
+		log.Printf("❌ Erro ao registrar alteração manual nos casos de %s: %v", msg.Address, err)
+	}
+}
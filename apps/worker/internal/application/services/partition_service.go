@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	// partitionManagerLockKey é a chave do advisory lock que impede dois workers de
+	// manterem as partições ao mesmo tempo
+	partitionManagerLockKey = 7424001
+	// archiveSchema recebe as partições desanexadas pela retenção com a ação "archive"
+	archiveSchema = "archive"
+	// monthLayout é o formato dos limites das partições mensais
+	monthLayout = "2006-01-02"
+)
+
+// Ações de retenção para partições antigas
+const (
+	PartitionRetentionArchive = "archive"
+	PartitionRetentionDrop    = "drop"
+)
+
+// partitionBoundPattern extrai os limites de "FOR VALUES FROM (...) TO (...)"
+var partitionBoundPattern = regexp.MustCompile(`FROM \('?([^')]*)'?\) TO \('?([^')]*)'?\)`)
+
+// partitionedTable descreve uma tabela particionada por faixa (ver migração 0006)
+type partitionedTable struct {
+	name    string
+	column  string
+	monthly bool // particionada por mês em vez de faixa de blocos
+}
+
+// partitionedTables são as tabelas mantidas pelo PartitionService
+var partitionedTables = []partitionedTable{
+	{name: "transactions", column: "block_number"},
+	{name: "events", column: "block_number"},
+	{name: "account_transactions", column: "block_number"},
+	{name: "account_events", column: "block_number"},
+	{name: "account_analytics", column: "date", monthly: true},
+}
+
+// tablePartition é uma partição existente com seus limites [from, to)
+type tablePartition struct {
+	name string
+	from string
+	to   string
+}
+
+// PartitionPolicy define o tamanho das partições, quantas manter criadas à frente e a retenção
+type PartitionPolicy struct {
+	BlockRange      uint64 // Blocos por partição
+	Premake         int    // Partições criadas além da que contém o bloco/mês atual
+	RetentionBlocks uint64 // Blocos mantidos abaixo do head (0 mantém tudo)
+	RetentionMonths int    // Meses completos mantidos antes do atual (0 mantém tudo)
+	RetentionAction string // archive (desanexa e move para o schema archive) ou drop
+}
+
+// PartitionService cria as próximas partições das tabelas grandes e aplica a retenção
+type PartitionService struct {
+	db     *pgxpool.Pool
+	policy PartitionPolicy
+}
+
+// NewPartitionService cria uma nova instância do serviço de partições
+func NewPartitionService(db *pgxpool.Pool, policy PartitionPolicy) *PartitionService {
+	if policy.BlockRange == 0 {
+		policy.BlockRange = 1000000
+	}
+	if policy.Premake < 0 {
+		policy.Premake = 0
+	}
+	if policy.RetentionAction != PartitionRetentionDrop {
+		policy.RetentionAction = PartitionRetentionArchive
+	}
+	return &PartitionService{
+		db:     db,
+		policy: policy,
+	}
+}
+
+// Maintain cria as partições que faltam até o head da chain (mais as antecipadas) e remove as
+// que saíram da retenção. Erros em uma tabela não impedem a manutenção das demais
+func (s *PartitionService) Maintain(ctx context.Context) error {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao obter conexão para manutenção de partições: %w", err)
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, partitionManagerLockKey).Scan(&locked); err != nil {
+		return fmt.Errorf("erro ao obter lock de manutenção de partições: %w", err)
+	}
+	if !locked {
+		log.Println("⏭️ Manutenção de partições já em andamento em outro worker")
+		return nil
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, partitionManagerLockKey)
+
+	var head uint64
+	if err := conn.QueryRow(ctx, `SELECT COALESCE(MAX(number), 0) FROM blocks`).Scan(&head); err != nil {
+		return fmt.Errorf("erro ao buscar head da chain: %w", err)
+	}
+	now := time.Now().UTC()
+
+	var firstErr error
+	for _, table := range partitionedTables {
+		if err := s.maintainTable(ctx, conn, table, head, now); err != nil {
+			log.Printf("❌ Erro ao manter partições de %s: %v", table.name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// maintainTable cria as partições que faltam e aplica a retenção em uma tabela
+func (s *PartitionService) maintainTable(ctx context.Context, conn *pgxpool.Conn, table partitionedTable, head uint64, now time.Time) error {
+	var partitioned bool
+	if err := conn.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = to_regclass($1))
+	`, table.name).Scan(&partitioned); err != nil {
+		return fmt.Errorf("erro ao verificar particionamento: %w", err)
+	}
+	if !partitioned {
+		log.Printf("⚠️ %s não é particionada (migração 0006 não aplicada), ignorando", table.name)
+		return nil
+	}
+
+	partitions, err := s.listPartitions(ctx, conn, table.name)
+	if err != nil {
+		return err
+	}
+
+	if table.monthly {
+		if err := s.ensureMonthPartitions(ctx, conn, table, partitions, now); err != nil {
+			return err
+		}
+		return s.retainMonthPartitions(ctx, conn, table, partitions, now)
+	}
+
+	if err := s.ensureBlockPartitions(ctx, conn, table, partitions, head); err != nil {
+		return err
+	}
+	return s.retainBlockPartitions(ctx, conn, table, partitions, head)
+}
+
+// listPartitions lista as partições de faixa de uma tabela (a partição default é ignorada)
+func (s *PartitionService) listPartitions(ctx context.Context, conn *pgxpool.Conn, tableName string) ([]tablePartition, error) {
+	rows, err := conn.Query(ctx, `
+		SELECT c.relname, pg_get_expr(c.relpartbound, c.oid)
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = to_regclass($1)
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar partições: %w", err)
+	}
+	defer rows.Close()
+
+	var partitions []tablePartition
+	for rows.Next() {
+		var name, bound string
+		if err := rows.Scan(&name, &bound); err != nil {
+			return nil, fmt.Errorf("erro ao ler partição: %w", err)
+		}
+		match := partitionBoundPattern.FindStringSubmatch(bound)
+		if match == nil {
+			continue
+		}
+		partitions = append(partitions, tablePartition{name: name, from: match[1], to: match[2]})
+	}
+	return partitions, rows.Err()
+}
+
+// ensureBlockPartitions cria partições de BlockRange blocos a partir do fim da última partição
+// até cobrir o head mais as partições antecipadas
+func (s *PartitionService) ensureBlockPartitions(ctx context.Context, conn *pgxpool.Conn, table partitionedTable, partitions []tablePartition, head uint64) error {
+	var upper uint64
+	for _, partition := range partitions {
+		to, err := strconv.ParseUint(partition.to, 10, 64)
+		if err != nil {
+			continue
+		}
+		if to > upper {
+			upper = to
+		}
+	}
+
+	target := head + uint64(s.policy.Premake)*s.policy.BlockRange
+	for ; upper <= target; upper += s.policy.BlockRange {
+		name := fmt.Sprintf("%s_b%d", table.name, upper)
+		if err := s.createPartition(ctx, conn, table, name, strconv.FormatUint(upper, 10), strconv.FormatUint(upper+s.policy.BlockRange, 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureMonthPartitions cria partições mensais a partir do fim da última partição até o mês
+// atual mais as partições antecipadas
+func (s *PartitionService) ensureMonthPartitions(ctx context.Context, conn *pgxpool.Conn, table partitionedTable, partitions []tablePartition, now time.Time) error {
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	upper := current
+	found := false
+	for _, partition := range partitions {
+		to, err := time.Parse(monthLayout, partition.to)
+		if err != nil {
+			continue
+		}
+		if !found || to.After(upper) {
+			upper = to
+			found = true
+		}
+	}
+
+	target := current.AddDate(0, s.policy.Premake, 0)
+	for ; !upper.After(target); upper = upper.AddDate(0, 1, 0) {
+		name := fmt.Sprintf("%s_m%s", table.name, upper.Format("200601"))
+		if err := s.createPartition(ctx, conn, table, name, upper.Format(monthLayout), upper.AddDate(0, 1, 0).Format(monthLayout)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createPartition cria a partição [from, to) com a função ensure_range_partition da migração 0006,
+// que também move para ela as linhas da faixa que estavam na partição default
+func (s *PartitionService) createPartition(ctx context.Context, conn *pgxpool.Conn, table partitionedTable, name, from, to string) error {
+	var created bool
+	if err := conn.QueryRow(ctx, `SELECT ensure_range_partition($1, $2, $3, $4, $5)`,
+		table.name, name, table.column, from, to).Scan(&created); err != nil {
+		return fmt.Errorf("erro ao criar partição %s: %w", name, err)
+	}
+	if created {
+		log.Printf("🧱 Partição %s criada [%s, %s)", name, from, to)
+	}
+	return nil
+}
+
+// retainBlockPartitions remove as partições que terminam antes de head - RetentionBlocks
+func (s *PartitionService) retainBlockPartitions(ctx context.Context, conn *pgxpool.Conn, table partitionedTable, partitions []tablePartition, head uint64) error {
+	if s.policy.RetentionBlocks == 0 || head <= s.policy.RetentionBlocks {
+		return nil
+	}
+	cutoff := head - s.policy.RetentionBlocks
+
+	for _, partition := range partitions {
+		to, err := strconv.ParseUint(partition.to, 10, 64)
+		if err != nil || to > cutoff {
+			continue
+		}
+		if err := s.removePartition(ctx, conn, table, partition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retainMonthPartitions remove as partições que terminam antes dos RetentionMonths meses
+// completos anteriores ao mês atual
+func (s *PartitionService) retainMonthPartitions(ctx context.Context, conn *pgxpool.Conn, table partitionedTable, partitions []tablePartition, now time.Time) error {
+	if s.policy.RetentionMonths <= 0 {
+		return nil
+	}
+	cutoff := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -s.policy.RetentionMonths, 0)
+
+	for _, partition := range partitions {
+		to, err := time.Parse(monthLayout, partition.to)
+		if err != nil || to.After(cutoff) {
+			continue
+		}
+		if err := s.removePartition(ctx, conn, table, partition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removePartition desanexa a partição e a move para o schema archive ou a apaga, conforme a política
+func (s *PartitionService) removePartition(ctx context.Context, conn *pgxpool.Conn, table partitionedTable, partition tablePartition) error {
+	parent := pgx.Identifier{table.name}.Sanitize()
+	child := pgx.Identifier{partition.name}.Sanitize()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar remoção da partição %s: %w", partition.name, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE %s DETACH PARTITION %s`, parent, child)); err != nil {
+		return fmt.Errorf("erro ao desanexar partição %s: %w", partition.name, err)
+	}
+
+	if s.policy.RetentionAction == PartitionRetentionDrop {
+		// DETACH não dispara os triggers de transaction_hashes: os hashes da faixa (e os métodos que os
+		// referenciam) saem junto com a partição. Partições arquivadas mantêm os hashes
+		if table.name == "transactions" {
+			if _, err := tx.Exec(ctx, `
+				DELETE FROM transaction_methods WHERE transaction_hash IN (
+					SELECT hash FROM transaction_hashes WHERE block_number >= $1::BIGINT AND block_number < $2::BIGINT
+				)`, partition.from, partition.to); err != nil {
+				return fmt.Errorf("erro ao apagar métodos da partição %s: %w", partition.name, err)
+			}
+			if _, err := tx.Exec(ctx, `DELETE FROM transaction_hashes WHERE block_number >= $1::BIGINT AND block_number < $2::BIGINT`,
+				partition.from, partition.to); err != nil {
+				return fmt.Errorf("erro ao apagar hashes da partição %s: %w", partition.name, err)
+			}
+		}
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`DROP TABLE %s`, child)); err != nil {
+			return fmt.Errorf("erro ao apagar partição %s: %w", partition.name, err)
+		}
+	} else {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, archiveSchema)); err != nil {
+			return fmt.Errorf("erro ao criar schema %s: %w", archiveSchema, err)
+		}
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE %s SET SCHEMA %s`, child, archiveSchema)); err != nil {
+			return fmt.Errorf("erro ao arquivar partição %s: %w", partition.name, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar remoção da partição %s: %w", partition.name, err)
+	}
+
+	log.Printf("🗄️ Partição %s [%s, %s) removida da retenção (%s)", partition.name, partition.from, partition.to, s.policy.RetentionAction)
+	return nil
+}
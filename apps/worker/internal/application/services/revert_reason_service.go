@@ -0,0 +1,354 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Seletores de Error(string) e Panic(uint256), os reverts padrão do Solidity
+var (
+	revertErrorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	revertPanicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// revertPanicReasons descreve os códigos de Panic(uint256) do Solidity
+var revertPanicReasons = map[uint64]string{
+	0x00: "panic genérico do compilador",
+	0x01: "assert falhou",
+	0x11: "overflow ou underflow aritmético",
+	0x12: "divisão ou módulo por zero",
+	0x21: "conversão para enum fora do intervalo",
+	0x22: "storage byte array codificado incorretamente",
+	0x31: "pop() em array vazio",
+	0x32: "acesso a array fora dos limites",
+	0x41: "memória demais alocada ou array grande demais",
+	0x51: "chamada a função interna não inicializada",
+}
+
+// Origem do motivo de falha
+const (
+	revertSourceReceipt = "receipt"
+	revertSourceReplay  = "replay"
+)
+
+// RevertReasonService descobre e decodifica o motivo da falha das transações: usa o revertReason do
+// receipt quando o Besu o fornece e, sem ele, reexecuta a transação via eth_call no bloco pai. Erros
+// customizados são decodificados com o ABI do contrato chamado gravado em smart_contracts
+type RevertReasonService struct {
+	db        *pgxpool.Pool
+	ethClient *ethclient.Client
+}
+
+// NewRevertReasonService cria uma nova instância do serviço de motivos de falha
+func NewRevertReasonService(db *pgxpool.Pool, ethClient *ethclient.Client) *RevertReasonService {
+	return &RevertReasonService{
+		db:        db,
+		ethClient: ethClient,
+	}
+}
+
+// Resolve retorna o motivo da falha da transação. receiptReason é o campo revertReason do receipt (vazio
+// se o nó não o fornece). Retorna erro apenas quando o nó está inacessível, para a transação ser tentada
+// de novo depois; falhas que o nó reporta viram um motivo do tipo unavailable
+func (s *RevertReasonService) Resolve(ctx context.Context, tx *entities.Transaction, receiptReason []byte) (*entities.RevertReason, error) {
+	if len(receiptReason) > 0 {
+		return s.decode(ctx, receiptReason, tx.To, revertSourceReceipt), nil
+	}
+
+	if tx.BlockNumber == nil || *tx.BlockNumber == 0 {
+		return &entities.RevertReason{
+			Kind:    entities.RevertKindUnavailable,
+			Message: "transação sem bloco pai para reexecução",
+			Source:  revertSourceReplay,
+		}, nil
+	}
+
+	msg := ethereum.CallMsg{
+		From:  common.HexToAddress(tx.From),
+		Gas:   tx.Gas,
+		Value: tx.Value,
+		Data:  tx.Data,
+	}
+	if tx.To != nil && *tx.To != "" {
+		to := common.HexToAddress(*tx.To)
+		msg.To = &to
+	}
+
+	_, err := s.ethClient.CallContract(ctx, msg, new(big.Int).SetUint64(*tx.BlockNumber-1))
+	if err == nil {
+		// A reexecução isolada passou: a falha dependeu de transações anteriores do mesmo bloco ou de gas
+		if tx.GasUsed != nil && *tx.GasUsed >= tx.Gas {
+			return &entities.RevertReason{Kind: entities.RevertKindOutOfGas, Message: "gas esgotado", Source: revertSourceReplay}, nil
+		}
+		return &entities.RevertReason{
+			Kind:    entities.RevertKindUnknown,
+			Message: "a transação não reverteu ao ser reexecutada no bloco pai; a falha depende de transações anteriores do mesmo bloco",
+			Source:  revertSourceReplay,
+		}, nil
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if text, ok := dataErr.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(text); decodeErr == nil && len(data) > 0 {
+				return s.decode(ctx, data, tx.To, revertSourceReplay), nil
+			}
+		}
+	}
+
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return nil, fmt.Errorf("erro ao reexecutar transação %s: %w", tx.Hash, err)
+	}
+	message := strings.ToLower(rpcErr.Error())
+	switch {
+	case strings.Contains(message, "out of gas") || strings.Contains(message, "gas required exceeds"):
+		return &entities.RevertReason{Kind: entities.RevertKindOutOfGas, Message: rpcErr.Error(), Source: revertSourceReplay}, nil
+	case strings.Contains(message, "revert"):
+		reason := s.decode(ctx, nil, tx.To, revertSourceReplay)
+		reason.Message = rpcErr.Error()
+		return reason, nil
+	}
+	return &entities.RevertReason{Kind: entities.RevertKindUnavailable, Message: rpcErr.Error(), Source: revertSourceReplay}, nil
+}
+
+// ProcessPending resolve os motivos das transações com falha ainda sem revert_reason (ex.: gravadas pelo
+// backfill ou com o nó indisponível), das mais recentes para as mais antigas. Retorna quantas foram gravadas
+func (s *RevertReasonService) ProcessPending(ctx context.Context, limit int) (int, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT hash, block_number, from_address, to_address, value, gas_limit, gas_used, data
+		FROM transactions
+		WHERE status = 'failed' AND revert_reason IS NULL AND block_number IS NOT NULL
+		ORDER BY block_number DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar transações com falha sem motivo: %w", err)
+	}
+
+	var pending []*entities.Transaction
+	for rows.Next() {
+		var (
+			tx          entities.Transaction
+			blockNumber int64
+			value       string
+			gasLimit    int64
+			gasUsed     *int64
+		)
+		if err := rows.Scan(&tx.Hash, &blockNumber, &tx.From, &tx.To, &value, &gasLimit, &gasUsed, &tx.Data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("erro ao ler transação com falha: %w", err)
+		}
+		number := uint64(blockNumber)
+		tx.BlockNumber = &number
+		tx.Gas = uint64(gasLimit)
+		if gasUsed != nil {
+			used := uint64(*gasUsed)
+			tx.GasUsed = &used
+		}
+		tx.Value, _ = new(big.Int).SetString(value, 10)
+		pending = append(pending, &tx)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("erro ao ler transações com falha: %w", err)
+	}
+
+	resolved := 0
+	for _, tx := range pending {
+		reason, err := s.Resolve(ctx, tx, nil)
+		if err != nil {
+			return resolved, err
+		}
+		if err := s.Save(ctx, tx.Hash, reason); err != nil {
+			return resolved, err
+		}
+		resolved++
+	}
+	return resolved, nil
+}
+
+// Save grava o motivo da falha na transação
+func (s *RevertReasonService) Save(ctx context.Context, txHash string, reason *entities.RevertReason) error {
+	data, err := json.Marshal(reason)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar revert_reason: %w", err)
+	}
+	if _, err := s.db.Exec(ctx, `UPDATE transactions SET revert_reason = $2, updated_at = NOW() WHERE hash = $1`, txHash, string(data)); err != nil {
+		return fmt.Errorf("erro ao gravar revert_reason da transação %s: %w", txHash, err)
+	}
+	return nil
+}
+
+// decode decodifica os dados do revert: Error(string), Panic(uint256) ou um erro customizado do ABI do
+// contrato chamado
+func (s *RevertReasonService) decode(ctx context.Context, data []byte, contract *string, source string) *entities.RevertReason {
+	reason := &entities.RevertReason{Kind: entities.RevertKindUnknown, Source: source}
+	if len(data) == 0 {
+		reason.Message = "execução revertida sem motivo"
+		return reason
+	}
+	reason.Data = hexutil.Encode(data)
+	if len(data) < 4 {
+		reason.Message = "dados de revert inválidos"
+		return reason
+	}
+
+	selector := data[:4]
+	switch {
+	case bytes.Equal(selector, revertErrorSelector):
+		if message, err := abi.UnpackRevert(data); err == nil {
+			reason.Kind, reason.Message = entities.RevertKindError, message
+			return reason
+		}
+	case bytes.Equal(selector, revertPanicSelector):
+		if len(data) == 36 {
+			code := new(big.Int).SetBytes(data[4:])
+			reason.Kind = entities.RevertKindPanic
+			if code.IsUint64() {
+				value := code.Uint64()
+				reason.Code = &value
+				if description, ok := revertPanicReasons[value]; ok {
+					reason.Message = fmt.Sprintf("Panic(0x%02x): %s", value, description)
+					return reason
+				}
+			}
+			reason.Message = fmt.Sprintf("Panic(0x%s)", code.Text(16))
+			return reason
+		}
+	}
+
+	if contract != nil && *contract != "" {
+		for _, contractABI := range s.contractABIs(ctx, *contract) {
+			for _, abiError := range contractABI.Errors {
+				if !bytes.Equal(abiError.ID[:4], selector) {
+					continue
+				}
+				values, err := abiError.Inputs.Unpack(data[4:])
+				if err != nil {
+					continue
+				}
+				reason.Kind = entities.RevertKindCustom
+				reason.Signature = abiError.Sig
+				reason.Message = abiError.Sig
+				parts := make([]string, 0, len(values))
+				for i, value := range values {
+					name := abiError.Inputs[i].Name
+					if name == "" {
+						name = fmt.Sprintf("arg%d", i)
+					}
+					formatted := revertArgValue(reflect.ValueOf(value))
+					reason.Args = append(reason.Args, entities.RevertArg{Name: name, Type: abiError.Inputs[i].Type.String(), Value: formatted})
+					parts = append(parts, fmt.Sprintf("%s=%v", name, formatted))
+				}
+				if len(parts) > 0 {
+					reason.Message = fmt.Sprintf("%s(%s)", abiError.Name, strings.Join(parts, ", "))
+				}
+				return reason
+			}
+		}
+	}
+
+	reason.Message = fmt.Sprintf("erro desconhecido (seletor %s)", hexutil.Encode(selector))
+	return reason
+}
+
+// contractABIs carrega o ABI gravado do contrato e, em proxies, o da implementação (primeiro)
+func (s *RevertReasonService) contractABIs(ctx context.Context, address string) []*abi.ABI {
+	var (
+		definition     *string
+		implementation *string
+	)
+	err := s.db.QueryRow(ctx, `SELECT abi::text, proxy_implementation FROM smart_contracts WHERE address = $1`,
+		strings.ToLower(address)).Scan(&definition, &implementation)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("⚠️ Erro ao buscar ABI de %s para decodificar revert: %v", address, err)
+		}
+		return nil
+	}
+
+	var definitions []*string
+	if implementation != nil && *implementation != "" && !strings.EqualFold(*implementation, address) {
+		var implementationABI *string
+		if err := s.db.QueryRow(ctx, `SELECT abi::text FROM smart_contracts WHERE address = $1`,
+			strings.ToLower(*implementation)).Scan(&implementationABI); err == nil {
+			definitions = append(definitions, implementationABI)
+		}
+	}
+	definitions = append(definitions, definition)
+
+	var parsed []*abi.ABI
+	for _, text := range definitions {
+		if text == nil || *text == "" || *text == "null" {
+			continue
+		}
+		contractABI, err := abi.JSON(strings.NewReader(*text))
+		if err != nil {
+			log.Printf("⚠️ ABI gravado de %s inválido: %v", address, err)
+			continue
+		}
+		parsed = append(parsed, &contractABI)
+	}
+	return parsed
+}
+
+// revertArgValue converte um argumento decodificado em um valor JSON: inteiros como strings decimais,
+// endereços com checksum e bytes em hex
+func revertArgValue(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	switch v := value.Interface().(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+
+	switch value.Kind() {
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(data), value)
+			return hexutil.Encode(data)
+		}
+		fallthrough
+	case reflect.Slice:
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			items[i] = revertArgValue(value.Index(i))
+		}
+		return items
+	case reflect.Struct:
+		fields := make(map[string]interface{}, value.NumField())
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			name := field.Tag.Get("json")
+			if name == "" {
+				name = field.Name
+			}
+			fields[name] = revertArgValue(value.Field(i))
+		}
+		return fields
+	}
+	return value.Interface()
+}
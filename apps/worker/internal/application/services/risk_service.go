@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+)
+
+const (
+	RiskTriggerActivity     = "activity"
+	RiskTriggerPolicyChange = "policy_change"
+	RiskTriggerManual       = "manual"
+)
+
+// RiskService avalia o risco de accounts com a política ativa de risk_policies
+type RiskService struct {
+	riskRepo     repositories.RiskRepository
+	alertService *AlertService
+	caseService  *ComplianceCaseService
+
+	mu       sync.RWMutex
+	version  int64
+	policy   *entities.RiskPolicy
+	rejected int64 // Última versão ativa que falhou no parse; evita recarregá-la a cada ciclo
+}
+
+// NewRiskService cria uma nova instância do serviço de risco. alertService e caseService são opcionais
+func NewRiskService(riskRepo repositories.RiskRepository, alertService *AlertService, caseService *ComplianceCaseService) *RiskService {
+	return &RiskService{
+		riskRepo:     riskRepo,
+		alertService: alertService,
+		caseService:  caseService,
+	}
+}
+
+// RefreshPolicy carrega a política ativa se a versão mudou. Retorna true quando uma nova versão passa a valer.
+// Uma versão inválida é ignorada e a anterior continua em uso
+func (s *RiskService) RefreshPolicy(ctx context.Context) (bool, error) {
+	active, err := s.riskRepo.GetActivePolicy(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if active == nil {
+		s.version, s.policy, s.rejected = 0, nil, 0
+		return false, nil
+	}
+	if active.Version == s.version || active.Version == s.rejected {
+		return false, nil
+	}
+
+	policy, err := entities.ParseRiskPolicy([]byte(active.Content), active.Format)
+	if err != nil {
+		// A versão em uso continua sendo a da política carregada, para que policy_version não aponte para a inválida
+		s.rejected = active.Version
+		return false, fmt.Errorf("erro ao carregar política de risco v%d (mantendo v%d): %w", active.Version, s.version, err)
+	}
+
+	s.version, s.policy, s.rejected = active.Version, policy, 0
+	log.Printf("📜 Política de risco v%d (%s) carregada com %d regras", active.Version, policy.Name, len(policy.Rules))
+	return true, nil
+}
+
+// activePolicy retorna a política carregada e sua versão
+func (s *RiskService) activePolicy() (*entities.RiskPolicy, int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy, s.version
+}
+
+// EvaluateAccount avalia uma account com a política ativa, grava o resultado e, se o status mudar, dispara os
+// alertas de compliance e abre um caso para investigação
+func (s *RiskService) EvaluateAccount(ctx context.Context, address, trigger string) (*entities.RiskEvaluation, error) {
+	policy, version := s.activePolicy()
+	if policy == nil {
+		return nil, fmt.Errorf("nenhuma política de risco ativa")
+	}
+
+	address = strings.ToLower(address)
+	facts, err := s.riskRepo.LoadFacts(ctx, address, policy.FactKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	score, status, breakdown := policy.Evaluate(facts)
+	evaluation := &entities.RiskEvaluation{
+		Address:       address,
+		PolicyVersion: version,
+		Score:         score,
+		Status:        status,
+		Breakdown:     breakdown,
+		Trigger:       trigger,
+		EvaluatedAt:   time.Now(),
+	}
+
+	previous, applied, err := s.riskRepo.SaveEvaluation(ctx, evaluation)
+	if err != nil {
+		return nil, err
+	}
+
+	if applied && previous != status {
+		notes := fmt.Sprintf("Score %d pela política de risco v%d", score, version)
+		if s.alertService != nil {
+			s.alertService.EvaluateComplianceChange(ctx, &entities.AccountComplianceUpdateMessage{
+				Address:          address,
+				ComplianceStatus: string(status),
+				ComplianceNotes:  &notes,
+				RiskScore:        &score,
+				Source:           "risk_policy",
+				Timestamp:        evaluation.EvaluatedAt,
+			}, previous, status)
+		}
+		if s.caseService != nil {
+			ref := fmt.Sprintf("v%d", version)
+			s.caseService.HandleStatusChange(ctx, entities.ComplianceCaseOriginRiskPolicy, &ref, address, previous, status, &notes)
+		}
+	}
+
+	return evaluation, nil
+}
+
+// ProcessPending avalia até limit accounts da fila de atividade. Retorna quantas foram avaliadas
+func (s *RiskService) ProcessPending(ctx context.Context, limit int) (int, error) {
+	if policy, _ := s.activePolicy(); policy == nil {
+		return 0, nil
+	}
+
+	addresses, err := s.riskRepo.ClaimPending(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+	return s.evaluateAll(ctx, addresses, RiskTriggerActivity), nil
+}
+
+// ReevaluateOutdated reavalia até limit accounts avaliadas por versões anteriores da política
+func (s *RiskService) ReevaluateOutdated(ctx context.Context, limit int) (int, error) {
+	policy, version := s.activePolicy()
+	if policy == nil {
+		return 0, nil
+	}
+
+	addresses, err := s.riskRepo.ClaimOutdated(ctx, version, limit)
+	if err != nil {
+		return 0, err
+	}
+	return s.evaluateAll(ctx, addresses, RiskTriggerPolicyChange), nil
+}
+
+// MarkPending enfileira accounts para avaliação
+func (s *RiskService) MarkPending(ctx context.Context, addresses []string) error {
+	return s.riskRepo.MarkPending(ctx, addresses)
+}
+
+// MarkAllPending enfileira todas as accounts para avaliação
+func (s *RiskService) MarkAllPending(ctx context.Context) (int64, error) {
+	return s.riskRepo.MarkAllPending(ctx)
+}
+
+// evaluateAll avalia as accounts reservadas; as que falharem voltam para a fila
+func (s *RiskService) evaluateAll(ctx context.Context, addresses []string, trigger string) int {
+	var evaluated int
+	var failed []string
+	for _, address := range addresses {
+		if _, err := s.EvaluateAccount(ctx, address, trigger); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue // Account removida
+			}
+			log.Printf("⚠️ Erro ao avaliar risco da account %s: %v", address, err)
+			failed = append(failed, address)
+			continue
+		}
+		evaluated++
+	}
+
+	if len(failed) > 0 {
+		if err := s.riskRepo.MarkPending(context.Background(), failed); err != nil {
+			log.Printf("❌ Erro ao devolver %d accounts para a fila de risco: %v", len(failed), err)
+		}
+	}
+	return evaluated
+}
@@ -0,0 +1,638 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// screeningLockKey é a chave do advisory lock que serializa a retriagem das listas
+const screeningLockKey = 7424004
+
+// Origem de cada contato entre dois endereços
+const (
+	ScreeningKindTransaction   = "transaction"
+	ScreeningKindInternal      = "internal"
+	ScreeningKindTokenTransfer = "token_transfer"
+)
+
+// exposureUpsert grava as exposições encontradas pela consulta interna, mantendo por endereço listado o
+// menor número de hops (e o contato que o gerou) e o maior peso. A consulta interna retorna address,
+// list_id, listed_address, hops, weight, via_address, tx_hash, block_number, kind e ts
+const exposureUpsert = `
+	INSERT INTO account_exposures (address, list_id, listed_address, hops, weight, via_address, tx_hash,
+		block_number, kind, first_seen_at, last_seen_at)
+	SELECT address, list_id, listed_address, MIN(hops), MAX(weight),
+		(array_agg(via_address ORDER BY hops, ts))[1],
+		(array_agg(tx_hash ORDER BY hops, ts))[1],
+		(array_agg(block_number ORDER BY hops, ts))[1],
+		(array_agg(kind ORDER BY hops, ts))[1],
+		MIN(ts), MAX(ts)
+	FROM (%s) AS found
+	WHERE address IS NOT NULL AND address <> listed_address
+	GROUP BY address, list_id, listed_address
+	ON CONFLICT (address, list_id, listed_address) DO UPDATE SET
+		via_address = CASE WHEN EXCLUDED.hops < account_exposures.hops THEN EXCLUDED.via_address ELSE account_exposures.via_address END,
+		tx_hash = CASE WHEN EXCLUDED.hops < account_exposures.hops THEN EXCLUDED.tx_hash ELSE account_exposures.tx_hash END,
+		block_number = CASE WHEN EXCLUDED.hops < account_exposures.hops THEN EXCLUDED.block_number ELSE account_exposures.block_number END,
+		kind = CASE WHEN EXCLUDED.hops < account_exposures.hops THEN EXCLUDED.kind ELSE account_exposures.kind END,
+		hops = LEAST(account_exposures.hops, EXCLUDED.hops),
+		weight = GREATEST(account_exposures.weight, EXCLUDED.weight),
+		first_seen_at = LEAST(account_exposures.first_seen_at, EXCLUDED.first_seen_at),
+		last_seen_at = GREATEST(account_exposures.last_seen_at, EXCLUDED.last_seen_at)
+	RETURNING address`
+
+// topicAddress extrai o endereço de um topic indexado de events.topics
+const topicAddress = `'0x' || right(e.topics->>%d, 40)`
+
+// ScreeningPolicy define a propagação da exposição e os pesos que alteram o status de compliance
+type ScreeningPolicy struct {
+	MaxHops       int     // Hops propagados a partir do endereço listado (1 = só contato direto)
+	Decay         float64 // Fator aplicado ao peso a cada hop além do primeiro
+	FlagWeight    float64 // Peso a partir do qual a account é sinalizada (flagged)
+	ReviewWeight  float64 // Peso a partir do qual a account entra em revisão (under_review)
+	TraceInternal bool    // Buscar transferências internas com debug_traceTransaction (callTracer)
+}
+
+// ScreeningEdge é um contato entre dois endereços: transação, transferência interna ou de token
+type ScreeningEdge struct {
+	From        string
+	To          string
+	TxHash      string
+	BlockNumber int64
+	Kind        string
+	Timestamp   time.Time
+}
+
+// ScreeningService confronta a atividade da rede com as listas de sanções e denylists, propaga a
+// exposição por N hops e atualiza o status de compliance das accounts expostas
+type ScreeningService struct {
+	db           *pgxpool.Pool
+	ethClient    *ethclient.Client
+	alertService *AlertService
+	caseService  *ComplianceCaseService
+	policy       ScreeningPolicy
+}
+
+// NewScreeningService cria uma nova instância do serviço de triagem. ethClient, alertService e caseService
+// são opcionais
+func NewScreeningService(db *pgxpool.Pool, ethClient *ethclient.Client, alertService *AlertService, caseService *ComplianceCaseService, policy ScreeningPolicy) *ScreeningService {
+	if policy.MaxHops <= 0 {
+		policy.MaxHops = 2
+	}
+	if policy.Decay <= 0 || policy.Decay > 1 {
+		policy.Decay = 0.5
+	}
+	if policy.FlagWeight <= 0 {
+		policy.FlagWeight = 1
+	}
+	if policy.ReviewWeight <= 0 || policy.ReviewWeight > policy.FlagWeight {
+		policy.ReviewWeight = policy.FlagWeight
+	}
+	return &ScreeningService{
+		db:           db,
+		ethClient:    ethClient,
+		alertService: alertService,
+		caseService:  caseService,
+		policy:       policy,
+	}
+}
+
+// ScreenBlock faz a triagem dos contatos de um bloco: transações, transferências de tokens dos logs e, se
+// habilitado, transferências internas
+func (s *ScreeningService) ScreenBlock(ctx context.Context, blockTxs []*BlockTransaction, logs map[string][]*types.Log) error {
+	var edges []ScreeningEdge
+	for _, bt := range blockTxs {
+		tx := bt.Transaction
+		if tx.BlockNumber == nil || tx.Status == entities.StatusFailed {
+			continue
+		}
+		ts := time.Now()
+		if tx.MinedAt != nil {
+			ts = *tx.MinedAt
+		}
+		edge := ScreeningEdge{From: tx.From, TxHash: tx.Hash, BlockNumber: int64(*tx.BlockNumber), Kind: ScreeningKindTransaction, Timestamp: ts}
+
+		if tx.To != nil && *tx.To != "" {
+			edge.To = *tx.To
+			edges = append(edges, edge)
+		} else if tx.ContractAddress != nil && *tx.ContractAddress != "" {
+			edge.To = *tx.ContractAddress
+			edges = append(edges, edge)
+		}
+
+		// Transfer(address indexed from, address indexed to, ...) de ERC-20 e ERC-721
+		for _, logEntry := range logs[tx.Hash] {
+			if len(logEntry.Topics) < 3 || logEntry.Topics[0].Hex() != erc20TransferTopic || logEntry.Removed {
+				continue
+			}
+			transfer := edge
+			transfer.Kind = ScreeningKindTokenTransfer
+			transfer.From = common.HexToAddress(logEntry.Topics[1].Hex()).Hex()
+			transfer.To = common.HexToAddress(logEntry.Topics[2].Hex()).Hex()
+			edges = append(edges, transfer)
+		}
+	}
+
+	if s.policy.TraceInternal {
+		internal, err := s.traceInternalTransfers(ctx, blockTxs)
+		if err != nil {
+			log.Printf("⚠️ Erro ao buscar transferências internas para triagem: %v", err)
+		}
+		edges = append(edges, internal...)
+	}
+
+	return s.ScreenEdges(ctx, edges)
+}
+
+// callFrame é um frame do callTracer
+type callFrame struct {
+	Type  string       `json:"type"`
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Value *hexutil.Big `json:"value"`
+	Error string       `json:"error"`
+	Calls []callFrame  `json:"calls"`
+}
+
+// traceInternalTransfers busca em batch os traces das chamadas a contratos e retorna as chamadas internas que movem valor
+func (s *ScreeningService) traceInternalTransfers(ctx context.Context, blockTxs []*BlockTransaction) ([]ScreeningEdge, error) {
+	var batch []rpc.BatchElem
+	var traced []*entities.Transaction
+	frames := make([]callFrame, len(blockTxs))
+	for _, bt := range blockTxs {
+		tx := bt.Transaction
+		if tx.BlockNumber == nil || tx.Status == entities.StatusFailed || tx.To == nil || len(tx.Data) == 0 {
+			continue
+		}
+		batch = append(batch, rpc.BatchElem{
+			Method: "debug_traceTransaction",
+			Args:   []interface{}{tx.Hash, map[string]interface{}{"tracer": "callTracer"}},
+			Result: &frames[len(traced)],
+		})
+		traced = append(traced, tx)
+	}
+	if len(batch) == 0 {
+		return nil, nil
+	}
+
+	if err := s.ethClient.Client().BatchCallContext(ctx, batch); err != nil {
+		return nil, fmt.Errorf("erro no batch de debug_traceTransaction: %w", err)
+	}
+
+	var edges []ScreeningEdge
+	for i, tx := range traced {
+		if batch[i].Error != nil {
+			log.Printf("⚠️ Erro ao rastrear transação %s para triagem: %v", tx.Hash, batch[i].Error)
+			continue
+		}
+		ts := time.Now()
+		if tx.MinedAt != nil {
+			ts = *tx.MinedAt
+		}
+		// O frame raiz é a própria transação, já registrada como contato
+		var walk func(calls []callFrame)
+		walk = func(calls []callFrame) {
+			for _, call := range calls {
+				if call.Error != "" {
+					continue // Chamada revertida não move valor
+				}
+				if call.Value != nil && (*big.Int)(call.Value).Sign() > 0 && call.To != "" {
+					edges = append(edges, ScreeningEdge{
+						From:        call.From,
+						To:          call.To,
+						TxHash:      tx.Hash,
+						BlockNumber: int64(*tx.BlockNumber),
+						Kind:        ScreeningKindInternal,
+						Timestamp:   ts,
+					})
+				}
+				walk(call.Calls)
+			}
+		}
+		walk(frames[i].Calls)
+	}
+	return edges, nil
+}
+
+// ScreenEdges grava a exposição gerada pelos contatos e atualiza o status das accounts afetadas.
+// Qualquer contato com um endereço listado é exposição direta (hop 1); a partir daí a exposição segue
+// o fluxo de valor: quem recebe de uma account exposta no hop N fica exposto no hop N+1
+func (s *ScreeningService) ScreenEdges(ctx context.Context, edges []ScreeningEdge) error {
+	if len(edges) == 0 {
+		return nil
+	}
+
+	froms := make([]string, len(edges))
+	tos := make([]string, len(edges))
+	hashes := make([]string, len(edges))
+	blocks := make([]int64, len(edges))
+	kinds := make([]string, len(edges))
+	timestamps := make([]time.Time, len(edges))
+	for i, edge := range edges {
+		froms[i] = strings.ToLower(edge.From)
+		tos[i] = strings.ToLower(edge.To)
+		hashes[i] = edge.TxHash
+		blocks[i] = edge.BlockNumber
+		kinds[i] = edge.Kind
+		timestamps[i] = edge.Timestamp
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação de triagem: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		CREATE TEMP TABLE screening_edges (
+			from_address TEXT, to_address TEXT, tx_hash TEXT, block_number BIGINT, kind TEXT, ts TIMESTAMPTZ
+		) ON COMMIT DROP`); err != nil {
+		return fmt.Errorf("erro ao criar tabela de contatos: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO screening_edges
+		SELECT * FROM UNNEST($1::text[], $2::text[], $3::text[], $4::bigint[], $5::text[], $6::timestamptz[])
+			AS e(from_address, to_address, tx_hash, block_number, kind, ts)
+		WHERE from_address <> to_address
+	`, froms, tos, hashes, blocks, kinds, timestamps); err != nil {
+		return fmt.Errorf("erro ao preparar contatos para triagem: %w", err)
+	}
+
+	affected := make(map[string]bool)
+
+	// Contas listadas que tiveram atividade
+	if err := collectAddresses(ctx, tx, affected, `
+		SELECT DISTINCT se.address
+		FROM screening_edges e
+		JOIN screening_entries se ON se.address IN (e.from_address, e.to_address)
+		JOIN screening_lists l ON l.id = se.list_id AND l.is_active`); err != nil {
+		return fmt.Errorf("erro ao buscar endereços listados: %w", err)
+	}
+
+	// Hop 1: contato em qualquer direção com um endereço listado
+	if err := collectAddresses(ctx, tx, affected, fmt.Sprintf(exposureUpsert, `
+		SELECT p.party AS address, se.list_id, se.address AS listed_address, 1 AS hops, 1.0 AS weight,
+			se.address AS via_address, p.tx_hash, p.block_number, p.kind, p.ts
+		FROM (
+			SELECT from_address AS party, to_address AS counterparty, tx_hash, block_number, kind, ts FROM screening_edges
+			UNION ALL
+			SELECT to_address, from_address, tx_hash, block_number, kind, ts FROM screening_edges
+		) p
+		JOIN screening_entries se ON se.address = p.counterparty
+		JOIN screening_lists l ON l.id = se.list_id AND l.is_active`)); err != nil {
+		return fmt.Errorf("erro ao gravar exposição direta: %w", err)
+	}
+
+	// Hops seguintes: o destinatário herda a exposição do remetente
+	for hops := 1; hops < s.policy.MaxHops; hops++ {
+		if err := collectAddresses(ctx, tx, affected, fmt.Sprintf(exposureUpsert, `
+			SELECT e.to_address AS address, x.list_id, x.listed_address, x.hops + 1 AS hops,
+				x.weight * $2::numeric AS weight, e.from_address AS via_address, e.tx_hash, e.block_number, e.kind, e.ts
+			FROM screening_edges e
+			JOIN account_exposures x ON x.address = e.from_address AND x.hops = $1
+			JOIN screening_lists l ON l.id = x.list_id AND l.is_active`), hops, s.policy.Decay); err != nil {
+			return fmt.Errorf("erro ao propagar exposição no hop %d: %w", hops+1, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar triagem: %w", err)
+	}
+
+	return s.ApplyStatuses(ctx, mapKeys(affected))
+}
+
+// Rescreen aplica as listas importadas, ativadas ou desativadas desde a última execução: a exposição das
+// listas desativadas é removida e a das ativadas é recalculada sobre o histórico. Retorna quantas listas foram processadas
+func (s *ScreeningService) Rescreen(ctx context.Context) (int, error) {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao obter conexão para triagem: %w", err)
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, screeningLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("erro ao obter lock de triagem: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, screeningLockKey)
+
+	type pendingList struct {
+		id       int
+		name     string
+		version  string
+		isActive bool
+	}
+	rows, err := conn.Query(ctx, `
+		SELECT id, name, version, is_active FROM screening_lists
+		WHERE needs_rescreen
+		ORDER BY is_active, id`) // Desativações primeiro: a versão nova de uma lista substitui a anterior
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar listas pendentes: %w", err)
+	}
+	var lists []pendingList
+	for rows.Next() {
+		var list pendingList
+		if err := rows.Scan(&list.id, &list.name, &list.version, &list.isActive); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("erro ao ler lista pendente: %w", err)
+		}
+		lists = append(lists, list)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("erro ao ler listas pendentes: %w", err)
+	}
+
+	var processed int
+	for _, list := range lists {
+		if ctx.Err() != nil {
+			break
+		}
+
+		started := time.Now()
+		affected := make(map[string]bool)
+		if err := s.rescreenList(ctx, conn, list.id, list.isActive, affected); err != nil {
+			return processed, fmt.Errorf("erro ao aplicar lista %s v%s: %w", list.name, list.version, err)
+		}
+		if err := s.ApplyStatuses(ctx, mapKeys(affected)); err != nil {
+			return processed, err
+		}
+
+		action := "aplicada"
+		if !list.isActive {
+			action = "removida"
+		}
+		log.Printf("🚫 Lista %s v%s %s na triagem: %d accounts afetadas em %v", list.name, list.version, action, len(affected), time.Since(started))
+		processed++
+	}
+
+	return processed, nil
+}
+
+// rescreenList remove a exposição de uma lista e, se ela estiver ativa, recalcula a partir do histórico de
+// transações e transferências de tokens. Transferências internas só entram pelo processamento de blocos
+func (s *ScreeningService) rescreenList(ctx context.Context, conn *pgxpool.Conn, listID int, isActive bool, affected map[string]bool) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Endereços listados e accounts expostas antes da retriagem também precisam ter o status revisto
+	if err := collectAddresses(ctx, tx, affected, `
+		DELETE FROM account_exposures WHERE list_id = $1 RETURNING address`, listID); err != nil {
+		return fmt.Errorf("erro ao remover exposição anterior: %w", err)
+	}
+	if err := collectAddresses(ctx, tx, affected, `
+		SELECT address FROM screening_entries WHERE list_id = $1`, listID); err != nil {
+		return fmt.Errorf("erro ao buscar endereços da lista: %w", err)
+	}
+
+	if isActive {
+		tokenFrom, tokenTo := fmt.Sprintf(topicAddress, 1), fmt.Sprintf(topicAddress, 2)
+
+		// Hop 1: todo contato histórico com os endereços listados. from_address e to_address são gravados com
+		// checksum; endereços de listas e exposições, em minúsculas
+		if err := collectAddresses(ctx, tx, affected, fmt.Sprintf(exposureUpsert, `
+			SELECT CASE WHEN LOWER(at.from_address) = se.address THEN LOWER(at.to_address) ELSE LOWER(at.from_address) END AS address,
+				se.list_id, se.address AS listed_address, 1 AS hops, 1.0 AS weight, se.address AS via_address,
+				at.transaction_hash AS tx_hash, at.block_number, '`+ScreeningKindTransaction+`' AS kind, at."timestamp" AS ts
+			FROM screening_entries se
+			JOIN account_transactions at ON at.account_address = se.address
+			WHERE se.list_id = $1 AND at.status = 'success'
+			UNION ALL
+			SELECT CASE WHEN `+tokenFrom+` = se.address THEN `+tokenTo+` ELSE `+tokenFrom+` END,
+				se.list_id, se.address, 1, 1.0, se.address,
+				e.transaction_hash, e.block_number, '`+ScreeningKindTokenTransfer+`', e."timestamp"
+			FROM screening_entries se
+			JOIN events e ON e.topics @> jsonb_build_array('0x000000000000000000000000' || substr(se.address, 3))
+			WHERE se.list_id = $1 AND e.event_signature = $2 AND NOT e.removed
+				AND se.address IN (`+tokenFrom+`, `+tokenTo+`)`), listID, erc20TransferTopic); err != nil {
+			return fmt.Errorf("erro ao calcular exposição direta: %w", err)
+		}
+
+		// Hops seguintes: valor enviado por accounts expostas depois da exposição
+		for hops := 1; hops < s.policy.MaxHops; hops++ {
+			if err := collectAddresses(ctx, tx, affected, fmt.Sprintf(exposureUpsert, `
+				SELECT LOWER(at.to_address) AS address, x.list_id, x.listed_address, x.hops + 1 AS hops,
+					x.weight * $3::numeric AS weight, x.address AS via_address,
+					at.transaction_hash AS tx_hash, at.block_number, '`+ScreeningKindTransaction+`' AS kind, at."timestamp" AS ts
+				FROM account_exposures x
+				JOIN account_transactions at ON at.account_address = x.address AND LOWER(at.from_address) = x.address
+				WHERE x.list_id = $1 AND x.hops = $2 AND at.to_address IS NOT NULL
+					AND at.status = 'success' AND at."timestamp" >= x.first_seen_at
+				UNION ALL
+				SELECT `+tokenTo+`, x.list_id, x.listed_address, x.hops + 1,
+					x.weight * $3::numeric, x.address,
+					e.transaction_hash, e.block_number, '`+ScreeningKindTokenTransfer+`', e."timestamp"
+				FROM account_exposures x
+				JOIN events e ON e.topics @> jsonb_build_array('0x000000000000000000000000' || substr(x.address, 3))
+				WHERE x.list_id = $1 AND x.hops = $2 AND e.event_signature = $4 AND NOT e.removed
+					AND `+tokenFrom+` = x.address AND e."timestamp" >= x.first_seen_at`),
+				listID, hops, s.policy.Decay, erc20TransferTopic); err != nil {
+				return fmt.Errorf("erro ao propagar exposição no hop %d: %w", hops+1, err)
+			}
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE screening_lists SET needs_rescreen = FALSE, screened_at = NOW() WHERE id = $1
+	`, listID); err != nil {
+		return fmt.Errorf("erro ao marcar lista como triada: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// MarkAllForRescreen agenda a retriagem de todas as listas ativas (ex.: depois de um backfill, que não
+// passa pelo processamento por bloco)
+func (s *ScreeningService) MarkAllForRescreen(ctx context.Context) (int64, error) {
+	tag, err := s.db.Exec(ctx, `UPDATE screening_lists SET needs_rescreen = TRUE WHERE is_active`)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao agendar retriagem: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ApplyStatuses atualiza o status de compliance das accounts pela maior exposição nas listas ativas.
+// Status definidos manualmente não são alterados; accounts que deixam de estar expostas voltam para a
+// política de risco, que as reavalia
+func (s *ScreeningService) ApplyStatuses(ctx context.Context, addresses []string) error {
+	for start := 0; start < len(addresses); start += 1000 {
+		end := start + 1000
+		if end > len(addresses) {
+			end = len(addresses)
+		}
+		if err := s.applyStatuses(ctx, addresses[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyStatuses aplica o status de um lote de accounts e dispara os alertas de compliance
+func (s *ScreeningService) applyStatuses(ctx context.Context, addresses []string) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	rows, err := s.db.Query(ctx, `
+		WITH target AS (
+			SELECT a.address, top.reason,
+				CASE WHEN top.weight >= $2 THEN 'flagged' WHEN top.weight >= $3 THEN 'under_review' END AS status
+			FROM UNNEST($1::text[]) AS a(address)
+			CROSS JOIN LATERAL (
+				SELECT weight, reason FROM (
+					SELECT 1.0 AS weight, 0 AS hops,
+						format('Triagem: endereço listado em %s v%s%s', l.name, l.version, ' (' || se.label || ')') AS reason
+					FROM screening_entries se
+					JOIN screening_lists l ON l.id = se.list_id AND l.is_active
+					WHERE se.address = a.address
+					UNION ALL
+					SELECT x.weight, x.hops,
+						format('Triagem: exposição a %s (%s v%s) em %s hop(s), peso %s, via %s na transação %s',
+							x.listed_address, l.name, l.version, x.hops, trim_scale(x.weight), x.via_address, x.tx_hash)
+					FROM account_exposures x
+					JOIN screening_lists l ON l.id = x.list_id AND l.is_active
+					WHERE x.address = a.address
+				) candidates
+				ORDER BY weight DESC, hops
+				LIMIT 1
+			) top
+		)
+		UPDATE accounts a SET
+			compliance_status = CASE
+				WHEN prev.compliance_source = 'screening'
+					OR array_position($4::text[], t.status) > array_position($4::text[], prev.compliance_status)
+				THEN t.status ELSE prev.compliance_status END,
+			compliance_notes = t.reason,
+			compliance_source = 'screening',
+			updated_at = NOW()
+		FROM target t, accounts prev
+		WHERE t.status IS NOT NULL AND a.address = t.address AND prev.address = a.address
+			AND prev.compliance_source NOT IN ('manual', 'case')
+			AND (prev.compliance_source <> 'screening' OR prev.compliance_status <> t.status
+				OR prev.compliance_notes IS DISTINCT FROM t.reason)
+		RETURNING a.address, prev.compliance_status, a.compliance_status, a.compliance_notes
+	`, addresses, s.policy.FlagWeight, s.policy.ReviewWeight, []string{
+		string(entities.ComplianceStatusCompliant),
+		string(entities.ComplianceStatusUnderReview),
+		string(entities.ComplianceStatusFlagged),
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar status de compliance pela triagem: %w", err)
+	}
+
+	type change struct {
+		address, previous, status string
+		notes                     *string
+	}
+	var changes []change
+	for rows.Next() {
+		var c change
+		if err := rows.Scan(&c.address, &c.previous, &c.status, &c.notes); err != nil {
+			rows.Close()
+			return fmt.Errorf("erro ao ler status atualizado pela triagem: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao ler status atualizados pela triagem: %w", err)
+	}
+
+	// Accounts sem exposição relevante voltam para a política de risco
+	if _, err := s.db.Exec(ctx, `
+		WITH released AS (
+			UPDATE accounts a SET
+				compliance_source = 'policy',
+				compliance_notes = 'Triagem: sem exposição relevante às listas ativas',
+				updated_at = NOW()
+			WHERE a.address = ANY($1) AND a.compliance_source = 'screening'
+				AND NOT EXISTS (
+					SELECT 1 FROM screening_entries se
+					JOIN screening_lists l ON l.id = se.list_id AND l.is_active
+					WHERE se.address = a.address
+				)
+				AND NOT EXISTS (
+					SELECT 1 FROM account_exposures x
+					JOIN screening_lists l ON l.id = x.list_id AND l.is_active
+					WHERE x.address = a.address AND x.weight >= $2
+				)
+			RETURNING a.address
+		)
+		INSERT INTO account_risk_pending (address)
+		SELECT address FROM released
+		ON CONFLICT (address) DO NOTHING
+	`, addresses, s.policy.ReviewWeight); err != nil {
+		return fmt.Errorf("erro ao devolver accounts à política de risco: %w", err)
+	}
+
+	for _, c := range changes {
+		if c.previous == c.status {
+			continue
+		}
+		log.Printf("🚫 Account %s: %s -> %s pela triagem", c.address, c.previous, c.status)
+		if s.alertService != nil {
+			s.alertService.EvaluateComplianceChange(ctx, &entities.AccountComplianceUpdateMessage{
+				Address:          c.address,
+				ComplianceStatus: c.status,
+				ComplianceNotes:  c.notes,
+				Source:           "screening",
+				Timestamp:        time.Now(),
+			}, entities.ComplianceStatus(c.previous), entities.ComplianceStatus(c.status))
+		}
+		if s.caseService != nil {
+			s.caseService.HandleStatusChange(ctx, entities.ComplianceCaseOriginScreening, nil, c.address,
+				entities.ComplianceStatus(c.previous), entities.ComplianceStatus(c.status), c.notes)
+		}
+	}
+
+	return nil
+}
+
+// collectAddresses executa uma consulta que retorna endereços e os adiciona ao conjunto
+func collectAddresses(ctx context.Context, q interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}, into map[string]bool, query string, args ...interface{}) error {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return err
+		}
+		into[address] = true
+	}
+	return rows.Err()
+}
+
+// mapKeys retorna as chaves de um conjunto de endereços
+func mapKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	return keys
+}
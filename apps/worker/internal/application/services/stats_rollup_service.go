@@ -0,0 +1,454 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// statsRollupLockKey é a chave do advisory lock que serializa as atualizações dos rollups de estatísticas
+const statsRollupLockKey = 7424003
+
+// rollupPeriods gera uma linha por período mantido em network_stats
+const rollupPeriods = `(VALUES ('hour'), ('day')) AS p(period)`
+
+// StatsRollupPolicy define quanto o processamento incremental aguarda atrás do head e o tamanho dos lotes
+type StatsRollupPolicy struct {
+	LagBlocks   uint64 // Blocos abaixo do head ainda não incorporados (blocos atrasados recalculam o dia depois)
+	BatchBlocks uint64 // Blocos incorporados por transação
+}
+
+// StatsRollupService mantém os rollups por hora e por dia de network_stats
+type StatsRollupService struct {
+	db     *pgxpool.Pool
+	policy StatsRollupPolicy
+}
+
+// NewStatsRollupService cria uma nova instância do serviço de rollups de estatísticas
+func NewStatsRollupService(db *pgxpool.Pool, policy StatsRollupPolicy) *StatsRollupService {
+	if policy.BatchBlocks == 0 {
+		policy.BatchBlocks = 1000
+	}
+	return &StatsRollupService{
+		db:     db,
+		policy: policy,
+	}
+}
+
+// Update incorpora aos rollups os blocos entre o último processado e head - LagBlocks, em lotes, e
+// recalcula os dias com blocos alterados depois de incorporados. Retorna a quantidade de blocos incorporados
+func (s *StatsRollupService) Update(ctx context.Context) (int64, error) {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao obter conexão para rollups de estatísticas: %w", err)
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, statsRollupLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("erro ao obter lock de rollups de estatísticas: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, statsRollupLockKey)
+
+	cursor, ok, err := s.loadCursor(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	var first, head *int64
+	if err := conn.QueryRow(ctx, `SELECT MIN(number), MAX(number) FROM blocks WHERE deleted_at IS NULL`).Scan(&first, &head); err != nil {
+		return 0, fmt.Errorf("erro ao buscar faixa de blocos: %w", err)
+	}
+	if head == nil {
+		return 0, nil
+	}
+	if !ok {
+		// Primeira execução: começa no menor bloco indexado; faixas anteriores entram via Rebuild
+		cursor = *first - 1
+	}
+
+	target := *head - int64(s.policy.LagBlocks)
+	var processed int64
+	for cursor < target && ctx.Err() == nil {
+		to := cursor + int64(s.policy.BatchBlocks)
+		if to > target {
+			to = target
+		}
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return processed, fmt.Errorf("erro ao iniciar transação: %w", err)
+		}
+		if err := s.applyRange(ctx, tx, cursor+1, to); err != nil {
+			tx.Rollback(ctx)
+			return processed, fmt.Errorf("erro ao incorporar blocos %d-%d: %w", cursor+1, to, err)
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO network_stats_state (id, last_block, updated_at) VALUES (1, $1, NOW())
+			ON CONFLICT (id) DO UPDATE SET last_block = EXCLUDED.last_block, updated_at = NOW()
+		`, to); err != nil {
+			tx.Rollback(ctx)
+			return processed, fmt.Errorf("erro ao salvar cursor dos rollups: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return processed, fmt.Errorf("erro ao confirmar rollups: %w", err)
+		}
+
+		processed += to - cursor
+		cursor = to
+	}
+
+	if processed > 0 {
+		// Endereços de períodos encerrados não recebem mais blocos do processamento incremental
+		if _, err := conn.Exec(ctx, `
+			WITH last AS (
+				SELECT "timestamp" AS ts FROM blocks
+				WHERE number <= $1 AND deleted_at IS NULL
+				ORDER BY number DESC LIMIT 1
+			)
+			DELETE FROM network_stats_addresses a USING last
+			WHERE a.bucket < date_trunc(a.period, last.ts, 'UTC')
+		`, cursor); err != nil {
+			log.Printf("⚠️ Erro ao limpar endereços de períodos encerrados: %v", err)
+		}
+	}
+
+	if err := s.rebuildDirtyDays(ctx, conn, cursor); err != nil {
+		return processed, err
+	}
+
+	return processed, nil
+}
+
+// rebuildDirtyDays recalcula os dias dos blocos marcados em network_stats_dirty_blocks (migration 0007): blocos
+// abaixo do cursor cujos blocos, transações ou eventos foram gravados ou alterados depois de incorporados
+func (s *StatsRollupService) rebuildDirtyDays(ctx context.Context, conn *pgxpool.Conn, cursor int64) error {
+	// Marcas de blocos ainda não gravados (transação de uma fila de compatibilidade que chegou antes do
+	// bloco) são descartadas: o bloco marca o seu número ao ser gravado
+	if _, err := conn.Exec(ctx, `
+		DELETE FROM network_stats_dirty_blocks d
+		WHERE NOT EXISTS (SELECT 1 FROM blocks b WHERE b.number = d.block_number)
+	`); err != nil {
+		return fmt.Errorf("erro ao limpar marcas de blocos inexistentes: %w", err)
+	}
+
+	// Inclui blocos removidos (deleted_at): as suas métricas já incorporadas precisam sair do dia
+	rows, err := conn.Query(ctx, `
+		SELECT DISTINCT date_trunc('day', b."timestamp", 'UTC')
+		FROM network_stats_dirty_blocks d
+		JOIN blocks b ON b.number = d.block_number
+		ORDER BY 1
+	`)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar dias com blocos alterados: %w", err)
+	}
+	var days []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			rows.Close()
+			return fmt.Errorf("erro ao ler dia com blocos alterados: %w", err)
+		}
+		days = append(days, day)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao buscar dias com blocos alterados: %w", err)
+	}
+
+	for _, day := range days {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.rebuildDay(ctx, conn, day.UTC(), cursor); err != nil {
+			return fmt.Errorf("erro ao recalcular rollups de %s: %w", day.Format("2006-01-02"), err)
+		}
+		log.Printf("📊 Rollups de estatísticas de %s recalculados (blocos alterados depois de incorporados)", day.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// Rebuild recalcula os rollups dos dias (UTC) que contêm os blocos de from a to, a partir das tabelas
+// de blocos, transações e eventos. Usado depois de backfills, que gravam blocos abaixo do cursor incremental
+func (s *StatsRollupService) Rebuild(ctx context.Context, from, to int64) error {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao obter conexão para rollups de estatísticas: %w", err)
+	}
+	defer conn.Release()
+
+	// Aguarda o processamento incremental em andamento
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, statsRollupLockKey); err != nil {
+		return fmt.Errorf("erro ao obter lock de rollups de estatísticas: %w", err)
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, statsRollupLockKey)
+
+	cursor, ok, err := s.loadCursor(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Println("ℹ️ Rollups de estatísticas ainda não iniciados; o processamento incremental incluirá os blocos")
+		return nil
+	}
+
+	// Blocos acima do cursor ainda serão incorporados pelo processamento incremental
+	if to > cursor {
+		to = cursor
+	}
+	if from > to {
+		return nil
+	}
+
+	var firstDay, lastDay *time.Time
+	if err := conn.QueryRow(ctx, `
+		SELECT date_trunc('day', MIN("timestamp"), 'UTC'), date_trunc('day', MAX("timestamp"), 'UTC')
+		FROM blocks
+		WHERE number BETWEEN $1 AND $2 AND deleted_at IS NULL
+	`, from, to).Scan(&firstDay, &lastDay); err != nil {
+		return fmt.Errorf("erro ao buscar dias da faixa %d-%d: %w", from, to, err)
+	}
+	if firstDay == nil {
+		return nil
+	}
+
+	for day := firstDay.UTC(); !day.After(lastDay.UTC()); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.rebuildDay(ctx, conn, day, cursor); err != nil {
+			return fmt.Errorf("erro ao recalcular rollups de %s: %w", day.Format("2006-01-02"), err)
+		}
+		log.Printf("📊 Rollups de estatísticas de %s recalculados", day.Format("2006-01-02"))
+	}
+
+	return nil
+}
+
+// rebuildDay apaga os rollups de um dia e incorpora novamente seus blocos (até o cursor) em uma transação.
+// As marcas de blocos alterados do dia são removidas primeiro, na mesma transação: gravações confirmadas
+// depois da remoção marcam o bloco de novo e o dia é recalculado na próxima atualização
+func (s *StatsRollupService) rebuildDay(ctx context.Context, conn *pgxpool.Conn, day time.Time, cursor int64) error {
+	next := day.AddDate(0, 0, 1)
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, query := range []string{
+		`DELETE FROM network_stats_dirty_blocks d USING blocks b
+		 WHERE b.number = d.block_number AND b."timestamp" >= $1 AND b."timestamp" < $2`,
+		`DELETE FROM network_stats WHERE bucket >= $1 AND bucket < $2`,
+		`DELETE FROM network_stats_addresses WHERE bucket >= $1 AND bucket < $2`,
+		`DELETE FROM network_address_first_seen WHERE first_seen_at >= $1 AND first_seen_at < $2`,
+	} {
+		if _, err := tx.Exec(ctx, query, day, next); err != nil {
+			return fmt.Errorf("erro ao limpar rollups do dia: %w", err)
+		}
+	}
+
+	var first, last *int64
+	if err := tx.QueryRow(ctx, `
+		SELECT MIN(number), MAX(number) FROM blocks
+		WHERE "timestamp" >= $1 AND "timestamp" < $2 AND number <= $3 AND deleted_at IS NULL
+	`, day, next, cursor).Scan(&first, &last); err != nil {
+		return fmt.Errorf("erro ao buscar blocos do dia: %w", err)
+	}
+
+	if first != nil {
+		for start := *first; start <= *last; start += int64(s.policy.BatchBlocks) {
+			end := start + int64(s.policy.BatchBlocks) - 1
+			if end > *last {
+				end = *last
+			}
+			if err := s.applyRange(ctx, tx, start, end); err != nil {
+				return fmt.Errorf("erro ao incorporar blocos %d-%d: %w", start, end, err)
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// loadCursor lê o último bloco incorporado; ok é false antes da primeira execução
+func (s *StatsRollupService) loadCursor(ctx context.Context, conn *pgxpool.Conn) (cursor int64, ok bool, err error) {
+	err = conn.QueryRow(ctx, `SELECT last_block FROM network_stats_state WHERE id = 1`).Scan(&cursor)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("erro ao ler cursor dos rollups: %w", err)
+	}
+	return cursor, true, nil
+}
+
+// applyRange soma aos rollups a contribuição dos blocos de from a to. Os dados da faixa são
+// copiados para tabelas temporárias e agregados por período com date_trunc em UTC
+func (s *StatsRollupService) applyRange(ctx context.Context, tx pgx.Tx, from, to int64) error {
+	steps := []struct {
+		name  string
+		query string
+		args  []interface{}
+	}{
+		{"limpar tabelas temporárias", `DROP TABLE IF EXISTS stats_blocks, stats_txs, stats_addresses`, nil},
+		{"criar stats_blocks", `
+			CREATE TEMP TABLE stats_blocks (
+				number BIGINT PRIMARY KEY,
+				ts TIMESTAMPTZ NOT NULL,
+				gas_used BIGINT NOT NULL,
+				gas_limit BIGINT NOT NULL,
+				block_time DOUBLE PRECISION
+			) ON COMMIT DROP`, nil},
+		{"carregar blocos", `
+			INSERT INTO stats_blocks (number, ts, gas_used, gas_limit, block_time)
+			SELECT DISTINCT ON (b.number)
+				b.number, b."timestamp", b.gas_used, b.gas_limit,
+				EXTRACT(EPOCH FROM b."timestamp" - parent."timestamp")
+			FROM blocks b
+			LEFT JOIN LATERAL (
+				SELECT "timestamp" FROM blocks
+				WHERE number = b.number - 1 AND deleted_at IS NULL
+				ORDER BY updated_at DESC LIMIT 1
+			) parent ON TRUE
+			WHERE b.number BETWEEN $1 AND $2 AND b.deleted_at IS NULL
+			ORDER BY b.number, b.updated_at DESC`, []interface{}{from, to}},
+		{"criar stats_txs", `
+			CREATE TEMP TABLE stats_txs (
+				block_number BIGINT NOT NULL,
+				ts TIMESTAMPTZ NOT NULL,
+				from_address VARCHAR(42) NOT NULL,
+				to_address VARCHAR(42),
+				status VARCHAR(20) NOT NULL,
+				contract_address VARCHAR(42)
+			) ON COMMIT DROP`, nil},
+		{"carregar transações", `
+			INSERT INTO stats_txs (block_number, ts, from_address, to_address, status, contract_address)
+			SELECT t.block_number, sb.ts, LOWER(t.from_address), LOWER(t.to_address), t.status, t.contract_address
+			FROM transactions t
+			JOIN stats_blocks sb ON sb.number = t.block_number
+			WHERE t.block_number BETWEEN $1 AND $2`, []interface{}{from, to}},
+		{"criar stats_addresses", `
+			CREATE TEMP TABLE stats_addresses ON COMMIT DROP AS
+			SELECT from_address AS address, block_number, ts FROM stats_txs
+			UNION ALL
+			SELECT to_address, block_number, ts FROM stats_txs WHERE to_address IS NOT NULL`, nil},
+		{"somar métricas de blocos", `
+			INSERT INTO network_stats (period, bucket, block_count, gas_used, gas_limit, block_time_sum, block_time_count, first_block, last_block)
+			SELECT p.period, date_trunc(p.period, sb.ts, 'UTC'),
+				COUNT(*), SUM(sb.gas_used), SUM(sb.gas_limit),
+				COALESCE(SUM(sb.block_time), 0), COUNT(sb.block_time),
+				MIN(sb.number), MAX(sb.number)
+			FROM stats_blocks sb CROSS JOIN ` + rollupPeriods + `
+			GROUP BY 1, 2
+			ON CONFLICT (period, bucket) DO UPDATE SET
+				` + rollupIncrements("block_count", "gas_used", "gas_limit", "block_time_sum", "block_time_count") + `,
+				first_block = LEAST(network_stats.first_block, EXCLUDED.first_block),
+				last_block = GREATEST(network_stats.last_block, EXCLUDED.last_block),
+				updated_at = NOW()`, nil},
+		{"somar métricas de transações", `
+			INSERT INTO network_stats (period, bucket, tx_count, failed_tx_count, contracts_deployed)
+			SELECT p.period, date_trunc(p.period, st.ts, 'UTC'),
+				COUNT(*),
+				COUNT(*) FILTER (WHERE st.status = 'failed'),
+				COUNT(*) FILTER (WHERE st.contract_address IS NOT NULL)
+			FROM stats_txs st CROSS JOIN ` + rollupPeriods + `
+			GROUP BY 1, 2
+			ON CONFLICT (period, bucket) DO UPDATE SET
+				` + rollupIncrements("tx_count", "failed_tx_count", "contracts_deployed") + `,
+				updated_at = NOW()`, nil},
+		{"contar transferências de tokens", `
+			INSERT INTO network_stats (period, bucket, token_transfers)
+			SELECT p.period, date_trunc(p.period, sb.ts, 'UTC'), COUNT(*)
+			FROM events e
+			JOIN stats_blocks sb ON sb.number = e.block_number
+			CROSS JOIN ` + rollupPeriods + `
+			WHERE e.block_number BETWEEN $1 AND $2 AND e.event_signature = $3 AND NOT e.removed
+			GROUP BY 1, 2
+			ON CONFLICT (period, bucket) DO UPDATE SET
+				` + rollupIncrements("token_transfers") + `,
+				updated_at = NOW()`, []interface{}{from, to, erc20TransferTopic}},
+		{"contar endereços ativos", `
+			WITH inserted AS (
+				INSERT INTO network_stats_addresses (period, bucket, address)
+				SELECT DISTINCT p.period, date_trunc(p.period, a.ts, 'UTC'), a.address
+				FROM stats_addresses a CROSS JOIN ` + rollupPeriods + `
+				ON CONFLICT DO NOTHING
+				RETURNING period, bucket
+			)
+			INSERT INTO network_stats (period, bucket, active_addresses)
+			SELECT period, bucket, COUNT(*) FROM inserted GROUP BY period, bucket
+			ON CONFLICT (period, bucket) DO UPDATE SET
+				` + rollupIncrements("active_addresses") + `,
+				updated_at = NOW()`, nil},
+		// Endereços que já tinham primeira aparição registrada em um bloco posterior (ex.: faixa de
+		// backfill) mudam de período: sai um do período antigo e entra um no novo
+		{"mover endereços antecipados", `
+			WITH seen AS (
+				SELECT address, MIN(block_number) AS first_block, MIN(ts) AS first_seen_at
+				FROM stats_addresses GROUP BY address
+			),
+			moved AS (
+				UPDATE network_address_first_seen f
+				SET first_block = s.first_block, first_seen_at = s.first_seen_at
+				FROM seen s, network_address_first_seen prev
+				WHERE f.address = s.address AND prev.address = s.address AND s.first_block < prev.first_block
+				RETURNING prev.first_seen_at AS old_at, f.first_seen_at AS new_at
+			),
+			changes AS (
+				SELECT old_at AS at, -1 AS delta FROM moved
+				UNION ALL
+				SELECT new_at, 1 FROM moved
+			)
+			INSERT INTO network_stats (period, bucket, new_addresses)
+			SELECT p.period, date_trunc(p.period, c.at, 'UTC'), SUM(c.delta)
+			FROM changes c CROSS JOIN ` + rollupPeriods + `
+			GROUP BY 1, 2
+			ON CONFLICT (period, bucket) DO UPDATE SET
+				` + rollupIncrements("new_addresses") + `,
+				updated_at = NOW()`, nil},
+		{"contar endereços novos", `
+			WITH seen AS (
+				SELECT address, MIN(block_number) AS first_block, MIN(ts) AS first_seen_at
+				FROM stats_addresses GROUP BY address
+			),
+			inserted AS (
+				INSERT INTO network_address_first_seen (address, first_block, first_seen_at)
+				SELECT address, first_block, first_seen_at FROM seen
+				ON CONFLICT (address) DO NOTHING
+				RETURNING first_seen_at
+			)
+			INSERT INTO network_stats (period, bucket, new_addresses)
+			SELECT p.period, date_trunc(p.period, i.first_seen_at, 'UTC'), COUNT(*)
+			FROM inserted i CROSS JOIN ` + rollupPeriods + `
+			GROUP BY 1, 2
+			ON CONFLICT (period, bucket) DO UPDATE SET
+				` + rollupIncrements("new_addresses") + `,
+				updated_at = NOW()`, nil},
+	}
+
+	for _, step := range steps {
+		if _, err := tx.Exec(ctx, step.query, step.args...); err != nil {
+			return fmt.Errorf("erro ao %s: %w", step.name, err)
+		}
+	}
+	return nil
+}
+
+// rollupIncrements monta o SET do upsert que soma os valores novos aos já acumulados
+func rollupIncrements(columns ...string) string {
+	sets := make([]string, len(columns))
+	for i, column := range columns {
+		sets[i] = fmt.Sprintf("%s = network_stats.%s + EXCLUDED.%s", column, column, column)
+	}
+	return strings.Join(sets, ",\n\t\t\t\t")
+}
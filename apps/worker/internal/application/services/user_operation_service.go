@@ -0,0 +1,495 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Eventos emitidos pela EntryPoint (iguais nas versões v0.6 e v0.7)
+const entryPointEventsABI = `[
+	{"type":"event","name":"UserOperationEvent","anonymous":false,"inputs":[
+		{"name":"userOpHash","type":"bytes32","indexed":true},
+		{"name":"sender","type":"address","indexed":true},
+		{"name":"paymaster","type":"address","indexed":true},
+		{"name":"nonce","type":"uint256","indexed":false},
+		{"name":"success","type":"bool","indexed":false},
+		{"name":"actualGasCost","type":"uint256","indexed":false},
+		{"name":"actualGasUsed","type":"uint256","indexed":false}]},
+	{"type":"event","name":"AccountDeployed","anonymous":false,"inputs":[
+		{"name":"userOpHash","type":"bytes32","indexed":true},
+		{"name":"sender","type":"address","indexed":true},
+		{"name":"factory","type":"address","indexed":false},
+		{"name":"paymaster","type":"address","indexed":false}]},
+	{"type":"event","name":"UserOperationRevertReason","anonymous":false,"inputs":[
+		{"name":"userOpHash","type":"bytes32","indexed":true},
+		{"name":"sender","type":"address","indexed":true},
+		{"name":"nonce","type":"uint256","indexed":false},
+		{"name":"revertReason","type":"bytes","indexed":false}]}
+]`
+
+// handleOps e handleAggregatedOps da EntryPoint v0.6 (UserOperation com os campos de gas separados)
+const entryPointV06ABI = `[
+	{"type":"function","name":"handleOps","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"ops","type":"tuple[]","components":[
+			{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},
+			{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},
+			{"name":"callGasLimit","type":"uint256"},{"name":"verificationGasLimit","type":"uint256"},
+			{"name":"preVerificationGas","type":"uint256"},{"name":"maxFeePerGas","type":"uint256"},
+			{"name":"maxPriorityFeePerGas","type":"uint256"},{"name":"paymasterAndData","type":"bytes"},
+			{"name":"signature","type":"bytes"}]},
+		{"name":"beneficiary","type":"address"}]},
+	{"type":"function","name":"handleAggregatedOps","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"opsPerAggregator","type":"tuple[]","components":[
+			{"name":"userOps","type":"tuple[]","components":[
+				{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},
+				{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},
+				{"name":"callGasLimit","type":"uint256"},{"name":"verificationGasLimit","type":"uint256"},
+				{"name":"preVerificationGas","type":"uint256"},{"name":"maxFeePerGas","type":"uint256"},
+				{"name":"maxPriorityFeePerGas","type":"uint256"},{"name":"paymasterAndData","type":"bytes"},
+				{"name":"signature","type":"bytes"}]},
+			{"name":"aggregator","type":"address"},{"name":"signature","type":"bytes"}]},
+		{"name":"beneficiary","type":"address"}]}
+]`
+
+// handleOps e handleAggregatedOps da EntryPoint v0.7 (PackedUserOperation: limites e taxas em bytes32)
+const entryPointV07ABI = `[
+	{"type":"function","name":"handleOps","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"ops","type":"tuple[]","components":[
+			{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},
+			{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},
+			{"name":"accountGasLimits","type":"bytes32"},{"name":"preVerificationGas","type":"uint256"},
+			{"name":"gasFees","type":"bytes32"},{"name":"paymasterAndData","type":"bytes"},
+			{"name":"signature","type":"bytes"}]},
+		{"name":"beneficiary","type":"address"}]},
+	{"type":"function","name":"handleAggregatedOps","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"opsPerAggregator","type":"tuple[]","components":[
+			{"name":"userOps","type":"tuple[]","components":[
+				{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},
+				{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},
+				{"name":"accountGasLimits","type":"bytes32"},{"name":"preVerificationGas","type":"uint256"},
+				{"name":"gasFees","type":"bytes32"},{"name":"paymasterAndData","type":"bytes"},
+				{"name":"signature","type":"bytes"}]},
+			{"name":"aggregator","type":"address"},{"name":"signature","type":"bytes"}]},
+		{"name":"beneficiary","type":"address"}]}
+]`
+
+var (
+	entryPointEvents = mustParseABI(entryPointEventsABI)
+	entryPointV06    = mustParseABI(entryPointV06ABI)
+	entryPointV07    = mustParseABI(entryPointV07ABI)
+)
+
+// mustParseABI interpreta um ABI fixo do pacote
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("ABI inválido: %v", err))
+	}
+	return parsed
+}
+
+// userOperationV06 é a UserOperation da EntryPoint v0.6
+type userOperationV06 struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+// packedUserOperation é a UserOperation da EntryPoint v0.7
+type packedUserOperation struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte // verificationGasLimit (16 bytes altos) | callGasLimit (16 bytes baixos)
+	PreVerificationGas *big.Int
+	GasFees            [32]byte // maxPriorityFeePerGas (16 bytes altos) | maxFeePerGas (16 bytes baixos)
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+// UserOperation é uma UserOperation ERC-4337 executada por uma EntryPoint
+type UserOperation struct {
+	Hash                 string
+	EntryPoint           string
+	Version              *string
+	TxHash               string
+	BlockNumber          uint64
+	LogIndex             uint
+	MinedAt              time.Time
+	Sender               string
+	Paymaster            *string
+	Bundler              string
+	Beneficiary          *string
+	Factory              *string
+	Nonce                *big.Int
+	Success              bool
+	ActualGasCost        *big.Int
+	ActualGasUsed        *big.Int
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	CallSelector         *string
+	RevertReason         *string
+}
+
+// userOperationCall são os parâmetros de uma UserOperation lidos da calldata de handleOps
+type userOperationCall struct {
+	version              string
+	beneficiary          string
+	callData             []byte
+	callGasLimit         *big.Int
+	verificationGasLimit *big.Int
+	preVerificationGas   *big.Int
+	maxFeePerGas         *big.Int
+	maxPriorityFeePerGas *big.Int
+}
+
+// UserOperationService indexa as UserOperations ERC-4337 das EntryPoints configuradas e marca os
+// senders como smart accounts
+type UserOperationService struct {
+	db          *pgxpool.Pool
+	entryPoints map[string]bool
+}
+
+// NewUserOperationService cria uma nova instância do serviço de UserOperations
+func NewUserOperationService(db *pgxpool.Pool, entryPoints []string) *UserOperationService {
+	configured := make(map[string]bool, len(entryPoints))
+	for _, address := range entryPoints {
+		if common.IsHexAddress(address) {
+			configured[strings.ToLower(address)] = true
+		} else {
+			log.Printf("⚠️ Endereço de EntryPoint inválido ignorado: %s", address)
+		}
+	}
+	return &UserOperationService{
+		db:          db,
+		entryPoints: configured,
+	}
+}
+
+// IndexBlock grava as UserOperations dos logs UserOperationEvent emitidos pelas EntryPoints no bloco,
+// completando-as com os limites de gas e taxas da calldata de handleOps quando a transação chama a
+// EntryPoint diretamente
+func (s *UserOperationService) IndexBlock(ctx context.Context, blockTxs []*BlockTransaction, logs map[string][]*types.Log) error {
+	if len(s.entryPoints) == 0 {
+		return nil
+	}
+
+	var ops []*UserOperation
+	for _, bt := range blockTxs {
+		tx := bt.Transaction
+		if tx.BlockNumber == nil || tx.Status == entities.StatusFailed {
+			continue
+		}
+		ops = append(ops, s.decodeTransaction(tx, logs[tx.Hash])...)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	if err := s.saveUserOperations(ctx, ops); err != nil {
+		return err
+	}
+
+	log.Printf("🧾 %d UserOperations indexadas no bloco %d", len(ops), ops[0].BlockNumber)
+	return nil
+}
+
+// decodeTransaction extrai as UserOperations de uma transação a partir dos logs das EntryPoints
+func (s *UserOperationService) decodeTransaction(tx *entities.Transaction, txLogs []*types.Log) []*UserOperation {
+	var ops []*UserOperation
+	deployed := make(map[string]string)
+	reverts := make(map[string]string)
+
+	for _, logEntry := range txLogs {
+		if logEntry.Removed || len(logEntry.Topics) < 3 || !s.entryPoints[strings.ToLower(logEntry.Address.Hex())] {
+			continue
+		}
+		hash := logEntry.Topics[1].Hex()
+
+		switch logEntry.Topics[0] {
+		case entryPointEvents.Events["UserOperationEvent"].ID:
+			if len(logEntry.Topics) < 4 {
+				continue
+			}
+			values, err := entryPointEvents.Unpack("UserOperationEvent", logEntry.Data)
+			if err != nil || len(values) != 4 {
+				log.Printf("⚠️ UserOperationEvent inválido na transação %s: %v", tx.Hash, err)
+				continue
+			}
+			op := &UserOperation{
+				Hash:          hash,
+				EntryPoint:    strings.ToLower(logEntry.Address.Hex()),
+				TxHash:        tx.Hash,
+				BlockNumber:   *tx.BlockNumber,
+				LogIndex:      logEntry.Index,
+				MinedAt:       transactionTime(tx),
+				Sender:        topicToAddress(logEntry.Topics[2]),
+				Bundler:       strings.ToLower(tx.From),
+				Nonce:         values[0].(*big.Int),
+				Success:       values[1].(bool),
+				ActualGasCost: values[2].(*big.Int),
+				ActualGasUsed: values[3].(*big.Int),
+			}
+			if paymaster := topicToAddress(logEntry.Topics[3]); paymaster != zeroAddress {
+				op.Paymaster = &paymaster
+			}
+			ops = append(ops, op)
+
+		case entryPointEvents.Events["AccountDeployed"].ID:
+			values, err := entryPointEvents.Unpack("AccountDeployed", logEntry.Data)
+			if err != nil || len(values) != 2 {
+				continue
+			}
+			deployed[hash] = strings.ToLower(values[0].(common.Address).Hex())
+
+		case entryPointEvents.Events["UserOperationRevertReason"].ID:
+			values, err := entryPointEvents.Unpack("UserOperationRevertReason", logEntry.Data)
+			if err != nil || len(values) != 2 {
+				continue
+			}
+			reverts[hash] = hexutil.Encode(values[1].([]byte))
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	var calls map[string]*userOperationCall
+	if tx.To != nil && s.entryPoints[strings.ToLower(*tx.To)] {
+		calls = decodeHandleOps(tx.Data)
+	}
+
+	for _, op := range ops {
+		if factory, ok := deployed[op.Hash]; ok {
+			op.Factory = &factory
+		}
+		if reason, ok := reverts[op.Hash]; ok {
+			op.RevertReason = &reason
+		}
+		call, ok := calls[userOperationKey(op.Sender, op.Nonce)]
+		if !ok {
+			continue
+		}
+		op.Version = &call.version
+		op.Beneficiary = &call.beneficiary
+		op.CallGasLimit = call.callGasLimit
+		op.VerificationGasLimit = call.verificationGasLimit
+		op.PreVerificationGas = call.preVerificationGas
+		op.MaxFeePerGas = call.maxFeePerGas
+		op.MaxPriorityFeePerGas = call.maxPriorityFeePerGas
+		if len(call.callData) >= 4 {
+			selector := hexutil.Encode(call.callData[:4])
+			op.CallSelector = &selector
+		}
+	}
+	return ops
+}
+
+// decodeHandleOps decodifica a calldata de handleOps ou handleAggregatedOps (v0.6 ou v0.7), indexando as
+// operações por sender e nonce. Retorna nil se a calldata não for de nenhuma das versões conhecidas
+func decodeHandleOps(data []byte) map[string]*userOperationCall {
+	if len(data) < 4 {
+		return nil
+	}
+
+	for _, entryPoint := range []struct {
+		version string
+		abi     abi.ABI
+	}{{"v0.6", entryPointV06}, {"v0.7", entryPointV07}} {
+		method, err := entryPoint.abi.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		values, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			log.Printf("⚠️ Erro ao decodificar %s da EntryPoint %s: %v", method.Name, entryPoint.version, err)
+			return nil
+		}
+
+		calls := make(map[string]*userOperationCall)
+		if entryPoint.version == "v0.6" {
+			var args struct {
+				Ops              []userOperationV06
+				OpsPerAggregator []struct {
+					UserOps    []userOperationV06
+					Aggregator common.Address
+					Signature  []byte
+				}
+				Beneficiary common.Address
+			}
+			if err := method.Inputs.Copy(&args, values); err != nil {
+				log.Printf("⚠️ Erro ao decodificar %s da EntryPoint %s: %v", method.Name, entryPoint.version, err)
+				return nil
+			}
+			ops := args.Ops
+			for _, aggregated := range args.OpsPerAggregator {
+				ops = append(ops, aggregated.UserOps...)
+			}
+			beneficiary := strings.ToLower(args.Beneficiary.Hex())
+			for _, op := range ops {
+				calls[userOperationKey(strings.ToLower(op.Sender.Hex()), op.Nonce)] = &userOperationCall{
+					version:              entryPoint.version,
+					beneficiary:          beneficiary,
+					callData:             op.CallData,
+					callGasLimit:         op.CallGasLimit,
+					verificationGasLimit: op.VerificationGasLimit,
+					preVerificationGas:   op.PreVerificationGas,
+					maxFeePerGas:         op.MaxFeePerGas,
+					maxPriorityFeePerGas: op.MaxPriorityFeePerGas,
+				}
+			}
+			return calls
+		}
+
+		var args struct {
+			Ops              []packedUserOperation
+			OpsPerAggregator []struct {
+				UserOps    []packedUserOperation
+				Aggregator common.Address
+				Signature  []byte
+			}
+			Beneficiary common.Address
+		}
+		if err := method.Inputs.Copy(&args, values); err != nil {
+			log.Printf("⚠️ Erro ao decodificar %s da EntryPoint %s: %v", method.Name, entryPoint.version, err)
+			return nil
+		}
+		ops := args.Ops
+		for _, aggregated := range args.OpsPerAggregator {
+			ops = append(ops, aggregated.UserOps...)
+		}
+		beneficiary := strings.ToLower(args.Beneficiary.Hex())
+		for _, op := range ops {
+			calls[userOperationKey(strings.ToLower(op.Sender.Hex()), op.Nonce)] = &userOperationCall{
+				version:              entryPoint.version,
+				beneficiary:          beneficiary,
+				callData:             op.CallData,
+				callGasLimit:         new(big.Int).SetBytes(op.AccountGasLimits[16:]),
+				verificationGasLimit: new(big.Int).SetBytes(op.AccountGasLimits[:16]),
+				preVerificationGas:   op.PreVerificationGas,
+				maxFeePerGas:         new(big.Int).SetBytes(op.GasFees[16:]),
+				maxPriorityFeePerGas: new(big.Int).SetBytes(op.GasFees[:16]),
+			}
+		}
+		return calls
+	}
+	return nil
+}
+
+// userOperationKey identifica uma UserOperation dentro de um bundle
+func userOperationKey(sender string, nonce *big.Int) string {
+	return sender + ":" + nonce.String()
+}
+
+// topicToAddress converte um topic indexado em endereço minúsculo
+func topicToAddress(topic common.Hash) string {
+	return strings.ToLower(common.BytesToAddress(topic.Bytes()).Hex())
+}
+
+// numericArg converte um inteiro opcional em parâmetro NUMERIC
+func numericArg(value *big.Int) interface{} {
+	if value == nil {
+		return nil
+	}
+	return value.String()
+}
+
+// saveUserOperations grava as UserOperations e marca os senders como smart accounts (com a factory,
+// quando a operação fez o deploy da account) em uma única transação
+func (s *UserOperationService) saveUserOperations(ctx context.Context, ops []*UserOperation) error {
+	batch := &pgx.Batch{}
+	for _, op := range ops {
+		batch.Queue(`
+			INSERT INTO user_operations (
+				user_op_hash, entry_point, entry_point_version, tx_hash, block_number, log_index, mined_at,
+				sender, paymaster, bundler, beneficiary, factory, nonce, success, actual_gas_cost,
+				actual_gas_used, call_gas_limit, verification_gas_limit, pre_verification_gas,
+				max_fee_per_gas, max_priority_fee_per_gas, call_selector, revert_reason
+			) VALUES (
+				$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+				$21, $22, $23
+			)
+			ON CONFLICT (user_op_hash) DO UPDATE SET
+				entry_point = EXCLUDED.entry_point,
+				entry_point_version = COALESCE(EXCLUDED.entry_point_version, user_operations.entry_point_version),
+				tx_hash = EXCLUDED.tx_hash,
+				block_number = EXCLUDED.block_number,
+				log_index = EXCLUDED.log_index,
+				mined_at = EXCLUDED.mined_at,
+				paymaster = EXCLUDED.paymaster,
+				bundler = EXCLUDED.bundler,
+				beneficiary = COALESCE(EXCLUDED.beneficiary, user_operations.beneficiary),
+				factory = COALESCE(EXCLUDED.factory, user_operations.factory),
+				success = EXCLUDED.success,
+				actual_gas_cost = EXCLUDED.actual_gas_cost,
+				actual_gas_used = EXCLUDED.actual_gas_used,
+				call_gas_limit = COALESCE(EXCLUDED.call_gas_limit, user_operations.call_gas_limit),
+				verification_gas_limit = COALESCE(EXCLUDED.verification_gas_limit, user_operations.verification_gas_limit),
+				pre_verification_gas = COALESCE(EXCLUDED.pre_verification_gas, user_operations.pre_verification_gas),
+				max_fee_per_gas = COALESCE(EXCLUDED.max_fee_per_gas, user_operations.max_fee_per_gas),
+				max_priority_fee_per_gas = COALESCE(EXCLUDED.max_priority_fee_per_gas, user_operations.max_priority_fee_per_gas),
+				call_selector = COALESCE(EXCLUDED.call_selector, user_operations.call_selector),
+				revert_reason = EXCLUDED.revert_reason`,
+			op.Hash, op.EntryPoint, op.Version, op.TxHash, int64(op.BlockNumber), int(op.LogIndex), op.MinedAt,
+			op.Sender, op.Paymaster, op.Bundler, op.Beneficiary, op.Factory, numericArg(op.Nonce), op.Success,
+			numericArg(op.ActualGasCost), numericArg(op.ActualGasUsed), numericArg(op.CallGasLimit),
+			numericArg(op.VerificationGasLimit), numericArg(op.PreVerificationGas), numericArg(op.MaxFeePerGas),
+			numericArg(op.MaxPriorityFeePerGas), op.CallSelector, op.RevertReason,
+		)
+
+		batch.Queue(`
+			INSERT INTO accounts (address, account_type, is_contract, factory_address, first_seen, last_activity, created_at, updated_at)
+			VALUES ($1, 'smart_account', TRUE, $2, $3, $3, NOW(), NOW())
+			ON CONFLICT (address) DO UPDATE SET
+				account_type = 'smart_account',
+				is_contract = TRUE,
+				factory_address = COALESCE(accounts.factory_address, EXCLUDED.factory_address),
+				last_activity = GREATEST(accounts.last_activity, EXCLUDED.last_activity),
+				updated_at = NOW()`,
+			op.Sender, op.Factory, op.MinedAt,
+		)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	results := tx.SendBatch(ctx, batch)
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := results.Exec(); err != nil {
+			results.Close()
+			return fmt.Errorf("erro ao gravar UserOperations: %w", err)
+		}
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar UserOperations: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,179 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// AlertSubjectType representa o tipo de ocorrência avaliada por uma regra de alerta
+type AlertSubjectType string
+
+const (
+	AlertSubjectTransaction      AlertSubjectType = "transaction"
+	AlertSubjectEvent            AlertSubjectType = "event"
+	AlertSubjectTokenTransfer    AlertSubjectType = "token_transfer"
+	AlertSubjectComplianceChange AlertSubjectType = "compliance"
+)
+
+// AlertDeliveryStatus representa o status de uma entrega de webhook
+type AlertDeliveryStatus string
+
+const (
+	AlertDeliveryPending   AlertDeliveryStatus = "pending"
+	AlertDeliveryDelivered AlertDeliveryStatus = "delivered"
+	AlertDeliveryFailed    AlertDeliveryStatus = "failed"
+)
+
+// AlertRule representa uma regra de alerta cadastrada via API
+type AlertRule struct {
+	ID              int64            `json:"id"`
+	Name            string           `json:"name"`
+	Description     *string          `json:"description,omitempty"`
+	SubjectType     AlertSubjectType `json:"subject_type"`
+	Conditions      AlertConditions  `json:"conditions"`
+	WebhookURL      string           `json:"webhook_url"`
+	WebhookSecret   string           `json:"-"`
+	IsActive        bool             `json:"is_active"`
+	CreatedBy       *string          `json:"created_by,omitempty"`
+	LastTriggeredAt *time.Time       `json:"last_triggered_at,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+// AlertConditions define os filtros de uma regra. Campos vazios são ignorados;
+// campos preenchidos precisam casar todos (AND) e listas casam por qualquer item (OR)
+type AlertConditions struct {
+	Addresses          []string `json:"addresses,omitempty"`           // Endereços observados (from/to)
+	Direction          string   `json:"direction,omitempty"`           // in, out ou any (padrão)
+	ContractAddresses  []string `json:"contract_addresses,omitempty"`  // Contrato emissor do evento/token
+	EventNames         []string `json:"event_names,omitempty"`         // Nomes de eventos decodificados
+	EventSignatures    []string `json:"event_signatures,omitempty"`    // topic0 dos eventos
+	MinValue           string   `json:"min_value,omitempty"`           // Valor mínimo em wei (decimal)
+	Status             string   `json:"status,omitempty"`              // Status da transação (success, failed)
+	ComplianceStatuses []string `json:"compliance_statuses,omitempty"` // Novos status de compliance
+}
+
+// Value implementa driver.Valuer para serializar para o banco
+func (c AlertConditions) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan implementa sql.Scanner para deserializar do banco
+func (c *AlertConditions) Scan(value interface{}) error {
+	if value == nil {
+		*c = AlertConditions{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("tipo inválido para AlertConditions: %T", value)
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+// AlertSubject representa uma ocorrência normalizada para avaliação das regras
+type AlertSubject struct {
+	Type             AlertSubjectType
+	Key              string // Identificador único da ocorrência (idempotência das entregas)
+	From             string
+	To               string
+	Contract         string
+	EventName        string
+	EventSignature   string
+	Value            *big.Int
+	Status           string
+	ComplianceStatus string
+	Data             map[string]interface{} // Dados enviados no payload do webhook
+}
+
+// Matches verifica se a ocorrência satisfaz as condições
+func (c *AlertConditions) Matches(subject *AlertSubject) bool {
+	if len(c.Addresses) > 0 {
+		matched := false
+		switch strings.ToLower(c.Direction) {
+		case "in":
+			matched = containsFold(c.Addresses, subject.To)
+		case "out":
+			matched = containsFold(c.Addresses, subject.From)
+		default:
+			matched = containsFold(c.Addresses, subject.From) || containsFold(c.Addresses, subject.To)
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(c.ContractAddresses) > 0 && !containsFold(c.ContractAddresses, subject.Contract) {
+		return false
+	}
+
+	if len(c.EventNames) > 0 && !containsFold(c.EventNames, subject.EventName) {
+		return false
+	}
+
+	if len(c.EventSignatures) > 0 && !containsFold(c.EventSignatures, subject.EventSignature) {
+		return false
+	}
+
+	if c.MinValue != "" {
+		minValue, ok := new(big.Int).SetString(c.MinValue, 10)
+		if ok && (subject.Value == nil || subject.Value.Cmp(minValue) < 0) {
+			return false
+		}
+	}
+
+	if c.Status != "" && !strings.EqualFold(c.Status, subject.Status) {
+		return false
+	}
+
+	if len(c.ComplianceStatuses) > 0 && !containsFold(c.ComplianceStatuses, subject.ComplianceStatus) {
+		return false
+	}
+
+	return true
+}
+
+// containsFold verifica se o valor está na lista ignorando maiúsculas/minúsculas
+func containsFold(list []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertDelivery representa uma entrega de webhook no log de entregas
+type AlertDelivery struct {
+	ID             int64               `json:"id"`
+	RuleID         int64               `json:"rule_id"`
+	SubjectType    AlertSubjectType    `json:"subject_type"`
+	SubjectKey     string              `json:"subject_key"`
+	Payload        json.RawMessage     `json:"payload"`
+	Status         AlertDeliveryStatus `json:"status"`
+	Attempts       int                 `json:"attempts"`
+	ResponseStatus *int                `json:"response_status,omitempty"`
+	LastError      *string             `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time           `json:"next_attempt_at"`
+	DeliveredAt    *time.Time          `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+
+	// Dados da regra necessários para o envio (preenchidos ao reservar a entrega)
+	WebhookURL    string `json:"-"`
+	WebhookSecret string `json:"-"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// Status de uma faixa do backfill histórico
+const (
+	BackfillRunning   = "running"
+	BackfillCompleted = "completed"
+	BackfillFailed    = "failed"
+)
+
+// BackfillRange representa o checkpoint de uma faixa de blocos do backfill histórico
+type BackfillRange struct {
+	Start       uint64     `json:"range_start"`
+	End         uint64     `json:"range_end"`
+	Status      string     `json:"status"`
+	Owner       string     `json:"owner"`
+	BlockCount  int        `json:"block_count"`
+	TxCount     int        `json:"tx_count"`
+	Attempts    int        `json:"attempts"`
+	LastError   *string    `json:"last_error,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Size retorna a quantidade de blocos da faixa
+func (r *BackfillRange) Size() int {
+	return int(r.End-r.Start) + 1
+}
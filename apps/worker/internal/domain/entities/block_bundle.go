@@ -0,0 +1,9 @@
+package entities
+
+// BlockBundle reúne tudo o que um bloco grava no banco: o próprio bloco, as transações
+// (já com os dados do receipt) e os eventos emitidos pelos logs
+type BlockBundle struct {
+	Block        *Block         `json:"block"`
+	Transactions []*Transaction `json:"transactions"`
+	Events       []*Event       `json:"events"`
+}
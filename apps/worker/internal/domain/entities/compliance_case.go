@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// ComplianceCaseOrigin representa quem abriu um caso de compliance
+type ComplianceCaseOrigin string
+
+const (
+	ComplianceCaseOriginManual     ComplianceCaseOrigin = "manual"
+	ComplianceCaseOriginRiskPolicy ComplianceCaseOrigin = "risk_policy"
+	ComplianceCaseOriginScreening  ComplianceCaseOrigin = "screening"
+)
+
+// ComplianceCaseActorWorker identifica o worker no histórico dos casos
+const ComplianceCaseActorWorker = "worker"
+
+// ComplianceCaseSignal é uma mudança de status feita pela política de risco ou pela triagem que exige
+// investigação: abre um caso ou é registrada no caso ativo da account
+type ComplianceCaseSignal struct {
+	Address    string
+	Origin     ComplianceCaseOrigin
+	OriginRef  *string // Versão da política ou lista que gerou o sinal
+	Previous   ComplianceStatus
+	Status     ComplianceStatus
+	Reason     *string
+	OccurredAt time.Time
+}
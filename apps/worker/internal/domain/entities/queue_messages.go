@@ -221,6 +221,21 @@ func (m *AccountTaggingMessage) IsValidOperation() bool {
 	return false
 }
 
+// ComplianceStatusValue converte o status recebido da API para o status de domínio. pending mantém a
+// account como compliant até a revisão; status inválidos retornam false
+func (m *AccountComplianceUpdateMessage) ComplianceStatusValue() (ComplianceStatus, bool) {
+	switch m.ComplianceStatus {
+	case "compliant", "pending":
+		return ComplianceStatusCompliant, true
+	case "non_compliant":
+		return ComplianceStatusFlagged, true
+	case "under_review":
+		return ComplianceStatusUnderReview, true
+	default:
+		return "", false
+	}
+}
+
 // IsValidComplianceStatus verifica se o status de compliance é válido
 func (m *AccountComplianceUpdateMessage) IsValidComplianceStatus() bool {
 	validStatuses := []string{"compliant", "non_compliant", "pending", "under_review"}
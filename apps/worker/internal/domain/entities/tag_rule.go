@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// TagMetricKind indica se a métrica é comparada como número ou como texto
+type TagMetricKind string
+
+const (
+	TagMetricNumeric TagMetricKind = "numeric" // Operadores gt, gte, lt, lte, eq, ne com value
+	TagMetricText    TagMetricKind = "text"    // Operadores in, not_in com values
+)
+
+// tagMetricSpec descreve uma métrica disponível para as condições das regras de tags
+type tagMetricSpec struct {
+	Kind           TagMetricKind
+	RequiresMethod bool
+	RequiresToken  bool
+}
+
+// TagMetrics contém as métricas aceitas nas condições das regras de tags. Valores em moeda nativa usam
+// NATIVE_TOKEN_DECIMALS casas decimais e saldos de tokens usam as casas decimais do token
+var TagMetrics = map[string]tagMetricSpec{
+	"account.balance":               {Kind: TagMetricNumeric},
+	"account.transaction_count":     {Kind: TagMetricNumeric},
+	"account.contract_interactions": {Kind: TagMetricNumeric},
+	"account.contract_deployments":  {Kind: TagMetricNumeric},
+	"account.is_contract":           {Kind: TagMetricNumeric},
+	"account.age_days":              {Kind: TagMetricNumeric},
+	"account.inactive_days":         {Kind: TagMetricNumeric},
+	"account.type":                  {Kind: TagMetricText},
+	"account.contract_type":         {Kind: TagMetricText},
+
+	"methods.unique_contracts": {Kind: TagMetricNumeric},
+	"methods.calls":            {Kind: TagMetricNumeric, RequiresMethod: true},
+	"methods.most_used":        {Kind: TagMetricText},
+
+	"tokens.count":   {Kind: TagMetricNumeric},
+	"tokens.balance": {Kind: TagMetricNumeric, RequiresToken: true},
+
+	"activity.success_rate": {Kind: TagMetricNumeric},
+	"activity.failed_count": {Kind: TagMetricNumeric},
+	"activity.max_tx_value": {Kind: TagMetricNumeric},
+}
+
+// TagOperators contém os operadores aceitos por tipo de métrica, com o operador SQL correspondente
+var TagOperators = map[TagMetricKind]map[string]string{
+	TagMetricNumeric: {"gt": ">", "gte": ">=", "lt": "<", "lte": "<=", "eq": "=", "ne": "<>"},
+	TagMetricText:    {"in": "IN", "not_in": "NOT IN"},
+}
+
+// TagRule atribui Tag às accounts em que todas as condições casam. Tags atribuídas por regras ficam com
+// created_by = 'system' e são removidas quando a account deixa de casar
+type TagRule struct {
+	ID          int64
+	Tag         string
+	Description *string
+	Conditions  []TagCondition
+	IsActive    bool
+	UpdatedAt   time.Time
+	EvaluatedAt *time.Time
+}
+
+// TagCondition compara uma métrica da account com um valor numérico ou com uma lista de textos
+type TagCondition struct {
+	Metric string   `json:"metric"`
+	Op     string   `json:"op"`
+	Value  *float64 `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+	Method string   `json:"method,omitempty"` // Método de methods.calls
+	Token  string   `json:"token,omitempty"`  // Endereço do token de tokens.balance
+}
+
+// ParseTagConditions lê e valida as condições gravadas em tag_rules.conditions
+func ParseTagConditions(content []byte) ([]TagCondition, error) {
+	var conditions []TagCondition
+	if err := json.Unmarshal(content, &conditions); err != nil {
+		return nil, fmt.Errorf("condições inválidas: %w", err)
+	}
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("regra sem condições")
+	}
+	for i := range conditions {
+		if err := conditions[i].Validate(); err != nil {
+			return nil, fmt.Errorf("condição %d: %w", i+1, err)
+		}
+	}
+	return conditions, nil
+}
+
+// Validate verifica a métrica, o operador e os parâmetros exigidos pela métrica
+func (c *TagCondition) Validate() error {
+	spec, ok := TagMetrics[c.Metric]
+	if !ok {
+		return fmt.Errorf("métrica desconhecida: %s", c.Metric)
+	}
+	if _, ok := TagOperators[spec.Kind][c.Op]; !ok {
+		if spec.Kind == TagMetricText {
+			return fmt.Errorf("operador %s inválido para %s (use in ou not_in)", c.Op, c.Metric)
+		}
+		return fmt.Errorf("operador %s inválido para %s (use gt, gte, lt, lte, eq ou ne)", c.Op, c.Metric)
+	}
+
+	switch spec.Kind {
+	case TagMetricNumeric:
+		if c.Value == nil || math.IsNaN(*c.Value) || math.IsInf(*c.Value, 0) {
+			return fmt.Errorf("%s exige value numérico", c.Metric)
+		}
+		if len(c.Values) > 0 {
+			return fmt.Errorf("%s não aceita values", c.Metric)
+		}
+	case TagMetricText:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%s exige values", c.Metric)
+		}
+		if c.Value != nil {
+			return fmt.Errorf("%s não aceita value", c.Metric)
+		}
+	}
+
+	if spec.RequiresMethod && strings.TrimSpace(c.Method) == "" {
+		return fmt.Errorf("%s exige method", c.Metric)
+	}
+	if !spec.RequiresMethod && c.Method != "" {
+		return fmt.Errorf("%s não aceita method", c.Metric)
+	}
+	if spec.RequiresToken && !isHexAddress(c.Token) {
+		return fmt.Errorf("%s exige token (endereço do contrato)", c.Metric)
+	}
+	if !spec.RequiresToken && c.Token != "" {
+		return fmt.Errorf("%s não aceita token", c.Metric)
+	}
+
+	return nil
+}
+
+// isHexAddress verifica se o valor é um endereço 0x com 40 dígitos hexadecimais
+func isHexAddress(value string) bool {
+	if len(value) != 42 || !strings.HasPrefix(value, "0x") {
+		return false
+	}
+	for _, r := range value[2:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -40,6 +40,36 @@ type Transaction struct {
 	CreatedAt            time.Time         `json:"created_at"`
 	UpdatedAt            time.Time         `json:"updated_at"`
 	MinedAt              *time.Time        `json:"mined_at"`
+	RevertReason         *RevertReason     `json:"revert_reason,omitempty"` // Motivo decodificado das transações com falha
+}
+
+// Tipos de motivo de falha de uma transação
+const (
+	RevertKindError       = "error"       // Error(string) (require/revert com mensagem)
+	RevertKindPanic       = "panic"       // Panic(uint256) (assert, overflow, divisão por zero...)
+	RevertKindCustom      = "custom"      // Erro customizado do ABI do contrato
+	RevertKindUnknown     = "unknown"     // Revert sem dados ou com seletor desconhecido
+	RevertKindOutOfGas    = "out_of_gas"  // Gas esgotado
+	RevertKindUnavailable = "unavailable" // O nó não conseguiu reexecutar a transação (ex.: estado podado)
+)
+
+// RevertReason é o motivo decodificado da falha de uma transação, obtido do campo revertReason do receipt
+// (Besu com --revert-reason-enabled) ou reexecutando a transação via eth_call no bloco pai
+type RevertReason struct {
+	Kind      string      `json:"kind"`
+	Message   string      `json:"message"`
+	Signature string      `json:"signature,omitempty"` // Assinatura do erro customizado
+	Code      *uint64     `json:"code,omitempty"`      // Código do Panic
+	Args      []RevertArg `json:"args,omitempty"`      // Argumentos do erro customizado
+	Data      string      `json:"data,omitempty"`      // Dados do revert em hex
+	Source    string      `json:"source"`              // receipt ou replay
+}
+
+// RevertArg é um argumento decodificado de um erro customizado
+type RevertArg struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
 }
 
 // NewTransaction cria uma nova instância de Transaction
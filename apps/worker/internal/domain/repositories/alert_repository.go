@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+)
+
+// AlertRepository define as operações de acesso a dados para regras de alerta e entregas
+type AlertRepository interface {
+	// GetActiveRules busca todas as regras ativas
+	GetActiveRules(ctx context.Context) ([]*entities.AlertRule, error)
+
+	// CreateDelivery registra uma entrega pendente; retorna false se a ocorrência já foi registrada para a regra
+	CreateDelivery(ctx context.Context, delivery *entities.AlertDelivery) (bool, error)
+
+	// ClaimDueDeliveries reserva entregas pendentes vencidas por um período de lease
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*entities.AlertDelivery, error)
+
+	// UpdateDelivery persiste o resultado de uma tentativa de entrega
+	UpdateDelivery(ctx context.Context, delivery *entities.AlertDelivery) error
+
+	// MarkRuleTriggered atualiza o horário do último disparo da regra
+	MarkRuleTriggered(ctx context.Context, ruleID int64, triggeredAt time.Time) error
+}
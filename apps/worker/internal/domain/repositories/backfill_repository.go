@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+)
+
+// BackfillRepository define as operações de checkpoint do backfill histórico
+type BackfillRepository interface {
+	// ClaimRange reserva a faixa para o processo; retorna false se ela já foi concluída
+	// ou está reservada por outro processo com lease ainda válido
+	ClaimRange(ctx context.Context, r *entities.BackfillRange, lease time.Duration) (bool, error)
+
+	// CompleteRange marca a faixa como concluída com as contagens gravadas
+	CompleteRange(ctx context.Context, r *entities.BackfillRange) error
+
+	// FailRange registra a falha da faixa para que seja reprocessada
+	FailRange(ctx context.Context, r *entities.BackfillRange, cause error) error
+
+	// CountIndexedBlocks conta os blocos da faixa que já estão no banco
+	CountIndexedBlocks(ctx context.Context, from, to uint64) (int, error)
+
+	// LowestIndexedBlock retorna o menor bloco do banco; false se não há blocos
+	LowestIndexedBlock(ctx context.Context) (uint64, bool, error)
+}
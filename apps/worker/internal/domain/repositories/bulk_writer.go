@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+)
+
+// BulkWriter grava blocos completos em lote: blocos, transações, eventos e as tabelas
+// derivadas de contas (account_transactions, accounts, token_holdings e account_method_stats)
+type BulkWriter interface {
+	// WriteBlocks grava os blocos em uma única transação; reprocessar um bloco já gravado
+	// não incrementa de novo os contadores das contas
+	WriteBlocks(ctx context.Context, bundles []*entities.BlockBundle) error
+
+	// WriteBlocksWith grava os blocos como WriteBlocks e aplica as escritas adicionais de opts na
+	// mesma transação. Bundles sem bloco gravam apenas as suas transações e eventos
+	WriteBlocksWith(ctx context.Context, bundles []*entities.BlockBundle, opts BulkWriteOptions) error
+}
+
+// BulkWriteOptions ajusta uma gravação do BulkWriter
+type BulkWriteOptions struct {
+	// SkipAccountStages deixa de gravar as tabelas de contas pelas etapas em lote; o pipeline em tempo real
+	// as grava com as escritas do AccountTransactionProcessor, passadas em Statements
+	SkipAccountStages bool
+
+	// Statements são aplicadas em ordem depois das etapas em lote, na mesma transação
+	Statements []BulkStatement
+}
+
+// BulkStatement é uma escrita adicional de uma gravação em lote
+type BulkStatement struct {
+	Query string
+	Args  []interface{}
+	Label string // Usado na mensagem de erro
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+)
+
+// ComplianceCaseRepository define as operações do worker sobre os casos de compliance
+type ComplianceCaseRepository interface {
+	// OpenForSignal abre um caso para a account ou registra o sinal no caso ativo em que ela é investigada.
+	// Retorna o ID do caso e se ele foi aberto agora
+	OpenForSignal(ctx context.Context, signal *entities.ComplianceCaseSignal) (int64, bool, error)
+
+	// RecordStatusOverride registra nos casos ativos da account uma alteração manual de status feita fora do caso
+	RecordStatusOverride(ctx context.Context, address string, previous, status entities.ComplianceStatus, actor, source string, reason *string) error
+}
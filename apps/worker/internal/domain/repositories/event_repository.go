@@ -59,6 +59,9 @@ type EventRepository interface {
 	// Exists verifica se um evento já existe
 	Exists(ctx context.Context, id string) (bool, error)
 
+	// ExistingInBlock retorna, dentre os IDs informados, os eventos do bloco que já existem
+	ExistingInBlock(ctx context.Context, blockNumber uint64, ids []string) (map[string]bool, error)
+
 	// GetLatest retorna os eventos mais recentes
 	GetLatest(ctx context.Context, limit int) ([]*entities.EventSummary, error)
 
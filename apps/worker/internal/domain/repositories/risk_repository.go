@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+)
+
+// RiskRepository define as operações de acesso a dados da avaliação de risco de accounts
+type RiskRepository interface {
+	// GetActivePolicy busca a versão ativa da política de risco; retorna nil se não houver
+	GetActivePolicy(ctx context.Context) (*entities.RiskPolicyVersion, error)
+
+	// LoadFacts calcula os fatos da account exigidos pela política; retorna sql.ErrNoRows se a account não existir
+	LoadFacts(ctx context.Context, address string, keys []entities.RiskFactKey) (map[entities.RiskFactKey]float64, error)
+
+	// SaveEvaluation grava a avaliação e atualiza score e status da account, retornando o status anterior.
+	// O status só é alterado se não tiver sido definido manualmente
+	SaveEvaluation(ctx context.Context, evaluation *entities.RiskEvaluation) (entities.ComplianceStatus, bool, error)
+
+	// MarkPending enfileira accounts para avaliação
+	MarkPending(ctx context.Context, addresses []string) error
+
+	// MarkAllPending enfileira todas as accounts para avaliação, retornando quantas entraram na fila
+	MarkAllPending(ctx context.Context) (int64, error)
+
+	// ClaimPending remove e retorna até limit accounts da fila de avaliação
+	ClaimPending(ctx context.Context, limit int) ([]string, error)
+
+	// ClaimOutdated reserva até limit accounts avaliadas por outra versão da política, marcando-as com a versão atual
+	ClaimOutdated(ctx context.Context, policyVersion int64, limit int) ([]string, error)
+}
@@ -8,4 +8,7 @@ import (
 type SmartContractRepository interface {
 	// GetContractName busca o nome de um contrato pelo endereço
 	GetContractName(ctx context.Context, address string) (string, error)
+
+	// GetContractABI busca a ABI verificada de um contrato pelo endereço (vazia se não houver)
+	GetContractABI(ctx context.Context, address string) (string, error)
 }
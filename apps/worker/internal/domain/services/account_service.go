@@ -277,7 +277,8 @@ func (s *accountService) UpdateDailyAnalytics(ctx context.Context, address strin
 	return s.accountAnalyticsRepo.Update(ctx, analytics)
 }
 
-// CalculateRiskScore calcula o score de risco de uma conta
+// CalculateRiskScore retorna o score de risco da conta. O score é mantido pela política de risco
+// versionada (risk_policies), avaliada pelo RiskService do worker
 func (s *accountService) CalculateRiskScore(ctx context.Context, address string) (int, error) {
 	address = strings.ToLower(address)
 
@@ -286,57 +287,26 @@ func (s *accountService) CalculateRiskScore(ctx context.Context, address string)
 		return 0, fmt.Errorf("failed to get account: %w", err)
 	}
 
-	riskScore := 0
-
-	// Fatores de risco:
-	// 1. Conta muito nova (alta atividade em pouco tempo)
-	if time.Since(account.FirstSeen).Hours() < 24 && account.TransactionCount > 100 {
-		riskScore += 2
-	}
-
-	// 2. Muitas interações com contratos desconhecidos
-	if account.ContractInteractions > account.TransactionCount/2 {
-		riskScore += 1
-	}
-
-	// 3. Padrões suspeitos de transações
-	// TODO: Implementar análise mais sofisticada
-
-	// Garantir que o score está entre 0 e 10
-	if riskScore > 10 {
-		riskScore = 10
+	if account.RiskScore == nil {
+		return 0, nil // Ainda não avaliada
 	}
-
-	return riskScore, nil
+	return *account.RiskScore, nil
 }
 
-// AnalyzeCompliance analisa o status de compliance de uma conta
+// AnalyzeCompliance retorna o status de compliance da conta, com os cortes definidos pela política de risco ativa
 func (s *accountService) AnalyzeCompliance(ctx context.Context, address string) (entities.ComplianceStatus, string, error) {
 	address = strings.ToLower(address)
 
-	// Calcular score de risco
-	riskScore, err := s.CalculateRiskScore(ctx, address)
+	account, err := s.accountRepo.GetByAddress(ctx, address)
 	if err != nil {
-		return entities.ComplianceStatusUnderReview, "Failed to calculate risk score", err
+		return entities.ComplianceStatusUnderReview, "Failed to get account", fmt.Errorf("failed to get account: %w", err)
 	}
 
-	// Determinar status baseado no score de risco
-	var status entities.ComplianceStatus
 	var notes string
-
-	switch {
-	case riskScore <= 2:
-		status = entities.ComplianceStatusCompliant
-		notes = "Low risk account with normal activity patterns"
-	case riskScore <= 5:
-		status = entities.ComplianceStatusUnderReview
-		notes = "Medium risk account requiring monitoring"
-	default:
-		status = entities.ComplianceStatusFlagged
-		notes = "High risk account with suspicious activity patterns"
+	if account.ComplianceNotes != nil {
+		notes = *account.ComplianceNotes
 	}
-
-	return status, notes, nil
+	return account.ComplianceStatus, notes, nil
 }
 
 // FlagAccount marca uma conta como flagged
@@ -602,17 +572,8 @@ func (s *accountService) UpdateAccountCompliance(ctx context.Context, msg *entit
 	}
 
 	// Atualizar status de compliance
-	var status entities.ComplianceStatus
-	switch msg.ComplianceStatus {
-	case "compliant":
-		status = entities.ComplianceStatusCompliant
-	case "non_compliant":
-		status = entities.ComplianceStatusFlagged
-	case "pending":
-		status = entities.ComplianceStatusCompliant
-	case "under_review":
-		status = entities.ComplianceStatusUnderReview
-	default:
+	status, ok := msg.ComplianceStatusValue()
+	if !ok {
 		return fmt.Errorf("invalid compliance status: %s", msg.ComplianceStatus)
 	}
 
@@ -0,0 +1,96 @@
+package blockpayload
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Version é a maior versão do formato de bloco enriquecido que o worker entende
+const Version = 1
+
+// EncodingGzip identifica o payload comprimido com gzip
+const EncodingGzip = "gzip"
+
+// Message é a mensagem recebida da fila block-processed.
+// Mensagens sem Version são do formato antigo e só trazem número, hash e timestamp
+type Message struct {
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	Timestamp int64  `json:"timestamp"`
+
+	Version  int    `json:"version,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Payload  []byte `json:"payload,omitempty"`
+	Ref      string `json:"ref,omitempty"`
+	Size     int    `json:"size,omitempty"`
+}
+
+// Block é o bloco enriquecido produzido pelo indexer: cabeçalho, transações e receipts (com os logs)
+type Block struct {
+	Hash         string        `json:"hash"`
+	Header       *types.Header `json:"header"`
+	Size         uint64        `json:"size"`
+	UncleCount   int           `json:"uncleCount"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// Transaction é uma transação do bloco com remetente e receipt já resolvidos
+type Transaction struct {
+	Raw     hexutil.Bytes   `json:"raw"`
+	From    common.Address  `json:"from"`
+	Receipt json.RawMessage `json:"receipt,omitempty"`
+}
+
+// Decode lê o bloco enriquecido da mensagem (inline ou do blob store).
+// Retorna nil sem erro para mensagens no formato antigo
+func Decode(ctx context.Context, msg *Message, store Store) (*Block, error) {
+	if msg.Version == 0 {
+		return nil, nil
+	}
+	if msg.Version > Version {
+		return nil, fmt.Errorf("versão de payload de bloco não suportada: %d", msg.Version)
+	}
+
+	data := msg.Payload
+	if msg.Ref != "" {
+		if store == nil {
+			return nil, fmt.Errorf("payload do bloco %d está no blob store (%s), mas nenhum store foi configurado: %w", msg.Number, msg.Ref, ErrPayloadNotFound)
+		}
+		var err error
+		if data, err = store.Get(ctx, msg.Ref); err != nil {
+			return nil, fmt.Errorf("erro ao ler payload do bloco %d do blob store: %w", msg.Number, err)
+		}
+	}
+
+	switch msg.Encoding {
+	case EncodingGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao descomprimir payload do bloco %d: %w", msg.Number, err)
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("erro ao descomprimir payload do bloco %d: %w", msg.Number, err)
+		}
+	case "":
+	default:
+		return nil, fmt.Errorf("encoding de payload desconhecido: %s", msg.Encoding)
+	}
+
+	var block Block
+	if err := json.Unmarshal(data, &block); err != nil {
+		return nil, fmt.Errorf("erro ao deserializar payload do bloco %d: %w", msg.Number, err)
+	}
+	if block.Header == nil {
+		return nil, fmt.Errorf("payload do bloco %d sem cabeçalho", msg.Number)
+	}
+	return &block, nil
+}
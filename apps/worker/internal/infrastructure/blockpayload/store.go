@@ -0,0 +1,123 @@
+package blockpayload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisRefPrefix = "redis:"
+	fileRefPrefix  = "file:"
+)
+
+// ErrPayloadNotFound indica que o payload referenciado expirou ou já foi removido do blob store
+var ErrPayloadNotFound = errors.New("payload expirou ou não existe")
+
+// Store lê (e remove após o processamento) payloads que o indexer gravou fora do RabbitMQ
+type Store interface {
+	Get(ctx context.Context, ref string) ([]byte, error)
+	Delete(ctx context.Context, ref string) error
+}
+
+// NewStore cria o blob store configurado (none, redis ou file); retorna nil para none
+func NewStore(kind, redisURL, dir string) (Store, error) {
+	switch strings.ToLower(kind) {
+	case "", "none":
+		return nil, nil
+	case "redis":
+		return NewRedisStore(redisURL)
+	case "file":
+		return NewFileStore(dir), nil
+	default:
+		return nil, fmt.Errorf("blob store de payload desconhecido: %s", kind)
+	}
+}
+
+// RedisStore lê payloads gravados pelo indexer no Redis
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore conecta no Redis informado (padrão redis://redis:6379)
+func NewRedisStore(redisURL string) (*RedisStore, error) {
+	if redisURL == "" {
+		redisURL = "redis://redis:6379"
+	}
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao parsear Redis URL: %w", err)
+	}
+	return &RedisStore{client: redis.NewClient(opt)}, nil
+}
+
+// Get lê o payload da referência redis:<chave>
+func (s *RedisStore) Get(ctx context.Context, ref string) ([]byte, error) {
+	key, ok := strings.CutPrefix(ref, redisRefPrefix)
+	if !ok {
+		return nil, fmt.Errorf("referência de payload inválida para o Redis: %s", ref)
+	}
+	data, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("payload %s: %w", ref, ErrPayloadNotFound)
+	}
+	return data, err
+}
+
+// Delete remove o payload já processado; a expiração cobre falhas na remoção
+func (s *RedisStore) Delete(ctx context.Context, ref string) error {
+	key, ok := strings.CutPrefix(ref, redisRefPrefix)
+	if !ok {
+		return nil
+	}
+	return s.client.Del(ctx, key).Err()
+}
+
+// FileStore lê payloads de um diretório compartilhado com o indexer
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore cria um store sobre o diretório informado
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// path resolve a referência file:<nome> dentro do diretório, sem permitir sair dele
+func (s *FileStore) path(ref string) (string, error) {
+	name, ok := strings.CutPrefix(ref, fileRefPrefix)
+	if !ok || name == "" || name != filepath.Base(name) {
+		return "", fmt.Errorf("referência de payload inválida para arquivo: %s", ref)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+// Get lê o payload da referência file:<nome>
+func (s *FileStore) Get(_ context.Context, ref string) ([]byte, error) {
+	path, err := s.path(ref)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("payload %s: %w", ref, ErrPayloadNotFound)
+	}
+	return data, err
+}
+
+// Delete remove o arquivo do payload já processado
+func (s *FileStore) Delete(_ context.Context, ref string) error {
+	path, err := s.path(ref)
+	if err != nil {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
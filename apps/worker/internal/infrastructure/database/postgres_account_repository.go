@@ -207,3 +207,31 @@ func (r *PostgresAccountRepository) GetByAddress(ctx context.Context, address st
 
 	return account, nil
 }
+
+// UpdateCompliance atualiza o status de compliance de uma account e retorna o status anterior.
+// O status passa a ser manual e deixa de ser alterado pela política de risco.
+// Retorna sql.ErrNoRows se a account não existir.
+func (r *PostgresAccountRepository) UpdateCompliance(ctx context.Context, address string, status entities.ComplianceStatus, notes *string, riskScore *int) (entities.ComplianceStatus, error) {
+	query := `
+		UPDATE accounts a
+		SET compliance_status = $2,
+		    compliance_notes = COALESCE($3, a.compliance_notes),
+		    risk_score = COALESCE($4, a.risk_score),
+		    compliance_source = 'manual',
+		    updated_at = NOW()
+		FROM (SELECT address, compliance_status FROM accounts WHERE address = $1 FOR UPDATE) prev
+		WHERE a.address = prev.address
+		RETURNING prev.compliance_status
+	`
+
+	var previous string
+	err := r.db.QueryRowContext(ctx, query, address, string(status), notes, riskScore).Scan(&previous)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", err
+		}
+		return "", fmt.Errorf("erro ao atualizar compliance da account %s: %w", address, err)
+	}
+
+	return entities.ComplianceStatus(previous), nil
+}
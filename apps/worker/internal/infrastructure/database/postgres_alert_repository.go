@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+)
+
+// PostgresAlertRepository implementa AlertRepository usando PostgreSQL
+type PostgresAlertRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAlertRepository cria uma nova instância do repositório
+func NewPostgresAlertRepository(db *sql.DB) repositories.AlertRepository {
+	return &PostgresAlertRepository{db: db}
+}
+
+// GetActiveRules busca todas as regras ativas
+func (r *PostgresAlertRepository) GetActiveRules(ctx context.Context) ([]*entities.AlertRule, error) {
+	query := `
+		SELECT id, name, description, subject_type, conditions, webhook_url, webhook_secret,
+		       is_active, created_by, last_triggered_at, created_at, updated_at
+		FROM alert_rules
+		WHERE is_active = true
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar regras de alerta ativas: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.AlertRule
+	for rows.Next() {
+		rule := &entities.AlertRule{}
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.Name,
+			&rule.Description,
+			&rule.SubjectType,
+			&rule.Conditions,
+			&rule.WebhookURL,
+			&rule.WebhookSecret,
+			&rule.IsActive,
+			&rule.CreatedBy,
+			&rule.LastTriggeredAt,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao ler regra de alerta: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// CreateDelivery registra uma entrega pendente; retorna false se a ocorrência já foi registrada para a regra
+func (r *PostgresAlertRepository) CreateDelivery(ctx context.Context, delivery *entities.AlertDelivery) (bool, error) {
+	query := `
+		INSERT INTO alert_deliveries (rule_id, subject_type, subject_key, payload, status, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (rule_id, subject_key) DO NOTHING
+		RETURNING id, next_attempt_at, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		delivery.RuleID,
+		string(delivery.SubjectType),
+		delivery.SubjectKey,
+		[]byte(delivery.Payload),
+		string(entities.AlertDeliveryPending),
+	).Scan(&delivery.ID, &delivery.NextAttemptAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil // Ocorrência já registrada para esta regra
+		}
+		return false, fmt.Errorf("erro ao registrar entrega de alerta: %w", err)
+	}
+
+	delivery.Status = entities.AlertDeliveryPending
+	return true, nil
+}
+
+// ClaimDueDeliveries reserva entregas pendentes vencidas por um período de lease.
+// O lease evita que outra instância do worker envie a mesma entrega em paralelo.
+func (r *PostgresAlertRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*entities.AlertDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM alert_deliveries d
+			WHERE d.status = 'pending' AND d.next_attempt_at <= NOW()
+			ORDER BY d.next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE alert_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM due, alert_rules r
+		WHERE d.id = due.id AND r.id = d.rule_id
+		RETURNING d.id, d.rule_id, d.subject_type, d.subject_key, d.payload, d.status, d.attempts,
+		          d.response_status, d.last_error, d.next_attempt_at, d.delivered_at,
+		          d.created_at, d.updated_at, r.webhook_url, r.webhook_secret
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reservar entregas de alerta: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.AlertDelivery
+	for rows.Next() {
+		delivery := &entities.AlertDelivery{}
+		var payload []byte
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.RuleID,
+			&delivery.SubjectType,
+			&delivery.SubjectKey,
+			&payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.ResponseStatus,
+			&delivery.LastError,
+			&delivery.NextAttemptAt,
+			&delivery.DeliveredAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+			&delivery.WebhookURL,
+			&delivery.WebhookSecret,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao ler entrega de alerta: %w", err)
+		}
+		delivery.Payload = payload
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// UpdateDelivery persiste o resultado de uma tentativa de entrega
+func (r *PostgresAlertRepository) UpdateDelivery(ctx context.Context, delivery *entities.AlertDelivery) error {
+	query := `
+		UPDATE alert_deliveries
+		SET status = $2, attempts = $3, response_status = $4, last_error = $5,
+		    next_attempt_at = $6, delivered_at = $7
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar entrega de alerta %d: %w", delivery.ID, err)
+	}
+
+	return nil
+}
+
+// MarkRuleTriggered atualiza o horário do último disparo da regra
+func (r *PostgresAlertRepository) MarkRuleTriggered(ctx context.Context, ruleID int64, triggeredAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE alert_rules SET last_triggered_at = $2 WHERE id = $1`, ruleID, triggeredAt)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar disparo da regra %d: %w", ruleID, err)
+	}
+	return nil
+}
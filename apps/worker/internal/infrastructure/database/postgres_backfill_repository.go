@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+)
+
+// PostgresBackfillRepository implementa BackfillRepository usando PostgreSQL
+type PostgresBackfillRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresBackfillRepository cria uma nova instância do repositório
+func NewPostgresBackfillRepository(db *sql.DB) repositories.BackfillRepository {
+	return &PostgresBackfillRepository{db: db}
+}
+
+// ClaimRange reserva a faixa; faixas com falha ou com lease vencido podem ser reservadas de novo
+func (r *PostgresBackfillRepository) ClaimRange(ctx context.Context, rng *entities.BackfillRange, lease time.Duration) (bool, error) {
+	query := `
+		INSERT INTO backfill_ranges (range_start, range_end, status, owner)
+		VALUES ($1, $2, 'running', $3)
+		ON CONFLICT (range_start, range_end) DO UPDATE SET
+			status = 'running',
+			owner = EXCLUDED.owner,
+			attempts = backfill_ranges.attempts + 1,
+			last_error = NULL,
+			started_at = NOW(),
+			updated_at = NOW()
+		WHERE backfill_ranges.status = 'failed'
+		   OR (backfill_ranges.status = 'running' AND backfill_ranges.updated_at < NOW() - make_interval(secs => $4))
+		RETURNING attempts
+	`
+
+	err := r.db.QueryRowContext(ctx, query, rng.Start, rng.End, rng.Owner, lease.Seconds()).Scan(&rng.Attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao reservar faixa %d-%d do backfill: %w", rng.Start, rng.End, err)
+	}
+	rng.Status = entities.BackfillRunning
+	return true, nil
+}
+
+// CompleteRange marca a faixa como concluída
+func (r *PostgresBackfillRepository) CompleteRange(ctx context.Context, rng *entities.BackfillRange) error {
+	query := `
+		UPDATE backfill_ranges
+		SET status = 'completed', block_count = $3, tx_count = $4, last_error = NULL,
+		    completed_at = NOW(), updated_at = NOW()
+		WHERE range_start = $1 AND range_end = $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, rng.Start, rng.End, rng.BlockCount, rng.TxCount); err != nil {
+		return fmt.Errorf("erro ao concluir faixa %d-%d do backfill: %w", rng.Start, rng.End, err)
+	}
+	rng.Status = entities.BackfillCompleted
+	return nil
+}
+
+// FailRange registra a falha da faixa; só afeta faixas reservadas pelo próprio processo
+func (r *PostgresBackfillRepository) FailRange(ctx context.Context, rng *entities.BackfillRange, cause error) error {
+	query := `
+		UPDATE backfill_ranges
+		SET status = 'failed', last_error = $3, updated_at = NOW()
+		WHERE range_start = $1 AND range_end = $2 AND owner = $4 AND status = 'running'
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, rng.Start, rng.End, cause.Error(), rng.Owner); err != nil {
+		return fmt.Errorf("erro ao registrar falha da faixa %d-%d do backfill: %w", rng.Start, rng.End, err)
+	}
+	rng.Status = entities.BackfillFailed
+	return nil
+}
+
+// CountIndexedBlocks conta os blocos já gravados na faixa
+func (r *PostgresBackfillRepository) CountIndexedBlocks(ctx context.Context, from, to uint64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM blocks WHERE number BETWEEN $1 AND $2`, from, to).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao contar blocos indexados %d-%d: %w", from, to, err)
+	}
+	return count, nil
+}
+
+// LowestIndexedBlock retorna o menor bloco gravado
+func (r *PostgresBackfillRepository) LowestIndexedBlock(ctx context.Context) (uint64, bool, error) {
+	var lowest sql.NullInt64
+	if err := r.db.QueryRowContext(ctx, `SELECT MIN(number) FROM blocks`).Scan(&lowest); err != nil {
+		return 0, false, fmt.Errorf("erro ao buscar menor bloco indexado: %w", err)
+	}
+	if !lowest.Valid {
+		return 0, false, nil
+	}
+	return uint64(lowest.Int64), true, nil
+}
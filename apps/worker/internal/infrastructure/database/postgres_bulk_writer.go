@@ -0,0 +1,632 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// erc20TransferTopic é o topic0 de Transfer(address,address,uint256)
+const erc20TransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// PostgresBulkWriter implementa o BulkWriter com COPY (pgx.CopyFrom) em tabelas temporárias
+// seguido de upserts em conjunto, tudo em uma única transação
+type PostgresBulkWriter struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresBulkWriter cria uma nova instância do bulk writer
+func NewPostgresBulkWriter(db *pgxpool.Pool) repositories.BulkWriter {
+	return &PostgresBulkWriter{db: db}
+}
+
+// bulkStage é uma tabela temporária preenchida com COPY e os comandos que a aplicam nas tabelas finais
+type bulkStage struct {
+	name       string
+	createStmt string
+	columns    []string
+	rows       [][]interface{}
+	applyStmts []string
+}
+
+// WriteBlocks grava os blocos, transações, eventos e dados de contas em uma única transação.
+// Contadores (accounts, account_method_stats e token_holdings) só consideram transações que ainda
+// não estavam no banco, então regravar um bloco é seguro
+func (w *PostgresBulkWriter) WriteBlocks(ctx context.Context, bundles []*entities.BlockBundle) error {
+	return w.WriteBlocksWith(ctx, bundles, repositories.BulkWriteOptions{})
+}
+
+// WriteBlocksWith grava os blocos como WriteBlocks e, na mesma transação, as escritas adicionais
+// enviadas em um único batch depois das etapas em lote
+func (w *PostgresBulkWriter) WriteBlocksWith(ctx context.Context, bundles []*entities.BlockBundle, opts repositories.BulkWriteOptions) error {
+	if len(bundles) == 0 && len(opts.Statements) == 0 {
+		return nil
+	}
+	for _, bundle := range bundles {
+		if bundle.Block != nil && !bundle.Block.IsValid() {
+			return fmt.Errorf("bloco inválido no lote: %+v", bundle.Block)
+		}
+		for _, tx := range bundle.Transactions {
+			if _, err := revertReasonJSON(tx.RevertReason); err != nil {
+				return fmt.Errorf("transação %s: %w", tx.Hash, err)
+			}
+		}
+	}
+
+	stages := []*bulkStage{
+		blocksStage(bundles),
+		transactionsStage(bundles),
+		eventsStage(bundles),
+	}
+	if !opts.SkipAccountStages {
+		stages = append(stages, accountTransactionsStage(bundles), tokenTransfersStage(bundles))
+	}
+
+	tx, err := w.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação do bulk writer: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, stage := range stages {
+		if len(stage.rows) == 0 {
+			continue
+		}
+		if _, err := tx.Exec(ctx, stage.createStmt); err != nil {
+			return fmt.Errorf("erro ao criar tabela temporária %s: %w", stage.name, err)
+		}
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{stage.name}, stage.columns, pgx.CopyFromRows(stage.rows)); err != nil {
+			return fmt.Errorf("erro ao copiar linhas para %s: %w", stage.name, err)
+		}
+		for _, stmt := range stage.applyStmts {
+			if _, err := tx.Exec(ctx, stmt); err != nil {
+				return fmt.Errorf("erro ao aplicar %s: %w", stage.name, err)
+			}
+		}
+	}
+
+	if err := execStatements(ctx, tx, opts.Statements); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("erro ao confirmar transação do bulk writer: %w", err)
+	}
+	return nil
+}
+
+// execStatements envia as escritas adicionais em um único batch
+func execStatements(ctx context.Context, tx pgx.Tx, stmts []repositories.BulkStatement) error {
+	if len(stmts) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, stmt := range stmts {
+		batch.Queue(stmt.Query, stmt.Args...)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	for _, stmt := range stmts {
+		if _, err := results.Exec(); err != nil {
+			results.Close()
+			return fmt.Errorf("erro ao gravar %s: %w", stmt.Label, err)
+		}
+	}
+	return results.Close()
+}
+
+// blocksStage grava os blocos com o mesmo upsert do PostgresBlockRepository.Save
+func blocksStage(bundles []*entities.BlockBundle) *bulkStage {
+	stage := &bulkStage{
+		name:       "stage_blocks",
+		createStmt: `CREATE TEMP TABLE stage_blocks (LIKE blocks INCLUDING DEFAULTS) ON COMMIT DROP`,
+		columns: []string{
+			"number", "hash", "parent_hash", "timestamp", "miner", "difficulty", "total_difficulty",
+			"size", "gas_limit", "gas_used", "base_fee_per_gas", "tx_count", "uncle_count",
+			"bloom", "extra_data", "mix_digest", "nonce", "receipt_hash", "state_root", "tx_hash",
+			"created_at", "updated_at",
+		},
+		applyStmts: []string{`
+			INSERT INTO blocks (
+				number, hash, parent_hash, timestamp, miner, difficulty, total_difficulty,
+				size, gas_limit, gas_used, base_fee_per_gas, tx_count, uncle_count,
+				bloom, extra_data, mix_digest, nonce, receipt_hash, state_root, tx_hash,
+				created_at, updated_at
+			)
+			SELECT
+				number, hash, parent_hash, timestamp, miner, difficulty, total_difficulty,
+				size, gas_limit, gas_used, base_fee_per_gas, tx_count, uncle_count,
+				bloom, extra_data, mix_digest, nonce, receipt_hash, state_root, tx_hash,
+				created_at, updated_at
+			FROM stage_blocks
+			ON CONFLICT (hash) DO UPDATE SET
+				parent_hash = EXCLUDED.parent_hash,
+				timestamp = EXCLUDED.timestamp,
+				miner = EXCLUDED.miner,
+				difficulty = EXCLUDED.difficulty,
+				total_difficulty = EXCLUDED.total_difficulty,
+				size = EXCLUDED.size,
+				gas_limit = EXCLUDED.gas_limit,
+				gas_used = EXCLUDED.gas_used,
+				base_fee_per_gas = EXCLUDED.base_fee_per_gas,
+				tx_count = EXCLUDED.tx_count,
+				uncle_count = EXCLUDED.uncle_count,
+				bloom = EXCLUDED.bloom,
+				extra_data = EXCLUDED.extra_data,
+				mix_digest = EXCLUDED.mix_digest,
+				nonce = EXCLUDED.nonce,
+				receipt_hash = EXCLUDED.receipt_hash,
+				state_root = EXCLUDED.state_root,
+				tx_hash = EXCLUDED.tx_hash,
+				updated_at = EXCLUDED.updated_at`,
+		},
+	}
+
+	now := time.Now()
+	for _, bundle := range bundles {
+		b := bundle.Block
+		if b == nil {
+			continue
+		}
+		stage.rows = append(stage.rows, []interface{}{
+			int64(b.Number), b.Hash, b.ParentHash, b.Timestamp, b.Miner,
+			bigString(b.Difficulty), bigString(b.TotalDifficulty),
+			int64(b.Size), int64(b.GasLimit), int64(b.GasUsed), bigString(b.BaseFeePerGas),
+			b.TxCount, b.UncleCount,
+			b.Bloom, b.ExtraData, b.MixDigest, int64(b.Nonce), b.ReceiptHash, b.StateRoot, b.TxHash,
+			now, now,
+		})
+	}
+	return stage
+}
+
+// transactionsStage grava as transações e registra em stage_new_transactions as que ainda não
+// estavam mineradas no banco, usadas pelos contadores das etapas seguintes: as que não existiam e as
+// gravadas como pendentes (block_number NULL). Como transactions é particionada por block_number, as
+// existentes são atualizadas pelo hash (movendo pendentes para a partição do bloco) e só as que não
+// existiam são inseridas
+func transactionsStage(bundles []*entities.BlockBundle) *bulkStage {
+	stage := &bulkStage{
+		name:       "stage_transactions",
+		createStmt: `CREATE TEMP TABLE stage_transactions (LIKE transactions INCLUDING DEFAULTS) ON COMMIT DROP`,
+		columns: []string{
+			"hash", "block_number", "block_hash", "transaction_index", "from_address", "to_address",
+			"value", "gas_limit", "gas_used", "gas_price", "max_fee_per_gas", "max_priority_fee_per_gas",
+			"nonce", "data", "status", "contract_address", "transaction_type", "mined_at", "created_at", "updated_at",
+			"revert_reason",
+		},
+		applyStmts: []string{`
+			CREATE TEMP TABLE stage_new_transactions ON COMMIT DROP AS
+			SELECT s.hash, EXISTS (SELECT 1 FROM transactions t WHERE t.hash = s.hash) AS pending
+			FROM stage_transactions s
+			WHERE NOT EXISTS (SELECT 1 FROM transactions t WHERE t.hash = s.hash AND t.block_number IS NOT NULL)`, `
+			UPDATE transactions t SET
+				block_number = s.block_number,
+				block_hash = s.block_hash,
+				transaction_index = s.transaction_index,
+				gas_used = s.gas_used,
+				status = s.status,
+				contract_address = COALESCE(s.contract_address, t.contract_address),
+				mined_at = s.mined_at,
+				revert_reason = COALESCE(s.revert_reason, t.revert_reason),
+				updated_at = s.updated_at
+			FROM stage_transactions s
+			WHERE t.hash = s.hash`, `
+			INSERT INTO transactions (
+				hash, block_number, block_hash, transaction_index, from_address, to_address,
+				value, gas_limit, gas_used, gas_price, max_fee_per_gas, max_priority_fee_per_gas,
+				nonce, data, status, contract_address, transaction_type, mined_at, created_at, updated_at,
+				revert_reason
+			)
+			SELECT
+				s.hash, s.block_number, s.block_hash, s.transaction_index, s.from_address, s.to_address,
+				s.value, s.gas_limit, s.gas_used, s.gas_price, s.max_fee_per_gas, s.max_priority_fee_per_gas,
+				s.nonce, s.data, s.status, s.contract_address, s.transaction_type, s.mined_at, s.created_at, s.updated_at,
+				s.revert_reason
+			FROM stage_transactions s
+			JOIN stage_new_transactions n ON n.hash = s.hash AND NOT n.pending
+			ON CONFLICT (hash, block_number) DO NOTHING`,
+		},
+	}
+
+	for _, bundle := range bundles {
+		for _, tx := range bundle.Transactions {
+			value := "0"
+			if tx.Value != nil {
+				value = tx.Value.String()
+			}
+			// Já validado em WriteBlocksWith
+			revertReason, _ := revertReasonJSON(tx.RevertReason)
+			stage.rows = append(stage.rows, []interface{}{
+				tx.Hash, uint64Ptr(tx.BlockNumber), tx.BlockHash, uint64Ptr(tx.TransactionIndex), tx.From, tx.To,
+				value, int64(tx.Gas), uint64Ptr(tx.GasUsed), bigString(tx.GasPrice),
+				bigString(tx.MaxFeePerGas), bigString(tx.MaxPriorityFeePerGas),
+				int64(tx.Nonce), tx.Data, string(tx.Status), tx.ContractAddress, int16(tx.Type), timePtr(tx.MinedAt),
+				tx.CreatedAt, tx.UpdatedAt, revertReason,
+			})
+		}
+	}
+	return stage
+}
+
+// eventsStage grava os eventos com o mesmo upsert do PostgresEventRepository.Create
+func eventsStage(bundles []*entities.BlockBundle) *bulkStage {
+	stage := &bulkStage{
+		name:       "stage_events",
+		createStmt: `CREATE TEMP TABLE stage_events (LIKE events INCLUDING DEFAULTS) ON COMMIT DROP`,
+		columns: []string{
+			"id", "contract_address", "contract_name", "event_name", "event_signature",
+			"transaction_hash", "block_number", "block_hash", "log_index", "transaction_index",
+			"from_address", "to_address", "topics", "data", "decoded_data", "gas_used", "gas_price",
+			"status", "removed", "timestamp", "created_at", "updated_at",
+		},
+		applyStmts: []string{`
+			INSERT INTO events (
+				id, contract_address, contract_name, event_name, event_signature,
+				transaction_hash, block_number, block_hash, log_index, transaction_index,
+				from_address, to_address, topics, data, decoded_data, gas_used, gas_price,
+				status, removed, timestamp, created_at, updated_at
+			)
+			SELECT
+				s.id, s.contract_address, COALESCE(s.contract_name, sc.name), s.event_name, s.event_signature,
+				s.transaction_hash, s.block_number, s.block_hash, s.log_index, s.transaction_index,
+				s.from_address, s.to_address, s.topics, s.data, s.decoded_data, s.gas_used, s.gas_price,
+				s.status, s.removed, s.timestamp, s.created_at, s.updated_at
+			FROM stage_events s
+			LEFT JOIN smart_contracts sc ON sc.address = LOWER(s.contract_address)
+			ON CONFLICT (id, block_number) DO UPDATE SET
+				contract_name = COALESCE(EXCLUDED.contract_name, events.contract_name),
+				decoded_data = COALESCE(EXCLUDED.decoded_data, events.decoded_data),
+				updated_at = EXCLUDED.updated_at`,
+		},
+	}
+
+	now := time.Now()
+	for _, bundle := range bundles {
+		for _, event := range bundle.Events {
+			topics := []byte("[]")
+			if event.Topics != nil {
+				topics, _ = json.Marshal(event.Topics)
+			}
+			var decoded []byte
+			if event.DecodedData != nil {
+				decoded, _ = json.Marshal(event.DecodedData)
+			}
+			stage.rows = append(stage.rows, []interface{}{
+				event.ID, event.ContractAddress, event.ContractName, event.EventName, event.EventSignature,
+				event.TransactionHash, int64(event.BlockNumber), event.BlockHash, int64(event.LogIndex), int64(event.TransactionIndex),
+				event.FromAddress, event.ToAddress, topics, event.Data, decoded, int64(event.GasUsed), event.GasPrice,
+				event.Status, event.Removed, event.Timestamp, now, now,
+			})
+		}
+	}
+	return stage
+}
+
+// accountTransactionsStage grava account_transactions e, para transações novas, os contadores
+// de accounts e account_method_stats. Destinatários que são contratos (já conhecidos ou criados
+// no próprio lote) viram contract_call e, como no AccountTransactionProcessor, não contam como conta
+func accountTransactionsStage(bundles []*entities.BlockBundle) *bulkStage {
+	stage := &bulkStage{
+		name: "stage_account_transactions",
+		createStmt: `
+			CREATE TEMP TABLE stage_account_transactions (
+				account_address VARCHAR(42) NOT NULL,
+				transaction_hash VARCHAR(66) NOT NULL,
+				block_number BIGINT NOT NULL,
+				transaction_index INTEGER NOT NULL,
+				transaction_type VARCHAR(20) NOT NULL,
+				from_address VARCHAR(42) NOT NULL,
+				to_address VARCHAR(42),
+				value TEXT NOT NULL,
+				gas_limit BIGINT NOT NULL,
+				gas_used BIGINT,
+				gas_price TEXT,
+				status VARCHAR(20) NOT NULL,
+				method_name VARCHAR(100),
+				method_signature VARCHAR(200),
+				contract_address VARCHAR(42),
+				decoded_input JSONB,
+				timestamp TIMESTAMP WITH TIME ZONE NOT NULL
+			) ON COMMIT DROP`,
+		columns: []string{
+			"account_address", "transaction_hash", "block_number", "transaction_index", "transaction_type",
+			"from_address", "to_address", "value", "gas_limit", "gas_used", "gas_price", "status",
+			"method_name", "method_signature", "contract_address", "decoded_input", "timestamp",
+		},
+		applyStmts: []string{`
+			UPDATE stage_account_transactions s SET transaction_type = 'contract_call'
+			WHERE s.transaction_type = 'received' AND (
+				EXISTS (SELECT 1 FROM accounts a WHERE a.address = s.account_address AND a.is_contract)
+				OR EXISTS (SELECT 1 FROM smart_contracts sc WHERE sc.address = s.account_address)
+				OR EXISTS (SELECT 1 FROM stage_account_transactions c
+					WHERE c.account_address = s.account_address AND c.transaction_type = 'contract_creation')
+			)`, `
+			INSERT INTO accounts (
+				address, account_type, transaction_count, smart_contract_deployments,
+				first_seen, last_activity, is_contract, created_at, updated_at
+			)
+			SELECT
+				s.account_address,
+				CASE WHEN bool_or(s.transaction_type = 'contract_creation') THEN 'smart_account' ELSE 'eoa' END,
+				COUNT(*),
+				COUNT(*) FILTER (WHERE s.transaction_type = 'contract_creation'),
+				MIN(s.timestamp), MAX(s.timestamp),
+				bool_or(s.transaction_type = 'contract_creation'),
+				NOW(), NOW()
+			FROM stage_account_transactions s
+			JOIN stage_new_transactions n ON n.hash = s.transaction_hash
+			WHERE s.transaction_type <> 'contract_call'
+			GROUP BY s.account_address
+			ON CONFLICT (address) DO UPDATE SET
+				account_type = CASE WHEN EXCLUDED.is_contract THEN 'smart_account' ELSE accounts.account_type END,
+				transaction_count = accounts.transaction_count + EXCLUDED.transaction_count,
+				smart_contract_deployments = accounts.smart_contract_deployments + EXCLUDED.smart_contract_deployments,
+				first_seen = LEAST(accounts.first_seen, EXCLUDED.first_seen),
+				last_activity = GREATEST(accounts.last_activity, EXCLUDED.last_activity),
+				is_contract = accounts.is_contract OR EXCLUDED.is_contract,
+				updated_at = NOW()`, `
+			INSERT INTO account_transactions (
+				account_address, transaction_hash, block_number, transaction_index,
+				transaction_type, from_address, to_address, value, gas_limit, gas_used,
+				gas_price, status, method_name, method_signature, contract_address,
+				contract_name, decoded_input, timestamp, created_at, updated_at
+			)
+			SELECT
+				s.account_address, s.transaction_hash, s.block_number, s.transaction_index,
+				s.transaction_type, s.from_address, s.to_address, s.value, s.gas_limit, s.gas_used,
+				s.gas_price, s.status, s.method_name, s.method_signature, s.contract_address,
+				LEFT(sc.name, 100), s.decoded_input, s.timestamp, NOW(), NOW()
+			FROM stage_account_transactions s
+			LEFT JOIN smart_contracts sc ON sc.address = s.contract_address
+			ON CONFLICT (account_address, transaction_hash, block_number) DO UPDATE SET
+				transaction_type = EXCLUDED.transaction_type,
+				status = EXCLUDED.status,
+				gas_used = EXCLUDED.gas_used,
+				method_signature = EXCLUDED.method_signature,
+				contract_name = COALESCE(EXCLUDED.contract_name, account_transactions.contract_name),
+				updated_at = NOW()`, `
+			CREATE TEMP TABLE stage_method_stats ON COMMIT DROP AS
+			SELECT
+				s.account_address, s.method_name, MAX(s.method_signature) AS method_signature,
+				LOWER(s.to_address) AS contract_address,
+				COUNT(*) AS execution_count,
+				COUNT(*) FILTER (WHERE s.status = 'success') AS success_count,
+				COUNT(*) FILTER (WHERE s.status <> 'success') AS failed_count,
+				SUM(COALESCE(s.gas_used, 0))::NUMERIC AS total_gas_used,
+				SUM(s.value::NUMERIC) AS total_value_sent,
+				MIN(s.timestamp) AS first_executed_at,
+				MAX(s.timestamp) AS last_executed_at
+			FROM stage_account_transactions s
+			JOIN stage_new_transactions n ON n.hash = s.transaction_hash
+			WHERE s.transaction_type = 'sent'
+			GROUP BY s.account_address, s.method_name, LOWER(s.to_address)`, `
+			UPDATE account_method_stats m SET
+				execution_count = m.execution_count + s.execution_count,
+				success_count = m.success_count + s.success_count,
+				failed_count = m.failed_count + s.failed_count,
+				total_gas_used = (m.total_gas_used::NUMERIC + s.total_gas_used)::TEXT,
+				total_value_sent = (m.total_value_sent::NUMERIC + s.total_value_sent)::TEXT,
+				avg_gas_used = ((m.total_gas_used::NUMERIC + s.total_gas_used) / (m.execution_count + s.execution_count))::BIGINT,
+				last_executed_at = GREATEST(m.last_executed_at, s.last_executed_at),
+				updated_at = NOW()
+			FROM stage_method_stats s
+			WHERE m.account_address = s.account_address
+				AND m.method_name = s.method_name
+				AND m.contract_address IS NOT DISTINCT FROM s.contract_address`, `
+			INSERT INTO account_method_stats (
+				account_address, method_name, method_signature, contract_address,
+				contract_name, execution_count, success_count, failed_count,
+				total_gas_used, total_value_sent, avg_gas_used, first_executed_at,
+				last_executed_at, created_at, updated_at
+			)
+			SELECT
+				s.account_address, s.method_name, s.method_signature, s.contract_address,
+				LEFT(sc.name, 100), s.execution_count, s.success_count, s.failed_count,
+				s.total_gas_used::TEXT, s.total_value_sent::TEXT, (s.total_gas_used / s.execution_count)::BIGINT,
+				s.first_executed_at, s.last_executed_at, NOW(), NOW()
+			FROM stage_method_stats s
+			LEFT JOIN smart_contracts sc ON sc.address = s.contract_address
+			WHERE NOT EXISTS (
+				SELECT 1 FROM account_method_stats m
+				WHERE m.account_address = s.account_address
+					AND m.method_name = s.method_name
+					AND m.contract_address IS NOT DISTINCT FROM s.contract_address
+			)`,
+		},
+	}
+
+	for _, bundle := range bundles {
+		for _, tx := range bundle.Transactions {
+			blockNumber := int64(0)
+			if tx.BlockNumber != nil {
+				blockNumber = int64(*tx.BlockNumber)
+			}
+			txIndex := 0
+			if tx.TransactionIndex != nil {
+				txIndex = int(*tx.TransactionIndex)
+			}
+			var timestamp time.Time
+			if bundle.Block != nil {
+				timestamp = bundle.Block.Timestamp
+			}
+			if tx.MinedAt != nil {
+				timestamp = *tx.MinedAt
+			}
+			value := "0"
+			if tx.Value != nil {
+				value = tx.Value.String()
+			}
+			gasPrice := "0"
+			if tx.GasPrice != nil {
+				gasPrice = tx.GasPrice.String()
+			}
+
+			var contractAddress *string
+			if tx.To != nil && *tx.To != "" {
+				contractAddress = lowerPtr(*tx.To)
+			}
+			if tx.ContractAddress != nil && *tx.ContractAddress != "" {
+				contractAddress = lowerPtr(*tx.ContractAddress)
+			}
+			methodName, methodSignature := methodFromInput(tx.Data)
+			var decodedInput []byte
+			if len(tx.Data) > 4 {
+				decodedInput, _ = json.Marshal(map[string]interface{}{
+					"method":    methodName,
+					"signature": methodSignature,
+					"raw_data":  fmt.Sprintf("0x%x", tx.Data),
+				})
+			}
+
+			addRow := func(account, txType string) {
+				stage.rows = append(stage.rows, []interface{}{
+					strings.ToLower(account), tx.Hash, blockNumber, txIndex, txType,
+					tx.From, tx.To, value, int64(tx.Gas), uint64Ptr(tx.GasUsed), gasPrice, string(tx.Status),
+					methodName, methodSignature, contractAddress, decodedInput, timestamp,
+				})
+			}
+
+			addRow(tx.From, "sent")
+			if tx.To != nil && *tx.To != "" && !strings.EqualFold(*tx.To, tx.From) {
+				addRow(*tx.To, "received")
+			}
+			if tx.ContractAddress != nil && *tx.ContractAddress != "" {
+				addRow(*tx.ContractAddress, "contract_creation")
+			}
+		}
+	}
+	return stage
+}
+
+// tokenTransfersStage aplica em token_holdings a variação de saldo dos Transfer ERC-20 das
+// transações novas. Tokens sem metadados em smart_contracts entram como UNKNOWN, como no
+// AccountTransactionProcessor, até o processamento em tempo real enriquecer o registro
+func tokenTransfersStage(bundles []*entities.BlockBundle) *bulkStage {
+	stage := &bulkStage{
+		name: "stage_token_transfers",
+		createStmt: `
+			CREATE TEMP TABLE stage_token_transfers (
+				transaction_hash VARCHAR(66) NOT NULL,
+				token_address VARCHAR(42) NOT NULL,
+				from_address VARCHAR(42) NOT NULL,
+				to_address VARCHAR(42) NOT NULL,
+				amount NUMERIC NOT NULL
+			) ON COMMIT DROP`,
+		columns: []string{"transaction_hash", "token_address", "from_address", "to_address", "amount"},
+		applyStmts: []string{`
+			CREATE TEMP TABLE stage_token_deltas ON COMMIT DROP AS
+			SELECT d.account_address, d.token_address, SUM(d.delta) AS delta
+			FROM (
+				SELECT t.from_address AS account_address, t.token_address, -t.amount AS delta
+				FROM stage_token_transfers t JOIN stage_new_transactions n ON n.hash = t.transaction_hash
+				UNION ALL
+				SELECT t.to_address, t.token_address, t.amount
+				FROM stage_token_transfers t JOIN stage_new_transactions n ON n.hash = t.transaction_hash
+			) d
+			WHERE d.account_address <> '` + zeroAddress + `'
+			GROUP BY d.account_address, d.token_address`, `
+			INSERT INTO accounts (address, first_seen, last_activity, created_at, updated_at)
+			SELECT DISTINCT account_address, NOW(), NOW(), NOW(), NOW() FROM stage_token_deltas
+			ON CONFLICT (address) DO NOTHING`, `
+			UPDATE token_holdings h SET
+				balance = GREATEST(h.balance::NUMERIC + d.delta, 0)::TEXT,
+				last_updated = NOW(),
+				updated_at = NOW()
+			FROM stage_token_deltas d
+			WHERE h.account_address = d.account_address AND h.token_address = d.token_address`, `
+			INSERT INTO token_holdings (
+				account_address, token_address, token_symbol, token_name,
+				token_decimals, balance, last_updated, created_at, updated_at
+			)
+			SELECT
+				d.account_address, d.token_address,
+				LEFT(COALESCE(NULLIF(sc.symbol, ''), 'UNKNOWN'), 20),
+				COALESCE(NULLIF(sc.name, ''), 'Unknown Token'),
+				18, GREATEST(d.delta, 0)::TEXT, NOW(), NOW(), NOW()
+			FROM stage_token_deltas d
+			LEFT JOIN smart_contracts sc ON sc.address = d.token_address AND sc.is_token
+			WHERE NOT EXISTS (
+				SELECT 1 FROM token_holdings h
+				WHERE h.account_address = d.account_address AND h.token_address = d.token_address
+			)`,
+		},
+	}
+
+	for _, bundle := range bundles {
+		for _, event := range bundle.Events {
+			if len(event.Topics) != 3 || event.Topics[0] != erc20TransferTopic || event.Removed {
+				continue
+			}
+			stage.rows = append(stage.rows, []interface{}{
+				event.TransactionHash,
+				strings.ToLower(event.ContractAddress),
+				topicAddress(event.Topics[1]),
+				topicAddress(event.Topics[2]),
+				new(big.Int).SetBytes(event.Data).String(),
+			})
+		}
+	}
+	return stage
+}
+
+// methodFromInput identifica o método pelo seletor, como o fallback do AccountTransactionProcessor
+// (a decodificação por ABI fica com o processamento em tempo real)
+func methodFromInput(data []byte) (string, string) {
+	if len(data) < 4 {
+		return "transfer", ""
+	}
+	selector := fmt.Sprintf("0x%x", data[:4])
+	return selector, selector
+}
+
+// topicAddress extrai o endereço (minúsculo) de um topic indexado de 32 bytes
+func topicAddress(topic string) string {
+	topic = strings.TrimPrefix(strings.ToLower(topic), "0x")
+	if len(topic) < 40 {
+		return zeroAddress
+	}
+	return "0x" + topic[len(topic)-40:]
+}
+
+// bigString converte big.Int para o texto gravado no banco (nil vira NULL)
+func bigString(value *big.Int) *string {
+	if value == nil {
+		return nil
+	}
+	s := value.String()
+	return &s
+}
+
+// uint64Ptr converte *uint64 para um valor aceito pelo COPY (nil vira NULL)
+func uint64Ptr(value *uint64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return int64(*value)
+}
+
+// timePtr converte *time.Time para um valor aceito pelo COPY (nil vira NULL)
+func timePtr(value *time.Time) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+// lowerPtr retorna o endereço em minúsculas
+func lowerPtr(address string) *string {
+	lower := strings.ToLower(address)
+	return &lower
+}
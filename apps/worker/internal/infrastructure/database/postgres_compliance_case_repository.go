@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+)
+
+// PostgresComplianceCaseRepository implementa ComplianceCaseRepository usando PostgreSQL
+type PostgresComplianceCaseRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresComplianceCaseRepository cria uma nova instância do repositório de casos
+func NewPostgresComplianceCaseRepository(db *sql.DB) repositories.ComplianceCaseRepository {
+	return &PostgresComplianceCaseRepository{db: db}
+}
+
+// activeCaseQuery busca o caso ativo mais antigo em que a account é investigada
+const activeCaseQuery = `
+	SELECT c.id FROM compliance_cases c
+	JOIN compliance_case_addresses ca ON ca.case_id = c.id
+	WHERE ca.address = $1 AND ca.role = 'subject' AND c.state <> 'closed'
+	ORDER BY c.id
+	LIMIT 1`
+
+// OpenForSignal abre um caso ou registra o sinal no caso ativo. O lock por endereço evita dois casos
+// abertos ao mesmo tempo para a mesma account
+func (r *PostgresComplianceCaseRepository) OpenForSignal(ctx context.Context, signal *entities.ComplianceCaseSignal) (int64, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('compliance_case:' || $1))`, signal.Address); err != nil {
+		return 0, false, fmt.Errorf("erro ao bloquear casos da account %s: %w", signal.Address, err)
+	}
+
+	details, err := json.Marshal(map[string]interface{}{
+		"origin":     signal.Origin,
+		"origin_ref": signal.OriginRef,
+		"reason":     signal.Reason,
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("erro ao serializar sinal: %w", err)
+	}
+
+	var caseID int64
+	err = tx.QueryRowContext(ctx, activeCaseQuery, signal.Address).Scan(&caseID)
+	switch {
+	case err == nil:
+		// Account já investigada: o sinal entra no histórico e flagged eleva a prioridade
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE compliance_cases SET
+				priority = CASE WHEN $2 = 'flagged' AND priority IN ('low', 'medium') THEN 'high' ELSE priority END,
+				updated_at = NOW()
+			WHERE id = $1`, caseID, string(signal.Status)); err != nil {
+			return 0, false, fmt.Errorf("erro ao atualizar caso %d: %w", caseID, err)
+		}
+		if err := insertCaseEvent(ctx, tx, caseID, "signal", string(signal.Previous), string(signal.Status), details, signal); err != nil {
+			return 0, false, err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, false, fmt.Errorf("erro ao confirmar sinal no caso %d: %w", caseID, err)
+		}
+		return caseID, false, nil
+	case err != sql.ErrNoRows:
+		return 0, false, fmt.Errorf("erro ao buscar caso ativo da account %s: %w", signal.Address, err)
+	}
+
+	priority := "medium"
+	if signal.Status == entities.ComplianceStatusFlagged {
+		priority = "high"
+	}
+	title := fmt.Sprintf("Account %s marcada como %s (%s)", signal.Address, signal.Status, signal.Origin)
+
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO compliance_cases (title, description, priority, origin, origin_ref, opened_by, opened_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		RETURNING id`,
+		title, signal.Reason, priority, string(signal.Origin), signal.OriginRef, entities.ComplianceCaseActorWorker, signal.OccurredAt,
+	).Scan(&caseID); err != nil {
+		return 0, false, fmt.Errorf("erro ao abrir caso para a account %s: %w", signal.Address, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO compliance_case_addresses (case_id, address, role, added_by, added_at)
+		VALUES ($1, $2, 'subject', $3, $4)`,
+		caseID, signal.Address, entities.ComplianceCaseActorWorker, signal.OccurredAt); err != nil {
+		return 0, false, fmt.Errorf("erro ao vincular account ao caso %d: %w", caseID, err)
+	}
+	if err := insertCaseEvent(ctx, tx, caseID, "opened", "", "open", details, signal); err != nil {
+		return 0, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("erro ao confirmar abertura do caso: %w", err)
+	}
+	return caseID, true, nil
+}
+
+// insertCaseEvent grava uma entrada do histórico de um caso aberto ou sinalizado pelo worker
+func insertCaseEvent(ctx context.Context, tx *sql.Tx, caseID int64, action, from, to string, details []byte, signal *entities.ComplianceCaseSignal) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO compliance_case_events (case_id, actor, action, from_value, to_value, details, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)`,
+		caseID, entities.ComplianceCaseActorWorker, action, from, to, details, signal.OccurredAt); err != nil {
+		return fmt.Errorf("erro ao gravar histórico do caso %d: %w", caseID, err)
+	}
+	return nil
+}
+
+// RecordStatusOverride registra a alteração manual em todos os casos ativos da account
+func (r *PostgresComplianceCaseRepository) RecordStatusOverride(ctx context.Context, address string, previous, status entities.ComplianceStatus, actor, source string, reason *string) error {
+	details, err := json.Marshal(map[string]interface{}{
+		"address": address,
+		"source":  source,
+		"reason":  reason,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao serializar alteração de status: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO compliance_case_events (case_id, actor, action, from_value, to_value, details)
+		SELECT c.id, $2, 'status_override', $3, $4, $5
+		FROM compliance_cases c
+		JOIN compliance_case_addresses ca ON ca.case_id = c.id
+		WHERE ca.address = $1 AND c.state <> 'closed'`,
+		address, actor, string(previous), string(status), details)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar alteração de status nos casos da account %s: %w", address, err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hubweb3/worker/internal/domain/entities"
 	"github.com/hubweb3/worker/internal/domain/repositories"
+	"github.com/lib/pq"
 )
 
 // PostgresEventRepository implementa EventRepository usando PostgreSQL
@@ -32,7 +33,7 @@ func (r *PostgresEventRepository) Create(ctx context.Context, event *entities.Ev
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
 		)
-		ON CONFLICT (id) DO UPDATE SET
+		ON CONFLICT (id, block_number) DO UPDATE SET
 			contract_name = EXCLUDED.contract_name,
 			decoded_data = EXCLUDED.decoded_data,
 			updated_at = EXCLUDED.updated_at
@@ -564,7 +565,7 @@ func (r *PostgresEventRepository) BulkCreate(ctx context.Context, events []*enti
 			from_address, to_address, topics, data, decoded_data, gas_used, gas_price,
 			status, removed, timestamp, created_at, updated_at
 		) VALUES %s
-		ON CONFLICT (id) DO UPDATE SET
+		ON CONFLICT (id, block_number) DO UPDATE SET
 			contract_name = EXCLUDED.contract_name,
 			decoded_data = EXCLUDED.decoded_data,
 			updated_at = EXCLUDED.updated_at
@@ -583,6 +584,31 @@ func (r *PostgresEventRepository) Exists(ctx context.Context, id string) (bool,
 	return exists, err
 }
 
+// ExistingInBlock retorna, dentre os IDs informados, os eventos do bloco que já existem
+func (r *PostgresEventRepository) ExistingInBlock(ctx context.Context, blockNumber uint64, ids []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	query := `SELECT id FROM events WHERE block_number = $1 AND id = ANY($2)`
+
+	rows, err := r.db.QueryContext(ctx, query, blockNumber, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		existing[id] = true
+	}
+	return existing, rows.Err()
+}
+
 // GetLatest retorna os eventos mais recentes
 func (r *PostgresEventRepository) GetLatest(ctx context.Context, limit int) ([]*entities.EventSummary, error) {
 	query := `
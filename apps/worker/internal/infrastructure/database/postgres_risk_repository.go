@@ -0,0 +1,392 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hubweb3/worker/internal/domain/entities"
+	"github.com/hubweb3/worker/internal/domain/repositories"
+	"github.com/lib/pq"
+)
+
+// weiToEth converte uma coluna de valor em wei (texto) para ETH, tratando valores inválidos como zero
+const weiToEth = `CASE WHEN %[1]s ~ '^[0-9]+$' THEN %[1]s::NUMERIC / 1e18 ELSE 0 END`
+
+// PostgresRiskRepository implementa RiskRepository usando PostgreSQL
+type PostgresRiskRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRiskRepository cria uma nova instância do repositório
+func NewPostgresRiskRepository(db *sql.DB) repositories.RiskRepository {
+	return &PostgresRiskRepository{db: db}
+}
+
+// GetActivePolicy busca a versão ativa da política de risco
+func (r *PostgresRiskRepository) GetActivePolicy(ctx context.Context) (*entities.RiskPolicyVersion, error) {
+	policy := &entities.RiskPolicyVersion{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT version, name, format, content
+		FROM risk_policies
+		WHERE is_active
+	`).Scan(&policy.Version, &policy.Name, &policy.Format, &policy.Content)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar política de risco ativa: %w", err)
+	}
+	return policy, nil
+}
+
+// LoadFacts calcula os fatos da account agrupando as métricas por origem e janela
+func (r *PostgresRiskRepository) LoadFacts(ctx context.Context, address string, keys []entities.RiskFactKey) (map[entities.RiskFactKey]float64, error) {
+	facts := make(map[entities.RiskFactKey]float64, len(keys))
+
+	if err := r.loadAccountFacts(ctx, address, keys, facts); err != nil {
+		return nil, err
+	}
+
+	activity := make(map[time.Duration][]entities.RiskFactKey)
+	counterparties := make(map[time.Duration][]entities.RiskFactKey)
+	var tagKeys []entities.RiskFactKey
+	for _, key := range keys {
+		switch key.Scope() {
+		case entities.RiskScopeTags:
+			tagKeys = append(tagKeys, key)
+		case entities.RiskScopeActivity:
+			activity[key.Window] = append(activity[key.Window], key)
+		case entities.RiskScopeCounterparties:
+			counterparties[key.Window] = append(counterparties[key.Window], key)
+		}
+	}
+
+	if len(tagKeys) > 0 {
+		if err := r.loadTagFacts(ctx, address, tagKeys, facts); err != nil {
+			return nil, err
+		}
+	}
+	for window, windowKeys := range activity {
+		if err := r.loadActivityFacts(ctx, address, window, windowKeys, facts); err != nil {
+			return nil, err
+		}
+	}
+	for window, windowKeys := range counterparties {
+		if err := r.loadCounterpartyFacts(ctx, address, window, windowKeys, facts); err != nil {
+			return nil, err
+		}
+	}
+
+	return facts, nil
+}
+
+// loadAccountFacts lê as métricas da linha de accounts; retorna sql.ErrNoRows se a account não existir
+func (r *PostgresRiskRepository) loadAccountFacts(ctx context.Context, address string, keys []entities.RiskFactKey, facts map[entities.RiskFactKey]float64) error {
+	var ageHours, balance float64
+	var txCount, interactions, deployments int64
+	var isContract bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXTRACT(EPOCH FROM NOW() - first_seen) / 3600, transaction_count, contract_interactions,
+		       smart_contract_deployments, (`+fmt.Sprintf(weiToEth, "balance")+`)::DOUBLE PRECISION, is_contract
+		FROM accounts
+		WHERE address = $1
+	`, address).Scan(&ageHours, &txCount, &interactions, &deployments, &balance, &isContract)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao buscar dados da account %s: %w", address, err)
+	}
+
+	for _, key := range keys {
+		switch key.Metric {
+		case "account.age_hours":
+			facts[key] = ageHours
+		case "account.transaction_count":
+			facts[key] = float64(txCount)
+		case "account.contract_interactions":
+			facts[key] = float64(interactions)
+		case "account.contract_interaction_ratio":
+			if txCount > 0 {
+				facts[key] = float64(interactions) / float64(txCount)
+			}
+		case "account.contract_deployments":
+			facts[key] = float64(deployments)
+		case "account.balance":
+			facts[key] = balance
+		case "account.is_contract":
+			if isContract {
+				facts[key] = 1
+			}
+		}
+	}
+	return nil
+}
+
+// loadTagFacts marca com 1 as tags que a account possui
+func (r *PostgresRiskRepository) loadTagFacts(ctx context.Context, address string, keys []entities.RiskFactKey, facts map[entities.RiskFactKey]float64) error {
+	rows, err := r.db.QueryContext(ctx, `SELECT LOWER(tag) FROM account_tags WHERE address = $1`, address)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar tags da account %s: %w", address, err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string]bool)
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return fmt.Errorf("erro ao ler tag: %w", err)
+		}
+		tags[tag] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro ao ler tags da account %s: %w", address, err)
+	}
+
+	for _, key := range keys {
+		if tags[key.Tag] {
+			facts[key] = 1
+		}
+	}
+	return nil
+}
+
+// loadActivityFacts agrega as transações da account na janela (zero: todo o histórico) em uma query
+func (r *PostgresRiskRepository) loadActivityFacts(ctx context.Context, address string, window time.Duration, keys []entities.RiskFactKey, facts map[entities.RiskFactKey]float64) error {
+	columns := make([]string, len(keys))
+	args := []interface{}{address, riskWindowStart(window)}
+	for i, key := range keys {
+		switch key.Metric {
+		case "velocity.tx_count":
+			columns[i] = `COUNT(*)`
+		case "velocity.sent_count":
+			columns[i] = `COUNT(*) FILTER (WHERE sender)`
+		case "velocity.received_count":
+			columns[i] = `COUNT(*) FILTER (WHERE recipient)`
+		case "velocity.failed_count":
+			columns[i] = `COUNT(*) FILTER (WHERE status = 'failed')`
+		case "velocity.value_sent":
+			columns[i] = `SUM(value) FILTER (WHERE sender)`
+		case "velocity.value_received":
+			columns[i] = `SUM(value) FILTER (WHERE recipient)`
+		case "value.max_tx":
+			columns[i] = `MAX(value)`
+		case "value.tx_above":
+			args = append(args, key.Threshold)
+			columns[i] = fmt.Sprintf(`COUNT(*) FILTER (WHERE value >= $%d)`, len(args))
+		default:
+			return fmt.Errorf("métrica de atividade desconhecida: %s", key.Metric)
+		}
+		columns[i] = fmt.Sprintf(`COALESCE(%s, 0)::DOUBLE PRECISION`, columns[i])
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM (
+			SELECT LOWER(from_address) = $1 AS sender, LOWER(to_address) = $1 AS recipient, status,
+			       (%s) AS value
+			FROM account_transactions
+			WHERE account_address = $1 AND "timestamp" >= $2
+		) t
+	`, strings.Join(columns, ", "), fmt.Sprintf(weiToEth, "value"))
+
+	return r.scanFacts(ctx, query, args, keys, facts)
+}
+
+// loadCounterpartyFacts conta as contrapartes distintas da account na janela (zero: todo o histórico)
+func (r *PostgresRiskRepository) loadCounterpartyFacts(ctx context.Context, address string, window time.Duration, keys []entities.RiskFactKey, facts map[entities.RiskFactKey]float64) error {
+	columns := make([]string, len(keys))
+	args := []interface{}{address, riskWindowStart(window)}
+	for i, key := range keys {
+		switch key.Metric {
+		case "counterparties.distinct":
+			columns[i] = `COUNT(*)`
+		case "counterparties.flagged":
+			columns[i] = `COUNT(*) FILTER (WHERE a.compliance_status = 'flagged')`
+		case "counterparties.tagged":
+			args = append(args, key.Tag)
+			columns[i] = fmt.Sprintf(`COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM account_tags tg WHERE tg.address = cp.address AND LOWER(tg.tag) = $%d
+			))`, len(args))
+		default:
+			return fmt.Errorf("métrica de contrapartes desconhecida: %s", key.Metric)
+		}
+		columns[i] = fmt.Sprintf(`(%s)::DOUBLE PRECISION`, columns[i])
+	}
+
+	query := fmt.Sprintf(`
+		WITH cp AS (
+			SELECT DISTINCT CASE WHEN LOWER(from_address) = $1 THEN LOWER(to_address) ELSE LOWER(from_address) END AS address
+			FROM account_transactions
+			WHERE account_address = $1 AND "timestamp" >= $2
+		)
+		SELECT %s
+		FROM cp
+		LEFT JOIN accounts a ON a.address = cp.address
+		WHERE cp.address IS NOT NULL AND cp.address <> $1
+	`, strings.Join(columns, ", "))
+
+	return r.scanFacts(ctx, query, args, keys, facts)
+}
+
+// scanFacts executa uma query de uma linha com uma coluna por fato
+func (r *PostgresRiskRepository) scanFacts(ctx context.Context, query string, args []interface{}, keys []entities.RiskFactKey, facts map[entities.RiskFactKey]float64) error {
+	values := make([]float64, len(keys))
+	dest := make([]interface{}, len(keys))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(dest...); err != nil {
+		return fmt.Errorf("erro ao calcular fatos de risco da account %s: %w", args[0], err)
+	}
+	for i, key := range keys {
+		facts[key] = values[i]
+	}
+	return nil
+}
+
+// riskWindowStart retorna o início da janela; janela zero cobre todo o histórico
+func riskWindowStart(window time.Duration) time.Time {
+	if window <= 0 {
+		return time.Unix(0, 0)
+	}
+	return time.Now().Add(-window)
+}
+
+// SaveEvaluation grava a avaliação e atualiza a account na mesma transação
+func (r *PostgresRiskRepository) SaveEvaluation(ctx context.Context, evaluation *entities.RiskEvaluation) (entities.ComplianceStatus, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO account_risk_evaluations (address, policy_version, score, status, breakdown, trigger, evaluated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, evaluation.Address, evaluation.PolicyVersion, evaluation.Score, string(evaluation.Status),
+		evaluation.Breakdown, evaluation.Trigger, evaluation.EvaluatedAt); err != nil {
+		return "", false, fmt.Errorf("erro ao gravar avaliação de risco da account %s: %w", evaluation.Address, err)
+	}
+
+	var previous string
+	var applied bool
+	err = tx.QueryRowContext(ctx, `
+		UPDATE accounts a
+		SET risk_score = $2,
+		    risk_policy_version = $3,
+		    risk_evaluated_at = $4,
+		    compliance_status = CASE WHEN a.compliance_source = 'policy' THEN $5 ELSE a.compliance_status END,
+		    compliance_notes = CASE WHEN a.compliance_source = 'policy' THEN $6 ELSE a.compliance_notes END,
+		    updated_at = NOW()
+		FROM (SELECT address, compliance_status FROM accounts WHERE address = $1 FOR UPDATE) prev
+		WHERE a.address = prev.address
+		RETURNING prev.compliance_status, a.compliance_source = 'policy'
+	`, evaluation.Address, evaluation.Score, evaluation.PolicyVersion, evaluation.EvaluatedAt,
+		string(evaluation.Status), riskNotes(evaluation)).Scan(&previous, &applied)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, err
+		}
+		return "", false, fmt.Errorf("erro ao atualizar risco da account %s: %w", evaluation.Address, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", false, fmt.Errorf("erro ao confirmar avaliação de risco: %w", err)
+	}
+	return entities.ComplianceStatus(previous), applied, nil
+}
+
+// riskNotes resume as regras que contribuíram para o score nas notas de compliance
+func riskNotes(evaluation *entities.RiskEvaluation) string {
+	var matched []string
+	for _, rule := range evaluation.Breakdown.Rules {
+		if rule.Matched {
+			matched = append(matched, fmt.Sprintf("%s (%+g)", rule.RuleID, rule.Contribution))
+		}
+	}
+	if len(matched) == 0 {
+		return fmt.Sprintf("Política de risco v%d: nenhuma regra acionada", evaluation.PolicyVersion)
+	}
+	return fmt.Sprintf("Política de risco v%d: %s", evaluation.PolicyVersion, strings.Join(matched, ", "))
+}
+
+// MarkPending enfileira accounts para avaliação
+func (r *PostgresRiskRepository) MarkPending(ctx context.Context, addresses []string) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO account_risk_pending (address)
+		SELECT DISTINCT LOWER(address) FROM UNNEST($1::text[]) AS address
+		ON CONFLICT (address) DO NOTHING
+	`, pq.Array(addresses)); err != nil {
+		return fmt.Errorf("erro ao enfileirar accounts para avaliação de risco: %w", err)
+	}
+	return nil
+}
+
+// MarkAllPending enfileira todas as accounts (ex.: após um backfill, que não passa pelo processamento por bloco)
+func (r *PostgresRiskRepository) MarkAllPending(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO account_risk_pending (address)
+		SELECT address FROM accounts
+		ON CONFLICT (address) DO NOTHING
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao enfileirar todas as accounts para avaliação de risco: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ClaimPending remove e retorna as accounts mais antigas da fila, sem disputar linhas com outros workers
+func (r *PostgresRiskRepository) ClaimPending(ctx context.Context, limit int) ([]string, error) {
+	return r.queryAddresses(ctx, `
+		DELETE FROM account_risk_pending
+		WHERE address IN (
+			SELECT address FROM account_risk_pending
+			ORDER BY queued_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING address
+	`, limit)
+}
+
+// ClaimOutdated reserva accounts avaliadas por outra versão, marcando-as com a versão atual
+func (r *PostgresRiskRepository) ClaimOutdated(ctx context.Context, policyVersion int64, limit int) ([]string, error) {
+	return r.queryAddresses(ctx, `
+		UPDATE accounts
+		SET risk_policy_version = $1
+		WHERE address IN (
+			SELECT address FROM accounts
+			WHERE risk_policy_version IS NOT NULL AND risk_policy_version <> $1
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING address
+	`, policyVersion, limit)
+}
+
+// queryAddresses executa uma query que retorna uma coluna de endereços
+func (r *PostgresRiskRepository) queryAddresses(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reservar accounts para avaliação de risco: %w", err)
+	}
+	defer rows.Close()
+
+	var addresses []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, fmt.Errorf("erro ao ler endereço: %w", err)
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, rows.Err()
+}
@@ -40,3 +40,24 @@ func (r *PostgresSmartContractRepository) GetContractName(ctx context.Context, a
 
 	return "", nil
 }
+
+// GetContractABI busca a ABI do contrato na tabela smart_contracts
+func (r *PostgresSmartContractRepository) GetContractABI(ctx context.Context, address string) (string, error) {
+	var contractABI sql.NullString
+
+	query := `SELECT abi FROM smart_contracts WHERE address = $1`
+
+	err := r.db.QueryRowContext(ctx, query, address).Scan(&contractABI)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if contractABI.Valid {
+		return contractABI.String, nil
+	}
+
+	return "", nil
+}
@@ -3,10 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/hubweb3/worker/internal/domain/entities"
 	"github.com/hubweb3/worker/internal/domain/repositories"
+	"github.com/lib/pq"
 )
 
 var (
@@ -25,24 +28,69 @@ func NewPostgresTransactionRepositorySimple(db *sql.DB) repositories.Transaction
 	}
 }
 
-// Save salva uma transação no banco de dados
+// Save salva uma transação no banco de dados. transactions é particionada por block_number e
+// a chave única é (hash, block_number), então a transação já gravada (ex.: pendente, com
+// block_number NULL) é atualizada pelo hash, o que a move para a partição do bloco; o INSERT
+// só acontece quando o hash ainda não existe. Se outro worker inserir o mesmo hash entre o UPDATE e
+// o INSERT, transaction_hashes (migration 0006) rejeita o INSERT e o UPDATE é refeito
 func (r *PostgresTransactionRepositorySimple) Save(ctx context.Context, tx *entities.Transaction) error {
+	updateQuery := `
+		UPDATE transactions SET
+			block_number = $2,
+			block_hash = $3,
+			transaction_index = $4,
+			gas_used = $5,
+			status = $6,
+			mined_at = $7,
+			updated_at = $8,
+			revert_reason = COALESCE($9, revert_reason)
+		WHERE hash = $1
+	`
+
+	revertReason, err := revertReasonJSON(tx.RevertReason)
+	if err != nil {
+		return err
+	}
+
+	update := func() (bool, error) {
+		result, err := r.db.ExecContext(ctx, updateQuery,
+			tx.Hash,
+			tx.BlockNumber,
+			tx.BlockHash,
+			tx.TransactionIndex,
+			tx.GasUsed,
+			tx.Status,
+			tx.MinedAt,
+			tx.UpdatedAt,
+			revertReason,
+		)
+		if err != nil {
+			return false, err
+		}
+		updated, err := result.RowsAffected()
+		return err == nil && updated > 0, nil
+	}
+	if updated, err := update(); err != nil || updated {
+		return err
+	}
+
 	query := `
 		INSERT INTO transactions (
 			hash, block_number, block_hash, transaction_index, from_address, to_address,
 			value, gas_limit, gas_used, gas_price, max_fee_per_gas, max_priority_fee_per_gas,
-			nonce, data, status, transaction_type, mined_at, created_at, updated_at
+			nonce, data, status, transaction_type, mined_at, created_at, updated_at, revert_reason
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 		)
-		ON CONFLICT (hash) DO UPDATE SET
+		ON CONFLICT (hash, block_number) DO UPDATE SET
 			block_number = EXCLUDED.block_number,
 			block_hash = EXCLUDED.block_hash,
 			transaction_index = EXCLUDED.transaction_index,
 			gas_used = EXCLUDED.gas_used,
 			status = EXCLUDED.status,
 			mined_at = EXCLUDED.mined_at,
-			updated_at = EXCLUDED.updated_at
+			updated_at = EXCLUDED.updated_at,
+			revert_reason = COALESCE(EXCLUDED.revert_reason, transactions.revert_reason)
 	`
 
 	// Converter big.Int para string para armazenamento
@@ -68,7 +116,7 @@ func (r *PostgresTransactionRepositorySimple) Save(ctx context.Context, tx *enti
 		maxPriorityFeePerGasStr = &val
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = r.db.ExecContext(ctx, query,
 		tx.Hash,
 		tx.BlockNumber,
 		tx.BlockHash,
@@ -88,11 +136,23 @@ func (r *PostgresTransactionRepositorySimple) Save(ctx context.Context, tx *enti
 		tx.MinedAt,
 		tx.CreatedAt,
 		tx.UpdatedAt,
+		revertReason,
 	)
+	if isUniqueViolation(err) {
+		if updated, updateErr := update(); updateErr != nil || updated {
+			return updateErr
+		}
+	}
 
 	return err
 }
 
+// isUniqueViolation indica se o erro é uma violação de chave única (SQLSTATE 23505)
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
 // FindByHash busca uma transação pelo hash
 func (r *PostgresTransactionRepositorySimple) FindByHash(ctx context.Context, hash string) (*entities.Transaction, error) {
 	// TODO: Implementar busca por hash
@@ -149,10 +209,16 @@ func (r *PostgresTransactionRepositorySimple) Update(ctx context.Context, tx *en
 			status = $15,
 			transaction_type = $16,
 			mined_at = $17,
-			updated_at = $18
+			updated_at = $18,
+			revert_reason = COALESCE($19, revert_reason)
 		WHERE hash = $1
 	`
 
+	revertReason, err := revertReasonJSON(tx.RevertReason)
+	if err != nil {
+		return err
+	}
+
 	// Converter big.Int para string para armazenamento
 	var valueStr, gasPriceStr, maxFeePerGasStr, maxPriorityFeePerGasStr *string
 
@@ -176,7 +242,7 @@ func (r *PostgresTransactionRepositorySimple) Update(ctx context.Context, tx *en
 		maxPriorityFeePerGasStr = &val
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = r.db.ExecContext(ctx, query,
 		tx.Hash,
 		tx.BlockNumber,
 		tx.BlockHash,
@@ -195,11 +261,24 @@ func (r *PostgresTransactionRepositorySimple) Update(ctx context.Context, tx *en
 		tx.Type,
 		tx.MinedAt,
 		tx.UpdatedAt,
+		revertReason,
 	)
 
 	return err
 }
 
+// revertReasonJSON serializa o motivo da falha para a coluna JSONB (NULL quando ausente)
+func revertReasonJSON(reason *entities.RevertReason) (interface{}, error) {
+	if reason == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(reason)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar revert_reason: %w", err)
+	}
+	return string(data), nil
+}
+
 // Exists verifica se uma transação existe
 func (r *PostgresTransactionRepositorySimple) Exists(ctx context.Context, hash string) (bool, error) {
 	query := `SELECT 1 FROM transactions WHERE hash = $1 LIMIT 1`
@@ -0,0 +1,330 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// lockKey identifica o advisory lock que serializa execuções concorrentes do runner
+const lockKey = 7424002
+
+// AppliedVersion é uma linha de schema_migrations
+type AppliedVersion struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+	Baseline  bool // Marcada via baseline, sem executar o script
+}
+
+// MigrationStatus combina uma migration conhecida com seu registro no banco
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	Baseline  bool
+}
+
+// Runner aplica e reverte as migrations embutidas, registrando as versões em schema_migrations
+type Runner struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewRunner cria um runner com as migrations embutidas no binário
+func NewRunner(db *sql.DB) (*Runner, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{db: db, migrations: migrations}, nil
+}
+
+// Migrations retorna as migrations conhecidas, ordenadas por versão
+func (r *Runner) Migrations() []Migration {
+	return r.migrations
+}
+
+// Up aplica as migrations pendentes até a versão target (0 aplica todas)
+func (r *Runner) Up(ctx context.Context, target int64) ([]Migration, error) {
+	if target != 0 && r.find(target) == nil {
+		return nil, fmt.Errorf("versão %d não existe", target)
+	}
+
+	var done []Migration
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if unknown := r.unknown(applied); len(unknown) > 0 {
+			return fmt.Errorf("banco possui versões desconhecidas por este binário (%v); atualize o worker antes de migrar", unknown)
+		}
+
+		for _, migration := range r.migrations {
+			if target != 0 && migration.Version > target {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			if err := r.exec(ctx, conn, migration.Up,
+				`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+				migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("erro ao aplicar migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverte as últimas steps migrations aplicadas, da mais recente para a mais antiga
+func (r *Runner) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("steps deve ser maior que zero")
+	}
+
+	var done []Migration
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]int64, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for i := 0; i < steps && i < len(versions); i++ {
+			migration := r.find(versions[i])
+			if migration == nil {
+				return fmt.Errorf("versão %d aplicada no banco não é conhecida por este binário", versions[i])
+			}
+
+			if err := r.exec(ctx, conn, migration.Down,
+				`DELETE FROM schema_migrations WHERE version = $1`,
+				migration.Version); err != nil {
+				return fmt.Errorf("erro ao reverter migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, *migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline marca as migrations até version como aplicadas sem executá-las.
+// Usado em bancos criados antes do runner (ddl.sql e migrations numeradas antigas)
+func (r *Runner) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	if r.find(version) == nil {
+		return nil, fmt.Errorf("versão %d não existe", version)
+	}
+
+	var done []Migration
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := r.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(applied) > 0 {
+			return fmt.Errorf("schema_migrations já possui %d versões registradas; baseline só pode ser feito em um banco sem histórico", len(applied))
+		}
+
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("erro ao iniciar transação: %w", err)
+		}
+		defer tx.Rollback()
+
+		for _, migration := range r.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO schema_migrations (version, name, baseline) VALUES ($1, $2, TRUE)`,
+				migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("erro ao registrar versão %d: %w", migration.Version, err)
+			}
+			done = append(done, migration)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("erro ao confirmar baseline: %w", err)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status retorna a situação de cada migration conhecida e as versões do banco que o binário desconhece
+func (r *Runner) Status(ctx context.Context) ([]MigrationStatus, []AppliedVersion, error) {
+	initialized, err := r.initialized(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied := make(map[int64]AppliedVersion)
+	if initialized {
+		if applied, err = r.applied(ctx, r.db); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(r.migrations))
+	for _, migration := range r.migrations {
+		status := MigrationStatus{Migration: migration}
+		if row, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = row.AppliedAt
+			status.Baseline = row.Baseline
+		}
+		statuses = append(statuses, status)
+	}
+
+	var unknown []AppliedVersion
+	for _, version := range r.unknown(applied) {
+		unknown = append(unknown, applied[version])
+	}
+
+	return statuses, unknown, nil
+}
+
+// Check verifica se o schema do banco corresponde exatamente às migrations do binário.
+// Retorna erro se o banco não foi inicializado, se há migrations pendentes ou versões desconhecidas
+func (r *Runner) Check(ctx context.Context) error {
+	initialized, err := r.initialized(ctx)
+	if err != nil {
+		return err
+	}
+	if !initialized {
+		return fmt.Errorf("tabela schema_migrations não encontrada: execute \"worker migrate up\" (banco novo) ou \"worker migrate baseline --version N\" (banco existente)")
+	}
+
+	applied, err := r.applied(ctx, r.db)
+	if err != nil {
+		return err
+	}
+
+	if unknown := r.unknown(applied); len(unknown) > 0 {
+		return fmt.Errorf("banco possui versões desconhecidas por este binário (%v): o schema é mais novo que o worker", unknown)
+	}
+
+	var pending []int64
+	for _, migration := range r.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration.Version)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("migrations pendentes %v: execute \"worker migrate up\"", pending)
+	}
+
+	return nil
+}
+
+// queryer abstrai *sql.DB e *sql.Conn para as consultas de leitura
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// withLock executa fn em uma conexão dedicada com o advisory lock do runner e schema_migrations criada
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao obter conexão: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("erro ao obter lock de migrations: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			baseline BOOLEAN NOT NULL DEFAULT FALSE,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`); err != nil {
+		return fmt.Errorf("erro ao criar schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// exec executa um script e o registro em schema_migrations na mesma transação
+func (r *Runner) exec(ctx context.Context, conn *sql.Conn, script, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("erro ao registrar versão: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// initialized indica se a tabela schema_migrations existe
+func (r *Runner) initialized(ctx context.Context) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return false, fmt.Errorf("erro ao verificar schema_migrations: %w", err)
+	}
+	return exists, nil
+}
+
+// applied lê as versões registradas em schema_migrations
+func (r *Runner) applied(ctx context.Context, q queryer) (map[int64]AppliedVersion, error) {
+	rows, err := q.QueryContext(ctx, `SELECT version, name, baseline, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]AppliedVersion)
+	for rows.Next() {
+		var row AppliedVersion
+		if err := rows.Scan(&row.Version, &row.Name, &row.Baseline, &row.AppliedAt); err != nil {
+			return nil, fmt.Errorf("erro ao ler versão aplicada: %w", err)
+		}
+		applied[row.Version] = row
+	}
+	return applied, rows.Err()
+}
+
+// unknown retorna, em ordem, as versões aplicadas no banco que não existem no binário
+func (r *Runner) unknown(applied map[int64]AppliedVersion) []int64 {
+	var versions []int64
+	for version := range applied {
+		if r.find(version) == nil {
+			versions = append(versions, version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+// find retorna a migration com a versão informada, ou nil
+func (r *Runner) find(version int64) *Migration {
+	for i := range r.migrations {
+		if r.migrations[i].Version == version {
+			return &r.migrations[i]
+		}
+	}
+	return nil
+}
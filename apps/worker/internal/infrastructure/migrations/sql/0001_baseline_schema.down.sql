@@ -0,0 +1,24 @@
+-- Remove todo o schema base. Destrói os dados indexados: use apenas em ambientes de desenvolvimento
+DROP TABLE IF EXISTS public.user_sessions;
+DROP TABLE IF EXISTS public.users;
+DROP TABLE IF EXISTS public.transaction_methods;
+DROP TABLE IF EXISTS public.token_holdings;
+DROP TABLE IF EXISTS public.smart_contract_functions;
+DROP TABLE IF EXISTS public.smart_contract_events;
+DROP TABLE IF EXISTS public.smart_contract_daily_metrics;
+DROP TABLE IF EXISTS public.contract_interactions;
+DROP TABLE IF EXISTS public.account_tags;
+DROP TABLE IF EXISTS public.account_analytics;
+DROP TABLE IF EXISTS public.account_method_stats;
+DROP TABLE IF EXISTS public.account_events;
+DROP TABLE IF EXISTS public.account_transactions;
+DROP TABLE IF EXISTS public.validators;
+DROP TABLE IF EXISTS public.transactions;
+DROP TABLE IF EXISTS public.smart_contracts;
+DROP TABLE IF EXISTS public.events;
+DROP TABLE IF EXISTS public.blocks;
+DROP TABLE IF EXISTS public.accounts;
+
+DROP FUNCTION IF EXISTS public.update_validators_updated_at();
+DROP FUNCTION IF EXISTS public.update_events_updated_at();
+DROP FUNCTION IF EXISTS public.update_updated_at_column();
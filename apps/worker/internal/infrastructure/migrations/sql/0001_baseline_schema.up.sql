@@ -1,284 +1,40 @@
--- DROP SCHEMA public;
+-- Schema base do explorer (blocos, transações, contas, contratos, eventos, validadores e usuários)
+-- Consolida as antigas migrations 000 a 013 e o database/ddl.sql. Bancos criados por esses
+-- arquivos devem ser marcados com "worker migrate baseline --version 1" em vez de executar esta migration
 
-CREATE SCHEMA public AUTHORIZATION pg_database_owner;
-
-COMMENT ON SCHEMA public IS 'standard public schema';
-
--- DROP SEQUENCE public.account_events_id_seq;
-
-CREATE SEQUENCE public.account_events_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.account_events_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.account_events_id_seq TO explorer;
-
--- DROP SEQUENCE public.account_events_id_seq1;
-
-CREATE SEQUENCE public.account_events_id_seq1
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.account_events_id_seq1 OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.account_events_id_seq1 TO explorer;
-
--- DROP SEQUENCE public.account_method_stats_id_seq;
-
-CREATE SEQUENCE public.account_method_stats_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.account_method_stats_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.account_method_stats_id_seq TO explorer;
-
--- DROP SEQUENCE public.account_method_stats_id_seq1;
-
-CREATE SEQUENCE public.account_method_stats_id_seq1
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.account_method_stats_id_seq1 OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.account_method_stats_id_seq1 TO explorer;
-
--- DROP SEQUENCE public.account_transactions_id_seq;
-
-CREATE SEQUENCE public.account_transactions_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.account_transactions_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.account_transactions_id_seq TO explorer;
-
--- DROP SEQUENCE public.account_transactions_id_seq1;
-
-CREATE SEQUENCE public.account_transactions_id_seq1
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.account_transactions_id_seq1 OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.account_transactions_id_seq1 TO explorer;
-
--- DROP SEQUENCE public.contract_interactions_id_seq;
-
-CREATE SEQUENCE public.contract_interactions_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.contract_interactions_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.contract_interactions_id_seq TO explorer;
-
--- DROP SEQUENCE public.contract_interactions_id_seq1;
-
-CREATE SEQUENCE public.contract_interactions_id_seq1
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.contract_interactions_id_seq1 OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.contract_interactions_id_seq1 TO explorer;
-
--- DROP SEQUENCE public.smart_contract_daily_metrics_id_seq;
-
-CREATE SEQUENCE public.smart_contract_daily_metrics_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 2147483647
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.smart_contract_daily_metrics_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.smart_contract_daily_metrics_id_seq TO explorer;
-
--- DROP SEQUENCE public.smart_contract_daily_metrics_id_seq1;
-
-CREATE SEQUENCE public.smart_contract_daily_metrics_id_seq1
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 2147483647
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.smart_contract_daily_metrics_id_seq1 OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.smart_contract_daily_metrics_id_seq1 TO explorer;
-
--- DROP SEQUENCE public.smart_contract_events_id_seq;
-
-CREATE SEQUENCE public.smart_contract_events_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 2147483647
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.smart_contract_events_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.smart_contract_events_id_seq TO explorer;
-
--- DROP SEQUENCE public.smart_contract_events_id_seq1;
-
-CREATE SEQUENCE public.smart_contract_events_id_seq1
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 2147483647
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.smart_contract_events_id_seq1 OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.smart_contract_events_id_seq1 TO explorer;
-
--- DROP SEQUENCE public.smart_contract_functions_id_seq;
-
-CREATE SEQUENCE public.smart_contract_functions_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 2147483647
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.smart_contract_functions_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.smart_contract_functions_id_seq TO explorer;
-
--- DROP SEQUENCE public.smart_contract_functions_id_seq1;
-
-CREATE SEQUENCE public.smart_contract_functions_id_seq1
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 2147483647
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.smart_contract_functions_id_seq1 OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.smart_contract_functions_id_seq1 TO explorer;
-
--- DROP SEQUENCE public.transaction_methods_id_seq;
-
-CREATE SEQUENCE public.transaction_methods_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.transaction_methods_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.transaction_methods_id_seq TO explorer;
-
--- DROP SEQUENCE public.transaction_methods_id_seq1;
-
-CREATE SEQUENCE public.transaction_methods_id_seq1
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 9223372036854775807
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.transaction_methods_id_seq1 OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.transaction_methods_id_seq1 TO explorer;
-
--- DROP SEQUENCE public.user_sessions_id_seq;
-
-CREATE SEQUENCE public.user_sessions_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 2147483647
-	START 1
-	CACHE 1
-	NO CYCLE;
-
--- Permissions
-
-ALTER SEQUENCE public.user_sessions_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.user_sessions_id_seq TO explorer;
-
--- DROP SEQUENCE public.users_id_seq;
+-- Funções de trigger para updated_at
+CREATE OR REPLACE FUNCTION public.update_updated_at_column()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$function$;
 
-CREATE SEQUENCE public.users_id_seq
-	INCREMENT BY 1
-	MINVALUE 1
-	MAXVALUE 2147483647
-	START 1
-	CACHE 1
-	NO CYCLE;
+CREATE OR REPLACE FUNCTION public.update_events_updated_at()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$function$;
 
--- Permissions
+CREATE OR REPLACE FUNCTION public.update_validators_updated_at()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$function$;
 
-ALTER SEQUENCE public.users_id_seq OWNER TO explorer;
-GRANT ALL ON SEQUENCE public.users_id_seq TO explorer;
 -- public.account_events definition
 
--- Drop table
-
--- DROP TABLE public.account_events;
-
 CREATE TABLE public.account_events (
 	id bigserial NOT NULL,
 	account_address varchar(42) NOT NULL,
@@ -321,18 +77,8 @@ COMMENT ON COLUMN public.account_events.updated_at IS 'Data e hora da última at
 
 COMMENT ON CONSTRAINT unique_account_event ON public.account_events IS 'Garante que cada evento é único por conta';
 
--- Permissions
-
-ALTER TABLE public.account_events OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.account_events TO explorer;
-
-
 -- public.account_method_stats definition
 
--- Drop table
-
--- DROP TABLE public.account_method_stats;
-
 CREATE TABLE public.account_method_stats (
 	id bigserial NOT NULL,
 	account_address varchar(42) NOT NULL,
@@ -371,18 +117,8 @@ COMMENT ON COLUMN public.account_method_stats.total_gas_used IS 'Total de gas us
 COMMENT ON COLUMN public.account_method_stats.avg_gas_used IS 'Média de gas usado por execução';
 COMMENT ON COLUMN public.account_method_stats.created_at IS 'Data de criação do registro de estatísticas';
 
--- Permissions
-
-ALTER TABLE public.account_method_stats OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.account_method_stats TO explorer;
-
-
 -- public.account_transactions definition
 
--- Drop table
-
--- DROP TABLE public.account_transactions;
-
 CREATE TABLE public.account_transactions (
 	id bigserial NOT NULL,
 	account_address varchar(42) NOT NULL,
@@ -425,18 +161,8 @@ COMMENT ON COLUMN public.account_transactions.transaction_type IS 'Tipo da trans
 COMMENT ON COLUMN public.account_transactions.status IS 'Status da transação: success, failed, pending';
 COMMENT ON COLUMN public.account_transactions.decoded_input IS 'Input da transação decodificado em formato JSON';
 
--- Permissions
-
-ALTER TABLE public.account_transactions OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.account_transactions TO explorer;
-
-
 -- public.accounts definition
 
--- Drop table
-
--- DROP TABLE public.accounts;
-
 CREATE TABLE public.accounts (
 	address varchar(42) NOT NULL, -- Endereço da conta (chave primária)
 	account_type varchar(20) DEFAULT 'eoa'::character varying NOT NULL, -- Tipo de conta: eoa ou smart_account
@@ -496,18 +222,8 @@ COMMENT ON COLUMN public.accounts.risk_score IS 'Score de risco (0-10)';
 COMMENT ON COLUMN public.accounts.compliance_status IS 'Status de compliance';
 COMMENT ON COLUMN public.accounts.compliance_notes IS 'Notas de compliance';
 
--- Permissions
-
-ALTER TABLE public.accounts OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.accounts TO explorer;
-
-
 -- public.blocks definition
 
--- Drop table
-
--- DROP TABLE public.blocks;
-
 CREATE TABLE public.blocks (
 	"number" int8 NOT NULL, -- Número sequencial do bloco
 	hash varchar(66) NOT NULL, -- Hash único do bloco (chave primária)
@@ -570,18 +286,8 @@ COMMENT ON COLUMN public.blocks.receipt_hash IS 'Hash da árvore Merkle das rece
 COMMENT ON COLUMN public.blocks.state_root IS 'Root da árvore Merkle do estado';
 COMMENT ON COLUMN public.blocks.tx_hash IS 'Hash da árvore Merkle das transações';
 
--- Permissions
-
-ALTER TABLE public.blocks OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.blocks TO explorer;
-
-
 -- public.events definition
 
--- Drop table
-
--- DROP TABLE public.events;
-
 CREATE TABLE public.events (
 	id varchar(255) NOT NULL,
 	contract_address varchar(42) NOT NULL,
@@ -623,18 +329,8 @@ CREATE INDEX idx_events_topics_gin ON public.events USING gin (topics);
 CREATE INDEX idx_events_transaction_hash ON public.events USING btree (transaction_hash);
 CREATE INDEX idx_events_tx_hash ON public.events USING btree (transaction_hash);
 
--- Permissions
-
-ALTER TABLE public.events OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.events TO explorer;
-
-
 -- public.smart_contracts definition
 
--- Drop table
-
--- DROP TABLE public.smart_contracts;
-
 CREATE TABLE public.smart_contracts (
 	address varchar(42) NOT NULL,
 	"name" varchar(255) NULL,
@@ -702,18 +398,8 @@ COMMENT ON COLUMN public.smart_contracts.total_value_transferred IS 'Valor total
 COMMENT ON COLUMN public.smart_contracts.is_proxy IS 'Indica se o contrato é um proxy (EIP-1967, etc.)';
 COMMENT ON COLUMN public.smart_contracts.proxy_implementation IS 'Endereço da implementação se for um proxy';
 
--- Permissions
-
-ALTER TABLE public.smart_contracts OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.smart_contracts TO explorer;
-
-
 -- public.transactions definition
 
--- Drop table
-
--- DROP TABLE public.transactions;
-
 CREATE TABLE public.transactions (
 	hash varchar(66) NOT NULL, -- Hash único da transação (chave primária)
 	block_number int8 NULL, -- Número do bloco (NULL para pendentes)
@@ -784,18 +470,8 @@ COMMENT ON COLUMN public.transactions.contract_address IS 'Endereço do contrato
 COMMENT ON COLUMN public.transactions.logs_bloom IS 'Bloom filter dos logs da transação';
 COMMENT ON COLUMN public.transactions.mined_at IS 'Timestamp de quando a transação foi minerada';
 
--- Permissions
-
-ALTER TABLE public.transactions OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.transactions TO explorer;
-
-
 -- public.users definition
 
--- Drop table
-
--- DROP TABLE public.users;
-
 CREATE TABLE public.users (
 	id serial4 NOT NULL,
 	username varchar(50) NOT NULL,
@@ -816,23 +492,11 @@ CREATE INDEX idx_users_username ON public.users USING btree (username);
 
 -- Table Triggers
 
-create trigger update_users_updated_at before
-update
-    on
-    public.users for each row execute function update_updated_at_column();
-
--- Permissions
-
-ALTER TABLE public.users OWNER TO explorer;
-GRANT ALL ON TABLE public.users TO explorer;
-
+CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON public.users
+    FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
 
 -- public.validators definition
 
--- Drop table
-
--- DROP TABLE public.validators;
-
 CREATE TABLE public.validators (
 	address varchar(42) NOT NULL,
 	proposed_block_count text DEFAULT '0'::text NOT NULL,
@@ -852,18 +516,8 @@ CREATE INDEX idx_validators_last_seen ON public.validators USING btree (last_see
 CREATE INDEX idx_validators_status ON public.validators USING btree (status);
 CREATE INDEX idx_validators_uptime ON public.validators USING btree (uptime DESC);
 
--- Permissions
-
-ALTER TABLE public.validators OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.validators TO explorer;
-
-
 -- public.account_analytics definition
 
--- Drop table
-
--- DROP TABLE public.account_analytics;
-
 CREATE TABLE public.account_analytics (
 	address varchar(42) NOT NULL,
 	"date" date NOT NULL,
@@ -885,18 +539,8 @@ CREATE INDEX idx_account_analytics_transactions_count ON public.account_analytic
 CREATE INDEX idx_account_analytics_value_transferred ON public.account_analytics USING btree (value_transferred);
 COMMENT ON TABLE public.account_analytics IS 'Métricas analíticas diárias das contas';
 
--- Permissions
-
-ALTER TABLE public.account_analytics OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.account_analytics TO explorer;
-
-
 -- public.account_tags definition
 
--- Drop table
-
--- DROP TABLE public.account_tags;
-
 CREATE TABLE public.account_tags (
 	address varchar(42) NOT NULL,
 	tag varchar(100) NOT NULL,
@@ -909,18 +553,8 @@ CREATE INDEX idx_account_tags_created_at ON public.account_tags USING btree (cre
 CREATE INDEX idx_account_tags_tag ON public.account_tags USING btree (tag);
 COMMENT ON TABLE public.account_tags IS 'Tags associadas às contas';
 
--- Permissions
-
-ALTER TABLE public.account_tags OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.account_tags TO explorer;
-
-
 -- public.contract_interactions definition
 
--- Drop table
-
--- DROP TABLE public.contract_interactions;
-
 CREATE TABLE public.contract_interactions (
 	id bigserial NOT NULL,
 	account_address varchar(42) NOT NULL,
@@ -944,18 +578,8 @@ CREATE INDEX idx_contract_interactions_last_interaction ON public.contract_inter
 CREATE INDEX idx_contract_interactions_method ON public.contract_interactions USING btree (method);
 COMMENT ON TABLE public.contract_interactions IS 'Interações das contas com contratos';
 
--- Permissions
-
-ALTER TABLE public.contract_interactions OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.contract_interactions TO explorer;
-
-
 -- public.smart_contract_daily_metrics definition
 
--- Drop table
-
--- DROP TABLE public.smart_contract_daily_metrics;
-
 CREATE TABLE public.smart_contract_daily_metrics (
 	id serial4 NOT NULL,
 	contract_address varchar(42) NOT NULL,
@@ -977,18 +601,8 @@ CREATE INDEX idx_contract_daily_metrics_date ON public.smart_contract_daily_metr
 CREATE INDEX idx_contract_daily_metrics_transactions ON public.smart_contract_daily_metrics USING btree (transactions_count);
 COMMENT ON TABLE public.smart_contract_daily_metrics IS 'Métricas diárias agregadas por smart contract';
 
--- Permissions
-
-ALTER TABLE public.smart_contract_daily_metrics OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.smart_contract_daily_metrics TO explorer;
-
-
 -- public.smart_contract_events definition
 
--- Drop table
-
--- DROP TABLE public.smart_contract_events;
-
 CREATE TABLE public.smart_contract_events (
 	id serial4 NOT NULL,
 	contract_address varchar(42) NOT NULL,
@@ -1009,18 +623,8 @@ CREATE INDEX idx_contract_events_name ON public.smart_contract_events USING btre
 CREATE INDEX idx_contract_events_signature ON public.smart_contract_events USING btree (event_signature);
 COMMENT ON TABLE public.smart_contract_events IS 'Eventos definidos em cada smart contract (parsed do ABI)';
 
--- Permissions
-
-ALTER TABLE public.smart_contract_events OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.smart_contract_events TO explorer;
-
-
 -- public.smart_contract_functions definition
 
--- Drop table
-
--- DROP TABLE public.smart_contract_functions;
-
 CREATE TABLE public.smart_contract_functions (
 	id serial4 NOT NULL,
 	contract_address varchar(42) NOT NULL,
@@ -1044,18 +648,8 @@ CREATE INDEX idx_contract_functions_signature ON public.smart_contract_functions
 CREATE INDEX idx_contract_functions_type ON public.smart_contract_functions USING btree (function_type);
 COMMENT ON TABLE public.smart_contract_functions IS 'Funções disponíveis em cada smart contract (parsed do ABI)';
 
--- Permissions
-
-ALTER TABLE public.smart_contract_functions OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.smart_contract_functions TO explorer;
-
-
 -- public.token_holdings definition
 
--- Drop table
-
--- DROP TABLE public.token_holdings;
-
 CREATE TABLE public.token_holdings (
 	account_address varchar(42) NOT NULL,
 	token_address varchar(42) NOT NULL,
@@ -1077,18 +671,8 @@ CREATE INDEX idx_token_holdings_token_symbol ON public.token_holdings USING btre
 CREATE INDEX idx_token_holdings_value_usd ON public.token_holdings USING btree (value_usd);
 COMMENT ON TABLE public.token_holdings IS 'Holdings de tokens das contas';
 
--- Permissions
-
-ALTER TABLE public.token_holdings OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.token_holdings TO explorer;
-
-
 -- public.transaction_methods definition
 
--- Drop table
-
--- DROP TABLE public.transaction_methods;
-
 CREATE TABLE public.transaction_methods (
 	id bigserial NOT NULL,
 	transaction_hash varchar(66) NOT NULL, -- Hash da transação
@@ -1118,18 +702,8 @@ COMMENT ON COLUMN public.transaction_methods.method_type IS 'Tipo do método (tr
 COMMENT ON COLUMN public.transaction_methods.contract_address IS 'Endereço do contrato (se aplicável)';
 COMMENT ON COLUMN public.transaction_methods.decoded_params IS 'Parâmetros decodificados em JSON (opcional)';
 
--- Permissions
-
-ALTER TABLE public.transaction_methods OWNER TO explorer;
-GRANT DELETE, INSERT, REFERENCES, TRIGGER, UPDATE, SELECT, TRUNCATE ON TABLE public.transaction_methods TO explorer;
-
-
 -- public.user_sessions definition
 
--- Drop table
-
--- DROP TABLE public.user_sessions;
-
 CREATE TABLE public.user_sessions (
 	id serial4 NOT NULL,
 	user_id int4 NOT NULL,
@@ -1146,112 +720,22 @@ CREATE INDEX idx_user_sessions_is_active ON public.user_sessions USING btree (is
 CREATE INDEX idx_user_sessions_token ON public.user_sessions USING btree (token);
 CREATE INDEX idx_user_sessions_user_id ON public.user_sessions USING btree (user_id);
 
--- Permissions
-
-ALTER TABLE public.user_sessions OWNER TO explorer;
-GRANT ALL ON TABLE public.user_sessions TO explorer;
-
-
-
--- DROP FUNCTION public.pg_stat_statements(in bool, out oid, out oid, out bool, out int8, out text, out int8, out float8, out float8, out float8, out float8, out float8, out int8, out float8, out float8, out float8, out float8, out float8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out float8, out float8, out float8, out float8, out int8, out int8, out numeric, out int8, out float8, out int8, out float8, out int8, out float8, out int8, out float8);
-
-CREATE OR REPLACE FUNCTION public.pg_stat_statements(showtext boolean, OUT userid oid, OUT dbid oid, OUT toplevel boolean, OUT queryid bigint, OUT query text, OUT plans bigint, OUT total_plan_time double precision, OUT min_plan_time double precision, OUT max_plan_time double precision, OUT mean_plan_time double precision, OUT stddev_plan_time double precision, OUT calls bigint, OUT total_exec_time double precision, OUT min_exec_time double precision, OUT max_exec_time double precision, OUT mean_exec_time double precision, OUT stddev_exec_time double precision, OUT rows bigint, OUT shared_blks_hit bigint, OUT shared_blks_read bigint, OUT shared_blks_dirtied bigint, OUT shared_blks_written bigint, OUT local_blks_hit bigint, OUT local_blks_read bigint, OUT local_blks_dirtied bigint, OUT local_blks_written bigint, OUT temp_blks_read bigint, OUT temp_blks_written bigint, OUT blk_read_time double precision, OUT blk_write_time double precision, OUT temp_blk_read_time double precision, OUT temp_blk_write_time double precision, OUT wal_records bigint, OUT wal_fpi bigint, OUT wal_bytes numeric, OUT jit_functions bigint, OUT jit_generation_time double precision, OUT jit_inlining_count bigint, OUT jit_inlining_time double precision, OUT jit_optimization_count bigint, OUT jit_optimization_time double precision, OUT jit_emission_count bigint, OUT jit_emission_time double precision)
- RETURNS SETOF record
- LANGUAGE c
- PARALLEL SAFE STRICT
-AS '$libdir/pg_stat_statements', $function$pg_stat_statements_1_10$function$
-;
-
--- Permissions
-
-ALTER FUNCTION public.pg_stat_statements(in bool, out oid, out oid, out bool, out int8, out text, out int8, out float8, out float8, out float8, out float8, out float8, out int8, out float8, out float8, out float8, out float8, out float8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out float8, out float8, out float8, out float8, out int8, out int8, out numeric, out int8, out float8, out int8, out float8, out int8, out float8, out int8, out float8) OWNER TO explorer;
-GRANT ALL ON FUNCTION public.pg_stat_statements(in bool, out oid, out oid, out bool, out int8, out text, out int8, out float8, out float8, out float8, out float8, out float8, out int8, out float8, out float8, out float8, out float8, out float8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out float8, out float8, out float8, out float8, out int8, out int8, out numeric, out int8, out float8, out int8, out float8, out int8, out float8, out int8, out float8) TO public;
-GRANT ALL ON FUNCTION public.pg_stat_statements(in bool, out oid, out oid, out bool, out int8, out text, out int8, out float8, out float8, out float8, out float8, out float8, out int8, out float8, out float8, out float8, out float8, out float8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out int8, out float8, out float8, out float8, out float8, out int8, out int8, out numeric, out int8, out float8, out int8, out float8, out int8, out float8, out int8, out float8) TO explorer;
-
--- DROP FUNCTION public.pg_stat_statements_info(out int8, out timestamptz);
-
-CREATE OR REPLACE FUNCTION public.pg_stat_statements_info(OUT dealloc bigint, OUT stats_reset timestamp with time zone)
- RETURNS record
- LANGUAGE c
- PARALLEL SAFE STRICT
-AS '$libdir/pg_stat_statements', $function$pg_stat_statements_info$function$
-;
-
--- Permissions
-
-ALTER FUNCTION public.pg_stat_statements_info(out int8, out timestamptz) OWNER TO explorer;
-GRANT ALL ON FUNCTION public.pg_stat_statements_info(out int8, out timestamptz) TO public;
-GRANT ALL ON FUNCTION public.pg_stat_statements_info(out int8, out timestamptz) TO explorer;
-
--- DROP FUNCTION public.pg_stat_statements_reset(oid, oid, int8);
-
-CREATE OR REPLACE FUNCTION public.pg_stat_statements_reset(userid oid DEFAULT 0, dbid oid DEFAULT 0, queryid bigint DEFAULT 0)
- RETURNS void
- LANGUAGE c
- PARALLEL SAFE STRICT
-AS '$libdir/pg_stat_statements', $function$pg_stat_statements_reset_1_7$function$
-;
-
--- Permissions
-
-ALTER FUNCTION public.pg_stat_statements_reset(oid, oid, int8) OWNER TO explorer;
-GRANT ALL ON FUNCTION public.pg_stat_statements_reset(oid, oid, int8) TO public;
-GRANT ALL ON FUNCTION public.pg_stat_statements_reset(oid, oid, int8) TO explorer;
-
--- DROP FUNCTION public.update_events_updated_at();
-
-CREATE OR REPLACE FUNCTION public.update_events_updated_at()
- RETURNS trigger
- LANGUAGE plpgsql
-AS $function$
-BEGIN
-    NEW.updated_at = NOW();
-    RETURN NEW;
-END;
-$function$
-;
-
--- Permissions
-
-ALTER FUNCTION public.update_events_updated_at() OWNER TO explorer;
-GRANT ALL ON FUNCTION public.update_events_updated_at() TO public;
-GRANT ALL ON FUNCTION public.update_events_updated_at() TO explorer;
-
--- DROP FUNCTION public.update_updated_at_column();
-
-CREATE OR REPLACE FUNCTION public.update_updated_at_column()
- RETURNS trigger
- LANGUAGE plpgsql
-AS $function$
-BEGIN
-    NEW.updated_at = CURRENT_TIMESTAMP;
-    RETURN NEW;
-END;
-$function$
-;
-
--- Permissions
-
-ALTER FUNCTION public.update_updated_at_column() OWNER TO explorer;
-GRANT ALL ON FUNCTION public.update_updated_at_column() TO public;
-GRANT ALL ON FUNCTION public.update_updated_at_column() TO explorer;
-
--- DROP FUNCTION public.update_validators_updated_at();
-
-CREATE OR REPLACE FUNCTION public.update_validators_updated_at()
- RETURNS trigger
- LANGUAGE plpgsql
-AS $function$ BEGIN NEW.updated_at = CURRENT_TIMESTAMP; RETURN NEW; END; $function$
-;
-
--- Permissions
-
-ALTER FUNCTION public.update_validators_updated_at() OWNER TO explorer;
-GRANT ALL ON FUNCTION public.update_validators_updated_at() TO public;
-GRANT ALL ON FUNCTION public.update_validators_updated_at() TO explorer;
-
-
--- Permissions
-
-GRANT ALL ON SCHEMA public TO pg_database_owner;
-GRANT USAGE ON SCHEMA public TO public;
+-- Triggers de updated_at
+CREATE TRIGGER update_accounts_updated_at BEFORE UPDATE ON public.accounts
+    FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
+CREATE TRIGGER update_smart_contracts_updated_at BEFORE UPDATE ON public.smart_contracts
+    FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
+CREATE TRIGGER trigger_events_updated_at BEFORE UPDATE ON public.events
+    FOR EACH ROW EXECUTE FUNCTION public.update_events_updated_at();
+CREATE TRIGGER update_validators_updated_at_trigger BEFORE UPDATE ON public.validators
+    FOR EACH ROW EXECUTE FUNCTION public.update_validators_updated_at();
+CREATE TRIGGER update_contract_interactions_updated_at BEFORE UPDATE ON public.contract_interactions
+    FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
+CREATE TRIGGER update_contract_events_updated_at BEFORE UPDATE ON public.smart_contract_events
+    FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
+CREATE TRIGGER update_contract_functions_updated_at BEFORE UPDATE ON public.smart_contract_functions
+    FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
+CREATE TRIGGER update_token_holdings_updated_at BEFORE UPDATE ON public.token_holdings
+    FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
+CREATE TRIGGER update_account_analytics_updated_at BEFORE UPDATE ON public.account_analytics
+    FOR EACH ROW EXECUTE FUNCTION public.update_updated_at_column();
@@ -0,0 +1,54 @@
+-- Regras de alerta com entrega via webhook
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    subject_type VARCHAR(30) NOT NULL, -- transaction, event, token_transfer, compliance
+    conditions JSONB NOT NULL DEFAULT '{}'::jsonb, -- Condições avaliadas pelo worker
+    webhook_url TEXT NOT NULL,
+    webhook_secret VARCHAR(255) NOT NULL, -- Segredo usado na assinatura HMAC-SHA256
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by VARCHAR(255),
+    last_triggered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT alert_rules_subject_type_check CHECK (subject_type IN ('transaction', 'event', 'token_transfer', 'compliance'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_active ON alert_rules(subject_type) WHERE is_active = true;
+CREATE INDEX IF NOT EXISTS idx_alert_rules_created_by ON alert_rules(created_by);
+
+-- Log de entregas de webhook (uma entrega por regra e ocorrência)
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    subject_type VARCHAR(30) NOT NULL,
+    subject_key VARCHAR(255) NOT NULL, -- Identificador da ocorrência (hash, id do evento, etc.)
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, delivered, failed
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT alert_deliveries_unique_subject UNIQUE (rule_id, subject_key),
+    CONSTRAINT alert_deliveries_status_check CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_deliveries_due ON alert_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_alert_deliveries_rule ON alert_deliveries(rule_id, created_at DESC);
+
+-- Triggers para updated_at (update_updated_at_column é criada na migration 0001)
+DROP TRIGGER IF EXISTS update_alert_rules_updated_at ON alert_rules;
+CREATE TRIGGER update_alert_rules_updated_at
+    BEFORE UPDATE ON alert_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_alert_deliveries_updated_at ON alert_deliveries;
+CREATE TRIGGER update_alert_deliveries_updated_at
+    BEFORE UPDATE ON alert_deliveries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
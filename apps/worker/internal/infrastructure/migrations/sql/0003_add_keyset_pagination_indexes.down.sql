@@ -0,0 +1,2 @@
+-- idx_transactions_block_tx_index já fazia parte do schema base (0001) e é mantido
+DROP INDEX IF EXISTS idx_account_transactions_keyset;
@@ -0,0 +1,9 @@
+-- Índices para paginação por keyset (cursor) nas listagens
+-- As consultas ordenam por (block_number, transaction_index) / (block_number, log_index)
+-- e posicionam a página com comparação de tupla, evitando OFFSET em páginas profundas
+
+CREATE INDEX IF NOT EXISTS idx_transactions_block_tx_index ON transactions(block_number, transaction_index);
+
+CREATE INDEX IF NOT EXISTS idx_account_transactions_keyset ON account_transactions(account_address, block_number, transaction_index);
+
+-- events já possui idx_events_block_log (block_number, log_index, transaction_hash)
@@ -0,0 +1,7 @@
+-- A extensão pg_trgm é mantida: pode estar em uso por outros objetos do banco
+DROP INDEX IF EXISTS idx_smart_contracts_address_prefix;
+DROP INDEX IF EXISTS idx_accounts_address_prefix;
+DROP INDEX IF EXISTS idx_accounts_label_trgm;
+DROP INDEX IF EXISTS idx_contract_events_name_trgm;
+DROP INDEX IF EXISTS idx_smart_contracts_symbol_trgm;
+DROP INDEX IF EXISTS idx_smart_contracts_name_trgm;
@@ -0,0 +1,17 @@
+-- Índices para a busca universal (/api/search)
+-- Busca por prefixo e similaridade em nomes/símbolos usa trigramas (pg_trgm)
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Smart contracts e tokens: nome e símbolo
+CREATE INDEX IF NOT EXISTS idx_smart_contracts_name_trgm ON smart_contracts USING GIN (LOWER(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_smart_contracts_symbol_trgm ON smart_contracts USING GIN (LOWER(symbol) gin_trgm_ops);
+
+-- Nomes de eventos das ABIs registradas
+CREATE INDEX IF NOT EXISTS idx_contract_events_name_trgm ON smart_contract_events USING GIN (LOWER(event_name) gin_trgm_ops);
+
+-- Labels de accounts
+CREATE INDEX IF NOT EXISTS idx_accounts_label_trgm ON accounts USING GIN (LOWER(label) gin_trgm_ops) WHERE label IS NOT NULL;
+
+-- Prefixo de endereço (type-ahead de 0x...)
+CREATE INDEX IF NOT EXISTS idx_accounts_address_prefix ON accounts (LOWER(address) varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_smart_contracts_address_prefix ON smart_contracts (LOWER(address) varchar_pattern_ops);
@@ -0,0 +1,20 @@
+-- Checkpoints do backfill histórico (worker backfill)
+-- Cada faixa de blocos é reservada por um processo, gravada em lote e marcada como concluída,
+-- permitindo retomar o backfill e rodar mais de um processo sem repetir faixas
+CREATE TABLE IF NOT EXISTS backfill_ranges (
+    range_start BIGINT NOT NULL,
+    range_end BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running', -- running, completed, failed
+    owner VARCHAR(255), -- Processo que reservou a faixa (hostname:pid)
+    block_count INTEGER NOT NULL DEFAULT 0,
+    tx_count INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    last_error TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (range_start, range_end),
+    CONSTRAINT backfill_ranges_status_check CHECK (status IN ('running', 'completed', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_backfill_ranges_status ON backfill_ranges(status, range_start);
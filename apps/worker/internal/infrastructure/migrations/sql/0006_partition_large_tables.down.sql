@@ -0,0 +1,226 @@
+-- Desfaz o particionamento: cada tabela volta a ser uma tabela comum com as chaves originais.
+-- Partições arquivadas (schema archive) não são reincorporadas e permanecem no schema archive.
+--
+-- ATENÇÃO: copia os dados de volta; em bases grandes execute em janela de manutenção.
+-- Falha se houver linhas que violem as chaves originais (ex.: mesmo hash de transação em dois blocos).
+
+-- =============================================================================
+-- transaction_hashes
+-- =============================================================================
+ALTER TABLE transaction_methods DROP CONSTRAINT IF EXISTS fk_transaction_methods_hash;
+DROP TRIGGER IF EXISTS trg_transaction_hashes_update ON transactions;
+DROP TRIGGER IF EXISTS trg_transaction_hashes_delete ON transactions;
+DROP TRIGGER IF EXISTS trg_transaction_hashes_insert ON transactions;
+DROP FUNCTION IF EXISTS transaction_hashes_update();
+DROP FUNCTION IF EXISTS transaction_hashes_delete();
+DROP FUNCTION IF EXISTS transaction_hashes_insert();
+DROP TABLE IF EXISTS transaction_hashes;
+
+-- =============================================================================
+-- transactions
+-- =============================================================================
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'transactions'::regclass) THEN
+        RAISE NOTICE 'transactions não é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE transactions RENAME TO transactions_partitioned;
+
+    CREATE TABLE transactions (
+        LIKE transactions_partitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    );
+    INSERT INTO transactions SELECT * FROM transactions_partitioned;
+    DROP TABLE transactions_partitioned;
+
+    ALTER TABLE transactions ADD CONSTRAINT transactions_pkey PRIMARY KEY (hash);
+    ALTER TABLE transactions ADD CONSTRAINT unique_tx_per_block UNIQUE (block_hash, transaction_index);
+
+    CREATE INDEX idx_transactions_address_composite ON transactions (from_address, created_at DESC);
+    CREATE INDEX idx_transactions_addresses ON transactions USING gin ((ARRAY[from_address, to_address]));
+    CREATE INDEX idx_transactions_block_hash ON transactions (block_hash);
+    CREATE INDEX idx_transactions_block_number ON transactions (block_number);
+    CREATE INDEX idx_transactions_block_tx_index ON transactions (block_number, transaction_index);
+    CREATE INDEX idx_transactions_contract_address ON transactions (contract_address);
+    CREATE INDEX idx_transactions_created_at ON transactions (created_at);
+    CREATE INDEX idx_transactions_deleted_at ON transactions (deleted_at) WHERE deleted_at IS NULL;
+    CREATE INDEX idx_transactions_from_address ON transactions (from_address);
+    CREATE INDEX idx_transactions_gas_used ON transactions (gas_used);
+    CREATE INDEX idx_transactions_hash ON transactions USING hash (hash);
+    CREATE INDEX idx_transactions_mined_at ON transactions (mined_at);
+    CREATE INDEX idx_transactions_nonce ON transactions (from_address, nonce);
+    CREATE INDEX idx_transactions_status ON transactions (status);
+    CREATE INDEX idx_transactions_to_address ON transactions (to_address);
+    CREATE INDEX idx_transactions_to_address_composite ON transactions (to_address, created_at DESC);
+    CREATE INDEX idx_transactions_transaction_index ON transactions (transaction_index);
+    CREATE INDEX idx_transactions_type ON transactions (transaction_type);
+
+    ALTER TABLE transaction_methods ADD CONSTRAINT fk_transaction_methods_hash
+        FOREIGN KEY (transaction_hash) REFERENCES transactions(hash) ON DELETE CASCADE;
+
+    COMMENT ON TABLE transactions IS 'Tabela de transações da blockchain';
+END $$;
+
+-- =============================================================================
+-- events
+-- =============================================================================
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'events'::regclass) THEN
+        RAISE NOTICE 'events não é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE events RENAME TO events_partitioned;
+
+    CREATE TABLE events (
+        LIKE events_partitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    );
+    INSERT INTO events SELECT * FROM events_partitioned;
+    DROP TABLE events_partitioned;
+
+    ALTER TABLE events ADD CONSTRAINT events_pkey PRIMARY KEY (id);
+
+    CREATE UNIQUE INDEX idx_events_block_log ON events (block_number, log_index, transaction_hash);
+    CREATE INDEX idx_events_block_number ON events (block_number);
+    CREATE INDEX idx_events_contract_address ON events (contract_address);
+    CREATE INDEX idx_events_contract_name_block ON events (contract_address, event_name, block_number DESC);
+    CREATE INDEX idx_events_decoded_data_gin ON events USING gin (decoded_data);
+    CREATE INDEX idx_events_event_name ON events (event_name);
+    CREATE INDEX idx_events_event_signature ON events (event_signature);
+    CREATE INDEX idx_events_from_address ON events (from_address);
+    CREATE INDEX idx_events_status ON events (status);
+    CREATE INDEX idx_events_timestamp ON events ("timestamp" DESC);
+    CREATE INDEX idx_events_timestamp_id ON events ("timestamp" DESC, id);
+    CREATE INDEX idx_events_to_address ON events (to_address);
+    CREATE INDEX idx_events_topics_gin ON events USING gin (topics);
+    CREATE INDEX idx_events_transaction_hash ON events (transaction_hash);
+
+    CREATE TRIGGER trigger_events_updated_at BEFORE UPDATE ON events
+        FOR EACH ROW EXECUTE FUNCTION update_events_updated_at();
+END $$;
+
+-- =============================================================================
+-- account_transactions
+-- =============================================================================
+DO $$
+DECLARE
+    id_sequence TEXT;
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'account_transactions'::regclass) THEN
+        RAISE NOTICE 'account_transactions não é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE account_transactions RENAME TO account_transactions_partitioned;
+
+    CREATE TABLE account_transactions (
+        LIKE account_transactions_partitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    );
+    INSERT INTO account_transactions SELECT * FROM account_transactions_partitioned;
+
+    id_sequence := pg_get_serial_sequence('account_transactions_partitioned', 'id');
+    IF id_sequence IS NOT NULL THEN
+        EXECUTE format('ALTER SEQUENCE %s OWNED BY account_transactions.id', id_sequence);
+    END IF;
+    DROP TABLE account_transactions_partitioned;
+
+    ALTER TABLE account_transactions ADD CONSTRAINT account_transactions_pkey PRIMARY KEY (id);
+    ALTER TABLE account_transactions ADD CONSTRAINT unique_account_transaction
+        UNIQUE (account_address, transaction_hash);
+
+    CREATE INDEX idx_account_transactions_address ON account_transactions (account_address);
+    CREATE INDEX idx_account_transactions_address_timestamp ON account_transactions (account_address, "timestamp" DESC);
+    CREATE INDEX idx_account_transactions_block ON account_transactions (block_number);
+    CREATE INDEX idx_account_transactions_hash ON account_transactions (transaction_hash);
+    CREATE INDEX idx_account_transactions_keyset ON account_transactions (account_address, block_number, transaction_index);
+    CREATE INDEX idx_account_transactions_method ON account_transactions (method_name);
+    CREATE INDEX idx_account_transactions_status ON account_transactions (status);
+    CREATE INDEX idx_account_transactions_timestamp ON account_transactions ("timestamp" DESC);
+    CREATE INDEX idx_account_transactions_type ON account_transactions (transaction_type);
+
+    COMMENT ON TABLE account_transactions IS 'Tabela para tracking detalhado de todas as transações relacionadas a uma conta';
+END $$;
+
+-- =============================================================================
+-- account_events
+-- =============================================================================
+DO $$
+DECLARE
+    id_sequence TEXT;
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'account_events'::regclass) THEN
+        RAISE NOTICE 'account_events não é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE account_events RENAME TO account_events_partitioned;
+
+    CREATE TABLE account_events (
+        LIKE account_events_partitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    );
+    INSERT INTO account_events SELECT * FROM account_events_partitioned;
+
+    id_sequence := pg_get_serial_sequence('account_events_partitioned', 'id');
+    IF id_sequence IS NOT NULL THEN
+        EXECUTE format('ALTER SEQUENCE %s OWNED BY account_events.id', id_sequence);
+    END IF;
+    DROP TABLE account_events_partitioned;
+
+    ALTER TABLE account_events ADD CONSTRAINT account_events_pkey PRIMARY KEY (id);
+    ALTER TABLE account_events ADD CONSTRAINT unique_account_event UNIQUE (account_address, event_id);
+    COMMENT ON CONSTRAINT unique_account_event ON account_events IS 'Garante que cada evento é único por conta';
+
+    CREATE INDEX idx_account_events_address ON account_events (account_address);
+    CREATE INDEX idx_account_events_address_timestamp ON account_events (account_address, "timestamp" DESC);
+    CREATE INDEX idx_account_events_contract ON account_events (contract_address);
+    CREATE INDEX idx_account_events_event_id ON account_events (event_id);
+    CREATE INDEX idx_account_events_involvement ON account_events (involvement_type);
+    CREATE INDEX idx_account_events_name ON account_events (event_name);
+    CREATE INDEX idx_account_events_timestamp ON account_events ("timestamp" DESC);
+
+    COMMENT ON TABLE account_events IS 'Tabela para tracking de eventos de smart contracts relacionados a uma conta';
+END $$;
+
+-- =============================================================================
+-- account_analytics
+-- =============================================================================
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'account_analytics'::regclass) THEN
+        RAISE NOTICE 'account_analytics não é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE account_analytics RENAME TO account_analytics_partitioned;
+
+    CREATE TABLE account_analytics (
+        LIKE account_analytics_partitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    );
+    INSERT INTO account_analytics SELECT * FROM account_analytics_partitioned;
+    DROP TABLE account_analytics_partitioned;
+
+    ALTER TABLE account_analytics ADD CONSTRAINT account_analytics_pkey PRIMARY KEY (address, "date");
+    ALTER TABLE account_analytics ADD CONSTRAINT account_analytics_address_fkey
+        FOREIGN KEY (address) REFERENCES accounts(address) ON DELETE CASCADE;
+
+    CREATE INDEX idx_account_analytics_date ON account_analytics ("date");
+    CREATE INDEX idx_account_analytics_transactions_count ON account_analytics (transactions_count);
+    CREATE INDEX idx_account_analytics_value_transferred ON account_analytics (value_transferred);
+
+    CREATE TRIGGER update_account_analytics_updated_at BEFORE UPDATE ON account_analytics
+        FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+    COMMENT ON TABLE account_analytics IS 'Métricas analíticas diárias das contas';
+END $$;
+
+DROP FUNCTION IF EXISTS ensure_range_partition(TEXT, TEXT, TEXT, TEXT, TEXT);
+
+-- O schema archive só é removido se não guardar partições arquivadas
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_tables WHERE schemaname = 'archive') THEN
+        DROP SCHEMA IF EXISTS archive;
+    END IF;
+END $$;
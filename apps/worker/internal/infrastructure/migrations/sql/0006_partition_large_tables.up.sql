@@ -0,0 +1,414 @@
+-- Particionamento declarativo das tabelas que crescem com a chain
+-- transactions, events, account_transactions e account_events passam a ser particionadas por
+-- faixa de blocos (block_number) e account_analytics por mês (date). Cada tabela ganha uma
+-- partição default que recebe linhas fora das faixas existentes (ex.: transações pendentes,
+-- com block_number NULL). Novas partições são criadas pelo worker (PartitionManagerHandler),
+-- que também desanexa/arquiva as partições fora da retenção configurada.
+--
+-- ATENÇÃO: a migração copia os dados para as novas tabelas; em bases grandes execute em
+-- janela de manutenção, com worker e indexer parados.
+--
+-- Mudanças de chave impostas pelo particionamento (toda chave única precisa conter a
+-- coluna de partição):
+--   transactions:         PK (hash)                  -> UNIQUE NULLS NOT DISTINCT (hash, block_number)
+--                         unique_tx_per_block        -> (block_number, block_hash, transaction_index)
+--                         fk_transaction_methods_hash passa a apontar para transaction_hashes (FK não pode
+--                         apontar para (hash) sozinho em uma tabela particionada)
+--   events:               PK (id)                    -> PK (id, block_number)
+--   account_transactions: PK (id)                    -> PK (id, block_number)
+--                         unique_account_transaction -> (account_address, transaction_hash, block_number)
+--   account_events:       PK (id)                    -> PK (id, block_number)
+--                         unique_account_event       -> (account_address, event_id, block_number)
+--   account_analytics:    PK (address, date) já contém a coluna de partição
+
+CREATE SCHEMA IF NOT EXISTS archive;
+
+-- ensure_range_partition cria a partição [range_from, range_to) de parent_table se ela ainda não
+-- existir. Linhas da faixa que caíram na partição default são movidas para a nova partição,
+-- senão o PostgreSQL recusa a criação. Retorna TRUE quando a partição foi criada
+CREATE OR REPLACE FUNCTION ensure_range_partition(
+    parent_table TEXT,
+    partition_name TEXT,
+    key_column TEXT,
+    range_from TEXT,
+    range_to TEXT
+) RETURNS BOOLEAN
+LANGUAGE plpgsql AS $$
+DECLARE
+    default_table TEXT := parent_table || '_default';
+    moved BIGINT := 0;
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    IF to_regclass(default_table) IS NOT NULL THEN
+        EXECUTE format('CREATE TEMP TABLE partition_rows_moved (LIKE %I) ON COMMIT DROP', parent_table);
+        EXECUTE format(
+            'WITH moved AS (DELETE FROM %I WHERE %I >= %L AND %I < %L RETURNING *) '
+            'INSERT INTO partition_rows_moved SELECT * FROM moved',
+            default_table, key_column, range_from, key_column, range_to
+        );
+        GET DIAGNOSTICS moved = ROW_COUNT;
+    END IF;
+
+    EXECUTE format(
+        'CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)',
+        partition_name, parent_table, range_from, range_to
+    );
+
+    IF to_regclass('pg_temp.partition_rows_moved') IS NOT NULL THEN
+        IF moved > 0 THEN
+            EXECUTE format('INSERT INTO %I SELECT * FROM partition_rows_moved', partition_name);
+        END IF;
+        DROP TABLE partition_rows_moved;
+    END IF;
+
+    RETURN TRUE;
+END;
+$$;
+
+-- =============================================================================
+-- transactions
+-- =============================================================================
+DO $$
+DECLARE
+    block_range CONSTANT BIGINT := 1000000;
+    max_block BIGINT;
+    range_start BIGINT := 0;
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'transactions'::regclass) THEN
+        RAISE NOTICE 'transactions já é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE transaction_methods DROP CONSTRAINT IF EXISTS fk_transaction_methods_hash;
+    ALTER TABLE transactions RENAME TO transactions_unpartitioned;
+
+    CREATE TABLE transactions (
+        LIKE transactions_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    ) PARTITION BY RANGE (block_number);
+    CREATE TABLE transactions_default PARTITION OF transactions DEFAULT;
+
+    SELECT COALESCE(MAX(block_number), 0) INTO max_block FROM transactions_unpartitioned;
+    WHILE range_start <= max_block LOOP
+        PERFORM ensure_range_partition('transactions', 'transactions_b' || range_start, 'block_number',
+            range_start::TEXT, (range_start + block_range)::TEXT);
+        range_start := range_start + block_range;
+    END LOOP;
+
+    INSERT INTO transactions SELECT * FROM transactions_unpartitioned;
+    DROP TABLE transactions_unpartitioned;
+
+    ALTER TABLE transactions ADD CONSTRAINT transactions_hash_block_key UNIQUE NULLS NOT DISTINCT (hash, block_number);
+    ALTER TABLE transactions ADD CONSTRAINT unique_tx_per_block UNIQUE (block_number, block_hash, transaction_index);
+
+    CREATE INDEX idx_transactions_address_composite ON transactions (from_address, created_at DESC);
+    CREATE INDEX idx_transactions_addresses ON transactions USING gin ((ARRAY[from_address, to_address]));
+    CREATE INDEX idx_transactions_block_hash ON transactions (block_hash);
+    CREATE INDEX idx_transactions_block_number ON transactions (block_number);
+    CREATE INDEX idx_transactions_block_tx_index ON transactions (block_number, transaction_index);
+    CREATE INDEX idx_transactions_contract_address ON transactions (contract_address);
+    CREATE INDEX idx_transactions_created_at ON transactions (created_at);
+    CREATE INDEX idx_transactions_deleted_at ON transactions (deleted_at) WHERE deleted_at IS NULL;
+    CREATE INDEX idx_transactions_from_address ON transactions (from_address);
+    CREATE INDEX idx_transactions_gas_used ON transactions (gas_used);
+    CREATE INDEX idx_transactions_hash ON transactions USING hash (hash);
+    CREATE INDEX idx_transactions_mined_at ON transactions (mined_at);
+    CREATE INDEX idx_transactions_nonce ON transactions (from_address, nonce);
+    CREATE INDEX idx_transactions_status ON transactions (status);
+    CREATE INDEX idx_transactions_to_address ON transactions (to_address);
+    CREATE INDEX idx_transactions_to_address_composite ON transactions (to_address, created_at DESC);
+    CREATE INDEX idx_transactions_transaction_index ON transactions (transaction_index);
+    CREATE INDEX idx_transactions_type ON transactions (transaction_type);
+
+    COMMENT ON TABLE transactions IS 'Tabela de transações da blockchain (particionada por block_number)';
+END $$;
+
+-- =============================================================================
+-- events
+-- =============================================================================
+DO $$
+DECLARE
+    block_range CONSTANT BIGINT := 1000000;
+    max_block BIGINT;
+    range_start BIGINT := 0;
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'events'::regclass) THEN
+        RAISE NOTICE 'events já é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE events RENAME TO events_unpartitioned;
+
+    CREATE TABLE events (
+        LIKE events_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    ) PARTITION BY RANGE (block_number);
+    CREATE TABLE events_default PARTITION OF events DEFAULT;
+
+    SELECT COALESCE(MAX(block_number), 0) INTO max_block FROM events_unpartitioned;
+    WHILE range_start <= max_block LOOP
+        PERFORM ensure_range_partition('events', 'events_b' || range_start, 'block_number',
+            range_start::TEXT, (range_start + block_range)::TEXT);
+        range_start := range_start + block_range;
+    END LOOP;
+
+    INSERT INTO events SELECT * FROM events_unpartitioned;
+    DROP TABLE events_unpartitioned;
+
+    ALTER TABLE events ADD CONSTRAINT events_pkey PRIMARY KEY (id, block_number);
+
+    CREATE UNIQUE INDEX idx_events_block_log ON events (block_number, log_index, transaction_hash);
+    CREATE INDEX idx_events_block_number ON events (block_number);
+    CREATE INDEX idx_events_contract_address ON events (contract_address);
+    CREATE INDEX idx_events_contract_name_block ON events (contract_address, event_name, block_number DESC);
+    CREATE INDEX idx_events_decoded_data_gin ON events USING gin (decoded_data);
+    CREATE INDEX idx_events_event_name ON events (event_name);
+    CREATE INDEX idx_events_event_signature ON events (event_signature);
+    CREATE INDEX idx_events_from_address ON events (from_address);
+    CREATE INDEX idx_events_status ON events (status);
+    CREATE INDEX idx_events_timestamp ON events ("timestamp" DESC);
+    CREATE INDEX idx_events_timestamp_id ON events ("timestamp" DESC, id);
+    CREATE INDEX idx_events_to_address ON events (to_address);
+    CREATE INDEX idx_events_topics_gin ON events USING gin (topics);
+    CREATE INDEX idx_events_transaction_hash ON events (transaction_hash);
+
+    IF to_regproc('update_events_updated_at') IS NOT NULL THEN
+        CREATE TRIGGER trigger_events_updated_at BEFORE UPDATE ON events
+            FOR EACH ROW EXECUTE FUNCTION update_events_updated_at();
+    END IF;
+
+    COMMENT ON TABLE events IS 'Eventos emitidos pelos contratos (particionada por block_number)';
+END $$;
+
+-- =============================================================================
+-- account_transactions
+-- =============================================================================
+DO $$
+DECLARE
+    block_range CONSTANT BIGINT := 1000000;
+    max_block BIGINT;
+    range_start BIGINT := 0;
+    id_sequence TEXT;
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'account_transactions'::regclass) THEN
+        RAISE NOTICE 'account_transactions já é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE account_transactions RENAME TO account_transactions_unpartitioned;
+
+    CREATE TABLE account_transactions (
+        LIKE account_transactions_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    ) PARTITION BY RANGE (block_number);
+    CREATE TABLE account_transactions_default PARTITION OF account_transactions DEFAULT;
+
+    SELECT COALESCE(MAX(block_number), 0) INTO max_block FROM account_transactions_unpartitioned;
+    WHILE range_start <= max_block LOOP
+        PERFORM ensure_range_partition('account_transactions', 'account_transactions_b' || range_start, 'block_number',
+            range_start::TEXT, (range_start + block_range)::TEXT);
+        range_start := range_start + block_range;
+    END LOOP;
+
+    INSERT INTO account_transactions SELECT * FROM account_transactions_unpartitioned;
+
+    -- A sequência do id pertence à tabela antiga e seria removida junto com ela
+    id_sequence := pg_get_serial_sequence('account_transactions_unpartitioned', 'id');
+    IF id_sequence IS NOT NULL THEN
+        EXECUTE format('ALTER SEQUENCE %s OWNED BY account_transactions.id', id_sequence);
+    END IF;
+    DROP TABLE account_transactions_unpartitioned;
+
+    ALTER TABLE account_transactions ADD CONSTRAINT account_transactions_pkey PRIMARY KEY (id, block_number);
+    ALTER TABLE account_transactions ADD CONSTRAINT unique_account_transaction
+        UNIQUE (account_address, transaction_hash, block_number);
+
+    CREATE INDEX idx_account_transactions_address ON account_transactions (account_address);
+    CREATE INDEX idx_account_transactions_address_timestamp ON account_transactions (account_address, "timestamp" DESC);
+    CREATE INDEX idx_account_transactions_block ON account_transactions (block_number);
+    CREATE INDEX idx_account_transactions_hash ON account_transactions (transaction_hash);
+    CREATE INDEX idx_account_transactions_keyset ON account_transactions (account_address, block_number, transaction_index);
+    CREATE INDEX idx_account_transactions_method ON account_transactions (method_name);
+    CREATE INDEX idx_account_transactions_status ON account_transactions (status);
+    CREATE INDEX idx_account_transactions_timestamp ON account_transactions ("timestamp" DESC);
+    CREATE INDEX idx_account_transactions_type ON account_transactions (transaction_type);
+
+    COMMENT ON TABLE account_transactions IS 'Tabela para tracking detalhado de todas as transações relacionadas a uma conta (particionada por block_number)';
+END $$;
+
+-- =============================================================================
+-- account_events
+-- =============================================================================
+DO $$
+DECLARE
+    block_range CONSTANT BIGINT := 1000000;
+    max_block BIGINT;
+    range_start BIGINT := 0;
+    id_sequence TEXT;
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'account_events'::regclass) THEN
+        RAISE NOTICE 'account_events já é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE account_events RENAME TO account_events_unpartitioned;
+
+    CREATE TABLE account_events (
+        LIKE account_events_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    ) PARTITION BY RANGE (block_number);
+    CREATE TABLE account_events_default PARTITION OF account_events DEFAULT;
+
+    SELECT COALESCE(MAX(block_number), 0) INTO max_block FROM account_events_unpartitioned;
+    WHILE range_start <= max_block LOOP
+        PERFORM ensure_range_partition('account_events', 'account_events_b' || range_start, 'block_number',
+            range_start::TEXT, (range_start + block_range)::TEXT);
+        range_start := range_start + block_range;
+    END LOOP;
+
+    INSERT INTO account_events SELECT * FROM account_events_unpartitioned;
+
+    id_sequence := pg_get_serial_sequence('account_events_unpartitioned', 'id');
+    IF id_sequence IS NOT NULL THEN
+        EXECUTE format('ALTER SEQUENCE %s OWNED BY account_events.id', id_sequence);
+    END IF;
+    DROP TABLE account_events_unpartitioned;
+
+    ALTER TABLE account_events ADD CONSTRAINT account_events_pkey PRIMARY KEY (id, block_number);
+    ALTER TABLE account_events ADD CONSTRAINT unique_account_event
+        UNIQUE (account_address, event_id, block_number);
+    COMMENT ON CONSTRAINT unique_account_event ON account_events IS 'Garante que cada evento é único por conta';
+
+    CREATE INDEX idx_account_events_address ON account_events (account_address);
+    CREATE INDEX idx_account_events_address_timestamp ON account_events (account_address, "timestamp" DESC);
+    CREATE INDEX idx_account_events_contract ON account_events (contract_address);
+    CREATE INDEX idx_account_events_event_id ON account_events (event_id);
+    CREATE INDEX idx_account_events_involvement ON account_events (involvement_type);
+    CREATE INDEX idx_account_events_name ON account_events (event_name);
+    CREATE INDEX idx_account_events_timestamp ON account_events ("timestamp" DESC);
+
+    COMMENT ON TABLE account_events IS 'Tabela para tracking de eventos de smart contracts relacionados a uma conta (particionada por block_number)';
+END $$;
+
+-- =============================================================================
+-- account_analytics
+-- =============================================================================
+DO $$
+DECLARE
+    first_month DATE;
+    last_month DATE := date_trunc('month', CURRENT_DATE)::DATE;
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = 'account_analytics'::regclass) THEN
+        RAISE NOTICE 'account_analytics já é particionada';
+        RETURN;
+    END IF;
+
+    ALTER TABLE account_analytics RENAME TO account_analytics_unpartitioned;
+
+    CREATE TABLE account_analytics (
+        LIKE account_analytics_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING COMMENTS
+    ) PARTITION BY RANGE ("date");
+    CREATE TABLE account_analytics_default PARTITION OF account_analytics DEFAULT;
+
+    SELECT date_trunc('month', COALESCE(MIN("date"), CURRENT_DATE))::DATE INTO first_month
+    FROM account_analytics_unpartitioned;
+    WHILE first_month <= last_month LOOP
+        PERFORM ensure_range_partition('account_analytics', 'account_analytics_m' || to_char(first_month, 'YYYYMM'), 'date',
+            first_month::TEXT, (first_month + INTERVAL '1 month')::DATE::TEXT);
+        first_month := (first_month + INTERVAL '1 month')::DATE;
+    END LOOP;
+
+    INSERT INTO account_analytics SELECT * FROM account_analytics_unpartitioned;
+    DROP TABLE account_analytics_unpartitioned;
+
+    ALTER TABLE account_analytics ADD CONSTRAINT account_analytics_pkey PRIMARY KEY (address, "date");
+    ALTER TABLE account_analytics ADD CONSTRAINT account_analytics_address_fkey
+        FOREIGN KEY (address) REFERENCES accounts(address) ON DELETE CASCADE;
+
+    CREATE INDEX idx_account_analytics_date ON account_analytics ("date");
+    CREATE INDEX idx_account_analytics_transactions_count ON account_analytics (transactions_count);
+    CREATE INDEX idx_account_analytics_value_transferred ON account_analytics (value_transferred);
+
+    IF to_regproc('update_updated_at_column') IS NOT NULL THEN
+        CREATE TRIGGER update_account_analytics_updated_at BEFORE UPDATE ON account_analytics
+            FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+    END IF;
+
+    COMMENT ON TABLE account_analytics IS 'Métricas analíticas diárias das contas (particionada por mês)';
+END $$;
+
+-- =============================================================================
+-- transaction_hashes
+-- =============================================================================
+-- Unicidade global do hash de transação. O particionamento trocou a PK (hash) de transactions por
+-- UNIQUE (hash, block_number), o que permite a mesma transação pendente (block_number NULL) e minerada
+-- em linhas distintas. transaction_hashes guarda um hash por transação, mantida por triggers em
+-- transactions: inserções concorrentes do mesmo hash falham com unique_violation em vez de duplicar.
+-- Quando um UPDATE muda block_number (pendente -> minerada), o PostgreSQL move a linha de partição
+-- como DELETE + INSERT; dentro da mesma partição (ex.: default) dispara o trigger de UPDATE.
+CREATE TABLE IF NOT EXISTS transaction_hashes (
+    hash VARCHAR(66) PRIMARY KEY,
+    block_number BIGINT -- NULL enquanto a transação está pendente
+);
+
+COMMENT ON TABLE transaction_hashes IS 'Um hash por transação (unicidade global de transactions.hash, mantida por triggers)';
+
+-- Transações pendentes que já têm a versão minerada gravada são duplicatas da mesma transação
+DELETE FROM transactions t
+WHERE t.block_number IS NULL
+  AND EXISTS (SELECT 1 FROM transactions m WHERE m.hash = t.hash AND m.block_number IS NOT NULL);
+
+INSERT INTO transaction_hashes (hash, block_number)
+SELECT DISTINCT ON (hash) hash, block_number
+FROM transactions
+ORDER BY hash, block_number DESC NULLS LAST
+ON CONFLICT (hash) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION transaction_hashes_insert() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO transaction_hashes (hash, block_number) VALUES (NEW.hash, NEW.block_number);
+    RETURN NULL;
+END;
+$$;
+
+-- Só remove o hash da própria linha; linhas duplicadas anteriores à migração não apagam o hash da outra
+CREATE OR REPLACE FUNCTION transaction_hashes_delete() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    DELETE FROM transaction_hashes WHERE hash = OLD.hash AND block_number IS NOT DISTINCT FROM OLD.block_number;
+    RETURN NULL;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION transaction_hashes_update() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    UPDATE transaction_hashes SET block_number = NEW.block_number
+    WHERE hash = OLD.hash AND block_number IS NOT DISTINCT FROM OLD.block_number;
+    RETURN NULL;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trg_transaction_hashes_insert ON transactions;
+CREATE TRIGGER trg_transaction_hashes_insert
+    AFTER INSERT ON transactions
+    FOR EACH ROW EXECUTE FUNCTION transaction_hashes_insert();
+
+DROP TRIGGER IF EXISTS trg_transaction_hashes_delete ON transactions;
+CREATE TRIGGER trg_transaction_hashes_delete
+    AFTER DELETE ON transactions
+    FOR EACH ROW EXECUTE FUNCTION transaction_hashes_delete();
+
+DROP TRIGGER IF EXISTS trg_transaction_hashes_update ON transactions;
+CREATE TRIGGER trg_transaction_hashes_update
+    AFTER UPDATE OF block_number ON transactions
+    FOR EACH ROW WHEN (OLD.block_number IS DISTINCT FROM NEW.block_number)
+    EXECUTE FUNCTION transaction_hashes_update();
+
+-- Restaura a FK removida acima, agora apontando para transaction_hashes. É DEFERRABLE para que a
+-- movimentação de partição (DELETE + INSERT do mesmo hash) não a viole; sem ON DELETE CASCADE pelo
+-- mesmo motivo. NOT VALID: métodos órfãos gravados antes desta migração não são verificados
+ALTER TABLE transaction_methods DROP CONSTRAINT IF EXISTS fk_transaction_methods_hash;
+ALTER TABLE transaction_methods ADD CONSTRAINT fk_transaction_methods_hash
+    FOREIGN KEY (transaction_hash) REFERENCES transaction_hashes(hash)
+    DEFERRABLE INITIALLY DEFERRED NOT VALID;
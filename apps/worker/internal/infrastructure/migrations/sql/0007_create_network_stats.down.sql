@@ -0,0 +1,19 @@
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_blocks_delete ON blocks;
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_blocks_update ON blocks;
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_blocks_insert ON blocks;
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_events_delete ON events;
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_events_update ON events;
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_events_insert ON events;
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_transactions_delete ON transactions;
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_transactions_update ON transactions;
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_transactions_insert ON transactions;
+DROP FUNCTION IF EXISTS network_stats_mark_dirty_blocks_update();
+DROP FUNCTION IF EXISTS network_stats_mark_dirty_events_update();
+DROP FUNCTION IF EXISTS network_stats_mark_dirty_transactions_update();
+DROP FUNCTION IF EXISTS network_stats_mark_dirty_blocks();
+DROP FUNCTION IF EXISTS network_stats_mark_dirty_rows();
+DROP TABLE IF EXISTS network_stats_dirty_blocks;
+DROP TABLE IF EXISTS network_stats_state;
+DROP TABLE IF EXISTS network_address_first_seen;
+DROP TABLE IF EXISTS network_stats_addresses;
+DROP TABLE IF EXISTS network_stats;
@@ -0,0 +1,194 @@
+-- Rollups de estatísticas da rede por hora e por dia (mantidos pelo worker, StatsRollupHandler)
+-- Cada linha acumula os blocos do período; métricas derivadas (tempo médio de bloco, utilização de gas,
+-- taxa de falhas) são calculadas na leitura a partir das somas
+CREATE TABLE IF NOT EXISTS network_stats (
+    period VARCHAR(5) NOT NULL, -- hour, day
+    bucket TIMESTAMPTZ NOT NULL, -- Início do período em UTC
+    block_count BIGINT NOT NULL DEFAULT 0,
+    tx_count BIGINT NOT NULL DEFAULT 0,
+    failed_tx_count BIGINT NOT NULL DEFAULT 0,
+    active_addresses BIGINT NOT NULL DEFAULT 0, -- Endereços distintos (from/to) com transações no período
+    new_addresses BIGINT NOT NULL DEFAULT 0, -- Endereços vistos pela primeira vez no período
+    contracts_deployed BIGINT NOT NULL DEFAULT 0,
+    token_transfers BIGINT NOT NULL DEFAULT 0, -- Eventos Transfer (ERC-20/ERC-721)
+    gas_used NUMERIC(40, 0) NOT NULL DEFAULT 0,
+    gas_limit NUMERIC(40, 0) NOT NULL DEFAULT 0,
+    block_time_sum DOUBLE PRECISION NOT NULL DEFAULT 0, -- Soma dos intervalos entre blocos (segundos)
+    block_time_count BIGINT NOT NULL DEFAULT 0, -- Blocos com bloco anterior conhecido
+    first_block BIGINT,
+    last_block BIGINT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (period, bucket),
+    CONSTRAINT network_stats_period_check CHECK (period IN ('hour', 'day'))
+);
+
+-- Endereços já contados como ativos em cada período ainda aberto; o worker remove as linhas de
+-- períodos encerrados
+CREATE TABLE IF NOT EXISTS network_stats_addresses (
+    period VARCHAR(5) NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    address VARCHAR(42) NOT NULL,
+    PRIMARY KEY (period, bucket, address)
+);
+
+-- Primeira aparição de cada endereço em transações (base de new_addresses)
+CREATE TABLE IF NOT EXISTS network_address_first_seen (
+    address VARCHAR(42) PRIMARY KEY,
+    first_block BIGINT NOT NULL,
+    first_seen_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_network_address_first_seen_at ON network_address_first_seen(first_seen_at);
+
+-- Último bloco incorporado aos rollups pelo processamento incremental
+CREATE TABLE IF NOT EXISTS network_stats_state (
+    id SMALLINT PRIMARY KEY DEFAULT 1,
+    last_block BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT network_stats_state_single_row CHECK (id = 1)
+);
+
+-- Blocos já incorporados aos rollups de estatísticas (número <= network_stats_state.last_block) cujos
+-- blocos, transações ou eventos foram gravados ou alterados depois: mensagens atrasadas ou reentregues,
+-- filas de compatibilidade e backfills. O StatsRollupHandler recalcula os dias desses blocos e remove as marcas.
+-- Os triggers são por comando (transition tables), então um INSERT em lote marca cada bloco uma vez
+CREATE TABLE IF NOT EXISTS network_stats_dirty_blocks (
+    block_number BIGINT PRIMARY KEY,
+    marked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE network_stats_dirty_blocks IS 'Blocos abaixo do cursor dos rollups alterados depois de incorporados (mantida por triggers)';
+
+-- INSERT e DELETE de transactions e events: as linhas ficam em changed_rows (coluna block_number)
+CREATE OR REPLACE FUNCTION network_stats_mark_dirty_rows() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO network_stats_dirty_blocks (block_number)
+    SELECT DISTINCT c.block_number
+    FROM changed_rows c, network_stats_state s
+    WHERE s.id = 1 AND c.block_number <= s.last_block
+    ON CONFLICT (block_number) DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+
+-- INSERT e DELETE de blocks: o número do bloco fica na coluna number
+CREATE OR REPLACE FUNCTION network_stats_mark_dirty_blocks() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO network_stats_dirty_blocks (block_number)
+    SELECT DISTINCT c.number
+    FROM changed_rows c, network_stats_state s
+    WHERE s.id = 1 AND c.number <= s.last_block
+    ON CONFLICT (block_number) DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+
+-- UPDATEs só marcam os blocos quando mudam colunas lidas pelos rollups (upserts de reentregas e o
+-- motivo da falha gravado depois não recalculam o dia). Uma transação movida de bloco marca os dois
+CREATE OR REPLACE FUNCTION network_stats_mark_dirty_transactions_update() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO network_stats_dirty_blocks (block_number)
+    SELECT DISTINCT b.block_number
+    FROM old_rows o
+    JOIN new_rows n ON n.hash = o.hash
+    CROSS JOIN LATERAL (VALUES (o.block_number), (n.block_number)) AS b(block_number)
+    JOIN network_stats_state s ON s.id = 1
+    WHERE (o.block_number, o.from_address, o.to_address, o.status, o.contract_address)
+          IS DISTINCT FROM (n.block_number, n.from_address, n.to_address, n.status, n.contract_address)
+      AND b.block_number <= s.last_block
+    ON CONFLICT (block_number) DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION network_stats_mark_dirty_events_update() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO network_stats_dirty_blocks (block_number)
+    SELECT DISTINCT b.block_number
+    FROM old_rows o
+    JOIN new_rows n ON n.id = o.id
+    CROSS JOIN LATERAL (VALUES (o.block_number), (n.block_number)) AS b(block_number)
+    JOIN network_stats_state s ON s.id = 1
+    WHERE (o.block_number, o.event_signature, o.removed)
+          IS DISTINCT FROM (n.block_number, n.event_signature, n.removed)
+      AND b.block_number <= s.last_block
+    ON CONFLICT (block_number) DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION network_stats_mark_dirty_blocks_update() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO network_stats_dirty_blocks (block_number)
+    SELECT DISTINCT n.number
+    FROM old_rows o
+    JOIN new_rows n ON n.hash = o.hash
+    JOIN network_stats_state s ON s.id = 1
+    WHERE (o."timestamp", o.gas_used, o.gas_limit, o.deleted_at)
+          IS DISTINCT FROM (n."timestamp", n.gas_used, n.gas_limit, n.deleted_at)
+      AND n.number <= s.last_block
+    ON CONFLICT (block_number) DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+
+-- Transition tables não admitem mais de um evento nem lista de colunas por trigger: um trigger por operação.
+-- A troca de partição de um UPDATE aparece nas transition tables do UPDATE
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_transactions_insert ON transactions;
+CREATE TRIGGER trg_network_stats_dirty_transactions_insert
+    AFTER INSERT ON transactions
+    REFERENCING NEW TABLE AS changed_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_rows();
+
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_transactions_update ON transactions;
+CREATE TRIGGER trg_network_stats_dirty_transactions_update
+    AFTER UPDATE ON transactions
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_transactions_update();
+
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_transactions_delete ON transactions;
+CREATE TRIGGER trg_network_stats_dirty_transactions_delete
+    AFTER DELETE ON transactions
+    REFERENCING OLD TABLE AS changed_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_rows();
+
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_events_insert ON events;
+CREATE TRIGGER trg_network_stats_dirty_events_insert
+    AFTER INSERT ON events
+    REFERENCING NEW TABLE AS changed_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_rows();
+
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_events_update ON events;
+CREATE TRIGGER trg_network_stats_dirty_events_update
+    AFTER UPDATE ON events
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_events_update();
+
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_events_delete ON events;
+CREATE TRIGGER trg_network_stats_dirty_events_delete
+    AFTER DELETE ON events
+    REFERENCING OLD TABLE AS changed_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_rows();
+
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_blocks_insert ON blocks;
+CREATE TRIGGER trg_network_stats_dirty_blocks_insert
+    AFTER INSERT ON blocks
+    REFERENCING NEW TABLE AS changed_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_blocks();
+
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_blocks_update ON blocks;
+CREATE TRIGGER trg_network_stats_dirty_blocks_update
+    AFTER UPDATE ON blocks
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_blocks_update();
+
+DROP TRIGGER IF EXISTS trg_network_stats_dirty_blocks_delete ON blocks;
+CREATE TRIGGER trg_network_stats_dirty_blocks_delete
+    AFTER DELETE ON blocks
+    REFERENCING OLD TABLE AS changed_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION network_stats_mark_dirty_blocks();
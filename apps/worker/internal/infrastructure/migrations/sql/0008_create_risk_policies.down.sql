@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_accounts_risk_policy_version;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_compliance_source_check;
+ALTER TABLE accounts DROP COLUMN IF EXISTS compliance_source;
+ALTER TABLE accounts DROP COLUMN IF EXISTS risk_evaluated_at;
+ALTER TABLE accounts DROP COLUMN IF EXISTS risk_policy_version;
+
+DROP TABLE IF EXISTS account_risk_pending;
+DROP TABLE IF EXISTS account_risk_evaluations;
+DROP TABLE IF EXISTS risk_policies;
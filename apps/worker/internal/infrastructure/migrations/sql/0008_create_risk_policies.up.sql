@@ -0,0 +1,76 @@
+-- Políticas de risco versionadas (YAML ou JSON) avaliadas pelo worker (RiskEvaluationHandler)
+-- Cada envio cria uma nova versão; apenas uma versão fica ativa por vez
+CREATE TABLE IF NOT EXISTS risk_policies (
+    version SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    format VARCHAR(4) NOT NULL, -- yaml, json
+    content TEXT NOT NULL, -- Política como enviada
+    checksum VARCHAR(64) NOT NULL, -- SHA-256 do conteúdo
+    is_active BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    activated_at TIMESTAMPTZ,
+    CONSTRAINT risk_policies_format_check CHECK (format IN ('yaml', 'json'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_risk_policies_active ON risk_policies(is_active) WHERE is_active;
+
+-- Histórico de avaliações com a explicação do score (contribuição de cada regra)
+CREATE TABLE IF NOT EXISTS account_risk_evaluations (
+    id BIGSERIAL PRIMARY KEY,
+    address VARCHAR(42) NOT NULL,
+    policy_version INTEGER NOT NULL REFERENCES risk_policies(version),
+    score INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    breakdown JSONB NOT NULL,
+    trigger VARCHAR(20) NOT NULL, -- activity, policy_change, manual
+    evaluated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_risk_evaluations_address ON account_risk_evaluations(address, evaluated_at DESC);
+
+-- Accounts com atividade nova aguardando avaliação
+CREATE TABLE IF NOT EXISTS account_risk_pending (
+    address VARCHAR(42) PRIMARY KEY,
+    queued_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_risk_pending_queued_at ON account_risk_pending(queued_at);
+
+-- Versão da política da última avaliação e origem do status de compliance: status definidos
+-- manualmente (API) não são sobrescritos pela política
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS risk_policy_version INTEGER;
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS risk_evaluated_at TIMESTAMPTZ;
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS compliance_source VARCHAR(10) NOT NULL DEFAULT 'policy';
+ALTER TABLE accounts ADD CONSTRAINT accounts_compliance_source_check CHECK (compliance_source IN ('policy', 'manual'));
+
+CREATE INDEX IF NOT EXISTS idx_accounts_risk_policy_version ON accounts(risk_policy_version) WHERE risk_policy_version IS NOT NULL;
+
+-- Política inicial equivalente às heurísticas fixas anteriores
+INSERT INTO risk_policies (name, format, content, checksum, is_active, created_by, activated_at)
+SELECT 'default', 'yaml', content, encode(sha256(convert_to(content, 'UTF8')), 'hex'), TRUE, 'system', NOW()
+FROM (VALUES ($policy$name: default
+description: Heurísticas iniciais de risco
+max_score: 10
+thresholds:
+  under_review: 3
+  flagged: 6
+rules:
+  - id: new_account_high_activity
+    description: Conta com menos de 24h e mais de 100 transações
+    weight: 2
+    when:
+      - metric: account.age_hours
+        op: lt
+        value: 24
+      - metric: account.transaction_count
+        op: gt
+        value: 100
+  - id: contract_heavy
+    description: Mais da metade das transações são interações com contratos
+    weight: 1
+    when:
+      - metric: account.contract_interaction_ratio
+        op: gt
+        value: 0.5
+$policy$)) AS seed(content);
@@ -0,0 +1,7 @@
+UPDATE accounts SET compliance_source = 'policy' WHERE compliance_source = 'screening';
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_compliance_source_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_compliance_source_check CHECK (compliance_source IN ('policy', 'manual'));
+
+DROP TABLE IF EXISTS account_exposures;
+DROP TABLE IF EXISTS screening_entries;
+DROP TABLE IF EXISTS screening_lists;
@@ -0,0 +1,55 @@
+-- Listas de sanções e denylists importadas pela API (CSV ou JSON) e triadas pelo worker (ScreeningHandler)
+-- Cada importação é uma versão; só uma versão de cada lista (name) fica ativa
+CREATE TABLE IF NOT EXISTS screening_lists (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL, -- Ex.: ofac-sdn, denylist-interna
+    source VARCHAR(255) NOT NULL, -- Órgão ou origem do arquivo
+    version VARCHAR(100) NOT NULL, -- Versão informada pela origem
+    format VARCHAR(4) NOT NULL, -- csv, json
+    checksum VARCHAR(64) NOT NULL, -- SHA-256 do arquivo importado
+    entry_count INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    needs_rescreen BOOLEAN NOT NULL DEFAULT TRUE, -- Worker ainda não aplicou a última ativação/desativação
+    imported_by VARCHAR(255),
+    imported_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    screened_at TIMESTAMPTZ,
+    CONSTRAINT screening_lists_format_check CHECK (format IN ('csv', 'json')),
+    CONSTRAINT screening_lists_name_version_key UNIQUE (name, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_screening_lists_active_name ON screening_lists(name) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_screening_lists_needs_rescreen ON screening_lists(id) WHERE needs_rescreen;
+
+-- Endereços de cada versão das listas
+CREATE TABLE IF NOT EXISTS screening_entries (
+    list_id INTEGER NOT NULL REFERENCES screening_lists(id) ON DELETE CASCADE,
+    address VARCHAR(42) NOT NULL,
+    label TEXT, -- Nome ou motivo informado na lista
+    PRIMARY KEY (list_id, address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_screening_entries_address ON screening_entries(address);
+
+-- Exposição de cada account a um endereço listado: hops = 1 para contato direto, N para contato
+-- por intermediários; weight decai a cada hop
+CREATE TABLE IF NOT EXISTS account_exposures (
+    address VARCHAR(42) NOT NULL,
+    list_id INTEGER NOT NULL REFERENCES screening_lists(id) ON DELETE CASCADE,
+    listed_address VARCHAR(42) NOT NULL,
+    hops SMALLINT NOT NULL,
+    weight NUMERIC(6,5) NOT NULL,
+    via_address VARCHAR(42) NOT NULL, -- Contraparte pela qual a exposição chegou
+    tx_hash VARCHAR(66) NOT NULL, -- Primeira transação que gerou a exposição
+    block_number BIGINT NOT NULL,
+    kind VARCHAR(20) NOT NULL, -- transaction, internal, token_transfer
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (address, list_id, listed_address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_exposures_list_hops ON account_exposures(list_id, hops);
+CREATE INDEX IF NOT EXISTS idx_account_exposures_listed_address ON account_exposures(listed_address);
+
+-- Status definido pela triagem não é alterado pela política de risco
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_compliance_source_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_compliance_source_check CHECK (compliance_source IN ('policy', 'manual', 'screening'));
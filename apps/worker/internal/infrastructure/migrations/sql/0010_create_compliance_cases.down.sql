@@ -0,0 +1,9 @@
+UPDATE accounts SET compliance_source = 'manual' WHERE compliance_source = 'case';
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_compliance_source_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_compliance_source_check CHECK (compliance_source IN ('policy', 'manual', 'screening'));
+
+DROP TABLE IF EXISTS compliance_case_events;
+DROP TABLE IF EXISTS compliance_case_notes;
+DROP TABLE IF EXISTS compliance_case_transactions;
+DROP TABLE IF EXISTS compliance_case_addresses;
+DROP TABLE IF EXISTS compliance_cases;
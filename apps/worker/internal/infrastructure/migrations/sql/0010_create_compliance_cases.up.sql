@@ -0,0 +1,80 @@
+-- Casos de compliance: abertos manualmente pela API ou automaticamente pela política de risco e pela triagem
+-- Estados: open -> investigating -> escalated -> closed (reabertura volta para open)
+CREATE TABLE IF NOT EXISTS compliance_cases (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    state VARCHAR(20) NOT NULL DEFAULT 'open',
+    priority VARCHAR(10) NOT NULL DEFAULT 'medium',
+    origin VARCHAR(20) NOT NULL, -- manual, risk_policy, screening
+    origin_ref VARCHAR(255), -- Versão da política ou lista que abriu o caso
+    resolution VARCHAR(20), -- cleared, confirmed (apenas casos fechados)
+    assigned_to INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    opened_by VARCHAR(255) NOT NULL, -- ID do usuário ou "worker"
+    opened_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ,
+    CONSTRAINT compliance_cases_state_check CHECK (state IN ('open', 'investigating', 'escalated', 'closed')),
+    CONSTRAINT compliance_cases_priority_check CHECK (priority IN ('low', 'medium', 'high', 'critical')),
+    CONSTRAINT compliance_cases_origin_check CHECK (origin IN ('manual', 'risk_policy', 'screening')),
+    CONSTRAINT compliance_cases_resolution_check CHECK (
+        (state = 'closed' AND resolution IN ('cleared', 'confirmed')) OR (state <> 'closed' AND resolution IS NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_compliance_cases_state ON compliance_cases(state, updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_compliance_cases_assigned_to ON compliance_cases(assigned_to) WHERE state <> 'closed';
+
+-- Endereços vinculados: subject tem o status de compliance conduzido pelo caso; counterparty é contexto
+CREATE TABLE IF NOT EXISTS compliance_case_addresses (
+    case_id BIGINT NOT NULL REFERENCES compliance_cases(id) ON DELETE CASCADE,
+    address VARCHAR(42) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'subject',
+    added_by VARCHAR(255) NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (case_id, address),
+    CONSTRAINT compliance_case_addresses_role_check CHECK (role IN ('subject', 'counterparty'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_compliance_case_addresses_address ON compliance_case_addresses(address);
+
+-- Transações vinculadas como evidência
+CREATE TABLE IF NOT EXISTS compliance_case_transactions (
+    case_id BIGINT NOT NULL REFERENCES compliance_cases(id) ON DELETE CASCADE,
+    tx_hash VARCHAR(66) NOT NULL,
+    note TEXT,
+    added_by VARCHAR(255) NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (case_id, tx_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_compliance_case_transactions_tx_hash ON compliance_case_transactions(tx_hash);
+
+-- Notas dos investigadores
+CREATE TABLE IF NOT EXISTS compliance_case_notes (
+    id BIGSERIAL PRIMARY KEY,
+    case_id BIGINT NOT NULL REFERENCES compliance_cases(id) ON DELETE CASCADE,
+    author VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_compliance_case_notes_case ON compliance_case_notes(case_id, created_at);
+
+-- Histórico completo e somente de inclusão de cada caso (trilha de auditoria)
+CREATE TABLE IF NOT EXISTS compliance_case_events (
+    id BIGSERIAL PRIMARY KEY,
+    case_id BIGINT NOT NULL REFERENCES compliance_cases(id) ON DELETE CASCADE,
+    actor VARCHAR(255) NOT NULL, -- ID do usuário ou "worker"
+    action VARCHAR(30) NOT NULL, -- opened, state_changed, assigned, address_added, account_status, ...
+    from_value TEXT,
+    to_value TEXT,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_compliance_case_events_case ON compliance_case_events(case_id, id);
+
+-- Status conduzido por um caso ativo não é alterado pela política de risco nem pela triagem
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_compliance_source_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_compliance_source_check CHECK (compliance_source IN ('policy', 'manual', 'screening', 'case'));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_account_tags_system_tag;
+DROP TABLE IF EXISTS tag_rules;
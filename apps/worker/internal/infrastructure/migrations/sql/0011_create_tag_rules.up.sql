@@ -0,0 +1,56 @@
+-- Regras de tags automáticas, editadas pela API (/api/tag-rules) e avaliadas pelo worker (AccountTaggingService)
+-- Cada regra define uma tag e condições (todas precisam casar) sobre agregados da account, métodos e tokens.
+-- Uma tag pode ter várias regras: a account recebe a tag quando qualquer uma delas casa.
+-- Valores em moeda nativa usam NATIVE_TOKEN_DECIMALS casas decimais
+CREATE TABLE IF NOT EXISTS tag_rules (
+    id BIGSERIAL PRIMARY KEY,
+    tag VARCHAR(100) NOT NULL,
+    description TEXT,
+    conditions JSONB NOT NULL, -- [{"metric": "account.balance", "op": "gt", "value": 1000}, ...]
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255),
+    updated_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    evaluated_at TIMESTAMPTZ -- Última reavaliação completa; regras alteradas depois dela são reavaliadas em todas as accounts
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_rules_tag ON tag_rules(tag);
+
+CREATE INDEX IF NOT EXISTS idx_account_tags_system_tag ON account_tags(tag) WHERE created_by = 'system';
+
+-- Regras equivalentes às tags fixas anteriores do AccountTaggingService
+INSERT INTO tag_rules (tag, description, conditions, created_by)
+SELECT v.tag, v.description, v.conditions::JSONB, 'system'
+FROM (VALUES
+    ('contract', 'Conta é um contrato', '[{"metric": "account.is_contract", "op": "eq", "value": 1}]'),
+    ('eoa', 'Conta externa', '[{"metric": "account.is_contract", "op": "eq", "value": 0}]'),
+    ('token', 'Contrato ERC-20', '[{"metric": "account.contract_type", "op": "in", "values": ["erc20"]}]'),
+    ('erc20', 'Contrato ERC-20', '[{"metric": "account.contract_type", "op": "in", "values": ["erc20"]}]'),
+    ('nft', 'Contrato ERC-721', '[{"metric": "account.contract_type", "op": "in", "values": ["erc721"]}]'),
+    ('erc721', 'Contrato ERC-721', '[{"metric": "account.contract_type", "op": "in", "values": ["erc721"]}]'),
+    ('multi-token', 'Contrato ERC-1155', '[{"metric": "account.contract_type", "op": "in", "values": ["erc1155"]}]'),
+    ('erc1155', 'Contrato ERC-1155', '[{"metric": "account.contract_type", "op": "in", "values": ["erc1155"]}]'),
+    ('high-activity', 'Mais de 1000 transações', '[{"metric": "account.transaction_count", "op": "gt", "value": 1000}]'),
+    ('active', 'Entre 101 e 1000 transações', '[{"metric": "account.transaction_count", "op": "gt", "value": 100}, {"metric": "account.transaction_count", "op": "lte", "value": 1000}]'),
+    ('low-activity', 'Menos de 10 transações', '[{"metric": "account.transaction_count", "op": "lt", "value": 10}]'),
+    ('whale', 'Saldo acima de 1000 na moeda nativa', '[{"metric": "account.balance", "op": "gt", "value": 1000}]'),
+    ('high-balance', 'Saldo entre 100 e 1000 na moeda nativa', '[{"metric": "account.balance", "op": "gt", "value": 100}, {"metric": "account.balance", "op": "lte", "value": 1000}]'),
+    ('medium-balance', 'Saldo entre 10 e 100 na moeda nativa', '[{"metric": "account.balance", "op": "gt", "value": 10}, {"metric": "account.balance", "op": "lte", "value": 100}]'),
+    ('low-balance', 'Saldo abaixo de 0.01 na moeda nativa', '[{"metric": "account.balance", "op": "lt", "value": 0.01}]'),
+    ('defi-user', 'Mais de 100 interações com contratos', '[{"metric": "account.contract_interactions", "op": "gt", "value": 100}]'),
+    ('multi-protocol', 'Interagiu com mais de 20 contratos', '[{"metric": "methods.unique_contracts", "op": "gt", "value": 20}]'),
+    ('developer', 'Fez deploy de contratos', '[{"metric": "account.contract_deployments", "op": "gt", "value": 0}]'),
+    ('contract-creator', 'Fez deploy de contratos', '[{"metric": "account.contract_deployments", "op": "gt", "value": 0}]'),
+    ('prolific-developer', 'Fez deploy de mais de 10 contratos', '[{"metric": "account.contract_deployments", "op": "gt", "value": 10}]'),
+    ('frequent-sender', 'Método mais usado é transfer', '[{"metric": "methods.most_used", "op": "in", "values": ["transfer"]}]'),
+    ('defi-approver', 'Método mais usado é approve', '[{"metric": "methods.most_used", "op": "in", "values": ["approve"]}]'),
+    ('trader', 'Método mais usado é um swap', '[{"metric": "methods.most_used", "op": "in", "values": ["swap", "swapExactTokensForTokens"]}]'),
+    ('token-collector', 'Mais de 50 tokens com saldo', '[{"metric": "tokens.count", "op": "gt", "value": 50}]'),
+    ('token-holder', 'Entre 11 e 50 tokens com saldo', '[{"metric": "tokens.count", "op": "gt", "value": 10}, {"metric": "tokens.count", "op": "lte", "value": 50}]'),
+    ('reliable', 'Taxa de sucesso acima de 95%', '[{"metric": "activity.success_rate", "op": "gt", "value": 0.95}]'),
+    ('error-prone', 'Taxa de sucesso abaixo de 80%', '[{"metric": "activity.success_rate", "op": "lt", "value": 0.8}, {"metric": "account.transaction_count", "op": "gt", "value": 0}]'),
+    ('high-value-tx', 'Transação acima de 100 na moeda nativa', '[{"metric": "activity.max_tx_value", "op": "gt", "value": 100}]'),
+    ('failed-tx', 'Possui transação com falha', '[{"metric": "activity.failed_count", "op": "gt", "value": 0}]')
+) AS v(tag, description, conditions)
+WHERE NOT EXISTS (SELECT 1 FROM tag_rules r WHERE r.tag = v.tag);
@@ -0,0 +1,28 @@
+-- Labels públicos de endereços (pontes, multisigs de tesouraria, coinbases de validadores, chaves de deploy...)
+-- Criados ou importados (CSV) por admins, ou sugeridos por usuários e moderados por admins.
+-- Cada endereço tem no máximo um label aprovado; aprovar outro substitui o anterior (superseded)
+CREATE TABLE IF NOT EXISTS address_labels (
+    id BIGSERIAL PRIMARY KEY,
+    address VARCHAR(42) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(30) NOT NULL,
+    description TEXT,
+    source VARCHAR(255), -- Origem da informação (ex.: documentação da ponte)
+    url TEXT,
+    verified BOOLEAN NOT NULL DEFAULT FALSE, -- Confirmado pelo dono do endereço ou por fonte oficial
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    submitted_by VARCHAR(255) NOT NULL, -- ID do usuário que criou, sugeriu ou importou
+    reviewed_by VARCHAR(255),
+    reviewed_at TIMESTAMPTZ,
+    review_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT address_labels_category_check CHECK (category IN (
+        'bridge', 'treasury', 'multisig', 'validator', 'deployer', 'exchange', 'token', 'contract', 'infrastructure', 'other'
+    )),
+    CONSTRAINT address_labels_status_check CHECK (status IN ('pending', 'approved', 'rejected', 'superseded'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_address_labels_approved ON address_labels(address) WHERE status = 'approved';
+CREATE INDEX IF NOT EXISTS idx_address_labels_status ON address_labels(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_address_labels_address ON address_labels(address);
@@ -0,0 +1,34 @@
+-- UserOperations ERC-4337 indexadas pelo worker a partir das transações enviadas às EntryPoints configuradas
+-- (ENTRYPOINT_ADDRESSES): calldata de handleOps e logs UserOperationEvent, AccountDeployed e UserOperationRevertReason
+CREATE TABLE IF NOT EXISTS user_operations (
+    user_op_hash VARCHAR(66) PRIMARY KEY,
+    entry_point VARCHAR(42) NOT NULL,
+    entry_point_version VARCHAR(10), -- v0.6 ou v0.7 (pelo seletor de handleOps; nulo se a calldata não foi decodificada)
+    tx_hash VARCHAR(66) NOT NULL,
+    block_number BIGINT NOT NULL,
+    log_index INTEGER NOT NULL, -- Índice do UserOperationEvent
+    mined_at TIMESTAMPTZ,
+    sender VARCHAR(42) NOT NULL, -- Smart account
+    paymaster VARCHAR(42), -- Nulo quando a própria account pagou
+    bundler VARCHAR(42) NOT NULL, -- Remetente da transação handleOps
+    beneficiary VARCHAR(42), -- Recebedor das taxas indicado pelo bundler
+    factory VARCHAR(42), -- Factory do AccountDeployed, quando a operação criou a account
+    nonce NUMERIC(78, 0) NOT NULL,
+    success BOOLEAN NOT NULL,
+    actual_gas_cost NUMERIC(78, 0) NOT NULL,
+    actual_gas_used NUMERIC(78, 0) NOT NULL,
+    call_gas_limit NUMERIC(78, 0),
+    verification_gas_limit NUMERIC(78, 0),
+    pre_verification_gas NUMERIC(78, 0),
+    max_fee_per_gas NUMERIC(78, 0),
+    max_priority_fee_per_gas NUMERIC(78, 0),
+    call_selector VARCHAR(10), -- Seletor da callData executada pela account
+    revert_reason TEXT, -- Dados de UserOperationRevertReason (hex)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_operations_sender ON user_operations(sender, block_number DESC);
+CREATE INDEX IF NOT EXISTS idx_user_operations_paymaster ON user_operations(paymaster, block_number DESC) WHERE paymaster IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_user_operations_bundler ON user_operations(bundler, block_number DESC);
+CREATE INDEX IF NOT EXISTS idx_user_operations_block ON user_operations(block_number DESC, log_index DESC);
+CREATE INDEX IF NOT EXISTS idx_user_operations_tx_hash ON user_operations(tx_hash);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_failed_without_reason;
+ALTER TABLE transactions DROP COLUMN IF EXISTS revert_reason;
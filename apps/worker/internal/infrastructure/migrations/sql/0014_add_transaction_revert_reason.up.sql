@@ -0,0 +1,11 @@
+-- Motivo decodificado das transações com falha, gravado pelo worker (TransactionHandler e RevertReasonHandler):
+-- {"kind": "error|panic|custom|unknown|out_of_gas|unavailable", "message": "...", "signature": "...",
+--  "code": 17, "args": [...], "data": "0x...", "source": "receipt|replay"}
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS revert_reason JSONB;
+
+COMMENT ON COLUMN transactions.revert_reason IS 'Motivo decodificado da falha (Error(string), Panic(uint256) ou erro customizado do ABI), do receipt ou da reexecução no bloco pai';
+
+-- Fila das transações com falha ainda sem motivo, varrida pelo RevertReasonHandler
+CREATE INDEX IF NOT EXISTS idx_transactions_failed_without_reason
+    ON transactions (block_number DESC)
+    WHERE status = 'failed' AND revert_reason IS NULL;
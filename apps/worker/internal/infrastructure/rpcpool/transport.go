@@ -0,0 +1,90 @@
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// routingTransport envia cada requisição JSON-RPC ao melhor endpoint HTTP,
+// repetindo no próximo candidato em caso de falha de rede, 5xx ou 429
+type routingTransport struct {
+	pool *Pool
+	base http.RoundTripper
+}
+
+func (t *routingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	candidates := t.pool.candidates(false)
+	if len(candidates) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	var lastErr error
+	for _, ep := range candidates {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+
+		target, err := url.Parse(ep.url)
+		if err != nil {
+			lastErr = fmt.Errorf("url inválida %s: %w", ep.url, err)
+			continue
+		}
+
+		attempt := req.Clone(req.Context())
+		attempt.URL = target
+		attempt.Host = target.Host
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+
+		start := time.Now()
+		resp, err := t.base.RoundTrip(attempt)
+		if err == nil && (resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests) {
+			resp.Body.Close()
+			err = fmt.Errorf("status HTTP %d", resp.StatusCode)
+		}
+		if err != nil {
+			// Cancelamento do chamador não conta contra o node
+			if !errors.Is(err, context.Canceled) {
+				ep.record(0, err)
+			}
+			lastErr = fmt.Errorf("endpoint %s: %w", ep.url, err)
+			continue
+		}
+
+		ep.record(time.Since(start), nil)
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
+
+// CallRaw envia um payload JSON-RPC já serializado pelo mesmo roteamento do pool e retorna o corpo da resposta
+func (p *Pool) CallRaw(ctx context.Context, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, routedURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição HTTP: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao fazer requisição HTTP: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status HTTP inválido: %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
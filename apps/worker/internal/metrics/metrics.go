@@ -0,0 +1,190 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "besuscan_worker"
+
+var (
+	chainHead   atomic.Uint64
+	lastIndexed atomic.Uint64
+
+	// QueuePublished conta as mensagens publicadas com sucesso por fila
+	QueuePublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_published_total",
+		Help:      "Mensagens publicadas no RabbitMQ por fila",
+	}, []string{"queue"})
+
+	// QueuePublishErrors conta as falhas de publicação por fila
+	QueuePublishErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_publish_errors_total",
+		Help:      "Falhas ao publicar mensagens no RabbitMQ por fila",
+	}, []string{"queue"})
+
+	// QueueConsumed conta as mensagens consumidas por fila e resultado (ack, nack, requeue, reject)
+	QueueConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_consumed_total",
+		Help:      "Mensagens consumidas do RabbitMQ por fila e resultado",
+	}, []string{"queue", "result"})
+
+	// RPCDuration mede a latência das chamadas JSON-RPC por método
+	RPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "Latência das chamadas JSON-RPC ao node por método",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method"})
+
+	// RPCErrors conta as chamadas JSON-RPC que falharam por método
+	RPCErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "Chamadas JSON-RPC ao node que retornaram erro por método",
+	}, []string{"method"})
+
+	// BlockBatchDuration mede o tempo de gravação dos lotes de blocos no PostgreSQL
+	BlockBatchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "postgres_block_batch_duration_seconds",
+		Help:      "Duração da gravação de blocos pelo bulk writer por resultado",
+		Buckets:   []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"result"})
+
+	// BlockBatchSize registra quantos blocos cada lote gravou
+	BlockBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "postgres_block_batch_size",
+		Help:      "Quantidade de blocos por lote gravado no PostgreSQL",
+		Buckets:   []float64{1, 2, 5, 10, 25, 50, 100},
+	})
+)
+
+func init() {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chain_head_block",
+		Help:      "Último bloco conhecido do node (head da chain)",
+	}, func() float64 { return float64(chainHead.Load()) })
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_indexed_block",
+		Help:      "Maior bloco gravado no PostgreSQL pelo worker",
+	}, func() float64 { return float64(lastIndexed.Load()) })
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "block_lag",
+		Help:      "Diferença entre o head da chain e o último bloco gravado",
+	}, blockLag)
+}
+
+// SetChainHead registra um novo head da chain; valores menores que o atual são ignorados
+func SetChainHead(number uint64) {
+	storeMax(&chainHead, number)
+}
+
+// SetLastIndexed registra o último bloco gravado; valores menores que o atual são ignorados
+func SetLastIndexed(number uint64) {
+	storeMax(&lastIndexed, number)
+}
+
+// storeMax atualiza o valor apenas se o novo número for maior
+func storeMax(target *atomic.Uint64, number uint64) {
+	for {
+		current := target.Load()
+		if number <= current || target.CompareAndSwap(current, number) {
+			return
+		}
+	}
+}
+
+// blockLag calcula o atraso em blocos; retorna 0 enquanto nenhum bloco foi gravado
+func blockLag() float64 {
+	head, indexed := chainHead.Load(), lastIndexed.Load()
+	if indexed == 0 || head <= indexed {
+		return 0
+	}
+	return float64(head - indexed)
+}
+
+// ObserveBlockBatch registra a duração e o tamanho de um lote de blocos gravado pelo bulk writer
+func ObserveBlockBatch(size int, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	BlockBatchDuration.WithLabelValues(result).Observe(duration.Seconds())
+	if err == nil {
+		BlockBatchSize.Observe(float64(size))
+	}
+}
+
+// ObserveRPC executa uma chamada JSON-RPC registrando latência e erro para o método informado
+func ObserveRPC(method string, call func() error) error {
+	start := time.Now()
+	err := call()
+	RPCDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		RPCErrors.WithLabelValues(method).Inc()
+	}
+	return err
+}
+
+// TrackChainHead consulta periodicamente o head da chain para calcular o lag do worker
+func TrackChainHead(ctx context.Context, client *ethclient.Client, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var head uint64
+		err := ObserveRPC("eth_blockNumber", func() (callErr error) {
+			head, callErr = client.BlockNumber(ctx)
+			return callErr
+		})
+		if err == nil {
+			SetChainHead(head)
+		} else if ctx.Err() == nil {
+			log.Printf("⚠️ Erro ao consultar head da chain para métricas: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StartServer expõe /metrics no endereço informado até o contexto ser cancelado
+func StartServer(ctx context.Context, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("📊 Métricas Prometheus disponíveis em %s/metrics", addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("❌ Erro no servidor de métricas: %v", err)
+	}
+}
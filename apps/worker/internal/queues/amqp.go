@@ -1,6 +1,7 @@
 package queues
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/rand"
@@ -8,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hubweb3/worker/internal/metrics"
+	"github.com/hubweb3/worker/internal/tracing"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -107,10 +110,18 @@ func (p *Publisher) reconnect() error {
 }
 
 func (p *Publisher) Publish(queue string, body []byte) error {
+	return p.PublishWithContext(context.Background(), queue, body)
+}
+
+// PublishWithContext publica a mensagem propagando o trace context do ctx nos headers AMQP
+func (p *Publisher) PublishWithContext(ctx context.Context, queue string, body []byte) (err error) {
 	if p.closed {
 		return fmt.Errorf("publisher está fechado")
 	}
 
+	span, headers := startPublishSpan(ctx, queue)
+	defer func() { tracing.End(span, err) }()
+
 	// Tentar publicar com retry em caso de erro de conexão
 	maxRetries := 3
 	for attempt := 1; attempt <= maxRetries; attempt++ {
@@ -121,12 +132,14 @@ func (p *Publisher) Publish(queue string, body []byte) error {
 			false, // immediate
 			amqp.Publishing{
 				ContentType: "application/json",
+				Headers:     headers,
 				Body:        body,
 				Timestamp:   time.Now(),
 			},
 		)
 
 		if err == nil {
+			metrics.QueuePublished.WithLabelValues(queue).Inc()
 			return nil
 		}
 
@@ -138,6 +151,7 @@ func (p *Publisher) Publish(queue string, body []byte) error {
 			if reconnectErr := p.reconnect(); reconnectErr != nil {
 				log.Printf("❌ Falha na reconexão: %v", reconnectErr)
 				if attempt == maxRetries {
+					metrics.QueuePublishErrors.WithLabelValues(queue).Inc()
 					return fmt.Errorf("falha ao publicar após %d tentativas: %w", maxRetries, err)
 				}
 				time.Sleep(time.Duration(attempt) * time.Second)
@@ -150,9 +164,11 @@ func (p *Publisher) Publish(queue string, body []byte) error {
 		}
 
 		// Se não é erro de conexão, falhar imediatamente
+		metrics.QueuePublishErrors.WithLabelValues(queue).Inc()
 		return err
 	}
 
+	metrics.QueuePublishErrors.WithLabelValues(queue).Inc()
 	return fmt.Errorf("falha ao publicar após %d tentativas", maxRetries)
 }
 
@@ -324,7 +340,7 @@ func (c *Consumer) Consume(queue string) (<-chan amqp.Delivery, error) {
 
 		if err == nil {
 			log.Printf("✅ Consumer [%s] iniciado para fila: %s", c.consumerTag, queue)
-			return msgs, nil
+			return instrumentDeliveries(queue, msgs), nil
 		}
 
 		// Se erro de consumer tag reuse, gerar nova tag
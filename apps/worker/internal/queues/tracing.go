@@ -0,0 +1,64 @@
+package queues
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hubweb3/worker/internal/tracing"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier adapta os headers AMQP ao TextMapCarrier do OpenTelemetry
+type headerCarrier amqp.Table
+
+func (c headerCarrier) Get(key string) string {
+	if value, ok := c[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// startPublishSpan abre o span de produtor e grava o trace context (traceparent) nos headers da mensagem
+func startPublishSpan(ctx context.Context, queue string) (trace.Span, amqp.Table) {
+	ctx, span := tracing.Tracer().Start(ctx, fmt.Sprintf("%s publish", queue),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", queue),
+		),
+	)
+
+	headers := amqp.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+	return span, headers
+}
+
+// StartConsumeSpan restaura o trace context dos headers da mensagem e abre o span de consumo
+func StartConsumeSpan(ctx context.Context, queue string, d amqp.Delivery) (context.Context, trace.Span) {
+	if d.Headers != nil {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(d.Headers))
+	}
+	return tracing.Tracer().Start(ctx, fmt.Sprintf("%s process", queue),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.source.name", queue),
+			attribute.Int("messaging.message.body.size", len(d.Body)),
+		),
+	)
+}
@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hubweb3/worker/internal/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/hubweb3/worker"
+
+// Init configura o TracerProvider global e o propagador W3C (traceparent/baggage).
+// O exporter é escolhido por OTEL_TRACES_EXPORTER: otlp, stdout, file ou none.
+// Sem a variável, usa otlp quando OTEL_EXPORTER_OTLP_ENDPOINT está definido e none caso contrário.
+// O arquivo do exporter file vem de OTEL_TRACES_FILE (padrão traces.jsonl)
+func Init(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, closer, err := newExporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		log.Println("ℹ️ Tracing desabilitado (OTEL_TRACES_EXPORTER=none)")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar resource de tracing: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	log.Printf("🔭 Tracing OpenTelemetry habilitado (exporter: %s)", exporterName())
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// exporterName resolve o exporter configurado no ambiente
+func exporterName() string {
+	if name := strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")); name != "" {
+		return name
+	}
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" {
+		return "otlp"
+	}
+	return "none"
+}
+
+// newExporter cria o exporter de spans; retorna nil quando o tracing está desabilitado
+func newExporter(ctx context.Context) (sdktrace.SpanExporter, io.Closer, error) {
+	switch name := exporterName(); name {
+	case "none":
+		return nil, nil, nil
+	case "otlp":
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao criar exporter OTLP: %w", err)
+		}
+		return exporter, nil, nil
+	case "stdout", "console":
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao criar exporter stdout: %w", err)
+		}
+		return exporter, nil, nil
+	case "file":
+		path := os.Getenv("OTEL_TRACES_FILE")
+		if path == "" {
+			path = "traces.jsonl"
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao abrir arquivo de traces %s: %w", path, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("erro ao criar exporter de arquivo: %w", err)
+		}
+		return exporter, file, nil
+	default:
+		return nil, nil, fmt.Errorf("exporter de tracing desconhecido: %s", name)
+	}
+}
+
+// Tracer retorna o tracer do worker
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End registra o erro (se houver) no span e o encerra
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ObserveRPC executa uma chamada JSON-RPC dentro de um span de cliente e registra as métricas do método
+func ObserveRPC(ctx context.Context, method string, call func(ctx context.Context) error) error {
+	ctx, span := Tracer().Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "jsonrpc"),
+			attribute.String("rpc.method", method),
+		),
+	)
+	err := metrics.ObserveRPC(method, func() error { return call(ctx) })
+	End(span, err)
+	return err
+}
+
+// WithSpan executa uma etapa de processamento dentro de um span filho de ctx
+func WithSpan(ctx context.Context, name string, fn func(ctx context.Context) error, attrs ...attribute.KeyValue) error {
+	ctx, span := Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+	err := fn(ctx)
+	End(span, err)
+	return err
+}
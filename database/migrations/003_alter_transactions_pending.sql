@@ -1,10 +0,0 @@
--- Migration: Adiciona suporte a transações pending e status
--- status: 'pending', 'success', 'error'
-ALTER TABLE transactions
-    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'pending',
-    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW();
-
-CREATE INDEX IF NOT EXISTS idx_transactions_status ON transactions(status);
-CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
-CREATE INDEX IF NOT EXISTS idx_transactions_updated_at ON transactions(updated_at);
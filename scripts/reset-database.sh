@@ -3,7 +3,8 @@
 # =============================================================================
 # RESET BANCO DE DADOS - BeSuScan Explorer
 # =============================================================================
-# Este script reseta completamente o banco de dados PostgreSQL usando o DDL original
+# Este script reseta completamente o banco de dados PostgreSQL e recria o schema com as
+# migrations versionadas do worker (worker migrate up)
 # 
 # Uso: ./scripts/reset-database.sh
 # =============================================================================
@@ -21,9 +22,9 @@ echo -e "${BLUE}============================================${NC}"
 echo -e "${BLUE} 🗄️  RESETANDO BANCO DE DADOS${NC}"
 echo -e "${BLUE}============================================${NC}"
 
-# Verificar se o arquivo DDL original existe
-if [[ ! -f "database/ddl.sql" ]]; then
-    echo -e "${RED}❌ Arquivo database/ddl.sql não encontrado!${NC}"
+# Verificar se as migrations do worker existem
+if [[ ! -d "apps/worker/internal/infrastructure/migrations/sql" ]]; then
+    echo -e "${RED}❌ Migrations do worker não encontradas (execute a partir da raiz do repositório)!${NC}"
     exit 1
 fi
 
@@ -64,98 +65,15 @@ docker exec explorer-postgres-dev dropdb -U explorer blockexplorer > /dev/null 2
 echo -e "${YELLOW}📦 Criando novo banco...${NC}"
 docker exec explorer-postgres-dev createdb -U explorer blockexplorer
 
-# Aplicar o DDL original
-echo -e "${YELLOW}🔄 Aplicando DDL original (database/ddl.sql)...${NC}"
-if docker exec -i explorer-postgres-dev psql -U explorer -d blockexplorer < database/ddl.sql > /dev/null 2>&1; then
-    echo -e "${GREEN}✅ DDL aplicado com sucesso!${NC}"
+# Aplicar as migrations versionadas
+echo -e "${YELLOW}🔄 Aplicando migrations (worker migrate up)...${NC}"
+if docker compose -f docker-compose.dev.yml run --rm --no-deps worker go run ./cmd migrate up; then
+    echo -e "${GREEN}✅ Migrations aplicadas com sucesso!${NC}"
 else
-    echo -e "${YELLOW}⚠️  DDL aplicado com alguns avisos (normal)${NC}"
+    echo -e "${RED}❌ Erro ao aplicar migrations!${NC}"
+    exit 1
 fi
 
-# Criar as funções de trigger que podem estar faltando
-echo -e "${YELLOW}🔧 Criando funções de trigger necessárias...${NC}"
-docker exec explorer-postgres-dev psql -U explorer -d blockexplorer -c "
--- Função para atualizar updated_at
-CREATE OR REPLACE FUNCTION update_updated_at_column()
-RETURNS TRIGGER AS \$\$
-BEGIN
-    NEW.updated_at = NOW();
-    RETURN NEW;
-END;
-\$\$ language 'plpgsql';
-
--- Função para atualizar events updated_at
-CREATE OR REPLACE FUNCTION update_events_updated_at()
-RETURNS TRIGGER AS \$\$
-BEGIN
-    NEW.updated_at = NOW();
-    RETURN NEW;
-END;
-\$\$ language 'plpgsql';
-
--- Função para atualizar validators updated_at
-CREATE OR REPLACE FUNCTION update_validators_updated_at()
-RETURNS TRIGGER AS \$\$
-BEGIN
-    NEW.updated_at = CURRENT_TIMESTAMP;
-    RETURN NEW;
-END;
-\$\$ language 'plpgsql';
-" > /dev/null 2>&1
-
-# Criar triggers que podem estar faltando
-echo -e "${YELLOW}⚙️  Criando triggers necessários...${NC}"
-docker exec explorer-postgres-dev psql -U explorer -d blockexplorer -c "
--- Triggers para updated_at (ignorar erros se já existirem)
-DO \$\$ 
-BEGIN
-    -- Accounts
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'update_accounts_updated_at') THEN
-        CREATE TRIGGER update_accounts_updated_at BEFORE UPDATE ON accounts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-    END IF;
-    
-    -- Smart contracts
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'update_smart_contracts_updated_at') THEN
-        CREATE TRIGGER update_smart_contracts_updated_at BEFORE UPDATE ON smart_contracts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-    END IF;
-    
-    -- Events
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'trigger_events_updated_at') THEN
-        CREATE TRIGGER trigger_events_updated_at BEFORE UPDATE ON events FOR EACH ROW EXECUTE FUNCTION update_events_updated_at();
-    END IF;
-    
-    -- Validators
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'update_validators_updated_at_trigger') THEN
-        CREATE TRIGGER update_validators_updated_at_trigger BEFORE UPDATE ON validators FOR EACH ROW EXECUTE FUNCTION update_validators_updated_at();
-    END IF;
-    
-    -- Contract interactions
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'update_contract_interactions_updated_at') THEN
-        CREATE TRIGGER update_contract_interactions_updated_at BEFORE UPDATE ON contract_interactions FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-    END IF;
-    
-    -- Smart contract events
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'update_contract_events_updated_at') THEN
-        CREATE TRIGGER update_contract_events_updated_at BEFORE UPDATE ON smart_contract_events FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-    END IF;
-    
-    -- Smart contract functions
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'update_contract_functions_updated_at') THEN
-        CREATE TRIGGER update_contract_functions_updated_at BEFORE UPDATE ON smart_contract_functions FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-    END IF;
-    
-    -- Token holdings
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'update_token_holdings_updated_at') THEN
-        CREATE TRIGGER update_token_holdings_updated_at BEFORE UPDATE ON token_holdings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-    END IF;
-    
-    -- Account analytics
-    IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'update_account_analytics_updated_at') THEN
-        CREATE TRIGGER update_account_analytics_updated_at BEFORE UPDATE ON account_analytics FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
-    END IF;
-END \$\$;
-" > /dev/null 2>&1
-
 # Verificar se a estrutura está correta
 echo -e "${YELLOW}🔍 Verificando estrutura da tabela blocks...${NC}"
 COLUMN_CHECK=$(docker exec explorer-postgres-dev psql -U explorer -d blockexplorer -t -c "SELECT column_name FROM information_schema.columns WHERE table_name = 'blocks' AND column_name = 'number';")